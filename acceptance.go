@@ -0,0 +1,280 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AnySubmod can be used in place of a submod name in RequireStatus and
+// RequireClaim to state a requirement on every submod of the result
+const AnySubmod = "*"
+
+// RuleFailure describes why an AttestationResult does not meet a Rule
+type RuleFailure struct {
+	// Rule is the description of the rule that is not met
+	Rule string
+	// Submod is the name of the submod at fault, if any
+	Submod string
+	// Claim is the name of the claim at fault, if any: "ear.status" or a
+	// trustworthiness vector claim (e.g., "executables")
+	Claim string
+	// Reason is a human readable explanation
+	Reason string
+}
+
+func (o RuleFailure) String() string {
+	var path []string
+
+	if o.Submod != "" {
+		path = append(path, o.Submod)
+	}
+
+	if o.Claim != "" {
+		path = append(path, o.Claim)
+	}
+
+	if len(path) == 0 {
+		return fmt.Sprintf("%s (%s)", o.Reason, o.Rule)
+	}
+
+	return fmt.Sprintf("%s: %s (%s)", strings.Join(path, "/"), o.Reason, o.Rule)
+}
+
+// Rule is an acceptance requirement on an AttestationResult.  Custom rules
+// can be implemented by relying parties, and combined with the built-in ones
+// (see RequireStatus, RequireClaim, AllOf and AnyOf).
+type Rule interface {
+	// Check returns the reasons why ar does not meet the rule, if any
+	Check(ar AttestationResult) []RuleFailure
+	// String returns a human readable description of the rule
+	String() string
+}
+
+type tierRule struct {
+	submod   string
+	claim    string
+	required TrustTier
+}
+
+// RequireStatus returns a Rule that is met if the "ear.status" of the named
+// submod (or of every submod, see AnySubmod) is at least as good as the
+// required tier, e.g., RequireStatus("PSA", TrustTierWarning) accepts both
+// affirming and warning.
+func RequireStatus(submod string, required TrustTier) Rule {
+	return tierRule{submod: submod, claim: "ear.status", required: required}
+}
+
+// RequireClaim returns a Rule that is met if the named trustworthiness vector
+// claim (e.g., "executables" or "hardware") of the named submod (or of every
+// submod, see AnySubmod) is at least as good as the required tier
+func RequireClaim(submod, claim string, required TrustTier) Rule {
+	return tierRule{submod: submod, claim: claim, required: required}
+}
+
+func (o tierRule) String() string {
+	return fmt.Sprintf("%s/%s >= %s", o.submod, o.claim, o.required)
+}
+
+func (o tierRule) Check(ar AttestationResult) []RuleFailure {
+	names := []string{o.submod}
+	if o.submod == AnySubmod {
+		names = ar.submodNames()
+	}
+
+	if len(names) == 0 {
+		return []RuleFailure{o.failure("", "no submods")}
+	}
+
+	var failures []RuleFailure
+
+	for _, name := range names {
+		a, ok := ar.GetSubmod(name)
+		if !ok {
+			failures = append(failures, o.failure(name, "submod not found"))
+			continue
+		}
+
+		actual, err := o.tier(a)
+		if err != nil {
+			failures = append(failures, o.failure(name, err.Error()))
+			continue
+		}
+
+		if !meetsTier(actual, o.required) {
+			failures = append(failures, o.failure(name,
+				fmt.Sprintf("%s does not meet %s", actual, o.required)))
+		}
+	}
+
+	return failures
+}
+
+func (o tierRule) tier(a *Appraisal) (TrustTier, error) {
+	if o.claim == "ear.status" {
+		if a.Status == nil {
+			return TrustTierNone, errors.New("missing 'ear.status'")
+		}
+		return *a.Status, nil
+	}
+
+	var tv TrustVector
+	if a.TrustVector != nil {
+		tv = *a.TrustVector
+	}
+
+	c, ok := tv.AsMap()[o.claim]
+	if !ok {
+		return TrustTierNone, fmt.Errorf("unknown trustworthiness vector claim %q", o.claim)
+	}
+
+	return c.GetTier(), nil
+}
+
+func (o tierRule) failure(submod, reason string) RuleFailure {
+	return RuleFailure{
+		Rule:   o.String(),
+		Submod: submod,
+		Claim:  o.claim,
+		Reason: reason,
+	}
+}
+
+type allOf []Rule
+
+// AllOf returns a Rule that is met if all of the supplied rules are met
+func AllOf(rules ...Rule) Rule {
+	return allOf(rules)
+}
+
+func (o allOf) String() string {
+	return "all of (" + joinRules(o) + ")"
+}
+
+func (o allOf) Check(ar AttestationResult) []RuleFailure {
+	var failures []RuleFailure
+
+	for _, r := range o {
+		failures = append(failures, r.Check(ar)...)
+	}
+
+	return failures
+}
+
+type anyOf []Rule
+
+// AnyOf returns a Rule that is met if at least one of the supplied rules is
+// met
+func AnyOf(rules ...Rule) Rule {
+	return anyOf(rules)
+}
+
+func (o anyOf) String() string {
+	return "any of (" + joinRules(o) + ")"
+}
+
+func (o anyOf) Check(ar AttestationResult) []RuleFailure {
+	var reasons []string
+
+	for _, r := range o {
+		failures := r.Check(ar)
+		if len(failures) == 0 {
+			return nil
+		}
+
+		for _, f := range failures {
+			reasons = append(reasons, f.String())
+		}
+	}
+
+	return []RuleFailure{{
+		Rule:   o.String(),
+		Reason: "no alternative met: " + strings.Join(reasons, "; "),
+	}}
+}
+
+func joinRules(rules []Rule) string {
+	s := make([]string, len(rules))
+	for i, r := range rules {
+		s[i] = r.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// AcceptancePolicy is a named set of rules that a relying party applies to
+// (verified) attestation results in order to decide whether to accept them,
+// e.g.:
+//
+//	p := ear.NewAcceptancePolicy("prod",
+//		ear.RequireClaim("PSA", "executables", ear.TrustTierAffirming),
+//		ear.RequireClaim("PSA", "hardware", ear.TrustTierWarning),
+//	)
+//
+// An AcceptancePolicy is met if all its rules are met.  (It is unrelated to
+// the appraisal policy used by the verifier, see Policy.)
+type AcceptancePolicy struct {
+	Name  string
+	Rules []Rule
+}
+
+// NewAcceptancePolicy returns an AcceptancePolicy with the supplied name and
+// rules
+func NewAcceptancePolicy(name string, rules ...Rule) *AcceptancePolicy {
+	return &AcceptancePolicy{Name: name, Rules: rules}
+}
+
+// AcceptanceResult is the outcome of the evaluation of an AcceptancePolicy
+type AcceptanceResult struct {
+	// Policy is the name of the evaluated policy
+	Policy string
+	// Accepted is true if all the rules of the policy are met
+	Accepted bool
+	// Failures are the reasons why the result was not accepted
+	Failures []RuleFailure
+}
+
+// Err returns nil if the result was accepted, or else an error listing the
+// failures
+func (o AcceptanceResult) Err() error {
+	if o.Accepted {
+		return nil
+	}
+
+	reasons := make([]string, len(o.Failures))
+	for i, f := range o.Failures {
+		reasons[i] = f.String()
+	}
+
+	return fmt.Errorf("acceptance policy %q not met: %s", o.Policy, strings.Join(reasons, "; "))
+}
+
+// Evaluate checks ar against all the rules of the policy
+func (o AcceptancePolicy) Evaluate(ar AttestationResult) AcceptanceResult {
+	failures := allOf(o.Rules).Check(ar)
+
+	return AcceptanceResult{
+		Policy:   o.Name,
+		Accepted: len(failures) == 0,
+		Failures: failures,
+	}
+}
+
+// WithAcceptancePolicy instructs Verify to evaluate the supplied policy
+// against the verified result, and to fail if it is not met.  A nil policy
+// disables the check.
+func WithAcceptancePolicy(p *AcceptancePolicy) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.acceptancePolicy = p
+	})
+}
+
+func (o AttestationResult) checkAcceptance(cfg *verifyConfig) error {
+	if cfg.acceptancePolicy == nil {
+		return nil
+	}
+
+	return cfg.acceptancePolicy.Evaluate(o).Err()
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAcceptanceResult() AttestationResult {
+	psaStatus := TrustTierWarning
+	tpmStatus := TrustTierAffirming
+
+	return AttestationResult{
+		Submods: map[string]*Appraisal{
+			"PSA": {
+				Status: &psaStatus,
+				TrustVector: &TrustVector{
+					Executables: ApprovedRuntimeClaim,
+					Hardware:    UnsafeHardwareClaim,
+				},
+			},
+			"TPM": {
+				Status: &tpmStatus,
+				TrustVector: &TrustVector{
+					Executables: ApprovedRuntimeClaim,
+					Hardware:    GenuineHardwareClaim,
+				},
+			},
+		},
+	}
+}
+
+func TestAcceptancePolicy_Evaluate(t *testing.T) {
+	ar := testAcceptanceResult()
+
+	tvs := []struct {
+		rules    []Rule
+		expected []string
+	}{
+		{
+			rules: []Rule{
+				RequireClaim("PSA", "executables", TrustTierAffirming),
+				RequireClaim("PSA", "hardware", TrustTierWarning),
+			},
+		},
+		{
+			rules: []Rule{
+				RequireStatus(AnySubmod, TrustTierWarning),
+				RequireClaim(AnySubmod, "executables", TrustTierAffirming),
+			},
+		},
+		{
+			rules: []Rule{
+				RequireStatus(AnySubmod, TrustTierAffirming),
+			},
+			expected: []string{
+				"PSA/ear.status: warning does not meet affirming (*/ear.status >= affirming)",
+			},
+		},
+		{
+			rules: []Rule{
+				RequireClaim("PSA", "hardware", TrustTierAffirming),
+				RequireClaim("TPM", "configuration", TrustTierWarning),
+				RequireClaim("TPM", "no-such-claim", TrustTierWarning),
+				RequireStatus("CCA", TrustTierWarning),
+			},
+			expected: []string{
+				"PSA/hardware: warning does not meet affirming (PSA/hardware >= affirming)",
+				"TPM/configuration: none does not meet warning (TPM/configuration >= warning)",
+				`TPM/no-such-claim: unknown trustworthiness vector claim "no-such-claim" (TPM/no-such-claim >= warning)`,
+				"CCA/ear.status: submod not found (CCA/ear.status >= warning)",
+			},
+		},
+		{
+			rules: []Rule{
+				AnyOf(
+					RequireStatus("PSA", TrustTierAffirming),
+					RequireStatus("TPM", TrustTierAffirming),
+				),
+			},
+		},
+		{
+			rules: []Rule{
+				AnyOf(
+					RequireStatus("PSA", TrustTierAffirming),
+					AllOf(
+						RequireStatus("TPM", TrustTierAffirming),
+						RequireClaim("TPM", "hardware", TrustTierContraindicated),
+						RequireClaim("TPM", "configuration", TrustTierAffirming),
+					),
+				),
+			},
+			expected: []string{
+				"no alternative met: " +
+					"PSA/ear.status: warning does not meet affirming (PSA/ear.status >= affirming); " +
+					"TPM/configuration: none does not meet affirming (TPM/configuration >= affirming) " +
+					"(any of (PSA/ear.status >= affirming, all of (TPM/ear.status >= affirming, " +
+					"TPM/hardware >= contraindicated, TPM/configuration >= affirming)))",
+			},
+		},
+	}
+
+	for i, tv := range tvs {
+		res := NewAcceptancePolicy("test", tv.rules...).Evaluate(ar)
+
+		var actual []string
+		for _, f := range res.Failures {
+			actual = append(actual, f.String())
+		}
+
+		assert.Equal(t, tv.expected, actual, "failed test vector at index %d", i)
+		assert.Equal(t, len(tv.expected) == 0, res.Accepted, "failed test vector at index %d", i)
+		assert.Equal(t, "test", res.Policy, "failed test vector at index %d", i)
+	}
+}
+
+func TestAcceptancePolicy_no_submods(t *testing.T) {
+	res := NewAcceptancePolicy("test", RequireStatus(AnySubmod, TrustTierWarning)).
+		Evaluate(AttestationResult{})
+	assert.False(t, res.Accepted)
+	assert.EqualError(t, res.Err(),
+		`acceptance policy "test" not met: ear.status: no submods (*/ear.status >= warning)`)
+}
+
+func TestVerify_WithAcceptancePolicy(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+
+	assert.NoError(t, actual.Verify(token, jwa.ES256, vfyK,
+		WithAcceptancePolicy(NewAcceptancePolicy("ok",
+			RequireStatus("test", TrustTierAffirming)))))
+
+	err = actual.Verify(token, jwa.ES256, vfyK,
+		WithAcceptancePolicy(NewAcceptancePolicy("strict",
+			RequireClaim("test", "hardware", TrustTierAffirming))))
+	assert.EqualError(t, err, `acceptance policy "strict" not met: `+
+		`test/hardware: none does not meet affirming (test/hardware >= affirming)`)
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/base64"
+	"time"
+)
+
+// SetProfile sets the `eat_profile` claim
+func (o *AttestationResult) SetProfile(profile string) {
+	o.Profile = &profile
+}
+
+// GetProfile returns the `eat_profile` claim, if set
+func (o AttestationResult) GetProfile() (string, bool) {
+	if o.Profile == nil {
+		return "", false
+	}
+	return *o.Profile, true
+}
+
+// SetIssuedAt sets the `iat` claim
+func (o *AttestationResult) SetIssuedAt(t time.Time) {
+	iat := t.Unix()
+	o.IssuedAt = &iat
+}
+
+// GetIssuedAt returns the `iat` claim, if set
+func (o AttestationResult) GetIssuedAt() (time.Time, bool) {
+	return unixTime(o.IssuedAt)
+}
+
+// GetExpiry returns the `exp` claim, if set
+func (o AttestationResult) GetExpiry() (time.Time, bool) {
+	return unixTime(o.Expiry)
+}
+
+// GetNotBefore returns the `nbf` claim, if set
+func (o AttestationResult) GetNotBefore() (time.Time, bool) {
+	return unixTime(o.NotBefore)
+}
+
+// SetVerifierID sets the `ear.verifier-id` claim from the build and developer
+// of the verifier
+func (o *AttestationResult) SetVerifierID(build, developer string) {
+	o.VerifierID = &VerifierIdentity{Build: &build, Developer: &developer}
+}
+
+// GetVerifierID returns the build and developer in the `ear.verifier-id`
+// claim, if set
+func (o AttestationResult) GetVerifierID() (build string, developer string, ok bool) {
+	if o.VerifierID == nil || o.VerifierID.Build == nil || o.VerifierID.Developer == nil {
+		return "", "", false
+	}
+	return *o.VerifierID.Build, *o.VerifierID.Developer, true
+}
+
+// SetNonce sets the `eat_nonce` claim to the base64url encoding of nonce, as
+// EAT requires for JSON serializations.  For 8 to 64 byte nonces, the
+// resulting claim is valid.  Any nonces set using SetNonces are replaced.
+func (o *AttestationResult) SetNonce(nonce []byte) {
+	n := base64.RawURLEncoding.EncodeToString(nonce)
+	o.Nonce = &n
+	o.Nonces = nil
+}
+
+// SetNonces sets the `eat_nonce` claim to the base64url encoding of each of
+// the supplied nonces.  Two or more nonces are carried in the array form of
+// the claim, while a single nonce is carried as is (see SetNonce).
+func (o *AttestationResult) SetNonces(nonces ...[]byte) {
+	if len(nonces) == 1 {
+		o.SetNonce(nonces[0])
+		return
+	}
+
+	o.Nonce = nil
+	o.Nonces = nil
+
+	for _, nonce := range nonces {
+		o.Nonces = append(o.Nonces, base64.RawURLEncoding.EncodeToString(nonce))
+	}
+}
+
+// GetNonce returns the decoded `eat_nonce` claim, if set to a single nonce and
+// base64url encoded (see SetNonce).  Use GetNonces for the array form.
+func (o AttestationResult) GetNonce() ([]byte, bool) {
+	if o.Nonce == nil {
+		return nil, false
+	}
+
+	nonce, err := base64.RawURLEncoding.DecodeString(*o.Nonce)
+	if err != nil {
+		return nil, false
+	}
+
+	return nonce, true
+}
+
+// GetNonces returns the decoded nonces in the `eat_nonce` claim, whether it is
+// a single nonce or an array of nonces.  false is returned if the claim is not
+// set, or if any of its nonces is not base64url encoded.
+func (o AttestationResult) GetNonces() ([][]byte, bool) {
+	encoded := o.rawNonces()
+	if len(encoded) == 0 {
+		return nil, false
+	}
+
+	nonces := make([][]byte, len(encoded))
+
+	for i, n := range encoded {
+		nonce, err := base64.RawURLEncoding.DecodeString(n)
+		if err != nil {
+			return nil, false
+		}
+		nonces[i] = nonce
+	}
+
+	return nonces, true
+}
+
+// rawNonces returns the (undecoded) nonces in the `eat_nonce` claim, in either
+// form
+func (o AttestationResult) rawNonces() []string {
+	if o.Nonce != nil {
+		return []string{*o.Nonce}
+	}
+
+	return o.Nonces
+}
+
+// SetRawEvidence sets the `ear.raw-evidence` claim
+func (o *AttestationResult) SetRawEvidence(evidence []byte) {
+	raw := B64Url(append([]byte(nil), evidence...))
+	o.RawEvidence = &raw
+}
+
+// GetRawEvidence returns the `ear.raw-evidence` claim, if set
+func (o AttestationResult) GetRawEvidence() ([]byte, bool) {
+	if o.RawEvidence == nil {
+		return nil, false
+	}
+	return []byte(*o.RawEvidence), true
+}
+
+// GetSubmod returns the appraisal of the named submod, if present
+func (o AttestationResult) GetSubmod(name string) (*Appraisal, bool) {
+	a, ok := o.Submods[name]
+	if !ok || a == nil {
+		return nil, false
+	}
+	return a, true
+}
+
+// SetStatus sets the "ear.status" of the named submod, adding the submod if
+// it is not present
+func (o *AttestationResult) SetStatus(submod string, status TrustTier) {
+	if o.Submods == nil {
+		o.Submods = map[string]*Appraisal{}
+	}
+
+	a, ok := o.Submods[submod]
+	if !ok || a == nil {
+		a = &Appraisal{}
+		o.Submods[submod] = a
+	}
+
+	a.SetStatus(status)
+}
+
+// GetStatus returns the "ear.status" of the named submod, if present
+func (o AttestationResult) GetStatus(submod string) (TrustTier, bool) {
+	a, ok := o.GetSubmod(submod)
+	if !ok {
+		return TrustTierNone, false
+	}
+	return a.GetStatus()
+}
+
+// SetStatus sets the "ear.status" claim
+func (o *Appraisal) SetStatus(status TrustTier) {
+	o.Status = &status
+}
+
+// GetStatus returns the "ear.status" claim, if set
+func (o Appraisal) GetStatus() (TrustTier, bool) {
+	if o.Status == nil {
+		return TrustTierNone, false
+	}
+	return *o.Status, true
+}
+
+// SetTrustVector sets the "ear.trustworthiness-vector" claim
+func (o *Appraisal) SetTrustVector(tv TrustVector) {
+	o.TrustVector = &tv
+}
+
+// GetTrustVector returns the "ear.trustworthiness-vector" claim, if set
+func (o Appraisal) GetTrustVector() (TrustVector, bool) {
+	if o.TrustVector == nil {
+		return TrustVector{}, false
+	}
+	return *o.TrustVector, true
+}
+
+// SetAppraisalPolicyID sets the "ear.appraisal-policy-id" claim
+func (o *Appraisal) SetAppraisalPolicyID(id string) {
+	o.AppraisalPolicyID = &id
+}
+
+// GetAppraisalPolicyID returns the "ear.appraisal-policy-id" claim, if set
+func (o Appraisal) GetAppraisalPolicyID() (string, bool) {
+	if o.AppraisalPolicyID == nil {
+		return "", false
+	}
+	return *o.AppraisalPolicyID, true
+}
+
+func unixTime(t *int64) (time.Time, bool) {
+	if t == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(*t, 0), true
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessors_round_trip(t *testing.T) {
+	var ar AttestationResult
+
+	ar.SetProfile(EatProfile)
+	ar.SetIssuedAt(time.Unix(testIAT, 0))
+	ar.SetExpiry(time.Unix(testIAT, 0).Add(time.Hour))
+	ar.SetNotBefore(time.Unix(testIAT, 0))
+	ar.SetVerifierID("rrtrap-v1.0.0", "Acme Inc.")
+	ar.SetNonce([]byte("0123456789abcdef"))
+	ar.SetRawEvidence([]byte{0xde, 0xad, 0xbe, 0xef})
+	ar.SetStatus("test", TrustTierAffirming)
+
+	require.NoError(t, ar.validate())
+
+	profile, ok := ar.GetProfile()
+	assert.True(t, ok)
+	assert.Equal(t, EatProfile, profile)
+
+	iat, ok := ar.GetIssuedAt()
+	assert.True(t, ok)
+	assert.Equal(t, testIAT, iat.Unix())
+
+	exp, ok := ar.GetExpiry()
+	assert.True(t, ok)
+	assert.Equal(t, testIAT+3600, exp.Unix())
+
+	nbf, ok := ar.GetNotBefore()
+	assert.True(t, ok)
+	assert.Equal(t, testIAT, nbf.Unix())
+
+	build, developer, ok := ar.GetVerifierID()
+	assert.True(t, ok)
+	assert.Equal(t, "rrtrap-v1.0.0", build)
+	assert.Equal(t, "Acme Inc.", developer)
+
+	nonce, ok := ar.GetNonce()
+	assert.True(t, ok)
+	assert.Equal(t, []byte("0123456789abcdef"), nonce)
+	assert.Equal(t, "MDEyMzQ1Njc4OWFiY2RlZg", *ar.Nonce)
+
+	evidence, ok := ar.GetRawEvidence()
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, evidence)
+
+	status, ok := ar.GetStatus("test")
+	assert.True(t, ok)
+	assert.Equal(t, TrustTierAffirming, status)
+
+	// updates the existing submod
+	ar.SetStatus("test", TrustTierWarning)
+	status, _ = ar.GetStatus("test")
+	assert.Equal(t, TrustTierWarning, status)
+	assert.Len(t, ar.Submods, 1)
+}
+
+func TestAccessors_absent(t *testing.T) {
+	var ar AttestationResult
+
+	_, ok := ar.GetProfile()
+	assert.False(t, ok)
+
+	_, ok = ar.GetIssuedAt()
+	assert.False(t, ok)
+
+	_, ok = ar.GetExpiry()
+	assert.False(t, ok)
+
+	_, ok = ar.GetNotBefore()
+	assert.False(t, ok)
+
+	_, _, ok = ar.GetVerifierID()
+	assert.False(t, ok)
+
+	_, ok = ar.GetNonce()
+	assert.False(t, ok)
+
+	_, ok = ar.GetRawEvidence()
+	assert.False(t, ok)
+
+	_, ok = ar.GetSubmod("test")
+	assert.False(t, ok)
+
+	status, ok := ar.GetStatus("test")
+	assert.False(t, ok)
+	assert.Equal(t, TrustTierNone, status)
+
+	// not base64url
+	nonce := "not base64url!"
+	ar.Nonce = &nonce
+	_, ok = ar.GetNonce()
+	assert.False(t, ok)
+	_, ok = ar.GetNonces()
+	assert.False(t, ok)
+}
+
+func TestAttestationResult_SetGetNonces(t *testing.T) {
+	var ar AttestationResult
+
+	_, ok := ar.GetNonces()
+	assert.False(t, ok)
+
+	ar.SetNonces([]byte("0123456789abcdef"), []byte("fedcba9876543210"))
+	assert.Nil(t, ar.Nonce)
+	assert.Equal(t, []string{"MDEyMzQ1Njc4OWFiY2RlZg", "ZmVkY2JhOTg3NjU0MzIxMA"}, ar.Nonces)
+
+	nonces, ok := ar.GetNonces()
+	require.True(t, ok)
+	assert.Equal(t, [][]byte{[]byte("0123456789abcdef"), []byte("fedcba9876543210")}, nonces)
+
+	// GetNonce only deals with the single form
+	_, ok = ar.GetNonce()
+	assert.False(t, ok)
+
+	// a single nonce is carried as is
+	ar.SetNonces([]byte("0123456789abcdef"))
+	assert.Nil(t, ar.Nonces)
+	assert.Equal(t, "MDEyMzQ1Njc4OWFiY2RlZg", *ar.Nonce)
+
+	nonces, ok = ar.GetNonces()
+	require.True(t, ok)
+	assert.Equal(t, [][]byte{[]byte("0123456789abcdef")}, nonces)
+}
+
+func TestAppraisal_accessors(t *testing.T) {
+	var a Appraisal
+
+	_, ok := a.GetStatus()
+	assert.False(t, ok)
+
+	_, ok = a.GetTrustVector()
+	assert.False(t, ok)
+
+	_, ok = a.GetAppraisalPolicyID()
+	assert.False(t, ok)
+
+	a.SetStatus(TrustTierContraindicated)
+	a.SetTrustVector(TrustVector{Executables: ApprovedRuntimeClaim})
+	a.SetAppraisalPolicyID("policy://test/01234")
+
+	status, ok := a.GetStatus()
+	assert.True(t, ok)
+	assert.Equal(t, TrustTierContraindicated, status)
+
+	tv, ok := a.GetTrustVector()
+	assert.True(t, ok)
+	assert.Equal(t, ApprovedRuntimeClaim, tv.Executables)
+
+	id, ok := a.GetAppraisalPolicyID()
+	assert.True(t, ok)
+	assert.Equal(t, "policy://test/01234", id)
+}
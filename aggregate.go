@@ -0,0 +1,161 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CompositeSubmodName is the name of the synthetic submod added by
+// AddCompositeSubmod
+const CompositeSubmodName = "composite"
+
+// AggregationStrategy computes an overall trust tier from the statuses of the
+// submods of a result, keyed by submod name.  Submods without an
+// "ear.status" are reported as TrustTierNone.
+type AggregationStrategy func(statuses map[string]TrustTier) TrustTier
+
+// WorstOf returns an AggregationStrategy that picks the worst of the
+// statuses (see CompareTiers)
+func WorstOf() AggregationStrategy {
+	return func(statuses map[string]TrustTier) TrustTier {
+		worst, first := TrustTierNone, true
+
+		for _, s := range statuses {
+			if first || CompareTiers(s, worst) < 0 {
+				worst, first = s, false
+			}
+		}
+
+		return worst
+	}
+}
+
+// Weighted returns an AggregationStrategy that computes the weighted average
+// of the statuses (ranked as in CompareTiers), rounded down to the nearest
+// tier.  Submods are weighted 1 unless otherwise stated in weights; submods
+// weighted 0 (or less) are ignored.  Any contraindicated submod that is not
+// ignored makes the aggregate contraindicated, regardless of weights.
+func Weighted(weights map[string]float64) AggregationStrategy {
+	return func(statuses map[string]TrustTier) TrustTier {
+		var sum, total float64
+
+		for name, s := range statuses {
+			w, ok := weights[name]
+			if !ok {
+				w = 1
+			}
+
+			if w <= 0 {
+				continue
+			}
+
+			if s == TrustTierContraindicated {
+				return TrustTierContraindicated
+			}
+
+			sum += w * float64(trustTierRank[s])
+			total += w
+		}
+
+		if total == 0 {
+			return TrustTierNone
+		}
+
+		return tierFromRank(int(sum / total))
+	}
+}
+
+func tierFromRank(rank int) TrustTier {
+	for t, r := range trustTierRank {
+		if r == rank {
+			return t
+		}
+	}
+
+	return TrustTierNone
+}
+
+func (o AttestationResult) submodStatuses() map[string]TrustTier {
+	statuses := make(map[string]TrustTier, len(o.Submods))
+
+	for name, a := range o.Submods {
+		s := TrustTierNone
+		if a != nil && a.Status != nil {
+			s = *a.Status
+		}
+		statuses[name] = s
+	}
+
+	return statuses
+}
+
+// AggregateStatus returns the worst of the statuses of all the submods, i.e.,
+// the single trust tier that the result as a whole warrants.  A result
+// without submods yields TrustTierNone.
+func (o AttestationResult) AggregateStatus() TrustTier {
+	return WorstOf()(o.submodStatuses())
+}
+
+// MergeSubmods returns a synthetic appraisal summarizing all the submods of
+// the result: its "ear.status" is computed using the supplied strategy, and
+// each claim of its trustworthiness vector is the worst of the corresponding
+// claims made by the submods.  The synthetic appraisal is not added to the result
+// (see AddCompositeSubmod).
+func (o AttestationResult) MergeSubmods(strategy AggregationStrategy) (*Appraisal, error) {
+	if strategy == nil {
+		return nil, errors.New("nil aggregation strategy")
+	}
+
+	if len(o.Submods) == 0 {
+		return nil, errors.New("no submods to merge")
+	}
+
+	status := strategy(o.submodStatuses())
+
+	var (
+		merged TrustVector
+		found  bool
+	)
+
+	for _, name := range o.submodNames() {
+		a := o.Submods[name]
+		if a == nil || a.TrustVector == nil {
+			continue
+		}
+
+		if !found {
+			merged, found = *a.TrustVector, true
+			continue
+		}
+
+		merged = merged.Merge(*a.TrustVector, MergeWorstOf())
+	}
+
+	composite := &Appraisal{Status: &status}
+	if found {
+		composite.TrustVector = &merged
+	}
+
+	return composite, nil
+}
+
+// AddCompositeSubmod adds the appraisal computed by MergeSubmods to the
+// result, under CompositeSubmodName.  An error is returned if a submod with
+// that name already exists.
+func (o *AttestationResult) AddCompositeSubmod(strategy AggregationStrategy) error {
+	if _, ok := o.Submods[CompositeSubmodName]; ok {
+		return fmt.Errorf("submod %q already exists", CompositeSubmodName)
+	}
+
+	composite, err := o.MergeSubmods(strategy)
+	if err != nil {
+		return err
+	}
+
+	o.Submods[CompositeSubmodName] = composite
+
+	return nil
+}
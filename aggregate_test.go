@@ -0,0 +1,131 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMultiSubmodResult(statuses map[string]TrustTier) AttestationResult {
+	ar := AttestationResult{Submods: map[string]*Appraisal{}}
+
+	for name, s := range statuses {
+		ar.SetStatus(name, s)
+	}
+
+	return ar
+}
+
+func TestAttestationResult_AggregateStatus(t *testing.T) {
+	tvs := []struct {
+		statuses map[string]TrustTier
+		expected TrustTier
+	}{
+		{
+			statuses: map[string]TrustTier{},
+			expected: TrustTierNone,
+		},
+		{
+			statuses: map[string]TrustTier{"a": TrustTierAffirming, "b": TrustTierAffirming},
+			expected: TrustTierAffirming,
+		},
+		{
+			statuses: map[string]TrustTier{"a": TrustTierAffirming, "b": TrustTierWarning},
+			expected: TrustTierWarning,
+		},
+		{
+			statuses: map[string]TrustTier{"a": TrustTierAffirming, "b": TrustTierNone},
+			expected: TrustTierNone,
+		},
+		{
+			statuses: map[string]TrustTier{"a": TrustTierContraindicated, "b": TrustTierNone},
+			expected: TrustTierContraindicated,
+		},
+	}
+
+	for i, tv := range tvs {
+		ar := testMultiSubmodResult(tv.statuses)
+		assert.Equal(t, tv.expected, ar.AggregateStatus(), "failed test vector at index %d", i)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	statuses := map[string]TrustTier{
+		"a": TrustTierAffirming,
+		"b": TrustTierAffirming,
+		"c": TrustTierWarning,
+	}
+
+	tvs := []struct {
+		weights  map[string]float64
+		expected TrustTier
+	}{
+		// (3 + 3 + 2) / 3, rounded down
+		{nil, TrustTierWarning},
+		// (3*5 + 3*5 + 2) / 11, rounded down
+		{map[string]float64{"a": 5, "b": 5}, TrustTierWarning},
+		// c ignored
+		{map[string]float64{"c": 0}, TrustTierAffirming},
+		// everything ignored
+		{map[string]float64{"a": 0, "b": 0, "c": 0}, TrustTierNone},
+	}
+
+	for i, tv := range tvs {
+		assert.Equal(t, tv.expected, Weighted(tv.weights)(statuses), "failed test vector at index %d", i)
+	}
+
+	statuses["d"] = TrustTierContraindicated
+	assert.Equal(t, TrustTierContraindicated, Weighted(map[string]float64{"d": 0.01})(statuses))
+	assert.Equal(t, TrustTierAffirming, Weighted(map[string]float64{"c": 0, "d": 0})(statuses))
+}
+
+func TestAttestationResult_MergeSubmods(t *testing.T) {
+	ar := testMultiSubmodResult(map[string]TrustTier{
+		"a": TrustTierAffirming,
+		"b": TrustTierWarning,
+		"c": TrustTierAffirming,
+	})
+
+	ar.Submods["a"].SetTrustVector(TrustVector{
+		Executables: ApprovedRuntimeClaim,
+		Hardware:    GenuineHardwareClaim,
+	})
+	ar.Submods["b"].SetTrustVector(TrustVector{
+		Executables: UnrecognizedRuntimeClaim,
+		FileSystem:  ApprovedFilesClaim,
+	})
+
+	composite, err := ar.MergeSubmods(WorstOf())
+	require.NoError(t, err)
+
+	status, _ := composite.GetStatus()
+	assert.Equal(t, TrustTierWarning, status)
+
+	tv, ok := composite.GetTrustVector()
+	require.True(t, ok)
+	assert.Equal(t, TrustVector{
+		Executables: UnrecognizedRuntimeClaim,
+		Hardware:    GenuineHardwareClaim,
+		FileSystem:  ApprovedFilesClaim,
+	}, tv)
+
+	require.NoError(t, ar.AddCompositeSubmod(WorstOf()))
+	assert.Equal(t, composite, ar.Submods[CompositeSubmodName])
+
+	err = ar.AddCompositeSubmod(WorstOf())
+	assert.EqualError(t, err, `submod "composite" already exists`)
+}
+
+func TestAttestationResult_MergeSubmods_fail(t *testing.T) {
+	var ar AttestationResult
+
+	_, err := ar.MergeSubmods(WorstOf())
+	assert.EqualError(t, err, "no submods to merge")
+
+	_, err = ar.MergeSubmods(nil)
+	assert.EqualError(t, err, "nil aggregation strategy")
+}
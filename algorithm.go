@@ -0,0 +1,198 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	cose "github.com/veraison/go-cose"
+)
+
+// CustomAlgorithm is a signature algorithm that is not natively supported by
+// the JOSE and COSE libraries used by this package, e.g., a post-quantum
+// algorithm such as ML-DSA.  Once plugged in using RegisterAlgorithm, it can
+// be used with Sign, Verify, SignCWT and VerifyCWT like any other algorithm.
+type CustomAlgorithm interface {
+	// JWSAlgorithm returns the JOSE identifier of the algorithm
+	JWSAlgorithm() jwa.SignatureAlgorithm
+	// COSEAlgorithm returns the COSE identifier of the algorithm
+	COSEAlgorithm() cose.Algorithm
+	// Sign returns the signature of data made with the supplied private key
+	Sign(key interface{}, data []byte) ([]byte, error)
+	// Verify checks the signature of data using the supplied public key
+	Verify(key interface{}, data, sig []byte) error
+}
+
+// JOSE identifiers of the ML-DSA (FIPS 204) post-quantum signature
+// algorithms.  An implementation is registered automatically when building
+// with Go 1.26 or later, which provides crypto/mldsa.  With older toolchains,
+// an implementation can be plugged in using RegisterAlgorithm.
+const (
+	MLDSA44 jwa.SignatureAlgorithm = "ML-DSA-44"
+	MLDSA65 jwa.SignatureAlgorithm = "ML-DSA-65"
+	MLDSA87 jwa.SignatureAlgorithm = "ML-DSA-87"
+)
+
+var (
+	customAlgorithmsMu sync.RWMutex
+	customAlgorithms   = map[jwa.SignatureAlgorithm]CustomAlgorithm{}
+)
+
+// RegisterAlgorithm plugs a CustomAlgorithm in, replacing any custom
+// algorithm previously registered with the same JOSE identifier.  Natively
+// supported algorithms cannot be replaced.
+func RegisterAlgorithm(a CustomAlgorithm) error {
+	if a == nil {
+		return errors.New("nil algorithm")
+	}
+
+	alg := a.JWSAlgorithm()
+
+	var native jwa.SignatureAlgorithm
+	if err := native.Accept(alg.String()); err == nil {
+		return fmt.Errorf("%q is natively supported", alg)
+	}
+
+	customAlgorithmsMu.Lock()
+	defer customAlgorithmsMu.Unlock()
+
+	for k, v := range customAlgorithms {
+		if k != alg && v.COSEAlgorithm() == a.COSEAlgorithm() {
+			return fmt.Errorf("COSE algorithm %d is already registered for %q", a.COSEAlgorithm(), k)
+		}
+	}
+
+	customAlgorithms[alg] = a
+
+	return nil
+}
+
+func lookupCustomAlgorithm(alg jwa.KeyAlgorithm) (CustomAlgorithm, bool) {
+	customAlgorithmsMu.RLock()
+	defer customAlgorithmsMu.RUnlock()
+
+	a, ok := customAlgorithms[jwa.SignatureAlgorithm(alg.String())]
+
+	return a, ok
+}
+
+func lookupCustomCOSEAlgorithm(alg int64) (CustomAlgorithm, bool) {
+	customAlgorithmsMu.RLock()
+	defer customAlgorithmsMu.RUnlock()
+
+	for _, a := range customAlgorithms {
+		if int64(a.COSEAlgorithm()) == alg {
+			return a, true
+		}
+	}
+
+	return nil, false
+}
+
+// signCustomJWT wraps the claims-set in a compact JWT signed using a custom
+// algorithm, which the JOSE library does not know about
+func signCustomJWT(
+	claims map[string]interface{},
+	a CustomAlgorithm,
+	key interface{},
+	headers map[string]interface{},
+) ([]byte, error) {
+	raw, err := rawKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	protected := map[string]interface{}{jws.TypeKey: "JWT"}
+	for k, v := range headers {
+		protected[k] = v
+	}
+	protected[jws.AlgorithmKey] = a.JWSAlgorithm().String()
+
+	hdr, err := json.Marshal(protected)
+	if err != nil {
+		return nil, fmt.Errorf("serializing protected header: %w", err)
+	}
+
+	payload, err := json.Marshal(numericDates(claims))
+	if err != nil {
+		return nil, fmt.Errorf("serializing claims-set: %w", err)
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(hdr) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+
+	sig, err := a.Sign(raw, []byte(input))
+	if err != nil {
+		return nil, fmt.Errorf("signing with %s: %w", a.JWSAlgorithm(), err)
+	}
+
+	return []byte(input + "." + base64.RawURLEncoding.EncodeToString(sig)), nil
+}
+
+// verifyCustomJWT is the counterpart of signCustomJWT.  As for natively
+// supported algorithms, the token is accepted if any of its signatures can be
+// verified.
+func verifyCustomJWT(
+	data []byte,
+	a CustomAlgorithm,
+	key interface{},
+	cfg *verifyConfig,
+) (map[string]interface{}, error) {
+	msg, err := parseJWSAsJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed verifying JWT message: %w", err)
+	}
+
+	verify, err := newJWSVerifyFunc(a.JWSAlgorithm(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !msg.verifiedBy(msg.Signatures, a.JWSAlgorithm(), verify) {
+		return nil, errors.New("failed verifying JWT message: " +
+			"could not verify message using any of the signatures or keys")
+	}
+
+	return msg.claims(cfg)
+}
+
+// coseCustomSigner adapts a CustomAlgorithm to the go-cose Signer interface
+type coseCustomSigner struct {
+	alg CustomAlgorithm
+	key interface{}
+}
+
+func (o coseCustomSigner) Algorithm() cose.Algorithm {
+	return o.alg.COSEAlgorithm()
+}
+
+func (o coseCustomSigner) Sign(_ io.Reader, content []byte) ([]byte, error) {
+	return o.alg.Sign(o.key, content)
+}
+
+// coseCustomVerifier adapts a CustomAlgorithm to the go-cose Verifier
+// interface
+type coseCustomVerifier struct {
+	alg CustomAlgorithm
+	key interface{}
+}
+
+func (o coseCustomVerifier) Algorithm() cose.Algorithm {
+	return o.alg.COSEAlgorithm()
+}
+
+func (o coseCustomVerifier) Verify(content, sig []byte) error {
+	if err := o.alg.Verify(o.key, content, sig); err != nil {
+		return cose.ErrVerification
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+// testAlgorithm is a stand-in for an algorithm unknown to the JOSE and COSE
+// libraries
+type testAlgorithm struct {
+	alg     jwa.SignatureAlgorithm
+	coseAlg cose.Algorithm
+}
+
+func (o testAlgorithm) JWSAlgorithm() jwa.SignatureAlgorithm { return o.alg }
+
+func (o testAlgorithm) COSEAlgorithm() cose.Algorithm { return o.coseAlg }
+
+func (o testAlgorithm) Sign(key interface{}, data []byte) ([]byte, error) {
+	sk, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an Ed25519 private key")
+	}
+
+	return ed25519.Sign(sk, data), nil
+}
+
+func (o testAlgorithm) Verify(key interface{}, data, sig []byte) error {
+	var pk ed25519.PublicKey
+
+	switch t := key.(type) {
+	case ed25519.PublicKey:
+		pk = t
+	case ed25519.PrivateKey:
+		pk = t.Public().(ed25519.PublicKey)
+	}
+
+	if !ed25519.Verify(pk, data, sig) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
+
+var testCustomAlgorithm = testAlgorithm{alg: "TEST-ALG", coseAlg: -65000}
+
+func TestRegisterAlgorithm(t *testing.T) {
+	require.NoError(t, RegisterAlgorithm(testCustomAlgorithm))
+
+	assert.EqualError(t, RegisterAlgorithm(nil), "nil algorithm")
+	assert.EqualError(t, RegisterAlgorithm(testAlgorithm{alg: jwa.ES256, coseAlg: -65001}),
+		`"ES256" is natively supported`)
+	assert.EqualError(t, RegisterAlgorithm(testAlgorithm{alg: "OTHER-ALG", coseAlg: -65000}),
+		`COSE algorithm -65000 is already registered for "TEST-ALG"`)
+}
+
+func TestCustomAlgorithm_round_trip(t *testing.T) {
+	require.NoError(t, RegisterAlgorithm(testCustomAlgorithm))
+
+	sigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	now := time.Unix(testIAT, 0)
+
+	// JWT
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(testCustomAlgorithm.alg, sigK,
+		WithClock(FixedClock(now)), WithTTL(time.Hour))
+	require.NoError(t, err)
+
+	expected := testAttestationResultsWithVeraisonExtns
+	expected.SetExpiry(now.Add(time.Hour))
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, testCustomAlgorithm.alg, vfyK,
+		WithClock(FixedClock(now))))
+	assert.Equal(t, expected, actual)
+
+	err = actual.Verify(token, testCustomAlgorithm.alg, vfyK,
+		WithClock(FixedClock(now.Add(time.Hour))))
+	assert.EqualError(t, err, `failed verifying JWT message: "exp" not satisfied`)
+
+	// the JOSE library is not involved
+	err = actual.Verify(token, jwa.EdDSA, vfyK)
+	assert.ErrorContains(t, err, "failed verifying JWT message")
+
+	token[len(token)-2] ^= 1
+	err = actual.Verify(token, testCustomAlgorithm.alg, vfyK)
+	assert.EqualError(t, err, "failed verifying JWT message: "+
+		"could not verify message using any of the signatures or keys")
+
+	// CWT
+	token, err = testAttestationResultsWithVeraisonExtns.SignCWT(testCustomAlgorithm.alg, sigK)
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.VerifyCWT(token, testCustomAlgorithm.alg, vfyK))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+	token[len(token)-1] ^= 1
+	err = actual.VerifyCWT(token, testCustomAlgorithm.alg, vfyK)
+	assert.EqualError(t, err, "failed verifying CWT message: verification error")
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GetAnnotatedEvidence returns the value found at path in the
+// "ear.veraison.annotated-evidence" claim, descending through nested JSON
+// objects one path element at a time.  With no path, the whole claim is
+// returned.  An error is returned if the claim is not set, or if path does not
+// lead to a value.
+func (o AppraisalExtensions) GetAnnotatedEvidence(path ...string) (any, error) {
+	if o.VeraisonAnnotatedEvidence == nil {
+		return nil, errors.New(`"ear.veraison.annotated-evidence" claim not found`)
+	}
+
+	var v any = *o.VeraisonAnnotatedEvidence
+
+	for i, k := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%q: not a JSON object", strings.Join(path[:i], "/"))
+		}
+
+		if v, ok = m[k]; !ok {
+			return nil, fmt.Errorf("%q: not found", strings.Join(path[:i+1], "/"))
+		}
+	}
+
+	return v, nil
+}
+
+// SetAnnotatedEvidence sets the value found at path in the
+// "ear.veraison.annotated-evidence" claim to v, creating the claim and any
+// missing intermediate JSON object along the way.  Intermediate values that
+// are not JSON objects are replaced.  With no path, SetAnnotatedEvidence is a
+// no-op.
+func (o *AppraisalExtensions) SetAnnotatedEvidence(path []string, v any) {
+	if len(path) == 0 {
+		return
+	}
+
+	if o.VeraisonAnnotatedEvidence == nil {
+		o.VeraisonAnnotatedEvidence = &map[string]interface{}{}
+	}
+
+	m := *o.VeraisonAnnotatedEvidence
+
+	for _, k := range path[:len(path)-1] {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[k] = next
+		}
+		m = next
+	}
+
+	m[path[len(path)-1]] = v
+}
+
+// GetAnnotatedEvidenceString is like GetAnnotatedEvidence, but the value must
+// be a string
+func (o AppraisalExtensions) GetAnnotatedEvidenceString(path ...string) (string, error) {
+	v, err := o.GetAnnotatedEvidence(path...)
+	if err != nil {
+		return "", err
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%q: not a string", strings.Join(path, "/"))
+	}
+
+	return s, nil
+}
+
+// GetAnnotatedEvidenceBytes is like GetAnnotatedEvidence, but the value must
+// be a byte string, i.e., base64url encoded (see B64Url) in a decoded result
+func (o AppraisalExtensions) GetAnnotatedEvidenceBytes(path ...string) ([]byte, error) {
+	v, err := o.GetAnnotatedEvidence(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := v.(type) {
+	case []byte:
+		return t, nil
+	case B64Url:
+		return t, nil
+	case string:
+		b, err := base64.RawURLEncoding.DecodeString(t)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", strings.Join(path, "/"), err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("%q: not a byte string", strings.Join(path, "/"))
+	}
+}
+
+// GetAnnotatedEvidenceInt is like GetAnnotatedEvidence, but the value must be
+// an integer
+func (o AppraisalExtensions) GetAnnotatedEvidenceInt(path ...string) (int64, error) {
+	v, err := o.GetAnnotatedEvidence(path...)
+	if err != nil {
+		return 0, err
+	}
+
+	switch t := v.(type) {
+	case int:
+		return int64(t), nil
+	case int64:
+		return t, nil
+	case uint64:
+		if t <= math.MaxInt64 {
+			return int64(t), nil
+		}
+	case float64:
+		if t == math.Trunc(t) && t >= math.MinInt64 && t < math.MaxInt64 {
+			return int64(t), nil
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%q: not an integer", strings.Join(path, "/"))
+}
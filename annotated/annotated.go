@@ -0,0 +1,101 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package annotated provides the plumbing shared by the scheme-specific
+// packages (psa, cca, tdx and nitro) that populate and parse the
+// "ear.veraison.annotated-evidence" claim of an appraisal, so that verifier
+// plugins and relying parties agree on the shape of scheme evidence in EARs.
+package annotated
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/veraison/ear"
+)
+
+// ClaimName is the name of the appraisal claim carrying annotated evidence
+const ClaimName = "ear.veraison.annotated-evidence"
+
+// Evidence is implemented by the scheme-specific annotated evidence types
+type Evidence interface {
+	Validate() error
+}
+
+// Set validates the supplied evidence and stores it in the annotated evidence
+// claim of the appraisal, replacing any previous value
+func Set[T Evidence](a *ear.Appraisal, e T) error {
+	if a == nil {
+		return errors.New("nil appraisal")
+	}
+
+	if err := e.Validate(); err != nil {
+		return fmt.Errorf("invalid annotated evidence: %w", err)
+	}
+
+	return ear.SetExtension(&a.AppraisalExtensions, ClaimName, e)
+}
+
+// Get decodes and validates the annotated evidence claim of the appraisal
+func Get[T Evidence](a *ear.Appraisal) (T, error) {
+	var zero T
+
+	if a == nil {
+		return zero, errors.New("nil appraisal")
+	}
+
+	e, err := ear.GetExtension[T](&a.AppraisalExtensions, ClaimName)
+	if err != nil {
+		return zero, err
+	}
+
+	if err := e.Validate(); err != nil {
+		return zero, fmt.Errorf("invalid annotated evidence: %w", err)
+	}
+
+	return e, nil
+}
+
+// Problems accumulates validation problems, which are then reported together
+type Problems []string
+
+// Add records a problem with the named field
+func (o *Problems) Add(field, format string, args ...interface{}) {
+	*o = append(*o, fmt.Sprintf("%s: %s", field, fmt.Sprintf(format, args...)))
+}
+
+// Missing records that the named (mandatory) field is absent
+func (o *Problems) Missing(field string) {
+	o.Add(field, "missing")
+}
+
+// Size records a problem if b is present and its size is not among the
+// allowed ones
+func (o *Problems) Size(field string, b []byte, sizes ...int) {
+	if b == nil {
+		return
+	}
+
+	for _, s := range sizes {
+		if len(b) == s {
+			return
+		}
+	}
+
+	allowed := make([]string, len(sizes))
+	for i, s := range sizes {
+		allowed[i] = fmt.Sprint(s)
+	}
+
+	o.Add(field, "invalid size %d (expecting %s bytes)", len(b), strings.Join(allowed, " or "))
+}
+
+// Err returns the accumulated problems as an error, or nil if there are none
+func (o Problems) Err() error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(o, "; "))
+}
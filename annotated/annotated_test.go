@@ -0,0 +1,72 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package annotated
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+type testEvidence struct {
+	Foo string `json:"foo"`
+}
+
+func (o testEvidence) Validate() error {
+	if o.Foo == "" {
+		return errors.New("foo: missing")
+	}
+	return nil
+}
+
+func TestSetGet_ok(t *testing.T) {
+	var a ear.Appraisal
+
+	require.NoError(t, Set(&a, testEvidence{Foo: "bar"}))
+	assert.Equal(t, "bar", (*a.VeraisonAnnotatedEvidence)["foo"])
+
+	e, err := Get[testEvidence](&a)
+	require.NoError(t, err)
+	assert.Equal(t, testEvidence{Foo: "bar"}, e)
+}
+
+func TestSet_fail_invalid(t *testing.T) {
+	var a ear.Appraisal
+
+	err := Set(&a, testEvidence{})
+	assert.EqualError(t, err, "invalid annotated evidence: foo: missing")
+	assert.Nil(t, a.VeraisonAnnotatedEvidence)
+}
+
+func TestGet_fail(t *testing.T) {
+	var a ear.Appraisal
+
+	_, err := Get[testEvidence](&a)
+	assert.EqualError(t, err, `"ear.veraison.annotated-evidence" claim not found`)
+
+	a.VeraisonAnnotatedEvidence = &map[string]interface{}{"foo": ""}
+
+	_, err = Get[testEvidence](&a)
+	assert.EqualError(t, err, "invalid annotated evidence: foo: missing")
+
+	_, err = Get[testEvidence](nil)
+	assert.EqualError(t, err, "nil appraisal")
+}
+
+func TestProblems(t *testing.T) {
+	var p Problems
+	assert.NoError(t, p.Err())
+
+	p.Missing("a")
+	p.Size("b", []byte{0x00}, 32, 64)
+	p.Size("c", nil, 32)
+	p.Size("d", make([]byte, 32), 32)
+	p.Add("e", "bad value %d", 42)
+
+	assert.EqualError(t, p.Err(),
+		"a: missing; b: invalid size 1 (expecting 32 or 64 bytes); e: bad value 42")
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cca provides a typed view of the annotated evidence carried in EARs
+// for Arm CCA attestation tokens, i.e., the platform token and the realm
+// token it delegates to.  Field names match those used by the Veraison CCA
+// scheme.
+package cca
+
+import (
+	"fmt"
+
+	"github.com/veraison/ear"
+	"github.com/veraison/ear/annotated"
+)
+
+// SwComponent is a measured platform software component
+type SwComponent struct {
+	MeasurementType  string `json:"measurement-type,omitempty"`
+	MeasurementValue []byte `json:"measurement-value"`
+	Version          string `json:"version,omitempty"`
+	SignerID         []byte `json:"signer-id"`
+	HashAlgID        string `json:"hash-algo-id,omitempty"`
+}
+
+// PlatformClaims are the claims of the CCA platform token
+type PlatformClaims struct {
+	Profile          string        `json:"cca-platform-profile,omitempty"`
+	Challenge        []byte        `json:"cca-platform-challenge"`
+	ImplementationID []byte        `json:"cca-platform-implementation-id"`
+	InstanceID       []byte        `json:"cca-platform-instance-id"`
+	Config           []byte        `json:"cca-platform-config"`
+	Lifecycle        uint16        `json:"cca-platform-lifecycle"`
+	SwComponents     []SwComponent `json:"cca-platform-sw-components"`
+	ServiceIndicator string        `json:"cca-platform-service-indicator,omitempty"`
+	HashAlgID        string        `json:"cca-platform-hash-algo-id"`
+}
+
+// RealmClaims are the claims of the CCA realm token
+type RealmClaims struct {
+	Challenge              []byte   `json:"cca-realm-challenge"`
+	PersonalizationValue   []byte   `json:"cca-realm-personalization-value"`
+	InitialMeasurement     []byte   `json:"cca-realm-initial-measurement"`
+	ExtensibleMeasurements [][]byte `json:"cca-realm-extensible-measurements"`
+	HashAlgID              string   `json:"cca-realm-hash-algo-id"`
+	PublicKey              []byte   `json:"cca-realm-public-key"`
+	PublicKeyHashAlgID     string   `json:"cca-realm-public-key-hash-algo-id"`
+}
+
+// Evidence is the annotated evidence of a CCA attestation token
+type Evidence struct {
+	Platform *PlatformClaims `json:"cca-platform-token"`
+	Realm    *RealmClaims    `json:"cca-realm-delegated-token,omitempty"`
+}
+
+// Validate checks that the mandatory CCA claims are present and well-formed
+func (o Evidence) Validate() error {
+	var p annotated.Problems
+
+	if o.Platform == nil {
+		p.Missing("cca-platform-token")
+	} else {
+		o.Platform.validate(&p)
+	}
+
+	if o.Realm != nil {
+		o.Realm.validate(&p)
+	}
+
+	return p.Err()
+}
+
+func (o PlatformClaims) validate(p *annotated.Problems) {
+	if o.Challenge == nil {
+		p.Missing("cca-platform-challenge")
+	}
+	p.Size("cca-platform-challenge", o.Challenge, 32, 48, 64)
+
+	if o.ImplementationID == nil {
+		p.Missing("cca-platform-implementation-id")
+	}
+	p.Size("cca-platform-implementation-id", o.ImplementationID, 32)
+
+	if o.InstanceID == nil {
+		p.Missing("cca-platform-instance-id")
+	} else if len(o.InstanceID) != 33 || o.InstanceID[0] != 0x01 {
+		p.Add("cca-platform-instance-id", "expecting a 33 bytes UEID of type RAND (0x01)")
+	}
+
+	if o.Config == nil {
+		p.Missing("cca-platform-config")
+	}
+
+	if o.HashAlgID == "" {
+		p.Missing("cca-platform-hash-algo-id")
+	}
+
+	if len(o.SwComponents) == 0 {
+		p.Missing("cca-platform-sw-components")
+	}
+
+	for i, c := range o.SwComponents {
+		if c.MeasurementValue == nil {
+			p.Missing(fmt.Sprintf("cca-platform-sw-components[%d].measurement-value", i))
+		}
+	}
+}
+
+func (o RealmClaims) validate(p *annotated.Problems) {
+	if o.Challenge == nil {
+		p.Missing("cca-realm-challenge")
+	}
+	p.Size("cca-realm-challenge", o.Challenge, 64)
+
+	p.Size("cca-realm-personalization-value", o.PersonalizationValue, 64)
+
+	if o.InitialMeasurement == nil {
+		p.Missing("cca-realm-initial-measurement")
+	}
+	p.Size("cca-realm-initial-measurement", o.InitialMeasurement, 32, 48, 64)
+
+	if len(o.ExtensibleMeasurements) != 4 {
+		p.Add("cca-realm-extensible-measurements", "expecting 4 measurements, got %d",
+			len(o.ExtensibleMeasurements))
+	}
+
+	for i, m := range o.ExtensibleMeasurements {
+		p.Size(fmt.Sprintf("cca-realm-extensible-measurements[%d]", i), m, 32, 48, 64)
+	}
+
+	if o.HashAlgID == "" {
+		p.Missing("cca-realm-hash-algo-id")
+	}
+
+	if o.PublicKey == nil {
+		p.Missing("cca-realm-public-key")
+	}
+}
+
+// Set stores the CCA evidence in the annotated evidence claim of a
+func Set(a *ear.Appraisal, e Evidence) error {
+	return annotated.Set(a, e)
+}
+
+// Get returns the CCA evidence from the annotated evidence claim of a
+func Get(a *ear.Appraisal) (Evidence, error) {
+	return annotated.Get[Evidence](a)
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package cca
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+func testEvidence() Evidence {
+	return Evidence{
+		Platform: &PlatformClaims{
+			Profile:          "http://arm.com/CCA-SSD/1.0.0",
+			Challenge:        bytes.Repeat([]byte{0xaa}, 32),
+			ImplementationID: bytes.Repeat([]byte{0xbb}, 32),
+			InstanceID:       append([]byte{0x01}, bytes.Repeat([]byte{0xcc}, 32)...),
+			Config:           []byte{0xcf},
+			Lifecycle:        0x3000,
+			SwComponents: []SwComponent{
+				{MeasurementValue: bytes.Repeat([]byte{0xdd}, 32), SignerID: []byte{0x01}},
+			},
+			HashAlgID: "sha-256",
+		},
+		Realm: &RealmClaims{
+			Challenge:          bytes.Repeat([]byte{0xee}, 64),
+			InitialMeasurement: bytes.Repeat([]byte{0xff}, 48),
+			ExtensibleMeasurements: [][]byte{
+				make([]byte, 48), make([]byte, 48), make([]byte, 48), make([]byte, 48),
+			},
+			HashAlgID:          "sha-384",
+			PublicKey:          []byte{0x04},
+			PublicKeyHashAlgID: "sha-256",
+		},
+	}
+}
+
+func TestSetGet_ok(t *testing.T) {
+	var a ear.Appraisal
+
+	require.NoError(t, Set(&a, testEvidence()))
+	assert.Contains(t, *a.VeraisonAnnotatedEvidence, "cca-platform-token")
+
+	e, err := Get(&a)
+	require.NoError(t, err)
+	assert.Equal(t, testEvidence(), e)
+}
+
+func TestValidate_fail(t *testing.T) {
+	tvs := []struct {
+		mutate   func(*Evidence)
+		expected string
+	}{
+		{
+			func(e *Evidence) { e.Platform = nil },
+			"cca-platform-token: missing",
+		},
+		{
+			func(e *Evidence) { e.Platform.Challenge = make([]byte, 16) },
+			"cca-platform-challenge: invalid size 16 (expecting 32 or 48 or 64 bytes)",
+		},
+		{
+			func(e *Evidence) { e.Platform.SwComponents = nil },
+			"cca-platform-sw-components: missing",
+		},
+		{
+			func(e *Evidence) { e.Realm.Challenge = make([]byte, 32) },
+			"cca-realm-challenge: invalid size 32 (expecting 64 bytes)",
+		},
+		{
+			func(e *Evidence) { e.Realm.ExtensibleMeasurements = e.Realm.ExtensibleMeasurements[:3] },
+			"cca-realm-extensible-measurements: expecting 4 measurements, got 3",
+		},
+		{
+			func(e *Evidence) { e.Realm.PublicKey = nil },
+			"cca-realm-public-key: missing",
+		},
+	}
+
+	for i, tv := range tvs {
+		e := testEvidence()
+		tv.mutate(&e)
+
+		assert.EqualError(t, e.Validate(), tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestValidate_platform_only(t *testing.T) {
+	e := testEvidence()
+	e.Realm = nil
+
+	assert.NoError(t, e.Validate())
+}
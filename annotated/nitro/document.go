@@ -0,0 +1,165 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package nitro
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/veraison/go-cose"
+)
+
+// attestationDocument is the payload of a Nitro attestation document
+type attestationDocument struct {
+	ModuleID    string         `cbor:"module_id"`
+	Digest      string         `cbor:"digest"`
+	Timestamp   uint64         `cbor:"timestamp"`
+	PCRs        map[int][]byte `cbor:"pcrs"`
+	Certificate []byte         `cbor:"certificate"`
+	CABundle    [][]byte       `cbor:"cabundle"`
+	PublicKey   []byte         `cbor:"public_key"`
+	UserData    []byte         `cbor:"user_data"`
+	Nonce       []byte         `cbor:"nonce"`
+}
+
+// VerifyAttestationDocument verifies a Nitro attestation document, i.e., a
+// (possibly untagged) COSE_Sign1 signed with ES384 by the enclave
+// certificate it carries.  The certificate is validated against roots (e.g.,
+// the AWS Nitro Enclaves root) using the intermediates in "cabundle", at the
+// time in the document "timestamp".  On success, the content of the document
+// is returned.
+func VerifyAttestationDocument(data []byte, roots *x509.CertPool) (*Evidence, error) {
+	if roots == nil {
+		return nil, errors.New("no trust roots supplied")
+	}
+
+	var msg cose.UntaggedSign1Message
+
+	if len(data) > 0 && data[0] == 0xd2 { // tag 18
+		if err := (*cose.Sign1Message)(&msg).UnmarshalCBOR(data); err != nil {
+			return nil, fmt.Errorf("parsing COSE_Sign1: %w", err)
+		}
+	} else if err := msg.UnmarshalCBOR(data); err != nil {
+		return nil, fmt.Errorf("parsing COSE_Sign1: %w", err)
+	}
+
+	var doc attestationDocument
+	if err := cbor.Unmarshal(msg.Payload, &doc); err != nil {
+		return nil, fmt.Errorf("decoding attestation document: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(doc.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+
+	intermediates := x509.NewCertPool()
+	for i, der := range doc.CABundle {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parsing cabundle[%d]: %w", i, err)
+		}
+		intermediates.AddCert(c)
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   time.UnixMilli(int64(doc.Timestamp)),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("validating certificate: %w", err)
+	}
+
+	verifier, err := cose.NewVerifier(cose.AlgorithmES384, leaf.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating verifier: %w", err)
+	}
+
+	if err := (*cose.Sign1Message)(&msg).Verify(nil, verifier); err != nil {
+		return nil, fmt.Errorf("verifying signature: %w", err)
+	}
+
+	e := Evidence{
+		ModuleID:  doc.ModuleID,
+		Digest:    doc.Digest,
+		Timestamp: doc.Timestamp,
+		PCRs:      doc.PCRs,
+		PublicKey: doc.PublicKey,
+		UserData:  doc.UserData,
+		Nonce:     doc.Nonce,
+	}
+
+	if err := e.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid attestation document: %w", err)
+	}
+
+	return &e, nil
+}
+
+// NewAttestationDocument creates a Nitro attestation document with the
+// content of e, signed by key with ES384.  chain is the certificate chain of
+// key, leaf first, without the root.  This is only meant to be used for
+// testing and simulation, since genuine documents are produced by the Nitro
+// Secure Module.
+func NewAttestationDocument(
+	e Evidence,
+	key crypto.Signer,
+	chain []*x509.Certificate,
+) ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid attestation document: %w", err)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("empty certificate chain")
+	}
+
+	doc := attestationDocument{
+		ModuleID:    e.ModuleID,
+		Digest:      e.Digest,
+		Timestamp:   e.Timestamp,
+		PCRs:        e.PCRs,
+		Certificate: chain[0].Raw,
+		PublicKey:   e.PublicKey,
+		UserData:    e.UserData,
+		Nonce:       e.Nonce,
+	}
+
+	// cabundle is ordered from the root down
+	for i := len(chain) - 1; i > 0; i-- {
+		doc.CABundle = append(doc.CABundle, chain[i].Raw)
+	}
+
+	payload, err := cbor.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encoding attestation document: %w", err)
+	}
+
+	signer, err := cose.NewSigner(cose.AlgorithmES384, key)
+	if err != nil {
+		return nil, fmt.Errorf("creating signer: %w", err)
+	}
+
+	msg := cose.UntaggedSign1Message{
+		Headers: cose.Headers{
+			Protected: cose.ProtectedHeader{
+				cose.HeaderLabelAlgorithm: cose.AlgorithmES384,
+			},
+		},
+		Payload: payload,
+	}
+
+	if err := msg.Sign(rand.Reader, nil, signer); err != nil {
+		return nil, fmt.Errorf("signing attestation document: %w", err)
+	}
+
+	return msg.MarshalCBOR()
+}
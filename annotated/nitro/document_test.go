@@ -0,0 +1,118 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package nitro
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPKI returns a root CA pool, and an enclave key with its certificate
+// chain (leaf first, without the root), valid at the time of testEvidence
+func newTestPKI(t *testing.T) (*x509.CertPool, *ecdsa.PrivateKey, []*x509.Certificate) {
+	notBefore := time.UnixMilli(int64(testEvidence().Timestamp)).Add(-time.Hour)
+	notAfter := notBefore.Add(2 * time.Hour)
+
+	newCert := func(
+		tmpl *x509.Certificate,
+		parent *x509.Certificate,
+		parentKey *ecdsa.PrivateKey,
+	) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		if parent == nil {
+			parent, parentKey = tmpl, key
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+
+		return cert, key
+	}
+
+	rootCert, rootKey := newCert(&x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Nitro Root"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	intCert, intKey := newCert(&x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Test Nitro Intermediate"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, rootCert, rootKey)
+
+	leafCert, leafKey := newCert(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "Test Enclave"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, intCert, intKey)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+
+	return roots, leafKey, []*x509.Certificate{leafCert, intCert}
+}
+
+func TestAttestationDocument_round_trip(t *testing.T) {
+	roots, key, chain := newTestPKI(t)
+
+	e := testEvidence()
+	e.PublicKey = []byte("public key")
+
+	doc, err := NewAttestationDocument(e, key, chain)
+	require.NoError(t, err)
+
+	actual, err := VerifyAttestationDocument(doc, roots)
+	require.NoError(t, err)
+	assert.Equal(t, e, *actual)
+}
+
+func TestVerifyAttestationDocument_fail(t *testing.T) {
+	roots, key, chain := newTestPKI(t)
+	otherRoots, _, _ := newTestPKI(t)
+
+	doc, err := NewAttestationDocument(testEvidence(), key, chain)
+	require.NoError(t, err)
+
+	_, err = VerifyAttestationDocument(doc, nil)
+	assert.EqualError(t, err, "no trust roots supplied")
+
+	_, err = VerifyAttestationDocument(doc, otherRoots)
+	assert.ErrorContains(t, err, "validating certificate: x509: certificate signed by unknown authority")
+
+	// signed by a key other than the one in the certificate
+	_, otherKey, _ := newTestPKI(t)
+	doc, err = NewAttestationDocument(testEvidence(), otherKey, chain)
+	require.NoError(t, err)
+
+	_, err = VerifyAttestationDocument(doc, roots)
+	assert.EqualError(t, err, "verifying signature: verification error")
+
+	_, err = VerifyAttestationDocument([]byte{0x80}, roots)
+	assert.ErrorContains(t, err, "parsing COSE_Sign1: ")
+}
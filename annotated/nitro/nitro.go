@@ -0,0 +1,102 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nitro provides a typed view of the annotated evidence carried in
+// EARs for AWS Nitro Enclaves attestation documents.  Field names match
+// those of the attestation document.
+package nitro
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/veraison/ear"
+	"github.com/veraison/ear/annotated"
+)
+
+// maxPCRIndex is the highest PCR index of a Nitro enclave
+const maxPCRIndex = 31
+
+// Evidence is the annotated evidence of a Nitro attestation document
+type Evidence struct {
+	ModuleID  string         `json:"module_id"`
+	Digest    string         `json:"digest"`
+	Timestamp uint64         `json:"timestamp"`
+	PCRs      map[int][]byte `json:"pcrs"`
+	PublicKey []byte         `json:"public_key,omitempty"`
+	UserData  []byte         `json:"user_data,omitempty"`
+	Nonce     []byte         `json:"nonce,omitempty"`
+}
+
+// pcrSizes maps the supported digest algorithms onto the size of the PCRs
+var pcrSizes = map[string]int{
+	"SHA256": 32,
+	"SHA384": 48,
+	"SHA512": 64,
+}
+
+// Validate checks that the mandatory Nitro claims are present and well-formed
+func (o Evidence) Validate() error {
+	var p annotated.Problems
+
+	if o.ModuleID == "" {
+		p.Missing("module_id")
+	}
+
+	size, ok := pcrSizes[o.Digest]
+	if !ok {
+		p.Add("digest", "unsupported algorithm %q", o.Digest)
+	}
+
+	if o.Timestamp == 0 {
+		p.Missing("timestamp")
+	}
+
+	if len(o.PCRs) == 0 {
+		p.Missing("pcrs")
+	}
+
+	indices := make([]int, 0, len(o.PCRs))
+	for i := range o.PCRs {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	for _, i := range indices {
+		name := fmt.Sprintf("pcrs[%d]", i)
+
+		if i < 0 || i > maxPCRIndex {
+			p.Add(name, "index out of range")
+			continue
+		}
+
+		if ok {
+			p.Size(name, o.PCRs[i], size)
+		}
+	}
+
+	for _, f := range []struct {
+		name  string
+		value []byte
+	}{
+		{"public_key", o.PublicKey},
+		{"user_data", o.UserData},
+		{"nonce", o.Nonce},
+	} {
+		if len(f.value) > 1024 {
+			p.Add(f.name, "too large (%d bytes, max 1024)", len(f.value))
+		}
+	}
+
+	return p.Err()
+}
+
+// Set stores the Nitro evidence in the annotated evidence claim of a
+func Set(a *ear.Appraisal, e Evidence) error {
+	return annotated.Set(a, e)
+}
+
+// Get returns the Nitro evidence from the annotated evidence claim of a
+func Get(a *ear.Appraisal) (Evidence, error) {
+	return annotated.Get[Evidence](a)
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package nitro
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+func testEvidence() Evidence {
+	return Evidence{
+		ModuleID:  "i-0123456789abcdef0-enc0123456789abcdef",
+		Digest:    "SHA384",
+		Timestamp: 1666091373000,
+		PCRs: map[int][]byte{
+			0: make([]byte, 48),
+			1: make([]byte, 48),
+			2: make([]byte, 48),
+		},
+		Nonce: []byte("nonce"),
+	}
+}
+
+func TestSetGet_ok(t *testing.T) {
+	var a ear.Appraisal
+
+	require.NoError(t, Set(&a, testEvidence()))
+	assert.Contains(t, *a.VeraisonAnnotatedEvidence, "module_id")
+
+	e, err := Get(&a)
+	require.NoError(t, err)
+	assert.Equal(t, testEvidence(), e)
+}
+
+func TestValidate_fail(t *testing.T) {
+	tvs := []struct {
+		mutate   func(*Evidence)
+		expected string
+	}{
+		{
+			func(e *Evidence) { e.ModuleID = "" },
+			"module_id: missing",
+		},
+		{
+			func(e *Evidence) { e.Digest = "MD5" },
+			`digest: unsupported algorithm "MD5"`,
+		},
+		{
+			func(e *Evidence) { e.PCRs = nil },
+			"pcrs: missing",
+		},
+		{
+			func(e *Evidence) { e.PCRs[1] = make([]byte, 32) },
+			"pcrs[1]: invalid size 32 (expecting 48 bytes)",
+		},
+		{
+			func(e *Evidence) { e.PCRs[32] = make([]byte, 48) },
+			"pcrs[32]: index out of range",
+		},
+		{
+			func(e *Evidence) { e.UserData = make([]byte, 1025) },
+			"user_data: too large (1025 bytes, max 1024)",
+		},
+	}
+
+	for i, tv := range tvs {
+		e := testEvidence()
+		tv.mutate(&e)
+
+		assert.EqualError(t, e.Validate(), tv.expected, "failed test vector at index %d", i)
+	}
+}
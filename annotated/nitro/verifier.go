@@ -0,0 +1,155 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package nitro
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/veraison/ear"
+)
+
+// Name is the "tee-name" of Nitro enclaves
+const Name = "aws-nitro"
+
+// MediaType is the media type of Nitro attestation documents
+const MediaType = "application/vnd.aws.nitro-attestation"
+
+// Verifier is the ear.AttesterVerifier for Nitro attestation documents.  It
+// is registered when this package is imported.
+//
+// If no trust roots are supplied in the options, they are taken from the
+// PEM-encoded certificates in the endorsements.  Reference values, if any,
+// are the expected values of the PCRs, keyed by "pcr<index>" (e.g., "pcr0").
+// The attested key is the "public_key" of the document.
+type Verifier struct{}
+
+func init() {
+	if err := ear.RegisterAttesterVerifier(Verifier{}); err != nil {
+		panic(err)
+	}
+}
+
+// Name returns "aws-nitro"
+func (Verifier) Name() string {
+	return Name
+}
+
+// SupportedMediaTypes returns the media type of Nitro attestation documents
+func (Verifier) SupportedMediaTypes() []string {
+	return []string{MediaType}
+}
+
+// Verify verifies the Nitro attestation document in evidence
+func (Verifier) Verify(
+	evidence []byte,
+	endorsements map[string][]byte,
+	refvals map[string][]byte,
+	opts ear.AttesterVerifyOptions,
+) (*ear.AttestedKey, error) {
+	roots := opts.Roots
+	if roots == nil {
+		var err error
+		if roots, err = rootsFromEndorsements(endorsements); err != nil {
+			return nil, err
+		}
+	}
+
+	e, err := VerifyAttestationDocument(evidence, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Nonce != nil && !bytes.Equal(opts.Nonce, e.Nonce) {
+		return nil, errors.New("nonce mismatch")
+	}
+
+	if err := checkPCRs(e.PCRs, refvals); err != nil {
+		return nil, err
+	}
+
+	if len(e.PublicKey) == 0 {
+		return nil, errors.New(`no "public_key" in attestation document`)
+	}
+
+	key, err := ear.ParseVerificationKey(e.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf(`parsing "public_key": %w`, err)
+	}
+
+	// the claims have the same shape as the annotated evidence
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, err
+	}
+
+	return &ear.AttestedKey{Key: key, Claims: claims}, nil
+}
+
+func rootsFromEndorsements(endorsements map[string][]byte) (*x509.CertPool, error) {
+	roots := x509.NewCertPool()
+	n := 0
+
+	for name, data := range endorsements {
+		for {
+			var block *pem.Block
+
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+
+			c, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("endorsement %q: %w", name, err)
+			}
+
+			roots.AddCert(c)
+			n++
+		}
+	}
+
+	if n == 0 {
+		return nil, errors.New("no trust roots supplied")
+	}
+
+	return roots, nil
+}
+
+func checkPCRs(pcrs map[int][]byte, refvals map[string][]byte) error {
+	names := make([]string, 0, len(refvals))
+	for name := range refvals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		i, err := strconv.Atoi(strings.TrimPrefix(name, "pcr"))
+		if err != nil || !strings.HasPrefix(name, "pcr") || i < 0 || i > maxPCRIndex {
+			return fmt.Errorf("unexpected reference value %q", name)
+		}
+
+		if !bytes.Equal(pcrs[i], refvals[name]) {
+			return fmt.Errorf("pcrs[%d]: does not match reference value", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package nitro
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+func TestVerifier_registered(t *testing.T) {
+	v, ok := ear.LookupAttesterVerifier(Name)
+	require.True(t, ok)
+	assert.Equal(t, []string{MediaType}, v.SupportedMediaTypes())
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	roots, key, chain := newTestPKI(t)
+
+	spki, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	e := testEvidence()
+	e.PublicKey = spki
+
+	doc, err := NewAttestationDocument(e, key, chain)
+	require.NoError(t, err)
+
+	// the intermediate can act as trust anchor too
+	endorsements := map[string][]byte{
+		"ca.pem": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: chain[1].Raw}),
+	}
+
+	tvs := []struct {
+		endorsements map[string][]byte
+		refvals      map[string][]byte
+		opts         ear.AttesterVerifyOptions
+		expected     string
+	}{
+		{nil, map[string][]byte{"pcr0": make([]byte, 48)}, ear.AttesterVerifyOptions{Roots: roots, Nonce: []byte("nonce")}, ""},
+		{endorsements, nil, ear.AttesterVerifyOptions{}, ""},
+		{nil, nil, ear.AttesterVerifyOptions{}, "no trust roots supplied"},
+		{nil, nil, ear.AttesterVerifyOptions{Roots: roots, Nonce: []byte("other")}, "nonce mismatch"},
+		{nil, map[string][]byte{"pcr1": {0x01}}, ear.AttesterVerifyOptions{Roots: roots}, "pcrs[1]: does not match reference value"},
+		{nil, map[string][]byte{"pcr32": {0x01}}, ear.AttesterVerifyOptions{Roots: roots}, `unexpected reference value "pcr32"`},
+		{nil, map[string][]byte{"mrenclave": {0x01}}, ear.AttesterVerifyOptions{Roots: roots}, `unexpected reference value "mrenclave"`},
+	}
+
+	for i, tv := range tvs {
+		ak, err := Verifier{}.Verify(doc, tv.endorsements, tv.refvals, tv.opts)
+		if tv.expected != "" {
+			assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+			continue
+		}
+
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, e.ModuleID, ak.Claims["module_id"], "failed test vector at index %d", i)
+		assert.Equal(t, "EC", ak.Key.KeyType().String(), "failed test vector at index %d", i)
+	}
+
+	// no public key in the document
+	doc, err = NewAttestationDocument(testEvidence(), key, chain)
+	require.NoError(t, err)
+
+	_, err = Verifier{}.Verify(doc, nil, nil, ear.AttesterVerifyOptions{Roots: roots})
+	assert.EqualError(t, err, `no "public_key" in attestation document`)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package psa provides a typed view of the annotated evidence carried in EARs
+// for PSA attestation tokens.  Field names match those used by the Veraison
+// PSA scheme.
+package psa
+
+import (
+	"fmt"
+
+	"github.com/veraison/ear"
+	"github.com/veraison/ear/annotated"
+)
+
+// SwComponent is a measured software component
+type SwComponent struct {
+	MeasurementType  string `json:"measurement-type,omitempty"`
+	MeasurementValue []byte `json:"measurement-value"`
+	Version          string `json:"version,omitempty"`
+	SignerID         []byte `json:"signer-id"`
+	MeasurementDesc  string `json:"measurement-desc,omitempty"`
+}
+
+// Evidence is the annotated evidence of a PSA attestation token
+type Evidence struct {
+	Profile                      string        `json:"psa-profile,omitempty"`
+	ClientID                     int32         `json:"psa-client-id"`
+	SecurityLifecycle            uint16        `json:"psa-security-lifecycle"`
+	ImplementationID             []byte        `json:"psa-implementation-id"`
+	BootSeed                     []byte        `json:"psa-boot-seed,omitempty"`
+	CertificationReference       string        `json:"psa-certification-reference,omitempty"`
+	SoftwareComponents           []SwComponent `json:"psa-software-components,omitempty"`
+	Nonce                        []byte        `json:"psa-nonce"`
+	InstanceID                   []byte        `json:"psa-instance-id"`
+	VerificationServiceIndicator string        `json:"psa-verification-service-indicator,omitempty"`
+}
+
+// Validate checks that the mandatory PSA claims are present and well-formed
+func (o Evidence) Validate() error {
+	var p annotated.Problems
+
+	if o.ImplementationID == nil {
+		p.Missing("psa-implementation-id")
+	}
+	p.Size("psa-implementation-id", o.ImplementationID, 32)
+
+	if o.InstanceID == nil {
+		p.Missing("psa-instance-id")
+	} else if len(o.InstanceID) != 33 || o.InstanceID[0] != 0x01 {
+		p.Add("psa-instance-id", "expecting a 33 bytes UEID of type RAND (0x01)")
+	}
+
+	if o.Nonce == nil {
+		p.Missing("psa-nonce")
+	}
+	p.Size("psa-nonce", o.Nonce, 32, 48, 64)
+
+	if o.SecurityLifecycle > 0x7fff {
+		p.Add("psa-security-lifecycle", "value %#x out of range", o.SecurityLifecycle)
+	}
+
+	for i, c := range o.SoftwareComponents {
+		if c.MeasurementValue == nil {
+			p.Missing(fmt.Sprintf("psa-software-components[%d].measurement-value", i))
+		}
+		if c.SignerID == nil {
+			p.Missing(fmt.Sprintf("psa-software-components[%d].signer-id", i))
+		}
+	}
+
+	return p.Err()
+}
+
+// Set stores the PSA evidence in the annotated evidence claim of a
+func Set(a *ear.Appraisal, e Evidence) error {
+	return annotated.Set(a, e)
+}
+
+// Get returns the PSA evidence from the annotated evidence claim of a
+func Get(a *ear.Appraisal) (Evidence, error) {
+	return annotated.Get[Evidence](a)
+}
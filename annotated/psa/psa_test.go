@@ -0,0 +1,84 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package psa
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+func testEvidence() Evidence {
+	return Evidence{
+		Profile:           "http://arm.com/psa/2.0.0",
+		ClientID:          1,
+		SecurityLifecycle: 0x3000,
+		ImplementationID:  bytes.Repeat([]byte{0xaa}, 32),
+		Nonce:             bytes.Repeat([]byte{0xbb}, 32),
+		InstanceID:        append([]byte{0x01}, bytes.Repeat([]byte{0xcc}, 32)...),
+		SoftwareComponents: []SwComponent{
+			{
+				MeasurementType:  "BL",
+				MeasurementValue: bytes.Repeat([]byte{0xdd}, 32),
+				SignerID:         bytes.Repeat([]byte{0xee}, 32),
+			},
+		},
+	}
+}
+
+func TestSetGet_ok(t *testing.T) {
+	var a ear.Appraisal
+
+	require.NoError(t, Set(&a, testEvidence()))
+	assert.Contains(t, *a.VeraisonAnnotatedEvidence, "psa-implementation-id")
+
+	e, err := Get(&a)
+	require.NoError(t, err)
+	assert.Equal(t, testEvidence(), e)
+}
+
+func TestValidate_fail(t *testing.T) {
+	tvs := []struct {
+		mutate   func(*Evidence)
+		expected string
+	}{
+		{
+			func(e *Evidence) { e.ImplementationID = nil },
+			"psa-implementation-id: missing",
+		},
+		{
+			func(e *Evidence) { e.ImplementationID = []byte{0x01} },
+			"psa-implementation-id: invalid size 1 (expecting 32 bytes)",
+		},
+		{
+			func(e *Evidence) { e.InstanceID = e.InstanceID[1:] },
+			"psa-instance-id: expecting a 33 bytes UEID of type RAND (0x01)",
+		},
+		{
+			func(e *Evidence) { e.Nonce = make([]byte, 16) },
+			"psa-nonce: invalid size 16 (expecting 32 or 48 or 64 bytes)",
+		},
+		{
+			func(e *Evidence) { e.SecurityLifecycle = 0x8000 },
+			"psa-security-lifecycle: value 0x8000 out of range",
+		},
+		{
+			func(e *Evidence) { e.SoftwareComponents[0].SignerID = nil },
+			"psa-software-components[0].signer-id: missing",
+		},
+	}
+
+	for i, tv := range tvs {
+		e := testEvidence()
+		tv.mutate(&e)
+
+		var a ear.Appraisal
+		err := Set(&a, e)
+		assert.EqualError(t, err, "invalid annotated evidence: "+tv.expected,
+			"failed test vector at index %d", i)
+	}
+}
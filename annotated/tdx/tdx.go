@@ -0,0 +1,79 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tdx provides a typed view of the annotated evidence carried in EARs
+// for Intel TDX quotes.  Measurement registers are 48 bytes (SHA-384).
+package tdx
+
+import (
+	"fmt"
+
+	"github.com/veraison/ear"
+	"github.com/veraison/ear/annotated"
+)
+
+// measurementSize is the size of a TDX measurement register
+const measurementSize = 48
+
+// Evidence is the annotated evidence of a TDX quote
+type Evidence struct {
+	MrTd          []byte   `json:"mrtd"`
+	Rtmrs         [][]byte `json:"rtmrs"`
+	MrSeam        []byte   `json:"mrseam"`
+	MrSignerSeam  []byte   `json:"mrsignerseam,omitempty"`
+	MrConfigID    []byte   `json:"mrconfigid,omitempty"`
+	MrOwner       []byte   `json:"mrowner,omitempty"`
+	MrOwnerConfig []byte   `json:"mrownerconfig,omitempty"`
+	TeeTcbSvn     []byte   `json:"tee-tcb-svn"`
+	TdAttributes  []byte   `json:"td-attributes"`
+	Xfam          []byte   `json:"xfam"`
+	ReportData    []byte   `json:"report-data"`
+}
+
+// Validate checks that the mandatory TDX claims are present and well-formed
+func (o Evidence) Validate() error {
+	var p annotated.Problems
+
+	for _, f := range []struct {
+		name      string
+		value     []byte
+		size      int
+		mandatory bool
+	}{
+		{"mrtd", o.MrTd, measurementSize, true},
+		{"mrseam", o.MrSeam, measurementSize, true},
+		{"mrsignerseam", o.MrSignerSeam, measurementSize, false},
+		{"mrconfigid", o.MrConfigID, measurementSize, false},
+		{"mrowner", o.MrOwner, measurementSize, false},
+		{"mrownerconfig", o.MrOwnerConfig, measurementSize, false},
+		{"tee-tcb-svn", o.TeeTcbSvn, 16, true},
+		{"td-attributes", o.TdAttributes, 8, true},
+		{"xfam", o.Xfam, 8, true},
+		{"report-data", o.ReportData, 64, true},
+	} {
+		if f.mandatory && f.value == nil {
+			p.Missing(f.name)
+		}
+		p.Size(f.name, f.value, f.size)
+	}
+
+	if len(o.Rtmrs) != 4 {
+		p.Add("rtmrs", "expecting 4 registers, got %d", len(o.Rtmrs))
+	}
+
+	for i, r := range o.Rtmrs {
+		p.Size(fmt.Sprintf("rtmrs[%d]", i), r, measurementSize)
+	}
+
+	return p.Err()
+}
+
+// Set stores the TDX evidence in the annotated evidence claim of a
+func Set(a *ear.Appraisal, e Evidence) error {
+	return annotated.Set(a, e)
+}
+
+// Get returns the TDX evidence from the annotated evidence claim of a
+func Get(a *ear.Appraisal) (Evidence, error) {
+	return annotated.Get[Evidence](a)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+func testEvidence() Evidence {
+	return Evidence{
+		MrTd: make([]byte, 48),
+		Rtmrs: [][]byte{
+			make([]byte, 48), make([]byte, 48), make([]byte, 48), make([]byte, 48),
+		},
+		MrSeam:       make([]byte, 48),
+		TeeTcbSvn:    make([]byte, 16),
+		TdAttributes: make([]byte, 8),
+		Xfam:         make([]byte, 8),
+		ReportData:   make([]byte, 64),
+	}
+}
+
+func TestSetGet_ok(t *testing.T) {
+	var a ear.Appraisal
+
+	require.NoError(t, Set(&a, testEvidence()))
+	assert.Contains(t, *a.VeraisonAnnotatedEvidence, "mrtd")
+
+	e, err := Get(&a)
+	require.NoError(t, err)
+	assert.Equal(t, testEvidence(), e)
+}
+
+func TestValidate_fail(t *testing.T) {
+	tvs := []struct {
+		mutate   func(*Evidence)
+		expected string
+	}{
+		{
+			func(e *Evidence) { e.MrTd = nil },
+			"mrtd: missing",
+		},
+		{
+			func(e *Evidence) { e.MrOwner = make([]byte, 32) },
+			"mrowner: invalid size 32 (expecting 48 bytes)",
+		},
+		{
+			func(e *Evidence) { e.ReportData = make([]byte, 48) },
+			"report-data: invalid size 48 (expecting 64 bytes)",
+		},
+		{
+			func(e *Evidence) { e.Rtmrs = nil },
+			"rtmrs: expecting 4 registers, got 0",
+		},
+		{
+			func(e *Evidence) { e.Rtmrs[2] = make([]byte, 32) },
+			"rtmrs[2]: invalid size 32 (expecting 48 bytes)",
+		},
+	}
+
+	for i, tv := range tvs {
+		e := testEvidence()
+		tv.mutate(&e)
+
+		assert.EqualError(t, e.Validate(), tv.expected, "failed test vector at index %d", i)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAnnotatedEvidence() AppraisalExtensions {
+	return AppraisalExtensions{
+		VeraisonAnnotatedEvidence: &map[string]interface{}{
+			"psa-client-id": float64(1),
+			"psa-software-components": map[string]interface{}{
+				"bl": map[string]interface{}{
+					"measurement-value": "3q2-7w",
+					"signer-id":         "signer",
+					"version":           float64(2.5),
+				},
+			},
+			"label": "not an object",
+		},
+	}
+}
+
+func TestGetAnnotatedEvidence_ok(t *testing.T) {
+	a := testAnnotatedEvidence()
+
+	v, err := a.GetAnnotatedEvidence("psa-software-components", "bl", "signer-id")
+	require.NoError(t, err)
+	assert.Equal(t, "signer", v)
+
+	v, err = a.GetAnnotatedEvidence()
+	require.NoError(t, err)
+	assert.Equal(t, *a.VeraisonAnnotatedEvidence, v)
+
+	s, err := a.GetAnnotatedEvidenceString("psa-software-components", "bl", "signer-id")
+	require.NoError(t, err)
+	assert.Equal(t, "signer", s)
+
+	b, err := a.GetAnnotatedEvidenceBytes("psa-software-components", "bl", "measurement-value")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, b)
+
+	i, err := a.GetAnnotatedEvidenceInt("psa-client-id")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), i)
+}
+
+func TestGetAnnotatedEvidence_fail(t *testing.T) {
+	a := testAnnotatedEvidence()
+
+	_, err := AppraisalExtensions{}.GetAnnotatedEvidence("x")
+	assert.EqualError(t, err, `"ear.veraison.annotated-evidence" claim not found`)
+
+	_, err = a.GetAnnotatedEvidence("psa-software-components", "fw")
+	assert.EqualError(t, err, `"psa-software-components/fw": not found`)
+
+	_, err = a.GetAnnotatedEvidence("label", "x")
+	assert.EqualError(t, err, `"label": not a JSON object`)
+
+	_, err = a.GetAnnotatedEvidenceString("psa-client-id")
+	assert.EqualError(t, err, `"psa-client-id": not a string`)
+
+	_, err = a.GetAnnotatedEvidenceBytes("psa-client-id")
+	assert.EqualError(t, err, `"psa-client-id": not a byte string`)
+
+	_, err = a.GetAnnotatedEvidenceBytes("label")
+	assert.ErrorContains(t, err, `"label": illegal base64 data`)
+
+	_, err = a.GetAnnotatedEvidenceInt("psa-software-components", "bl", "version")
+	assert.EqualError(t, err, `"psa-software-components/bl/version": not an integer`)
+}
+
+func TestSetAnnotatedEvidence(t *testing.T) {
+	var a AppraisalExtensions
+
+	a.SetAnnotatedEvidence(nil, "ignored")
+	assert.Nil(t, a.VeraisonAnnotatedEvidence)
+
+	a.SetAnnotatedEvidence([]string{"a", "b", "c"}, 1)
+	a.SetAnnotatedEvidence([]string{"a", "d"}, "x")
+
+	i, err := a.GetAnnotatedEvidenceInt("a", "b", "c")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), i)
+
+	// non-object intermediate values are replaced
+	a.SetAnnotatedEvidence([]string{"a", "d", "e"}, []byte{1})
+
+	b, err := a.GetAnnotatedEvidenceBytes("a", "d", "e")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, b)
+}
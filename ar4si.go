@@ -0,0 +1,260 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// LegacyAR4SIProfile is the profile of the attestation results produced by
+// the legacy github.com/veraison/ar4si package
+const LegacyAR4SIProfile = "tag:github.com/veraison/ar4si,2022-10-17"
+
+// LegacySubmodName is the name of the submod used to carry the (single)
+// appraisal of a legacy AR4SI result after conversion
+const LegacySubmodName = "ar4si"
+
+// legacyUnknown is used in place of the verifier identity fields, which the
+// legacy format does not carry
+const legacyUnknown = "unknown"
+
+// LegacyAttestationResult is the attestation result shape used by the legacy
+// github.com/veraison/ar4si package, which this package grew out of: a
+// single, flat appraisal, whose mandatory attributes are the status, an
+// RFC3339 timestamp and the profile, and no verifier identity.  Tokens issued
+// in this shape by older Veraison releases look like:
+//
+//	{
+//	  "status": "affirming",
+//	  "timestamp": "2022-09-26T17:29:00Z",
+//	  "appraisal-policy-id": "https://veraison.example/policy/1/60a0068d",
+//	  "veraison.processed-evidence": {"k1": "v1", "k2": "v2"},
+//	  "veraison.verifier-added-claims": {"bar": "baz", "foo": "bar"}
+//	}
+type LegacyAttestationResult struct {
+	Status                      *TrustTier              `json:"status"`
+	Profile                     *string                 `json:"profile,omitempty"`
+	Timestamp                   *string                 `json:"timestamp"`
+	TrustVector                 *TrustVector            `json:"trust-vector,omitempty"`
+	RawEvidence                 *[]byte                 `json:"evidence,omitempty"`
+	AppraisalPolicyID           *string                 `json:"appraisal-policy-id,omitempty"`
+	VeraisonProcessedEvidence   *map[string]interface{} `json:"veraison.processed-evidence,omitempty"`
+	VeraisonVerifierAddedClaims *map[string]interface{} `json:"veraison.verifier-added-claims,omitempty"`
+}
+
+// MarshalJSON serializes a LegacyAttestationResult to JSON
+func (o LegacyAttestationResult) MarshalJSON() ([]byte, error) {
+	m, err := structAsMap(o, "json")
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON de-serializes a LegacyAttestationResult from its JSON
+// representation
+func (o *LegacyAttestationResult) UnmarshalJSON(data []byte) error {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	return o.populateFromMap(m)
+}
+
+func (o *LegacyAttestationResult) populateFromMap(m map[string]interface{}) error {
+	parsers := map[string]parser{
+		"status": func(v interface{}) (interface{}, error) {
+			return ToTrustTier(v)
+		},
+		"trust-vector": func(v interface{}) (interface{}, error) {
+			return ToTrustVector(v)
+		},
+		"evidence": func(v interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("not a base64 string")
+			}
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, err
+			}
+			return &b, nil
+		},
+		"veraison.processed-evidence":    stringMapPtrParser,
+		"veraison.verifier-added-claims": stringMapPtrParser,
+	}
+
+	return populateStructFromMap(o, m, "json", parsers, stringPtrParser, true)
+}
+
+// FromAR4SI converts a legacy AR4SI result into an AttestationResult.  The
+// legacy appraisal is placed in a submod called LegacySubmodName, the
+// timestamp is converted into `iat`, and the Veraison extensions are mapped
+// onto their current equivalents.  Since the legacy format does not carry a
+// verifier identity, the returned VerifierID has its fields set to "unknown":
+// callers that know better should overwrite it.
+func FromAR4SI(old *LegacyAttestationResult) (*AttestationResult, error) {
+	if old == nil {
+		return nil, errors.New("nil legacy attestation result")
+	}
+
+	if old.Status == nil {
+		return nil, errors.New("missing mandatory 'status'")
+	}
+
+	if old.Timestamp == nil {
+		return nil, errors.New("missing mandatory 'timestamp'")
+	}
+
+	ts, err := time.Parse(time.RFC3339, *old.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing 'timestamp': %w", err)
+	}
+
+	iat := ts.Unix()
+	profile := EatProfile
+	status := *old.Status
+	build, developer := legacyUnknown, legacyUnknown
+
+	appraisal := &Appraisal{
+		Status:            &status,
+		TrustVector:       old.TrustVector,
+		AppraisalPolicyID: old.AppraisalPolicyID,
+		AppraisalExtensions: AppraisalExtensions{
+			VeraisonAnnotatedEvidence: old.VeraisonProcessedEvidence,
+			VeraisonPolicyClaims:      old.VeraisonVerifierAddedClaims,
+		},
+	}
+
+	ar := &AttestationResult{
+		Profile:  &profile,
+		IssuedAt: &iat,
+		VerifierID: &VerifierIdentity{
+			Build:     &build,
+			Developer: &developer,
+		},
+		Submods: map[string]*Appraisal{
+			LegacySubmodName: appraisal,
+		},
+	}
+
+	if old.RawEvidence != nil {
+		rawEvidence := B64Url(*old.RawEvidence)
+		ar.RawEvidence = &rawEvidence
+	}
+
+	return ar, nil
+}
+
+// populateFromLegacyMap populates the target AttestationResult from a legacy
+// AR4SI claims-set, converting it into the current shape (see FromAR4SI)
+func (o *AttestationResult) populateFromLegacyMap(m map[string]interface{}) error {
+	var old LegacyAttestationResult
+	if err := old.populateFromMap(m); err != nil {
+		return fmt.Errorf("decoding legacy AR4SI result: %w", err)
+	}
+
+	ar, err := FromAR4SI(&old)
+	if err != nil {
+		return fmt.Errorf("converting legacy AR4SI result: %w", err)
+	}
+
+	*o = *ar
+
+	return nil
+}
+
+// ToAR4SI converts an AttestationResult into the legacy AR4SI shape.  Since
+// the legacy format can only carry one appraisal, the AttestationResult must
+// have exactly one submod.  Claims that have no legacy equivalent (e.g., the
+// verifier identity or the nonce) are dropped.
+func ToAR4SI(ar *AttestationResult) (*LegacyAttestationResult, error) {
+	if ar == nil {
+		return nil, errors.New("nil attestation result")
+	}
+
+	if len(ar.Submods) != 1 {
+		return nil, fmt.Errorf("legacy format requires exactly one submod, found %d", len(ar.Submods))
+	}
+
+	if ar.IssuedAt == nil {
+		return nil, errors.New("missing mandatory 'iat'")
+	}
+
+	var appraisal *Appraisal
+	for _, a := range ar.Submods {
+		appraisal = a
+	}
+
+	if appraisal == nil || appraisal.Status == nil {
+		return nil, errors.New("missing mandatory 'ear.status'")
+	}
+
+	profile := LegacyAR4SIProfile
+	ts := time.Unix(*ar.IssuedAt, 0).UTC().Format(time.RFC3339)
+	status := *appraisal.Status
+
+	old := &LegacyAttestationResult{
+		Status:                      &status,
+		Profile:                     &profile,
+		Timestamp:                   &ts,
+		TrustVector:                 appraisal.TrustVector,
+		AppraisalPolicyID:           appraisal.AppraisalPolicyID,
+		VeraisonProcessedEvidence:   appraisal.VeraisonAnnotatedEvidence,
+		VeraisonVerifierAddedClaims: appraisal.VeraisonPolicyClaims,
+	}
+
+	if ar.RawEvidence != nil {
+		rawEvidence := []byte(*ar.RawEvidence)
+		old.RawEvidence = &rawEvidence
+	}
+
+	return old, nil
+}
+
+// IsLegacyAR4SIClaimsSet reports whether the supplied JSON claims-set looks
+// like a legacy AR4SI result, i.e., it either uses the legacy profile, or it
+// has the legacy flat layout (top-level "status" and "timestamp", and no
+// "submods").
+func IsLegacyAR4SIClaimsSet(data []byte) bool {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return false
+	}
+
+	return isLegacyAR4SIMap(m)
+}
+
+// IsLegacyAR4SIToken reports whether the payload of the supplied compact JWS
+// looks like a legacy AR4SI result (see IsLegacyAR4SIClaimsSet).  The token
+// signature is NOT verified: this is only meant to triage token stores prior
+// to migration.
+func IsLegacyAR4SIToken(token []byte) bool {
+	msg, err := jws.Parse(token)
+	if err != nil {
+		return false
+	}
+
+	return IsLegacyAR4SIClaimsSet(msg.Payload())
+}
+
+func isLegacyAR4SIMap(m map[string]interface{}) bool {
+	if p, ok := m["profile"].(string); ok && p == LegacyAR4SIProfile {
+		return true
+	}
+
+	_, hasStatus := m["status"]
+	_, hasTimestamp := m["timestamp"]
+	_, hasSubmods := m["submods"]
+
+	return hasStatus && hasTimestamp && !hasSubmods
+}
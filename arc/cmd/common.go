@@ -3,11 +3,22 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"strings"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
 )
 
+const (
+	formatJWT  = "jwt"
+	formatCWT  = "cwt"
+	formatJSON = "json"
+	formatCBOR = "cbor"
+)
+
 func algList() string {
 	var l []string // nolint: prealloc
 
@@ -17,3 +28,41 @@ func algList() string {
 
 	return strings.Join(l, ", ")
 }
+
+func checkFormat(f string) error {
+	switch f {
+	case formatJWT, formatCWT:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q (expecting %s or %s)", f, formatJWT, formatCWT)
+	}
+}
+
+// decodeBinaryInput returns the CBOR data (e.g., a COSE_Sign1 message) in
+// data, which can either be binary, or its hex or base64 (standard or
+// URL-safe, with or without padding) text encoding.  Untagged COSE_Sign1
+// messages are tagged.  JSON and JWT inputs are returned unchanged.
+func decodeBinaryInput(data []byte) []byte {
+	text := string(bytes.TrimSpace(data))
+
+	if b, err := hex.DecodeString(text); err == nil && len(b) > 0 {
+		data = b
+	} else {
+		for _, enc := range []*base64.Encoding{
+			base64.StdEncoding, base64.RawStdEncoding,
+			base64.URLEncoding, base64.RawURLEncoding,
+		} {
+			if b, err := enc.DecodeString(text); err == nil && len(b) > 0 {
+				data = b
+				break
+			}
+		}
+	}
+
+	// an untagged COSE_Sign1 is an array of 4 items
+	if len(data) > 0 && data[0] == 0x84 {
+		data = append([]byte{0xd2}, data...)
+	}
+
+	return data
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/veraison/ear"
+)
+
+var (
+	convertInput     string
+	convertOutput    string
+	convertTo        string
+	convertSKey      string
+	convertAlg       string
+	convertPKey      string
+	convertVerifyAlg string
+)
+
+var convertCmd = NewConvertCmd()
+
+func NewConvertCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert [flags] <input-file> <output-file>",
+		Short: "Read an EAR from input-file and save it to output-file in a different serialization",
+		Long: `Read an EAR from input-file and save it to output-file in a different serialization
+
+The input EAR can be a JSON claims-set, a JWT, a CBOR claims-set or a CWT: its
+format is detected automatically.  Binary inputs can also be hex or base64
+encoded.
+
+Convert the JWT in "my-ear.jwt" into a bare CBOR claims-set.  The signature of
+the JWT is not verified.
+
+	arc convert --to cbor my-ear.jwt my-ear.cbor
+
+Convert the same JWT into a CWT signed with the key in "skey.json", after
+verifying it with the public key in "pkey.json".
+
+	arc convert --to cwt --pkey pkey.json --skey skey.json my-ear.jwt my-ear.cwt
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				inBytes, outBytes []byte
+				opts              []ear.DecodeOption
+				err               error
+			)
+
+			if err = checkConvertArgs(args); err != nil {
+				return fmt.Errorf("validating arguments: %w", err)
+			}
+
+			convertInput, convertOutput = args[0], args[1]
+
+			if inBytes, err = afero.ReadFile(fs, convertInput); err != nil {
+				return fmt.Errorf("loading EAR from %q: %w", convertInput, err)
+			}
+
+			if convertPKey != "" {
+				pKey, err := afero.ReadFile(fs, convertPKey)
+				if err != nil {
+					return fmt.Errorf("loading verification key from %q: %w", convertPKey, err)
+				}
+
+				vfyK, err := ear.ParseVerificationKey(pKey)
+				if err != nil {
+					return fmt.Errorf("parsing verification key from %q: %w", convertPKey, err)
+				}
+
+				opts = append(opts, ear.WithVerificationKey(jwa.KeyAlgorithmFrom(convertVerifyAlg), vfyK))
+			}
+
+			ar, inFormat, err := ear.Decode(decodeBinaryInput(inBytes), opts...)
+			if err != nil {
+				return fmt.Errorf("decoding EAR from %q: %w", convertInput, err)
+			}
+
+			if outBytes, err = encodeEAR(ar, convertTo); err != nil {
+				return err
+			}
+
+			if err = afero.WriteFile(fs, convertOutput, outBytes, 0644); err != nil {
+				return fmt.Errorf("saving EAR to file %q: %w", convertOutput, err)
+			}
+
+			if convertPKey == "" && (inFormat == ear.FormatJWT || inFormat == ear.FormatCWT) {
+				fmt.Printf(">> warning: the signature of %q has not been verified\n", convertInput)
+			}
+
+			fmt.Printf(">> converted %q (%s) to %q (%s)\n",
+				convertInput, inFormat, convertOutput, strings.ToUpper(convertTo))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&convertTo, "to", "t", formatJSON, "output format (json, jwt, cbor, cwt)",
+	)
+
+	cmd.Flags().StringVarP(
+		&convertSKey, "skey", "s", "", "signing key (JWK, PEM, DER or COSE_Key), required for jwt and cwt output",
+	)
+
+	cmd.Flags().StringVarP(
+		&convertAlg, "alg", "a", "ES256", "signing algorithm ("+algList()+")",
+	)
+
+	cmd.Flags().StringVarP(
+		&convertPKey, "pkey", "p", "", "verification key (JWK, PEM, DER or COSE_Key) for signed inputs (default is not to verify)",
+	)
+
+	cmd.Flags().StringVarP(
+		&convertVerifyAlg, "verify-alg", "A", "ES256", "verification algorithm ("+algList()+")",
+	)
+
+	return cmd
+}
+
+// encodeEAR serializes ar in the requested format, signing it with the key in
+// convertSKey if the format is jwt or cwt
+func encodeEAR(ar *ear.AttestationResult, format string) ([]byte, error) {
+	switch format {
+	case formatJSON:
+		return ar.MarshalJSONIndent("", "    ")
+	case formatCBOR:
+		return ar.MarshalCBOR()
+	}
+
+	sKey, err := afero.ReadFile(fs, convertSKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading signing key from %q: %w", convertSKey, err)
+	}
+
+	sigK, err := ear.ParseSigningKey(sKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key from %q: %w", convertSKey, err)
+	}
+
+	alg := jwa.KeyAlgorithmFrom(convertAlg)
+
+	var out []byte
+
+	if format == formatCWT {
+		out, err = ar.SignCWT(alg, sigK)
+	} else {
+		out, err = ar.Sign(alg, sigK)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("signing EAR: %w", err)
+	}
+
+	return out, nil
+}
+
+func checkConvertArgs(args []string) error {
+	if len(args) != 2 {
+		return errors.New("expecting an input and an output file")
+	}
+
+	switch convertTo {
+	case formatJSON, formatCBOR:
+	case formatJWT, formatCWT:
+		if convertSKey == "" {
+			return fmt.Errorf("a signing key (--skey) is required for %s output", convertTo)
+		}
+	default:
+		return fmt.Errorf("unsupported output format %q (expecting json, jwt, cbor or cwt)", convertTo)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+}
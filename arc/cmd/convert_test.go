@@ -0,0 +1,117 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+func Test_ConvertCmd_bad_args(t *testing.T) {
+	tvs := []struct {
+		args        []string
+		expectedErr string
+	}{
+		{
+			[]string{"ear.jwt"},
+			"validating arguments: expecting an input and an output file",
+		},
+		{
+			[]string{"--to=xml", "ear.jwt", "ear.xml"},
+			`validating arguments: unsupported output format "xml" (expecting json, jwt, cbor or cwt)`,
+		},
+		{
+			[]string{"--to=cwt", "ear.jwt", "ear.cwt"},
+			"validating arguments: a signing key (--skey) is required for cwt output",
+		},
+	}
+
+	for i, tv := range tvs {
+		cmd := NewConvertCmd()
+		cmd.SetArgs(tv.args)
+
+		err := cmd.Execute()
+		assert.EqualError(t, err, tv.expectedErr, "failed test vector at index %d", i)
+	}
+}
+
+func Test_ConvertCmd_jwt_to_cbor(t *testing.T) {
+	cmd := NewConvertCmd()
+
+	files := []fileEntry{
+		{"ear.jwt", testJWT},
+	}
+	makeFS(t, files)
+
+	cmd.SetArgs([]string{"--to=cbor", "ear.jwt", "ear.cbor"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	data, err := afero.ReadFile(fs, "ear.cbor")
+	require.NoError(t, err)
+
+	ar, f, err := ear.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, ear.FormatCBOR, f)
+	assert.Equal(t, "rrtrap-v1.0.0", *ar.VerifierID.Build)
+}
+
+func Test_ConvertCmd_jwt_to_cwt_and_back(t *testing.T) {
+	files := []fileEntry{
+		{"ear.jwt", testJWT},
+		{"skey.json", testSKey},
+		{"pkey.json", testPKey},
+	}
+	makeFS(t, files)
+
+	cmd := NewConvertCmd()
+	cmd.SetArgs([]string{
+		"--to=cwt", "--skey=skey.json", "--pkey=pkey.json", "ear.jwt", "ear.cwt",
+	})
+	require.NoError(t, cmd.Execute())
+
+	cwt, err := afero.ReadFile(fs, "ear.cwt")
+	require.NoError(t, err)
+
+	vfyK, err := ear.ParseVerificationKey(testPKey)
+	require.NoError(t, err)
+
+	var ar ear.AttestationResult
+	require.NoError(t, ar.VerifyCWT(cwt, jwa.ES256, vfyK))
+
+	// hex-encoded CWT back to a JSON claims-set
+	require.NoError(t, afero.WriteFile(fs, "ear.hex", []byte(hex.EncodeToString(cwt)), 0444))
+
+	cmd = NewConvertCmd()
+	cmd.SetArgs([]string{"--to=json", "--pkey=pkey.json", "ear.hex", "ear.json"})
+	require.NoError(t, cmd.Execute())
+
+	data, err := afero.ReadFile(fs, "ear.json")
+	require.NoError(t, err)
+
+	var actual ear.AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Equal(t, ar, actual)
+}
+
+func Test_ConvertCmd_verification_failed(t *testing.T) {
+	cmd := NewConvertCmd()
+
+	files := []fileEntry{
+		{"ear.jwt", testJWT},
+		{"pkey.json", testPKey},
+	}
+	makeFS(t, files)
+
+	cmd.SetArgs([]string{"--pkey=pkey.json", "--verify-alg=ES384", "ear.jwt", "ear.json"})
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, `decoding EAR from "ear.jwt": decoding JWT: failed verifying JWT message`)
+}
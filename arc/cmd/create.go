@@ -18,20 +18,26 @@ var (
 	createSKey   string
 	createAlg    string
 	createOutput string
+	createFormat string
 )
 
 var createCmd = NewCreateCmd()
 
 func NewCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "create [flags] <jwt-file>",
-		Short: "Read the EAR claims-set from a JSON file, sign it and save the resulting JWT to jwt-file",
-		Long: `Read the EAR claims-set from a JSON file, sign it and save the resulting JWT to jwt-file
+		Use:   "create [flags] <ear-file>",
+		Short: "Read the EAR claims-set from a JSON file, sign it and save the resulting JWT or CWT to ear-file",
+		Long: `Read the EAR claims-set from a JSON file, sign it and save the resulting JWT or CWT to ear-file
 
 Create an EAR from the default claims-set file "ear-claims.json".  Sign it with
 the key in the default key file "skey.json", and save the result to "my-ear.jwt".
 
 	arc create my-ear.jwt
+
+Do the same, but encode the EAR in CBOR and sign it as a COSE_Sign1 message,
+saving the (binary) result to "my-ear.cbor".
+
+	arc create --format cwt my-ear.cbor
 	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var (
@@ -45,6 +51,10 @@ the key in the default key file "skey.json", and save the result to "my-ear.jwt"
 				return fmt.Errorf("validating arguments: %w", err)
 			}
 
+			if err = checkFormat(createFormat); err != nil {
+				return fmt.Errorf("validating arguments: %w", err)
+			}
+
 			createOutput = args[0]
 
 			if claimsSet, err = afero.ReadFile(fs, createClaims); err != nil {
@@ -60,11 +70,19 @@ the key in the default key file "skey.json", and save the result to "my-ear.jwt"
 				return fmt.Errorf("loading signing key from %q: %w", createSKey, err)
 			}
 
-			if sigK, err = jwk.ParseKey(sKey); err != nil {
+			if sigK, err = ear.ParseSigningKey(sKey); err != nil {
 				return fmt.Errorf("parsing signing key from %q: %w", createSKey, err)
 			}
 
-			if arBytes, err = ar.Sign(jwa.KeyAlgorithmFrom(createAlg), sigK); err != nil {
+			alg := jwa.KeyAlgorithmFrom(createAlg)
+
+			if createFormat == formatCWT {
+				arBytes, err = ar.SignCWT(alg, sigK)
+			} else {
+				arBytes, err = ar.Sign(alg, sigK)
+			}
+
+			if err != nil {
 				return fmt.Errorf("signing EAR: %w", err)
 			}
 
@@ -80,7 +98,7 @@ the key in the default key file "skey.json", and save the result to "my-ear.jwt"
 	}
 
 	cmd.Flags().StringVarP(
-		&createSKey, "skey", "s", "skey.json", "signing key in JWK format",
+		&createSKey, "skey", "s", "skey.json", "signing key (JWK, PEM, DER or COSE_Key)",
 	)
 
 	cmd.Flags().StringVarP(
@@ -91,6 +109,10 @@ the key in the default key file "skey.json", and save the result to "my-ear.jwt"
 		&createAlg, "alg", "a", "ES256", "signing algorithm ("+algList()+")",
 	)
 
+	cmd.Flags().StringVarP(
+		&createFormat, "format", "f", formatJWT, "signed EAR format (jwt, cwt)",
+	)
+
 	return cmd
 }
 
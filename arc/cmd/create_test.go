@@ -5,7 +5,9 @@ package cmd
 import (
 	"testing"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_CreateCmd_unknown_argument(t *testing.T) {
@@ -89,7 +91,7 @@ func Test_CreateCmd_skey_not_ok_for_signing(t *testing.T) {
 	}
 	cmd.SetArgs(args)
 
-	expectedErr := `failed to generate signature for signer #0 (alg=ES256): failed to sign payload: failed to retrieve ecdsa.PrivateKey out of *jwk.ecdsaPublicKey: failed to produce ecdsa.PrivateKey from *jwk.ecdsaPublicKey: argument to AssignIfCompatible() must be compatible with *ecdsa.PublicKey (was *ecdsa.PrivateKey)`
+	expectedErr := `parsing signing key from "pkey.json": EC key is not a signing key`
 
 	err := cmd.Execute()
 	assert.ErrorContains(t, err, expectedErr)
@@ -183,3 +185,44 @@ func Test_CreateCmd_ok(t *testing.T) {
 	_, err = fs.Stat("ear.jwt")
 	assert.NoError(t, err)
 }
+
+func Test_CreateCmd_unknown_format(t *testing.T) {
+	cmd := NewCreateCmd()
+
+	args := []string{
+		"--format=xml",
+		"ear.xml",
+	}
+	cmd.SetArgs(args)
+
+	expectedErr := `validating arguments: unsupported format "xml" (expecting jwt or cwt)`
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, expectedErr)
+}
+
+func Test_CreateCmd_cwt_ok(t *testing.T) {
+	cmd := NewCreateCmd()
+
+	files := []fileEntry{
+		{"skey.json", testSKey},
+		{"ear-claims.json", testMiniClaimsSet},
+	}
+	makeFS(t, files)
+
+	args := []string{
+		"--skey=skey.json",
+		"--claims=ear-claims.json",
+		"--alg=ES256",
+		"--format=cwt",
+		"ear.cbor",
+	}
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	assert.NoError(t, err)
+
+	data, err := afero.ReadFile(fs, "ear.cbor")
+	require.NoError(t, err)
+	assert.Equal(t, byte(0xd2), data[0], "expecting a tagged COSE_Sign1")
+}
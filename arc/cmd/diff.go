@@ -0,0 +1,253 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/veraison/ear"
+)
+
+var (
+	diffAlg      string
+	diffPKey     string
+	diffFormat   string
+	diffInsecure bool
+	diffColor    bool
+)
+
+var diffCmd = NewDiffCmd()
+
+func NewDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [flags] <ear-file> <ear-file>",
+		Short: "Read two signed EARs, verify them and print the differences between their appraisals",
+		Long: `Read two signed EARs, verify them and print the differences between their appraisals
+
+Verify the signed EARs in "a.jwt" and "b.jwt" using the public key in the
+default key file "pkey.json".  If cryptographic verification is successful,
+print the statuses, trust vector claims, appraisal policy IDs and extensions
+that differ between the two, one per path.
+
+	arc diff a.jwt b.jwt
+
+Do the same without verifying the signatures (e.g., if the EARs have been
+signed by different verifier instances), and colorize the output.
+
+	arc diff --insecure --color a.jwt b.jwt
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				vfyK  jwk.Key
+				left  ear.AttestationResult
+				right ear.AttestationResult
+				err   error
+			)
+
+			if err = checkDiffArgs(args); err != nil {
+				return fmt.Errorf("validating arguments: %w", err)
+			}
+
+			if !diffInsecure {
+				pKey, err := afero.ReadFile(fs, diffPKey)
+				if err != nil {
+					return fmt.Errorf("loading verification key from %q: %w", diffPKey, err)
+				}
+
+				if vfyK, err = ear.ParseVerificationKey(pKey); err != nil {
+					return fmt.Errorf("parsing verification key from %q: %w", diffPKey, err)
+				}
+			}
+
+			if left, err = loadDiffEAR(args[0], vfyK); err != nil {
+				return err
+			}
+
+			if right, err = loadDiffEAR(args[1], vfyK); err != nil {
+				return err
+			}
+
+			printDiff(cmd.OutOrStdout(), args[0], args[1], flattenEAR(left), flattenEAR(right), diffColor)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&diffPKey, "pkey", "p", "pkey.json", "verification key (JWK, PEM, DER or COSE_Key)",
+	)
+
+	cmd.Flags().StringVarP(
+		&diffAlg, "alg", "a", "ES256", "verification algorithm ("+algList()+")",
+	)
+
+	cmd.Flags().StringVarP(
+		&diffFormat, "format", "f", formatJWT, "signed EAR format (jwt, cwt)",
+	)
+
+	cmd.Flags().BoolVarP(
+		&diffInsecure, "insecure", "i", false, "do not verify the signatures (the EAR format is detected automatically)",
+	)
+
+	cmd.Flags().BoolVarP(
+		&diffColor, "color", "c", false, "render the differences with colors (default is b&w)",
+	)
+
+	return cmd
+}
+
+// loadDiffEAR reads the EAR in file and verifies it using vfyK, unless the
+// --insecure flag is set
+func loadDiffEAR(file string, vfyK jwk.Key) (ear.AttestationResult, error) {
+	var ar ear.AttestationResult
+
+	arBytes, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return ar, fmt.Errorf("loading signed EAR from %q: %w", file, err)
+	}
+
+	if diffInsecure {
+		decoded, _, err := ear.Decode(decodeBinaryInput(arBytes))
+		if err != nil {
+			return ar, fmt.Errorf("decoding EAR from %q: %w", file, err)
+		}
+		return *decoded, nil
+	}
+
+	alg := jwa.KeyAlgorithmFrom(diffAlg)
+
+	if diffFormat == formatCWT {
+		err = ar.VerifyCWT(decodeBinaryInput(arBytes), alg, vfyK)
+	} else {
+		err = ar.Verify(arBytes, alg, vfyK)
+	}
+
+	if err != nil {
+		return ar, fmt.Errorf("verifying signed EAR from %s: %w", file, err)
+	}
+
+	return ar, nil
+}
+
+// flattenEAR returns the statuses, trust vector claims, appraisal policy IDs
+// and extensions of ar, indexed by their slash-separated path
+func flattenEAR(ar ear.AttestationResult) map[string]string {
+	m := map[string]string{}
+
+	for k, v := range jsonFields(ar.AttestationResultExtensions) {
+		m[k] = scalarString(v)
+	}
+
+	for _, name := range submodNames(ar) {
+		a := ar.Submods[name]
+		prefix := "submods/" + name + "/"
+
+		m[prefix+"ear.status"] = statusString(a)
+
+		if a == nil {
+			continue
+		}
+
+		for claim, v := range trustVectorAsMap(a) {
+			m[prefix+"ear.trustworthiness-vector/"+claim] = v
+		}
+
+		if a.AppraisalPolicyID != nil {
+			m[prefix+"ear.appraisal-policy-id"] = *a.AppraisalPolicyID
+		}
+
+		for k, v := range extensionsAsMap(a) {
+			m[prefix+k] = scalarString(v)
+		}
+	}
+
+	return m
+}
+
+// jsonFields returns the fields of v in their JSON form
+func jsonFields(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+
+	return m
+}
+
+func printDiff(out io.Writer, lname, rname string, left, right map[string]string, color bool) {
+	const (
+		reset = "\033[0m"
+		red   = "\033[31m"
+		green = "\033[32m"
+	)
+
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + reset
+	}
+
+	paths := make([]string, 0, len(left)+len(right))
+	for p := range left {
+		paths = append(paths, p)
+	}
+	for p := range right {
+		if _, ok := left[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	fmt.Fprintln(out, paint(red, "--- "+lname))
+	fmt.Fprintln(out, paint(green, "+++ "+rname))
+
+	n := 0
+
+	for _, p := range paths {
+		l, lok := left[p]
+		r, rok := right[p]
+
+		if lok && rok && l == r {
+			continue
+		}
+
+		n++
+
+		fmt.Fprintln(out, p)
+		if lok {
+			fmt.Fprintln(out, paint(red, "- "+l))
+		}
+		if rok {
+			fmt.Fprintln(out, paint(green, "+ "+r))
+		}
+	}
+
+	if n == 0 {
+		fmt.Fprintln(out, "no differences")
+	}
+}
+
+func checkDiffArgs(args []string) error {
+	if len(args) != 2 {
+		return errors.New("expecting two EAR files")
+	}
+	return checkFormat(diffFormat)
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
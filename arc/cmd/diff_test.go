@@ -0,0 +1,128 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+func Test_DiffCmd_bad_args(t *testing.T) {
+	tvs := []struct {
+		args        []string
+		expectedErr string
+	}{
+		{
+			[]string{"a.jwt"},
+			"validating arguments: expecting two EAR files",
+		},
+		{
+			[]string{"--format=xml", "a.jwt", "b.jwt"},
+			`validating arguments: unsupported format "xml" (expecting jwt or cwt)`,
+		},
+	}
+
+	for i, tv := range tvs {
+		cmd := NewDiffCmd()
+		cmd.SetArgs(tv.args)
+
+		err := cmd.Execute()
+		assert.EqualError(t, err, tv.expectedErr, "failed test vector at index %d", i)
+	}
+}
+
+func Test_DiffCmd_ok(t *testing.T) {
+	cmd := NewDiffCmd()
+
+	files := []fileEntry{
+		{"pkey.json", testPKey},
+		{"a.jwt", signTestEAR(t, ear.TrustTierAffirming, ear.TrustTierAffirming)},
+		{"b.jwt", signTestEAR(t, ear.TrustTierAffirming, ear.TrustTierWarning)},
+	}
+	makeFS(t, files)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"a.jwt", "b.jwt"})
+
+	require.NoError(t, cmd.Execute())
+
+	expected := `--- a.jwt
++++ b.jwt
+submods/b/ear.status
+- affirming
++ warning
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_DiffCmd_no_differences(t *testing.T) {
+	cmd := NewDiffCmd()
+
+	files := []fileEntry{
+		{"pkey.json", testPKey},
+		{"a.jwt", testJWT},
+	}
+	makeFS(t, files)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"a.jwt", "a.jwt"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "--- a.jwt\n+++ a.jwt\nno differences\n", out.String())
+}
+
+func Test_DiffCmd_insecure_color(t *testing.T) {
+	cmd := NewDiffCmd()
+
+	// no verification key is needed
+	files := []fileEntry{
+		{"a.jwt", testJWT},
+		{"b.jwt", signTestEAR(t, ear.TrustTierContraindicated, ear.TrustTierAffirming)},
+	}
+	makeFS(t, files)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--insecure", "--color", "a.jwt", "b.jwt"})
+
+	require.NoError(t, cmd.Execute())
+
+	expected := "\033[31m--- a.jwt\033[0m\n" +
+		"\033[32m+++ b.jwt\033[0m\n" +
+		"submods/a/ear.status\n" +
+		"\033[32m+ contraindicated\033[0m\n" +
+		"submods/b/ear.status\n" +
+		"\033[32m+ affirming\033[0m\n" +
+		"submods/test/ear.appraisal-policy-id\n" +
+		"\033[31m- https://veraison.example/policy/1/60a0068d\033[0m\n" +
+		"submods/test/ear.status\n" +
+		"\033[31m- affirming\033[0m\n"
+
+	actual := out.String()
+	assert.True(t, strings.HasPrefix(actual, expected), actual)
+	assert.Contains(t, actual, "submods/test/ear.trustworthiness-vector/executables\n\033[31m- affirming (3)\033[0m\n")
+}
+
+func Test_DiffCmd_verification_failed(t *testing.T) {
+	cmd := NewDiffCmd()
+
+	files := []fileEntry{
+		{"pkey.json", testPKey},
+		{"a.jwt", testJWT},
+		{"b.jwt", []byte("not an EAR")},
+	}
+	makeFS(t, files)
+
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"a.jwt", "b.jwt"})
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "verifying signed EAR from b.jwt: failed verifying JWT message")
+}
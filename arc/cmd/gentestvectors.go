@@ -0,0 +1,142 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/veraison/ear"
+)
+
+var (
+	genTVClaims string
+	genTVSKey   string
+	genTVAlg    string
+	genTVOutput string
+)
+
+// testVectorsIndexFile lists the generated test vectors
+const testVectorsIndexFile = "index.json"
+
+var genTestVectorsCmd = NewGenTestVectorsCmd()
+
+func NewGenTestVectorsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen-testvectors [flags] <output-dir>",
+		Short: "Generate malformed EARs that conforming verifiers must reject, and save them to output-dir",
+		Long: `Generate malformed EARs that conforming verifiers must reject, and save them to output-dir
+
+Derive a corpus of malformed-but-plausible EARs (missing mandatory claims, bad
+base64url evidence, out-of-range claims, wrong profile, tampered signature,
+...) from the valid claims-set in the default file "ear-claims.json".  Sign
+them with the key in the default key file "skey.json" and save them to the
+"testvectors" directory, one JWT per file, together with an "index.json" file
+describing each of them.
+
+	arc gen-testvectors testvectors
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				claimsSet, sKey []byte
+				sigK            jwk.Key
+				ar              ear.AttestationResult
+				tvs             []ear.TestVector
+				err             error
+			)
+
+			if err = checkGenTestVectorsArgs(args); err != nil {
+				return fmt.Errorf("validating arguments: %w", err)
+			}
+
+			genTVOutput = args[0]
+
+			if claimsSet, err = afero.ReadFile(fs, genTVClaims); err != nil {
+				return fmt.Errorf("loading EAR claims-set from %q: %w", genTVClaims, err)
+			}
+
+			if err = ar.UnmarshalJSON(claimsSet); err != nil {
+				return fmt.Errorf("decoding EAR claims-set from %q: %w", genTVClaims, err)
+			}
+
+			if sKey, err = afero.ReadFile(fs, genTVSKey); err != nil {
+				return fmt.Errorf("loading signing key from %q: %w", genTVSKey, err)
+			}
+
+			if sigK, err = ear.ParseSigningKey(sKey); err != nil {
+				return fmt.Errorf("parsing signing key from %q: %w", genTVSKey, err)
+			}
+
+			if tvs, err = ear.NegativeTestVectors(ar, jwa.KeyAlgorithmFrom(genTVAlg), sigK); err != nil {
+				return fmt.Errorf("generating test vectors: %w", err)
+			}
+
+			if err = fs.MkdirAll(genTVOutput, 0755); err != nil {
+				return fmt.Errorf("creating output directory %q: %w", genTVOutput, err)
+			}
+
+			type indexEntry struct {
+				ear.TestVector
+				File string `json:"file"`
+			}
+
+			index := make([]indexEntry, 0, len(tvs))
+
+			for _, tv := range tvs {
+				name := tv.Name + ".jwt"
+
+				if err = afero.WriteFile(fs, filepath.Join(genTVOutput, name), tv.Token, 0644); err != nil {
+					return fmt.Errorf("saving test vector %q: %w", tv.Name, err)
+				}
+
+				index = append(index, indexEntry{TestVector: tv, File: name})
+			}
+
+			indexData, err := json.MarshalIndent(index, "", "  ")
+			if err != nil {
+				return fmt.Errorf("serializing test vectors index: %w", err)
+			}
+
+			indexFile := filepath.Join(genTVOutput, testVectorsIndexFile)
+			if err = afero.WriteFile(fs, indexFile, indexData, 0644); err != nil {
+				return fmt.Errorf("saving test vectors index to %q: %w", indexFile, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), ">> generated %d test vectors in %q from %q using %q as signing key\n",
+				len(tvs), genTVOutput, genTVClaims, genTVSKey)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&genTVSKey, "skey", "s", "skey.json", "signing key (JWK, PEM, DER or COSE_Key)",
+	)
+
+	cmd.Flags().StringVarP(
+		&genTVClaims, "claims", "c", "ear-claims.json", "valid EAR claims-set in JSON",
+	)
+
+	cmd.Flags().StringVarP(
+		&genTVAlg, "alg", "a", "ES256", "signing algorithm ("+algList()+")",
+	)
+
+	return cmd
+}
+
+func checkGenTestVectorsArgs(args []string) error {
+	if len(args) != 1 {
+		return errors.New("no output directory supplied")
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(genTestVectorsCmd)
+}
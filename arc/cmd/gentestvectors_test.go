@@ -0,0 +1,95 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+func Test_GenTestVectorsCmd_no_output_dir(t *testing.T) {
+	cmd := NewGenTestVectorsCmd()
+
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "validating arguments: no output directory supplied")
+}
+
+func Test_GenTestVectorsCmd_bad_claims(t *testing.T) {
+	cmd := NewGenTestVectorsCmd()
+
+	files := []fileEntry{
+		{"ear-claims.json", testEmptyClaimsSet},
+		{"skey.json", testSKey},
+	}
+	makeFS(t, files)
+
+	cmd.SetArgs([]string{"--claims=ear-claims.json", "--skey=skey.json", "out"})
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, `decoding EAR claims-set from "ear-claims.json": missing mandatory`)
+}
+
+func Test_GenTestVectorsCmd_ok(t *testing.T) {
+	cmd := NewGenTestVectorsCmd()
+
+	files := []fileEntry{
+		{"ear-claims.json", testMiniClaimsSet},
+		{"skey.json", testSKey},
+	}
+	makeFS(t, files)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--claims=ear-claims.json", "--skey=skey.json", "--alg=ES256", "out"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), `test vectors in "out"`)
+
+	indexData, err := afero.ReadFile(fs, "out/index.json")
+	require.NoError(t, err)
+
+	var index []struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		File        string `json:"file"`
+	}
+	require.NoError(t, json.Unmarshal(indexData, &index))
+	require.NotEmpty(t, index)
+
+	vfyK, err := ear.ParseVerificationKey(testPKey)
+	require.NoError(t, err)
+
+	for _, e := range index {
+		token, err := afero.ReadFile(fs, "out/"+e.File)
+		require.NoError(t, err)
+
+		assert.Error(t, verifyTestVector(token, vfyK), "test vector %q verified", e.Name)
+	}
+}
+
+// verifyTestVector checks token as a conforming verifier would: Verify checks
+// the signature, and the claims-set is then validated in full
+func verifyTestVector(token []byte, key jwk.Key) error {
+	var ar ear.AttestationResult
+	if err := ar.Verify(token, jwa.ES256, key); err != nil {
+		return err
+	}
+
+	msg, err := jws.Parse(token)
+	if err != nil {
+		return err
+	}
+
+	return ar.UnmarshalJSON(msg.Payload())
+}
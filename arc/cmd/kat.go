@@ -0,0 +1,94 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/spf13/afero"
+	"github.com/veraison/ear"
+
+	// register the supported attester verifiers
+	_ "github.com/veraison/ear/annotated/nitro"
+)
+
+// katCheck routes the TEE evidence referenced by the tee-info claim of ar
+// through the matching ear.AttesterVerifier, and checks that the key it
+// attests is the one that has been used to verify the EAR (see
+// ear.AttestationResult.CheckKeyAttestation).  evidenceFile, if not empty,
+// contains the evidence conveyed out of band; otherwise, the evidence
+// embedded in the tee-info claim is used.  rootsFile contains the trust
+// anchors of the attester in PEM format.
+func katCheck(ar *ear.AttestationResult, vfyK jwk.Key, evidenceFile, rootsFile string) (string, error) {
+	var (
+		evidence []byte
+		err      error
+	)
+
+	if evidenceFile != "" {
+		if evidence, err = afero.ReadFile(fs, evidenceFile); err != nil {
+			return "", fmt.Errorf("loading evidence from %q: %w", evidenceFile, err)
+		}
+	}
+
+	if rootsFile == "" {
+		return "", errors.New("no trust anchors supplied, use --kat-roots")
+	}
+
+	roots, err := loadCertPool(rootsFile)
+	if err != nil {
+		return "", fmt.Errorf("loading trust anchors from %q: %w", rootsFile, err)
+	}
+
+	opts := ear.AttesterVerifyOptions{Roots: roots}
+
+	if _, err = ar.CheckKeyAttestation(vfyK, evidence, nil, nil, opts); err != nil {
+		return "", err
+	}
+
+	ti := ar.VeraisonTeeInfo
+
+	return fmt.Sprintf("%s evidence (evidence-id %s) corroborates the EAR signing key",
+		*ti.TeeName, *ti.EvidenceID), nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	n := 0
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		pool.AddCert(c)
+		n++
+	}
+
+	if n == 0 {
+		return nil, errors.New("no certificates found")
+	}
+
+	return pool, nil
+}
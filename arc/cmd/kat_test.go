@@ -0,0 +1,220 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+	"github.com/veraison/ear/annotated/nitro"
+)
+
+const testNitroTimestamp = 1666091373000
+
+// testNitroDocument returns a Nitro attestation document attesting pub,
+// together with the PEM-encoded root of its certificate chain
+func testNitroDocument(t *testing.T, pub interface{}) ([]byte, []byte) {
+	notBefore := time.UnixMilli(testNitroTimestamp).Add(-time.Hour)
+	notAfter := notBefore.Add(2 * time.Hour)
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	rootTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Nitro Root"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTmpl, rootTmpl, &rootKey.PublicKey, rootKey)
+	require.NoError(t, err)
+
+	rootCert, err := x509.ParseCertificate(rootDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test Enclave"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, rootCert, &leafKey.PublicKey, rootKey)
+	require.NoError(t, err)
+
+	leafCert, err := x509.ParseCertificate(leafDER)
+	require.NoError(t, err)
+
+	spki, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	doc, err := nitro.NewAttestationDocument(nitro.Evidence{
+		ModuleID:  "i-0123456789abcdef0-enc0123456789abcdef",
+		Digest:    "SHA384",
+		Timestamp: testNitroTimestamp,
+		PCRs:      map[int][]byte{0: make([]byte, 48)},
+		PublicKey: spki,
+	}, leafKey, []*x509.Certificate{leafCert})
+	require.NoError(t, err)
+
+	return doc, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER})
+}
+
+// testKATJWT returns a signed EAR with the supplied tee-info
+func testKATJWT(t *testing.T, ti *ear.VeraisonTeeInfo) []byte {
+	var ar ear.AttestationResult
+	require.NoError(t, ar.UnmarshalJSON(testMiniClaimsSet))
+
+	ar.VeraisonTeeInfo = ti
+
+	sigK, err := ear.ParseSigningKey(testSKey)
+	require.NoError(t, err)
+
+	token, err := ar.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	return token
+}
+
+func testRawPublicKey(t *testing.T, k []byte) interface{} {
+	key, err := ear.ParseVerificationKey(k)
+	require.NoError(t, err)
+
+	var raw interface{}
+	require.NoError(t, key.Raw(&raw))
+
+	return raw
+}
+
+func Test_VerifyCmd_kat_check_ok(t *testing.T) {
+	doc, roots := testNitroDocument(t, testRawPublicKey(t, testPKey))
+
+	name, id := "aws-nitro", "nitro-01"
+
+	embedded := ear.VeraisonTeeInfo{TeeName: &name, EvidenceID: &id}
+	require.NoError(t, embedded.SetEvidence(doc, "application/vnd.aws.nitro-attestation"))
+
+	referenced := ear.VeraisonTeeInfo{TeeName: &name, EvidenceID: &id}
+	require.NoError(t, referenced.SetEvidenceDigest("sha-256", doc))
+
+	tvs := []struct {
+		ti   *ear.VeraisonTeeInfo
+		args []string
+	}{
+		{&embedded, nil},
+		{&referenced, []string{"--kat-evidence=nitro.cbor"}},
+	}
+
+	for i, tv := range tvs {
+		cmd := NewVerifyCmd()
+
+		files := []fileEntry{
+			{"ear.jwt", testKATJWT(t, tv.ti)},
+			{"pkey.json", testPKey},
+			{"roots.pem", roots},
+			{"nitro.cbor", doc},
+		}
+		makeFS(t, files)
+
+		args := append([]string{"--pkey=pkey.json", "--kat-check", "--kat-roots=roots.pem"}, tv.args...)
+		cmd.SetArgs(append(args, "ear.jwt"))
+
+		assert.NoError(t, cmd.Execute(), "failed test vector at index %d", i)
+	}
+}
+
+func Test_VerifyCmd_kat_check_fail(t *testing.T) {
+	otherPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	doc, roots := testNitroDocument(t, &otherPriv.PublicKey)
+	_, otherRoots := testNitroDocument(t, &otherPriv.PublicKey)
+
+	name, id, unknown := "aws-nitro", "nitro-01", "sgx"
+
+	embedded := ear.VeraisonTeeInfo{TeeName: &name, EvidenceID: &id}
+	require.NoError(t, embedded.SetEvidence(doc, ""))
+
+	referenced := ear.VeraisonTeeInfo{TeeName: &name, EvidenceID: &id}
+	require.NoError(t, referenced.SetEvidenceDigest("sha-256", []byte("other evidence")))
+
+	unsupported := ear.VeraisonTeeInfo{TeeName: &unknown, EvidenceID: &id}
+	require.NoError(t, unsupported.SetEvidence(doc, ""))
+
+	tvs := []struct {
+		ti       *ear.VeraisonTeeInfo
+		args     []string
+		expected string
+	}{
+		{
+			nil,
+			[]string{"--kat-roots=roots.pem"},
+			`key attestation check: "ear.veraison.tee-info" claim not found`,
+		},
+		{
+			&unsupported,
+			[]string{"--kat-roots=roots.pem"},
+			`key attestation check: unsupported TEE "sgx" (supported: [aws-nitro])`,
+		},
+		{
+			&embedded,
+			nil,
+			"key attestation check: no trust anchors supplied, use --kat-roots",
+		},
+		{
+			&embedded,
+			[]string{"--kat-roots=other-roots.pem"},
+			"key attestation check: verifying aws-nitro evidence: validating certificate: x509: certificate signed by unknown authority",
+		},
+		{
+			&embedded,
+			[]string{"--kat-roots=roots.pem"},
+			"key attestation check: aws-nitro evidence does not attest the EAR signing key",
+		},
+		{
+			&referenced,
+			[]string{"--kat-roots=roots.pem"},
+			"key attestation check: no evidence embedded in tee-info",
+		},
+		{
+			&referenced,
+			[]string{"--kat-roots=roots.pem", "--kat-evidence=nitro.cbor"},
+			`key attestation check: evidence does not match tee-info: "evidence-digest": digest mismatch`,
+		},
+	}
+
+	for i, tv := range tvs {
+		cmd := NewVerifyCmd()
+
+		files := []fileEntry{
+			{"ear.jwt", testKATJWT(t, tv.ti)},
+			{"pkey.json", testPKey},
+			{"roots.pem", roots},
+			{"other-roots.pem", otherRoots},
+			{"nitro.cbor", doc},
+		}
+		makeFS(t, files)
+
+		args := append([]string{"--pkey=pkey.json", "--kat-check"}, tv.args...)
+		cmd.SetArgs(append(args, "ear.jwt"))
+
+		assert.ErrorContains(t, cmd.Execute(), tv.expected, "failed test vector at index %d", i)
+	}
+}
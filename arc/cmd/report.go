@@ -0,0 +1,204 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/veraison/ear"
+)
+
+var (
+	reportInput  string
+	reportAlg    string
+	reportPKey   string
+	reportFormat string
+	reportPolicy string
+)
+
+var reportCmd = NewReportCmd()
+
+func NewReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report [flags] <jwt-file>",
+		Short: "Read a signed EAR from jwt-file, verify it and render a report of its content",
+		Long: `Read a signed EAR from jwt-file, verify it and render a report of its content
+
+Verify the signed EAR in "my-ear.jwt" using the public key in the default key
+file "pkey.json".  If cryptographic verification is successful, print a
+one-line summary of the EAR.
+
+	arc report my-ear.jwt
+
+Render the per-submod statuses and trust vectors as an SVG badge, which can be
+embedded in dashboards.
+
+	arc report --format svg my-ear.jwt > badge.svg
+
+Render the same as a Graphviz graph.
+
+	arc report --format dot my-ear.jwt | dot -Tpng > ear.png
+
+Annotate the text report with the appraisal policies of the submods, which are
+looked up in the "policies" directory by the last path segment of their
+"ear.appraisal-policy-id" (e.g., "policies/60a0068d" for
+"https://veraison.example/policy/1/60a0068d").
+
+	arc report --policy-dir policies my-ear.jwt
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				pKey, arBytes []byte
+				vfyK          jwk.Key
+				ar            ear.AttestationResult
+				out           string
+				opts          []ear.VerifyOption
+				err           error
+			)
+
+			if err = checkReportArgs(args); err != nil {
+				return fmt.Errorf("validating arguments: %w", err)
+			}
+
+			reportInput = args[0]
+
+			if arBytes, err = afero.ReadFile(fs, reportInput); err != nil {
+				return fmt.Errorf("loading signed EAR from %q: %w", reportInput, err)
+			}
+
+			if pKey, err = afero.ReadFile(fs, reportPKey); err != nil {
+				return fmt.Errorf("loading verification key from %q: %w", reportPKey, err)
+			}
+
+			if vfyK, err = ear.ParseVerificationKey(pKey); err != nil {
+				return fmt.Errorf("parsing verification key from %q: %w", reportPKey, err)
+			}
+
+			if reportPolicy != "" {
+				opts = append(opts, ear.WithPolicyResolver(newDirPolicyResolver(reportPolicy)))
+			}
+
+			vr, err := ear.Verify(arBytes, jwa.KeyAlgorithmFrom(reportAlg), vfyK, opts...)
+			if err != nil {
+				return fmt.Errorf("verifying signed EAR from %s: %w", reportInput, err)
+			}
+
+			ar = vr.AttestationResult()
+
+			switch reportFormat {
+			case "text":
+				out = ar.Summary() + "\n" + policyReport(vr)
+			case "dot":
+				out = ar.DOT()
+			case "svg":
+				out = ar.SVG()
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), out)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&reportPKey, "pkey", "p", "pkey.json", "verification key (JWK, PEM, DER or COSE_Key)",
+	)
+
+	cmd.Flags().StringVarP(
+		&reportAlg, "alg", "a", "ES256", "verification algorithm ("+algList()+")",
+	)
+
+	cmd.Flags().StringVarP(
+		&reportFormat, "format", "f", "text", "report format (text, dot, svg)",
+	)
+
+	cmd.Flags().StringVarP(
+		&reportPolicy, "policy-dir", "P", "", "directory containing the appraisal policies (text format only)",
+	)
+
+	return cmd
+}
+
+// newDirPolicyResolver returns a resolver that loads policies from dir, using
+// the last path segment of the policy ID as the file name
+func newDirPolicyResolver(dir string) ear.PolicyResolver {
+	return ear.PolicyResolverFunc(func(id string) (*ear.Policy, error) {
+		name := id[strings.LastIndexAny(id, "/:")+1:]
+		if name == "" || name == "." || name == ".." {
+			return nil, fmt.Errorf("cannot derive a file name from policy ID %q", id)
+		}
+
+		file := filepath.Join(dir, name)
+
+		content, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(content)
+
+		return &ear.Policy{
+			ID:      id,
+			Content: content,
+			Metadata: map[string]string{
+				"file":    file,
+				"sha-256": hex.EncodeToString(sum[:]),
+			},
+		}, nil
+	})
+}
+
+// policyReport returns one line for each submod whose appraisal policy has
+// been resolved, with the policy metadata
+func policyReport(vr *ear.VerifiedResult) string {
+	var b strings.Builder
+
+	for _, submod := range vr.Submods() {
+		p, ok := vr.Policy(submod)
+		if !ok {
+			continue
+		}
+
+		keys := make([]string, 0, len(p.Metadata))
+		for k := range p.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(&b, "Submod '%s' appraisal policy %s", submod, p.ID)
+		for _, k := range keys {
+			fmt.Fprintf(&b, " %s=%s", k, p.Metadata[k])
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func checkReportArgs(args []string) error {
+	if len(args) != 1 {
+		return errors.New("no input file supplied")
+	}
+
+	switch reportFormat {
+	case "text", "dot", "svg":
+	default:
+		return fmt.Errorf("unsupported report format %q", reportFormat)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReportCmd_no_input_file(t *testing.T) {
+	cmd := NewReportCmd()
+
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "validating arguments: no input file supplied")
+}
+
+func Test_ReportCmd_unsupported_format(t *testing.T) {
+	cmd := NewReportCmd()
+
+	cmd.SetArgs([]string{"--format=pdf", "ear.jwt"})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, `validating arguments: unsupported report format "pdf"`)
+}
+
+func Test_ReportCmd_skey_not_ok_for_verifying(t *testing.T) {
+	cmd := NewReportCmd()
+
+	files := []fileEntry{
+		{"ear.jwt", testJWT},
+		{"skey.json", testSKey},
+	}
+	makeFS(t, files)
+
+	args := []string{
+		"--pkey=skey.json",
+		"--format=text",
+		"ear.jwt",
+	}
+	cmd.SetArgs(args)
+
+	expectedErr := `verifying signed EAR from ear.jwt: failed verifying JWT message: could not verify message using any of the signatures or keys`
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, expectedErr)
+}
+
+func Test_ReportCmd_ok(t *testing.T) {
+	tvs := []struct {
+		format   string
+		expected string
+	}{
+		{"text", "Submod 'test' is affirming; issued by Acme Inc. verifier build rrtrap-v1.0.0 at 2022-10-18T11:09:33Z\n"},
+		{"dot", "digraph EAR {\n"},
+		{"svg", `<svg xmlns="http://www.w3.org/2000/svg"`},
+	}
+
+	for i, tv := range tvs {
+		cmd := NewReportCmd()
+
+		files := []fileEntry{
+			{"pkey.json", testPKey},
+			{"ear.jwt", testJWT},
+		}
+		makeFS(t, files)
+
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+
+		cmd.SetArgs([]string{"--pkey=pkey.json", "--format=" + tv.format, "ear.jwt"})
+
+		require.NoError(t, cmd.Execute(), "failed test vector at index %d", i)
+		assert.True(t, strings.HasPrefix(out.String(), tv.expected), "failed test vector at index %d", i)
+	}
+}
+
+func Test_ReportCmd_policy_dir(t *testing.T) {
+	cmd := NewReportCmd()
+
+	files := []fileEntry{
+		{"pkey.json", testPKey},
+		{"ear.jwt", testJWT},
+		{"policies/60a0068d", []byte("package policy")},
+	}
+	makeFS(t, files)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	cmd.SetArgs([]string{"--pkey=pkey.json", "--policy-dir=policies", "ear.jwt"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(),
+		"Submod 'test' appraisal policy https://veraison.example/policy/1/60a0068d file=policies/60a0068d sha-256=")
+}
+
+func Test_ReportCmd_policy_not_found(t *testing.T) {
+	cmd := NewReportCmd()
+
+	files := []fileEntry{
+		{"pkey.json", testPKey},
+		{"ear.jwt", testJWT},
+	}
+	makeFS(t, files)
+
+	cmd.SetArgs([]string{"--pkey=pkey.json", "--policy-dir=policies", "ear.jwt"})
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err,
+		`verifying signed EAR from ear.jwt: resolving appraisal policy "https://veraison.example/policy/1/60a0068d" of submod "test": open policies/60a0068d`)
+}
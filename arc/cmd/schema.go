@@ -0,0 +1,63 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/veraison/ear/schema"
+)
+
+var schemaFormat string
+
+var schemaCmd = NewSchemaCmd()
+
+func NewSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema [flags]",
+		Short: "Print the JSON Schema or the CDDL of the EAR claims-set",
+		Long: `Print the JSON Schema or the CDDL of the EAR claims-set
+
+Print the JSON Schema of the JSON serialization of the EAR claims-set, as
+implemented by this tool.
+
+	arc schema > ear.schema.json
+
+Print the CDDL, which covers both the JSON and the CBOR serializations.
+
+	arc schema --format cddl > ear.cddl
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var out string
+
+			switch schemaFormat {
+			case "json-schema":
+				data, err := schema.JSONSchema()
+				if err != nil {
+					return fmt.Errorf("generating JSON Schema: %w", err)
+				}
+				out = string(data) + "\n"
+			case "cddl":
+				out = schema.CDDL()
+			default:
+				return fmt.Errorf("validating arguments: unsupported format %q (expecting json-schema or cddl)", schemaFormat)
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), out)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&schemaFormat, "format", "f", "json-schema", "schema format (json-schema, cddl)",
+	)
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear/schema"
+)
+
+func Test_SchemaCmd(t *testing.T) {
+	jsonSchema, err := schema.JSONSchema()
+	require.NoError(t, err)
+
+	tvs := []struct {
+		args     []string
+		expected string
+	}{
+		{nil, string(jsonSchema) + "\n"},
+		{[]string{"--format=cddl"}, schema.CDDL()},
+	}
+
+	for i, tv := range tvs {
+		cmd := NewSchemaCmd()
+
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs(tv.args)
+
+		require.NoError(t, cmd.Execute(), "failed test vector at index %d", i)
+		assert.Equal(t, tv.expected, out.String(), "failed test vector at index %d", i)
+	}
+}
+
+func Test_SchemaCmd_unknown_format(t *testing.T) {
+	cmd := NewSchemaCmd()
+
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--format=xsd"})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, `validating arguments: unsupported format "xsd" (expecting json-schema or cddl)`)
+}
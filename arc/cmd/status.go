@@ -0,0 +1,208 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/veraison/ear"
+)
+
+// exit codes of the status sub-command
+const (
+	statusExitAffirming       = 0
+	statusExitWarning         = 1
+	statusExitContraindicated = 2
+	statusExitError           = 3
+)
+
+// exitCodeError makes Execute terminate the process with the wrapped code.  If
+// err is nil, nothing is printed before exiting.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (o exitCodeError) Error() string {
+	if o.err == nil {
+		return fmt.Sprintf("exit code %d", o.code)
+	}
+	return o.err.Error()
+}
+
+func (o exitCodeError) Unwrap() error {
+	return o.err
+}
+
+var (
+	statusInput   string
+	statusAlg     string
+	statusPKey    string
+	statusFormat  string
+	statusSubmod  string
+	statusRequire string
+)
+
+var statusCmd = NewStatusCmd()
+
+func NewStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [flags] <ear-file>",
+		Short: "Read a signed EAR from ear-file, verify it and exit with a code reflecting its status",
+		Long: `Read a signed EAR from ear-file, verify it and exit with a code reflecting its status
+
+Verify the signed EAR in "my-ear.jwt" using the public key in the default key
+file "pkey.json", print a one-line summary of its status, i.e., the worst of
+the statuses of its submods, and exit with:
+
+	0 if the status is affirming
+	1 if the status is warning
+	2 if the status is contraindicated
+	3 if the status is none, or the EAR cannot be verified
+
+	arc status my-ear.jwt
+
+Only consider the status of the "PARSEC_TPM" submod, and exit with 0 if it is
+at least warning.
+
+	arc status --submod PARSEC_TPM --require warning my-ear.jwt
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tier, what, err := statusOf(args)
+			if err != nil {
+				return exitCodeError{statusExitError, err}
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: %s: %s\n", statusInput, what, tier)
+
+			if statusRequire != "" {
+				required, err := ear.ToTrustTier(statusRequire)
+				if err != nil {
+					return exitCodeError{statusExitError, fmt.Errorf("validating arguments: %w", err)}
+				}
+
+				if ear.CompareTiers(tier, *required) >= 0 {
+					return nil
+				}
+			}
+
+			if code := statusExitCode(tier); code != statusExitAffirming {
+				return exitCodeError{code: code}
+			}
+
+			return nil
+		},
+	}
+
+	// a non-affirming status is not a usage error
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return exitCodeError{statusExitError, err}
+	})
+
+	cmd.Flags().StringVarP(
+		&statusPKey, "pkey", "p", "pkey.json", "verification key (JWK, PEM, DER or COSE_Key)",
+	)
+
+	cmd.Flags().StringVarP(
+		&statusAlg, "alg", "a", "ES256", "verification algorithm ("+algList()+")",
+	)
+
+	cmd.Flags().StringVarP(
+		&statusFormat, "format", "f", formatJWT, "signed EAR format (jwt, cwt)",
+	)
+
+	cmd.Flags().StringVarP(
+		&statusSubmod, "submod", "s", "", "only consider the status of this submod (default is the worst of all submods)",
+	)
+
+	cmd.Flags().StringVarP(
+		&statusRequire, "require", "r", "", "exit with 0 if the status is at least this tier (affirming, warning, none, contraindicated)",
+	)
+
+	return cmd
+}
+
+// statusOf verifies the EAR in the file named by args and returns the status
+// of the selected submod (or the aggregate status), together with a
+// description of what the status refers to
+func statusOf(args []string) (ear.TrustTier, string, error) {
+	var (
+		pKey, arBytes []byte
+		vfyK          jwk.Key
+		ar            ear.AttestationResult
+		err           error
+	)
+
+	if err = checkStatusArgs(args); err != nil {
+		return ear.TrustTierNone, "", fmt.Errorf("validating arguments: %w", err)
+	}
+
+	statusInput = args[0]
+
+	if arBytes, err = afero.ReadFile(fs, statusInput); err != nil {
+		return ear.TrustTierNone, "", fmt.Errorf("loading signed EAR from %q: %w", statusInput, err)
+	}
+
+	if pKey, err = afero.ReadFile(fs, statusPKey); err != nil {
+		return ear.TrustTierNone, "", fmt.Errorf("loading verification key from %q: %w", statusPKey, err)
+	}
+
+	if vfyK, err = ear.ParseVerificationKey(pKey); err != nil {
+		return ear.TrustTierNone, "", fmt.Errorf("parsing verification key from %q: %w", statusPKey, err)
+	}
+
+	alg := jwa.KeyAlgorithmFrom(statusAlg)
+
+	if statusFormat == formatCWT {
+		err = ar.VerifyCWT(decodeBinaryInput(arBytes), alg, vfyK)
+	} else {
+		err = ar.Verify(arBytes, alg, vfyK)
+	}
+
+	if err != nil {
+		return ear.TrustTierNone, "", fmt.Errorf("verifying signed EAR from %s: %w", statusInput, err)
+	}
+
+	if statusSubmod == "" {
+		return ar.AggregateStatus(), "status", nil
+	}
+
+	tier, ok := ar.GetStatus(statusSubmod)
+	if !ok {
+		return ear.TrustTierNone, "", fmt.Errorf("submod %q not found in %s", statusSubmod, statusInput)
+	}
+
+	return tier, fmt.Sprintf("submod(%s)", statusSubmod), nil
+}
+
+func statusExitCode(tier ear.TrustTier) int {
+	switch tier {
+	case ear.TrustTierAffirming:
+		return statusExitAffirming
+	case ear.TrustTierWarning:
+		return statusExitWarning
+	case ear.TrustTierContraindicated:
+		return statusExitContraindicated
+	default:
+		return statusExitError
+	}
+}
+
+func checkStatusArgs(args []string) error {
+	if len(args) != 1 {
+		return errors.New("no input file supplied")
+	}
+	return checkFormat(statusFormat)
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+// signTestEAR returns a JWT with a submod "a" with status a, and a submod "b"
+// with status b
+func signTestEAR(t *testing.T, a, b ear.TrustTier) []byte {
+	var ar ear.AttestationResult
+	require.NoError(t, ar.UnmarshalJSON(testMiniClaimsSet))
+
+	ar.Submods = map[string]*ear.Appraisal{
+		"a": {Status: &a},
+		"b": {Status: &b},
+	}
+
+	sKey, err := ear.ParseSigningKey(testSKey)
+	require.NoError(t, err)
+
+	jwt, err := ar.Sign(jwa.ES256, sKey)
+	require.NoError(t, err)
+
+	return jwt
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var ec exitCodeError
+	if errors.As(err, &ec) {
+		return ec.code
+	}
+
+	return -1
+}
+
+func Test_StatusCmd(t *testing.T) {
+	tvs := []struct {
+		a, b           ear.TrustTier
+		args           []string
+		expectedCode   int
+		expectedOutput string
+	}{
+		{
+			ear.TrustTierAffirming, ear.TrustTierAffirming, nil,
+			0, "ear.jwt: status: affirming\n",
+		},
+		{
+			ear.TrustTierAffirming, ear.TrustTierWarning, nil,
+			1, "ear.jwt: status: warning\n",
+		},
+		{
+			ear.TrustTierContraindicated, ear.TrustTierWarning, nil,
+			2, "ear.jwt: status: contraindicated\n",
+		},
+		{
+			ear.TrustTierAffirming, ear.TrustTierNone, nil,
+			3, "ear.jwt: status: none\n",
+		},
+		{
+			ear.TrustTierAffirming, ear.TrustTierWarning, []string{"--submod=a"},
+			0, "ear.jwt: submod(a): affirming\n",
+		},
+		{
+			ear.TrustTierAffirming, ear.TrustTierWarning, []string{"--require=warning"},
+			0, "ear.jwt: status: warning\n",
+		},
+		{
+			ear.TrustTierAffirming, ear.TrustTierWarning, []string{"--require=affirming"},
+			1, "ear.jwt: status: warning\n",
+		},
+		{
+			ear.TrustTierAffirming, ear.TrustTierContraindicated, []string{"--submod=b", "--require=none"},
+			2, "ear.jwt: submod(b): contraindicated\n",
+		},
+	}
+
+	for i, tv := range tvs {
+		cmd := NewStatusCmd()
+
+		files := []fileEntry{
+			{"pkey.json", testPKey},
+			{"ear.jwt", signTestEAR(t, tv.a, tv.b)},
+		}
+		makeFS(t, files)
+
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs(append(tv.args, "ear.jwt"))
+
+		err := cmd.Execute()
+		assert.Equal(t, tv.expectedCode, exitCode(err), "failed test vector at index %d", i)
+		assert.Equal(t, tv.expectedOutput, out.String(), "failed test vector at index %d", i)
+	}
+}
+
+func Test_StatusCmd_errors(t *testing.T) {
+	tvs := []struct {
+		args        []string
+		expectedErr string
+	}{
+		{
+			[]string{"--unknown-argument=val"},
+			"unknown flag: --unknown-argument",
+		},
+		{
+			[]string{},
+			"validating arguments: no input file supplied",
+		},
+		{
+			[]string{"--submod=c", "ear.jwt"},
+			`submod "c" not found in ear.jwt`,
+		},
+		{
+			[]string{"--require=great", "ear.jwt"},
+			`validating arguments: not a valid TrustTier name: "great"`,
+		},
+		{
+			[]string{"--alg=ES384", "ear.jwt"},
+			"verifying signed EAR from ear.jwt: failed verifying JWT message: could not verify message using any of the signatures or keys",
+		},
+	}
+
+	for i, tv := range tvs {
+		cmd := NewStatusCmd()
+
+		files := []fileEntry{
+			{"pkey.json", testPKey},
+			{"ear.jwt", signTestEAR(t, ear.TrustTierAffirming, ear.TrustTierAffirming)},
+		}
+		makeFS(t, files)
+
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs(tv.args)
+
+		err := cmd.Execute()
+		assert.EqualError(t, err, tv.expectedErr, "failed test vector at index %d", i)
+		assert.Equal(t, statusExitError, exitCode(err), "failed test vector at index %d", i)
+	}
+}
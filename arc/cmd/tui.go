@@ -0,0 +1,463 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/veraison/ear"
+)
+
+var (
+	tuiAlg  string
+	tuiPKey string
+)
+
+var tuiCmd = NewTuiCmd()
+
+func NewTuiCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tui [flags] <jwt-file> [<jwt-file>...]",
+		Short: "Interactively browse one or more signed EARs",
+		Long: `Interactively browse one or more signed EARs
+
+Verify the signed EARs in "a.jwt" and "b.jwt" using the public key in the
+default key file "pkey.json".  If cryptographic verification is successful,
+start an interactive session where submods can be navigated, trust claim
+descriptions expanded, extensions viewed raw or decoded, and results compared
+side by side.  Type "help" at the prompt for the list of commands.
+
+	arc tui a.jwt b.jwt
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkTuiArgs(args); err != nil {
+				return fmt.Errorf("validating arguments: %w", err)
+			}
+
+			pKey, err := afero.ReadFile(fs, tuiPKey)
+			if err != nil {
+				return fmt.Errorf("loading verification key from %q: %w", tuiPKey, err)
+			}
+
+			vfyK, err := ear.ParseVerificationKey(pKey)
+			if err != nil {
+				return fmt.Errorf("parsing verification key from %q: %w", tuiPKey, err)
+			}
+
+			b := &browser{out: cmd.OutOrStdout()}
+
+			for _, input := range args {
+				arBytes, err := afero.ReadFile(fs, input)
+				if err != nil {
+					return fmt.Errorf("loading signed EAR from %q: %w", input, err)
+				}
+
+				var ar ear.AttestationResult
+				if err := ar.Verify(arBytes, jwa.KeyAlgorithmFrom(tuiAlg), vfyK); err != nil {
+					return fmt.Errorf("verifying signed EAR from %s: %w", input, err)
+				}
+
+				b.ears = append(b.ears, loadedEAR{name: input, ar: ar})
+			}
+
+			return b.run(cmd.InOrStdin())
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&tuiPKey, "pkey", "p", "pkey.json", "verification key (JWK, PEM, DER or COSE_Key)",
+	)
+
+	cmd.Flags().StringVarP(
+		&tuiAlg, "alg", "a", "ES256", "verification algorithm ("+algList()+")",
+	)
+
+	return cmd
+}
+
+func checkTuiArgs(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no input file supplied")
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+type loadedEAR struct {
+	name string
+	ar   ear.AttestationResult
+}
+
+// browser holds the state of an interactive session
+type browser struct {
+	out     io.Writer
+	ears    []loadedEAR
+	cur     int
+	submod  string
+	verbose bool
+	raw     bool
+}
+
+const tuiHelp = `commands:
+  ls               list the loaded EARs (or the submods of the current EAR)
+  open <n>         select the n-th loaded EAR
+  cd <submod>      select a submod of the current EAR ("cd .." to go back)
+  show             show the current EAR or submod
+  expand           toggle short/long trust claim descriptions
+  raw              toggle raw/decoded view of extensions
+  compare <n> <m>  compare the n-th and m-th loaded EARs side by side
+  help             show this help
+  quit             end the session
+`
+
+func (o *browser) run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+
+	for {
+		fmt.Fprint(o.out, o.prompt())
+
+		if !scanner.Scan() {
+			fmt.Fprintln(o.out)
+			return scanner.Err()
+		}
+
+		if quit := o.exec(scanner.Text()); quit {
+			return nil
+		}
+	}
+}
+
+func (o browser) prompt() string {
+	p := o.ears[o.cur].name
+	if o.submod != "" {
+		p += "/" + o.submod
+	}
+	return p + "> "
+}
+
+// exec runs one command and reports whether the session should end
+func (o *browser) exec(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+
+	var err error
+
+	switch cmd, args := fields[0], fields[1:]; cmd {
+	case "quit", "exit", "q":
+		return true
+	case "help", "?":
+		fmt.Fprint(o.out, tuiHelp)
+	case "ls":
+		o.list()
+	case "open":
+		err = o.open(args)
+	case "cd":
+		err = o.cd(args)
+	case "show":
+		o.show()
+	case "expand":
+		o.verbose = !o.verbose
+		fmt.Fprintf(o.out, "long descriptions: %t\n", o.verbose)
+	case "raw":
+		o.raw = !o.raw
+		fmt.Fprintf(o.out, "raw extensions: %t\n", o.raw)
+	case "compare":
+		err = o.compare(args)
+	default:
+		err = fmt.Errorf("unknown command %q (try \"help\")", cmd)
+	}
+
+	if err != nil {
+		fmt.Fprintf(o.out, "error: %v\n", err)
+	}
+
+	return false
+}
+
+func (o browser) current() ear.AttestationResult {
+	return o.ears[o.cur].ar
+}
+
+func (o browser) list() {
+	if o.submod != "" || len(o.ears) == 1 {
+		for _, name := range submodNames(o.current()) {
+			fmt.Fprintf(o.out, "%s\t%s\n", name, statusString(o.current().Submods[name]))
+		}
+		return
+	}
+
+	for i, e := range o.ears {
+		marker := " "
+		if i == o.cur {
+			marker = "*"
+		}
+		fmt.Fprintf(o.out, "%s %d\t%s\t(%d submods)\n", marker, i+1, e.name, len(e.ar.Submods))
+	}
+}
+
+func (o *browser) open(args []string) error {
+	i, err := o.index(args)
+	if err != nil {
+		return err
+	}
+
+	o.cur, o.submod = i, ""
+
+	return nil
+}
+
+func (o browser) index(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errors.New("expecting one EAR number")
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > len(o.ears) {
+		return 0, fmt.Errorf("no EAR number %q (1-%d)", args[0], len(o.ears))
+	}
+
+	return n - 1, nil
+}
+
+func (o *browser) cd(args []string) error {
+	if len(args) != 1 {
+		return errors.New("expecting one submod name")
+	}
+
+	if args[0] == ".." || args[0] == "/" {
+		o.submod = ""
+		return nil
+	}
+
+	if _, ok := o.current().Submods[args[0]]; !ok {
+		return fmt.Errorf("no submod %q", args[0])
+	}
+
+	o.submod = args[0]
+
+	return nil
+}
+
+func (o browser) show() {
+	ar := o.current()
+
+	if o.submod == "" {
+		fmt.Fprintln(o.out, ar.Summary())
+		return
+	}
+
+	a := ar.Submods[o.submod]
+
+	fmt.Fprintf(o.out, "status: %s\n", statusString(a))
+
+	if a.AppraisalPolicyID != nil {
+		fmt.Fprintf(o.out, "policy: %s\n", *a.AppraisalPolicyID)
+	}
+
+	if a.TrustVector != nil {
+		fmt.Fprintln(o.out, "[trustworthiness vector]")
+		fmt.Fprint(o.out, a.TrustVector.Report(!o.verbose, false))
+	}
+
+	exts := extensionsAsMap(a)
+	if len(exts) == 0 {
+		return
+	}
+
+	fmt.Fprintln(o.out, "[extensions]")
+
+	names := make([]string, 0, len(exts))
+	for name := range exts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if o.raw {
+			data, _ := json.Marshal(exts[name])
+			fmt.Fprintf(o.out, "%s: %s\n", name, data)
+			continue
+		}
+
+		fmt.Fprintf(o.out, "%s:\n", name)
+		for _, l := range decodeExtension(exts[name]) {
+			fmt.Fprintf(o.out, "  %s\n", l)
+		}
+	}
+}
+
+func (o browser) compare(args []string) error {
+	if len(args) != 2 {
+		return errors.New("expecting two EAR numbers")
+	}
+
+	i, err := o.index(args[:1])
+	if err != nil {
+		return err
+	}
+
+	j, err := o.index(args[1:])
+	if err != nil {
+		return err
+	}
+
+	left, right := o.ears[i].ar, o.ears[j].ar
+
+	names := submodNames(left)
+	for _, name := range submodNames(right) {
+		if _, ok := left.Submods[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	row := func(label, l, r string) {
+		mark := " "
+		if l != r {
+			mark = "!"
+		}
+		fmt.Fprintf(o.out, "%s %-20s %-32s %-32s\n", mark, label, l, r)
+	}
+
+	row("", o.ears[i].name, o.ears[j].name)
+
+	for _, name := range names {
+		l, r := left.Submods[name], right.Submods[name]
+
+		row("["+name+"]", statusString(l), statusString(r))
+
+		lv, rv := trustVectorAsMap(l), trustVectorAsMap(r)
+		for _, claim := range trustVectorClaimNames {
+			row("  "+claim, lv[claim], rv[claim])
+		}
+	}
+
+	return nil
+}
+
+var trustVectorClaimNames = []string{
+	"instance-identity",
+	"configuration",
+	"executables",
+	"file-system",
+	"hardware",
+	"runtime-opaque",
+	"storage-opaque",
+	"sourced-data",
+}
+
+func trustVectorAsMap(a *ear.Appraisal) map[string]string {
+	ret := map[string]string{}
+
+	if a == nil || a.TrustVector == nil {
+		return ret
+	}
+
+	for name, claim := range a.TrustVector.AsMap() {
+		ret[name] = fmt.Sprintf("%s (%d)", claim.GetTier(), claim)
+	}
+
+	return ret
+}
+
+func submodNames(ar ear.AttestationResult) []string {
+	names := make([]string, 0, len(ar.Submods))
+	for name := range ar.Submods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func statusString(a *ear.Appraisal) string {
+	if a == nil {
+		return "-"
+	}
+	if a.Status == nil {
+		return "none"
+	}
+	return a.Status.String()
+}
+
+// extensionsAsMap returns the extensions of the appraisal in their JSON form
+func extensionsAsMap(a *ear.Appraisal) map[string]interface{} {
+	return jsonFields(a.AppraisalExtensions)
+}
+
+// decodeExtension renders an extension value as human-readable lines
+func decodeExtension(v interface{}) []string {
+	var lines []string
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("%s = %s", k, scalarString(t[k])))
+		}
+	case []interface{}:
+		for _, e := range t {
+			lines = append(lines, "- "+entryString(e))
+		}
+	default:
+		lines = append(lines, scalarString(t))
+	}
+
+	return lines
+}
+
+// entryString renders an array entry, such as a status reason or a policy
+// result, on one line
+func entryString(v interface{}) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return scalarString(v)
+	}
+
+	if reason, ok := m["reason"]; ok {
+		return fmt.Sprintf("%s -> %s: %s",
+			scalarString(m["from"]), scalarString(m["to"]), scalarString(reason))
+	}
+
+	if rule, ok := m["rule-id"]; ok {
+		s := fmt.Sprintf("%s: %s", scalarString(rule), scalarString(m["outcome"]))
+		if dim, ok := m["vector-dimension"]; ok {
+			s += fmt.Sprintf(" (%s)", scalarString(dim))
+		}
+		return s
+	}
+
+	return scalarString(v)
+}
+
+func scalarString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TuiCmd_no_input_file(t *testing.T) {
+	cmd := NewTuiCmd()
+
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "validating arguments: no input file supplied")
+}
+
+func Test_TuiCmd_skey_not_ok_for_verifying(t *testing.T) {
+	cmd := NewTuiCmd()
+
+	files := []fileEntry{
+		{"ear.jwt", testJWT},
+		{"skey.json", testSKey},
+	}
+	makeFS(t, files)
+
+	cmd.SetArgs([]string{"--pkey=skey.json", "ear.jwt"})
+
+	expectedErr := `verifying signed EAR from ear.jwt: failed verifying JWT message: could not verify message using any of the signatures or keys`
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, expectedErr)
+}
+
+func Test_TuiCmd_session(t *testing.T) {
+	cmd := NewTuiCmd()
+
+	files := []fileEntry{
+		{"pkey.json", testPKey},
+		{"a.jwt", testJWT},
+		{"b.jwt", testJWT},
+	}
+	makeFS(t, files)
+
+	script := []string{
+		"ls",
+		"open 2",
+		"cd test",
+		"show",
+		"expand",
+		"show",
+		"cd ..",
+		"cd nope",
+		"compare 1 2",
+		"compare 1 3",
+		"frobnicate",
+		"quit",
+	}
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader(strings.Join(script, "\n")))
+	cmd.SetArgs([]string{"--pkey=pkey.json", "a.jwt", "b.jwt"})
+
+	require.NoError(t, cmd.Execute())
+
+	for _, expected := range []string{
+		"* 1\ta.jwt\t(1 submods)\n  2\tb.jwt\t(1 submods)\n",
+		"b.jwt/test> ",
+		"status: affirming\npolicy: https://veraison.example/policy/1/60a0068d\n",
+		"Executables [affirming]: recognized and approved boot-time\n",
+		"long descriptions: true\n",
+		"Executables [affirming]: Only a recognized genuine set of approved executables have been loaded during the boot process.\n",
+		"error: no submod \"nope\"\n",
+		"  [test]               affirming                        affirming",
+		"error: no EAR number \"3\" (1-2)\n",
+		"error: unknown command \"frobnicate\" (try \"help\")\n",
+	} {
+		assert.Contains(t, out.String(), expected)
+	}
+}
+
+func Test_browser_extensions(t *testing.T) {
+	lines := decodeExtension([]interface{}{
+		map[string]interface{}{"from": "affirming", "to": "warning", "reason": "stale"},
+		map[string]interface{}{"rule-id": "r1", "outcome": "fail", "vector-dimension": "executables"},
+	})
+	assert.Equal(t, []string{"- affirming -> warning: stale", "- r1: fail (executables)"}, lines)
+
+	lines = decodeExtension(map[string]interface{}{"b": 1.0, "a": "x"})
+	assert.Equal(t, []string{"a = x", "b = 1"}, lines)
+}
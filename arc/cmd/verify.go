@@ -19,21 +19,36 @@ var (
 	verifyPKey    string
 	verifyColor   bool
 	verifyVerbose bool
+	verifyKAT     bool
+	verifyKATEvid string
+	verifyKATCA   string
+	verifyFormat  string
 )
 
 var verifyCmd = NewVerifyCmd()
 
 func NewVerifyCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "verify [flags] <jwt-file>",
-		Short: "Read a signed EAR from jwt-file, verify it and pretty-print its content",
-		Long: `Read a signed EAR from jwt-file, verify it and pretty-print its content
+		Use:   "verify [flags] <ear-file>",
+		Short: "Read a signed EAR from ear-file, verify it and pretty-print its content",
+		Long: `Read a signed EAR from ear-file, verify it and pretty-print its content
 
 Verify the signed EAR in "my-ear.jwt" using the public key in the default key
 file "pkey.json".  If cryptographic verification is successful, print the
 embedded EAR claims-set and present a report of the trustworthiness vector.
 
 	arc verify my-ear.jwt
+
+Do the same for a CWT EAR (COSE_Sign1).  The file can contain the binary CBOR
+message, or its hex or base64 encoding.
+
+	arc verify --format cwt my-ear.cbor
+
+If the EAR carries an "ear.veraison.tee-info" claim, also verify the TEE
+evidence it embeds (or references) against the trust anchors in
+"nitro-root.pem", and check that the evidence attests the EAR signing key.
+
+	arc verify --kat-check --kat-roots nitro-root.pem my-ear.jwt
 	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var (
@@ -47,6 +62,10 @@ embedded EAR claims-set and present a report of the trustworthiness vector.
 				return fmt.Errorf("validating arguments: %w", err)
 			}
 
+			if err = checkFormat(verifyFormat); err != nil {
+				return fmt.Errorf("validating arguments: %w", err)
+			}
+
 			verifyInput = args[0]
 
 			if arBytes, err = afero.ReadFile(fs, verifyInput); err != nil {
@@ -58,16 +77,31 @@ embedded EAR claims-set and present a report of the trustworthiness vector.
 				return fmt.Errorf("loading verification key from %q: %w", verifyPKey, err)
 			}
 
-			if vfyK, err = jwk.ParseKey(pKey); err != nil {
+			if vfyK, err = ear.ParseVerificationKey(pKey); err != nil {
 				return fmt.Errorf("parsing verification key from %q: %w", verifyPKey, err)
 			}
 
-			if err = ar.Verify(arBytes, jwa.KeyAlgorithmFrom(verifyAlg), vfyK); err != nil {
+			var warnings []ear.Warning
+
+			alg := jwa.KeyAlgorithmFrom(verifyAlg)
+			warn := ear.WithWarningHandler(func(w ear.Warning) { warnings = append(warnings, w) })
+
+			if verifyFormat == formatCWT {
+				err = ar.VerifyCWT(decodeBinaryInput(arBytes), alg, vfyK, warn)
+			} else {
+				err = ar.Verify(arBytes, alg, vfyK, warn)
+			}
+
+			if err != nil {
 				return fmt.Errorf("verifying signed EAR from %s: %w", verifyInput, err)
 			}
 
 			fmt.Printf(">> %q signature successfully verified using %q\n", verifyInput, verifyPKey)
 
+			for _, w := range warnings {
+				fmt.Printf(">> warning: %s\n", w)
+			}
+
 			fmt.Println("[claims-set]")
 			if claimsSet, err = ar.MarshalJSONIndent("", "    "); err != nil {
 				return fmt.Errorf("unable to re-serialize the EAR claims-set: %w", err)
@@ -84,12 +118,20 @@ embedded EAR claims-set and present a report of the trustworthiness vector.
 				}
 			}
 
+			if verifyKAT {
+				res, err := katCheck(&ar, vfyK, verifyKATEvid, verifyKATCA)
+				if err != nil {
+					return fmt.Errorf("key attestation check: %w", err)
+				}
+				fmt.Printf(">> key attestation check: %s\n", res)
+			}
+
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(
-		&verifyPKey, "pkey", "p", "pkey.json", "verification key in JWK format",
+		&verifyPKey, "pkey", "p", "pkey.json", "verification key (JWK, PEM, DER or COSE_Key)",
 	)
 
 	cmd.Flags().StringVarP(
@@ -104,6 +146,22 @@ embedded EAR claims-set and present a report of the trustworthiness vector.
 		&verifyColor, "color", "c", false, "render trustworthiness vector tiers with colors (default is b&w)",
 	)
 
+	cmd.Flags().BoolVarP(
+		&verifyKAT, "kat-check", "k", false, "verify the TEE evidence in tee-info and check that it attests the EAR signing key",
+	)
+
+	cmd.Flags().StringVarP(
+		&verifyKATEvid, "kat-evidence", "e", "", "TEE evidence referenced by tee-info, if not embedded (requires --kat-check)",
+	)
+
+	cmd.Flags().StringVarP(
+		&verifyKATCA, "kat-roots", "r", "", "TEE trust anchors in PEM format (requires --kat-check)",
+	)
+
+	cmd.Flags().StringVarP(
+		&verifyFormat, "format", "f", formatJWT, "signed EAR format (jwt, cwt)",
+	)
+
 	return cmd
 }
 
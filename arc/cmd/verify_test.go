@@ -3,9 +3,17 @@
 package cmd
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
 	"testing"
 
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
 )
 
 func Test_VerifyCmd_unknown_argument(t *testing.T) {
@@ -176,3 +184,111 @@ func Test_VerifyCmd_ok(t *testing.T) {
 	err := cmd.Execute()
 	assert.NoError(t, err)
 }
+
+func Test_VerifyCmd_pem_key_ok(t *testing.T) {
+	cmd := NewVerifyCmd()
+
+	k, err := jwk.ParseKey(testPKey)
+	require.NoError(t, err)
+
+	var raw interface{}
+	require.NoError(t, k.Raw(&raw))
+
+	der, err := x509.MarshalPKIXPublicKey(raw)
+	require.NoError(t, err)
+
+	files := []fileEntry{
+		{"pkey.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})},
+		{"ear.jwt", testJWT},
+	}
+	makeFS(t, files)
+
+	args := []string{
+		"--pkey=pkey.pem",
+		"--alg=ES256",
+		"ear.jwt",
+	}
+	cmd.SetArgs(args)
+
+	err = cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func Test_VerifyCmd_unknown_format(t *testing.T) {
+	cmd := NewVerifyCmd()
+
+	args := []string{
+		"--format=xml",
+		"ear.xml",
+	}
+	cmd.SetArgs(args)
+
+	expectedErr := `validating arguments: unsupported format "xml" (expecting jwt or cwt)`
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, expectedErr)
+}
+
+func Test_VerifyCmd_cwt_ok(t *testing.T) {
+	sKey, err := ear.ParseSigningKey(testSKey)
+	require.NoError(t, err)
+
+	var ar ear.AttestationResult
+	require.NoError(t, ar.UnmarshalJSON(testMiniClaimsSet))
+
+	cwt, err := ar.SignCWT(jwa.ES256, sKey)
+	require.NoError(t, err)
+
+	tvs := []struct {
+		name    string
+		content []byte
+	}{
+		{"binary", cwt},
+		{"untagged", cwt[1:]},
+		{"hex", []byte(hex.EncodeToString(cwt) + "\n")},
+		{"base64", []byte(base64.StdEncoding.EncodeToString(cwt))},
+		{"base64url", []byte(base64.RawURLEncoding.EncodeToString(cwt))},
+	}
+
+	for _, tv := range tvs {
+		cmd := NewVerifyCmd()
+
+		files := []fileEntry{
+			{"pkey.json", testPKey},
+			{"ear.cbor", tv.content},
+		}
+		makeFS(t, files)
+
+		args := []string{
+			"--pkey=pkey.json",
+			"--alg=ES256",
+			"--format=cwt",
+			"ear.cbor",
+		}
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+		assert.NoError(t, err, "failed test vector %q", tv.name)
+	}
+}
+
+func Test_VerifyCmd_cwt_wrong_format(t *testing.T) {
+	cmd := NewVerifyCmd()
+
+	files := []fileEntry{
+		{"pkey.json", testPKey},
+		{"ear.jwt", testJWT},
+	}
+	makeFS(t, files)
+
+	args := []string{
+		"--pkey=pkey.json",
+		"--alg=ES256",
+		"--format=cwt",
+		"ear.jwt",
+	}
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "verifying signed EAR from ear.jwt: failed parsing CWT message")
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// AttesterVerifyOptions configures the verification of attester evidence
+type AttesterVerifyOptions struct {
+	// Roots are the trust anchors of the evidence signer.  If nil, the
+	// AttesterVerifier may derive them from the endorsements.
+	Roots *x509.CertPool
+	// Nonce, if set, must match the freshness nonce in the evidence
+	Nonce []byte
+}
+
+// AttestedKey is the outcome of a successful verification of attester
+// evidence: the public key attested by the evidence, and the claims extracted
+// from it
+type AttestedKey struct {
+	// Key is the attested public key
+	Key jwk.Key
+	// Claims are the (scheme-specific) claims in the evidence
+	Claims map[string]interface{}
+}
+
+// AttesterVerifier verifies the evidence produced by one kind of attester
+// (e.g., an AWS Nitro enclave), and extracts the key that it attests.  This
+// is used to check that the key used to sign an EAR is bound to the TEE the
+// verifier runs in (see AttestationResult.CheckKeyAttestation).
+type AttesterVerifier interface {
+	// Name returns the name of the attester, as used in the "tee-name" of
+	// the "ear.veraison.tee-info" claim (e.g., "aws-nitro")
+	Name() string
+	// SupportedMediaTypes returns the media types of the evidence that can
+	// be verified
+	SupportedMediaTypes() []string
+	// Verify verifies the evidence, using the supplied endorsements and
+	// reference values (both keyed by name, with a scheme-specific
+	// meaning), and returns the attested key
+	Verify(
+		evidence []byte,
+		endorsements map[string][]byte,
+		refvals map[string][]byte,
+		opts AttesterVerifyOptions,
+	) (*AttestedKey, error)
+}
+
+var (
+	attesterVerifiersMu sync.RWMutex
+	attesterVerifiers   = map[string]AttesterVerifier{}
+)
+
+// RegisterAttesterVerifier plugs an AttesterVerifier in, replacing any
+// verifier previously registered with the same name.  A media type can only
+// be supported by one of the registered verifiers.
+func RegisterAttesterVerifier(v AttesterVerifier) error {
+	if v == nil {
+		return errors.New("nil attester verifier")
+	}
+
+	name := v.Name()
+	if name == "" {
+		return errors.New("empty attester verifier name")
+	}
+
+	attesterVerifiersMu.Lock()
+	defer attesterVerifiersMu.Unlock()
+
+	for k, other := range attesterVerifiers {
+		if k == name {
+			continue
+		}
+
+		for _, mt := range v.SupportedMediaTypes() {
+			for _, otherMT := range other.SupportedMediaTypes() {
+				if mt == otherMT {
+					return fmt.Errorf("media type %q is already supported by %q", mt, k)
+				}
+			}
+		}
+	}
+
+	attesterVerifiers[name] = v
+
+	return nil
+}
+
+// LookupAttesterVerifier returns the AttesterVerifier registered with the
+// supplied name
+func LookupAttesterVerifier(name string) (AttesterVerifier, bool) {
+	attesterVerifiersMu.RLock()
+	defer attesterVerifiersMu.RUnlock()
+
+	v, ok := attesterVerifiers[name]
+
+	return v, ok
+}
+
+// LookupAttesterVerifierByMediaType returns the registered AttesterVerifier
+// that supports the supplied evidence media type
+func LookupAttesterVerifierByMediaType(mediaType string) (AttesterVerifier, bool) {
+	attesterVerifiersMu.RLock()
+	defer attesterVerifiersMu.RUnlock()
+
+	for _, v := range attesterVerifiers {
+		for _, mt := range v.SupportedMediaTypes() {
+			if mt == mediaType {
+				return v, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// AttesterVerifierNames returns the names of the registered attester
+// verifiers, in alphabetical order
+func AttesterVerifierNames() []string {
+	attesterVerifiersMu.RLock()
+	defer attesterVerifiersMu.RUnlock()
+
+	names := make([]string, 0, len(attesterVerifiers))
+	for name := range attesterVerifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// CheckKeyAttestation verifies the TEE evidence referenced by the
+// "ear.veraison.tee-info" claim using the AttesterVerifier registered for its
+// "tee-name" (or, failing that, for its "media-type"), and checks that the
+// attested key is key, i.e., the key used to verify the EAR.  evidence, if
+// not nil, is the evidence conveyed out of band, which must match the
+// reference in tee-info; otherwise, the evidence embedded in tee-info is
+// used.  endorsements, refvals and opts are passed to the AttesterVerifier.
+func (o AttestationResult) CheckKeyAttestation(
+	key interface{},
+	evidence []byte,
+	endorsements map[string][]byte,
+	refvals map[string][]byte,
+	opts AttesterVerifyOptions,
+) (*AttestedKey, error) {
+	ti := o.VeraisonTeeInfo
+	if ti == nil {
+		return nil, errors.New(`"ear.veraison.tee-info" claim not found`)
+	}
+
+	if err := ti.Validate(); err != nil {
+		return nil, fmt.Errorf(`invalid "ear.veraison.tee-info": %w`, err)
+	}
+
+	v, ok := LookupAttesterVerifier(*ti.TeeName)
+	if !ok && ti.MediaType != nil {
+		v, ok = LookupAttesterVerifierByMediaType(*ti.MediaType)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupported TEE %q (supported: %v)", *ti.TeeName, AttesterVerifierNames())
+	}
+
+	if evidence != nil {
+		if err := ti.VerifyEvidence(evidence, *ti.EvidenceID); err != nil {
+			return nil, fmt.Errorf("evidence does not match tee-info: %w", err)
+		}
+	} else if ti.Evidence == nil {
+		return nil, errors.New("no evidence embedded in tee-info")
+	} else {
+		evidence = *ti.Evidence
+	}
+
+	ak, err := v.Verify(evidence, endorsements, refvals, opts)
+	if err != nil {
+		return nil, fmt.Errorf("verifying %s evidence: %w", v.Name(), err)
+	}
+
+	same, err := sameJWKPublicKey(ak.Key, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !same {
+		return nil, fmt.Errorf("%s evidence does not attest the EAR signing key", v.Name())
+	}
+
+	return ak, nil
+}
+
+// sameJWKPublicKey reports whether the public parts of the supplied keys
+// (either jwk.Key or raw keys) are the same
+func sameJWKPublicKey(a, b interface{}) (bool, error) {
+	var tps [2]string
+
+	for i, k := range []interface{}{a, b} {
+		if k == nil {
+			return false, errors.New("nil key")
+		}
+
+		jk, ok := k.(jwk.Key)
+		if !ok {
+			var err error
+			if jk, err = jwk.FromRaw(k); err != nil {
+				return false, fmt.Errorf("importing key: %w", err)
+			}
+		}
+
+		pub, err := jwk.PublicKeyOf(jk)
+		if err != nil {
+			return false, fmt.Errorf("extracting public key: %w", err)
+		}
+
+		tp, err := pub.Thumbprint(crypto.SHA256)
+		if err != nil {
+			return false, fmt.Errorf("computing key thumbprint: %w", err)
+		}
+
+		tps[i] = string(tp)
+	}
+
+	return tps[0] == tps[1], nil
+}
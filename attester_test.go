@@ -0,0 +1,135 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAttesterVerifier "verifies" evidence that is equal to the JWK of the
+// attested key
+type testAttesterVerifier struct {
+	name       string
+	mediaTypes []string
+}
+
+func (o testAttesterVerifier) Name() string                  { return o.name }
+func (o testAttesterVerifier) SupportedMediaTypes() []string { return o.mediaTypes }
+
+func (o testAttesterVerifier) Verify(
+	evidence []byte,
+	endorsements map[string][]byte,
+	refvals map[string][]byte,
+	opts AttesterVerifyOptions,
+) (*AttestedKey, error) {
+	if opts.Nonce != nil && !bytes.Contains(evidence, opts.Nonce) {
+		return nil, errors.New("nonce mismatch")
+	}
+
+	k, err := jwk.ParseKey(evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AttestedKey{Key: k, Claims: map[string]interface{}{"kty": k.KeyType().String()}}, nil
+}
+
+func registerTestAttesterVerifier(t *testing.T, v testAttesterVerifier) {
+	require.NoError(t, RegisterAttesterVerifier(v))
+
+	t.Cleanup(func() {
+		attesterVerifiersMu.Lock()
+		delete(attesterVerifiers, v.name)
+		attesterVerifiersMu.Unlock()
+	})
+}
+
+func TestRegisterAttesterVerifier(t *testing.T) {
+	registerTestAttesterVerifier(t, testAttesterVerifier{"test-tee", []string{"application/x-test"}})
+
+	v, ok := LookupAttesterVerifier("test-tee")
+	require.True(t, ok)
+	assert.Equal(t, "test-tee", v.Name())
+
+	v, ok = LookupAttesterVerifierByMediaType("application/x-test")
+	require.True(t, ok)
+	assert.Equal(t, "test-tee", v.Name())
+
+	_, ok = LookupAttesterVerifier("unknown")
+	assert.False(t, ok)
+
+	assert.Contains(t, AttesterVerifierNames(), "test-tee")
+
+	err := RegisterAttesterVerifier(testAttesterVerifier{"other-tee", []string{"application/x-test"}})
+	assert.EqualError(t, err, `media type "application/x-test" is already supported by "test-tee"`)
+
+	// re-registering under the same name replaces the verifier
+	registerTestAttesterVerifier(t, testAttesterVerifier{"test-tee", []string{"application/x-test2"}})
+	_, ok = LookupAttesterVerifierByMediaType("application/x-test")
+	assert.False(t, ok)
+
+	assert.EqualError(t, RegisterAttesterVerifier(nil), "nil attester verifier")
+	assert.EqualError(t, RegisterAttesterVerifier(testAttesterVerifier{}), "empty attester verifier name")
+}
+
+func TestCheckKeyAttestation(t *testing.T) {
+	registerTestAttesterVerifier(t, testAttesterVerifier{"test-tee", []string{"application/x-test"}})
+
+	evidence := []byte(testECDSAPublicKey)
+
+	name, unknown, id := "test-tee", "unknown-tee", "evidence-01"
+
+	embedded := VeraisonTeeInfo{TeeName: &name, EvidenceID: &id}
+	require.NoError(t, embedded.SetEvidence(evidence, ""))
+
+	byMediaType := VeraisonTeeInfo{TeeName: &unknown, EvidenceID: &id}
+	require.NoError(t, byMediaType.SetEvidence(evidence, "application/x-test"))
+
+	referenced := VeraisonTeeInfo{TeeName: &name, EvidenceID: &id}
+	require.NoError(t, referenced.SetEvidenceDigest("sha-256", evidence))
+
+	unsupported := VeraisonTeeInfo{TeeName: &unknown, EvidenceID: &id}
+	require.NoError(t, unsupported.SetEvidence(evidence, ""))
+
+	tvs := []struct {
+		ti       *VeraisonTeeInfo
+		key      interface{}
+		evidence []byte
+		expected string
+	}{
+		{&embedded, mustParseKey(t, testECDSAPublicKey), nil, ""},
+		{&byMediaType, mustParseKey(t, testECDSAPrivateKey), nil, ""},
+		{&referenced, mustParseKey(t, testECDSAPublicKey), evidence, ""},
+		{nil, mustParseKey(t, testECDSAPublicKey), nil, `"ear.veraison.tee-info" claim not found`},
+		{&unsupported, mustParseKey(t, testECDSAPublicKey), nil, `unsupported TEE "unknown-tee"`},
+		{&referenced, mustParseKey(t, testECDSAPublicKey), nil, "no evidence embedded in tee-info"},
+		{&referenced, mustParseKey(t, testECDSAPublicKey), []byte("{}"), `evidence does not match tee-info: "evidence-digest": digest mismatch`},
+		{&embedded, mustParseKey(t, testEd25519PublicKey), nil, "test-tee evidence does not attest the EAR signing key"},
+		{&embedded, nil, nil, "nil key"},
+	}
+
+	for i, tv := range tvs {
+		ar := AttestationResult{AttestationResultExtensions: AttestationResultExtensions{VeraisonTeeInfo: tv.ti}}
+
+		ak, err := ar.CheckKeyAttestation(tv.key, tv.evidence, nil, nil, AttesterVerifyOptions{})
+		if tv.expected != "" {
+			assert.ErrorContains(t, err, tv.expected, "failed test vector at index %d", i)
+			continue
+		}
+
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, "EC", ak.Claims["kty"], "failed test vector at index %d", i)
+	}
+
+	ar := AttestationResult{AttestationResultExtensions: AttestationResultExtensions{VeraisonTeeInfo: &embedded}}
+	_, err := ar.CheckKeyAttestation(mustParseKey(t, testECDSAPublicKey), nil, nil, nil,
+		AttesterVerifyOptions{Nonce: []byte("not there")})
+	assert.EqualError(t, err, "verifying test-tee evidence: nonce mismatch")
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Token formats reported in an AuditRecord
+const (
+	AuditFormatJWT = "jwt"
+	AuditFormatCWT = "cwt"
+)
+
+// AuditRecord describes an EAR that has been issued (see Sign and SignCWT)
+// or consumed (see Verify and VerifyCWT)
+type AuditRecord struct {
+	// Time is the time of signing or verification, as reported by the Clock
+	// in use
+	Time time.Time
+	// Format is the format of the token, i.e., AuditFormatJWT or
+	// AuditFormatCWT
+	Format string
+	// TokenDigest is the SHA-256 digest of the (serialized) token
+	TokenDigest []byte
+	// Profile is the `eat_profile` of the result
+	Profile string
+	// VerifierID is the "ear.verifier-id" of the result
+	VerifierID VerifierIdentity
+	// Status is the aggregate status of the result (see AggregateStatus)
+	Status TrustTier
+	// SubmodStatuses maps the name of each submod onto its "ear.status"
+	SubmodStatuses map[string]TrustTier
+}
+
+// AuditSink receives an AuditRecord for each EAR that is successfully signed
+// or verified, e.g., to stream them into a transparency or audit log.  Sinks
+// are called synchronously, so they should not block; failures to record are
+// the sink's own business and do not affect signing and verification.
+type AuditSink interface {
+	RecordSigned(AuditRecord)
+	RecordVerified(AuditRecord)
+}
+
+var (
+	auditSinkMu sync.RWMutex
+	auditSink   AuditSink
+)
+
+// RegisterAuditSink installs s as the package-level AuditSink, which is
+// notified of every EAR signed or verified by this package, replacing any
+// sink previously registered.  A nil s unregisters the current sink.
+func RegisterAuditSink(s AuditSink) {
+	auditSinkMu.Lock()
+	defer auditSinkMu.Unlock()
+
+	auditSink = s
+}
+
+func registeredAuditSink() AuditSink {
+	auditSinkMu.RLock()
+	defer auditSinkMu.RUnlock()
+
+	return auditSink
+}
+
+type auditSinkOption struct {
+	sink AuditSink
+}
+
+func (o auditSinkOption) applySignOption(c *signConfig)     { c.auditSink = o.sink }
+func (o auditSinkOption) applyVerifyOption(c *verifyConfig) { c.auditSink = o.sink }
+
+// WithAuditSink makes Sign and Verify (and their CWT, COSE_Sign and SD-JWT
+// counterparts) notify s, in addition to the package-level sink (see
+// RegisterAuditSink), if any
+func WithAuditSink(s AuditSink) Option {
+	return auditSinkOption{s}
+}
+
+// newAuditRecord describes the token, which carries the result o
+func (o AttestationResult) newAuditRecord(format string, token []byte, t time.Time) AuditRecord {
+	digest := sha256.Sum256(token)
+
+	rec := AuditRecord{
+		Time:           t,
+		Format:         format,
+		TokenDigest:    digest[:],
+		Status:         o.AggregateStatus(),
+		SubmodStatuses: o.submodStatuses(),
+	}
+
+	if o.Profile != nil {
+		rec.Profile = *o.Profile
+	}
+
+	if o.VerifierID != nil {
+		rec.VerifierID = *o.VerifierID
+	}
+
+	return rec
+}
+
+// auditSinks returns the sinks to be notified, i.e., the package-level one
+// and the one supplied using WithAuditSink
+func auditSinks(s AuditSink) []AuditSink {
+	var sinks []AuditSink
+
+	if r := registeredAuditSink(); r != nil {
+		sinks = append(sinks, r)
+	}
+
+	if s != nil {
+		sinks = append(sinks, s)
+	}
+
+	return sinks
+}
+
+// auditSigned notifies the sinks that token, which carries the result o, has
+// been signed
+func (o AttestationResult) auditSigned(format string, token []byte, cfg *signConfig) {
+	sinks := auditSinks(cfg.auditSink)
+	if len(sinks) == 0 {
+		return
+	}
+
+	rec := o.newAuditRecord(format, token, cfg.clock.Now())
+
+	for _, s := range sinks {
+		s.RecordSigned(rec)
+	}
+}
+
+// auditVerified notifies the sinks that token, which carries the result o,
+// has been verified
+func (o AttestationResult) auditVerified(format string, token []byte, cfg *verifyConfig) {
+	sinks := auditSinks(cfg.auditSink)
+	if len(sinks) == 0 {
+		return
+	}
+
+	rec := o.newAuditRecord(format, token, cfg.clock.Now())
+
+	for _, s := range sinks {
+		s.RecordVerified(rec)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAuditSink struct {
+	signed   []AuditRecord
+	verified []AuditRecord
+}
+
+func (o *testAuditSink) RecordSigned(r AuditRecord)   { o.signed = append(o.signed, r) }
+func (o *testAuditSink) RecordVerified(r AuditRecord) { o.verified = append(o.verified, r) }
+
+func TestAudit_per_call_sink(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	clock := WithClock(FixedClock(now))
+
+	multiSigK := []CWTKey{{Alg: jwa.ES256, Key: sigK}}
+	multiVfyK := []CWTKey{{Alg: jwa.ES256, Key: vfyK}}
+
+	for _, tc := range []struct {
+		name   string
+		format string
+		sign   func(AttestationResult, ...SignOption) ([]byte, error)
+		verify func(*AttestationResult, []byte, ...VerifyOption) error
+	}{
+		{
+			"jwt",
+			AuditFormatJWT,
+			func(ar AttestationResult, opts ...SignOption) ([]byte, error) {
+				return ar.Sign(jwa.ES256, sigK, opts...)
+			},
+			func(ar *AttestationResult, token []byte, opts ...VerifyOption) error {
+				return ar.Verify(token, jwa.ES256, vfyK, opts...)
+			},
+		},
+		{
+			"cwt",
+			AuditFormatCWT,
+			func(ar AttestationResult, opts ...SignOption) ([]byte, error) {
+				return ar.SignCWT(jwa.ES256, sigK, opts...)
+			},
+			func(ar *AttestationResult, token []byte, opts ...VerifyOption) error {
+				return ar.VerifyCWT(token, jwa.ES256, vfyK, opts...)
+			},
+		},
+		{
+			"cwt-multi",
+			AuditFormatCWT,
+			func(ar AttestationResult, opts ...SignOption) ([]byte, error) {
+				return ar.SignCWTMulti(multiSigK, opts...)
+			},
+			func(ar *AttestationResult, token []byte, opts ...VerifyOption) error {
+				return ar.VerifyCWTAny(token, multiVfyK, opts...)
+			},
+		},
+		{
+			"sd-jwt",
+			AuditFormatJWT,
+			func(ar AttestationResult, opts ...SignOption) ([]byte, error) {
+				return ar.SignSD(jwa.ES256, sigK, opts...)
+			},
+			func(ar *AttestationResult, token []byte, opts ...VerifyOption) error {
+				return ar.VerifySD(token, jwa.ES256, vfyK, opts...)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := &testAuditSink{}
+
+			token, err := tc.sign(testAttestationResultsWithVeraisonExtns, WithAuditSink(sink), clock)
+			require.NoError(t, err)
+			require.Len(t, sink.signed, 1)
+
+			digest := sha256.Sum256(token)
+			expected := AuditRecord{
+				Time:           now,
+				Format:         tc.format,
+				TokenDigest:    digest[:],
+				Profile:        testProfile,
+				VerifierID:     *testAttestationResultsWithVeraisonExtns.VerifierID,
+				Status:         TrustTierAffirming,
+				SubmodStatuses: map[string]TrustTier{"test": TrustTierAffirming},
+			}
+			assert.Equal(t, expected, sink.signed[0])
+
+			var actual AttestationResult
+			require.NoError(t, tc.verify(&actual, token, WithAuditSink(sink), clock))
+			require.Len(t, sink.verified, 1)
+			assert.Equal(t, expected, sink.verified[0])
+
+			// failed verifications are not recorded
+			token[len(token)-3] ^= 1
+			assert.Error(t, tc.verify(&actual, token, WithAuditSink(sink), clock))
+			assert.Len(t, sink.verified, 1)
+		})
+	}
+}
+
+func TestAudit_registered_sink(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	registered, perCall := &testAuditSink{}, &testAuditSink{}
+
+	RegisterAuditSink(registered)
+	defer RegisterAuditSink(nil)
+
+	_, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+	assert.Len(t, registered.signed, 1)
+
+	_, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK, WithAuditSink(perCall))
+	require.NoError(t, err)
+	assert.Len(t, registered.signed, 2)
+	assert.Len(t, perCall.signed, 1)
+
+	// failures to sign are not recorded
+	_, err = AttestationResult{}.Sign(jwa.ES256, sigK)
+	assert.Error(t, err)
+	assert.Len(t, registered.signed, 2)
+
+	RegisterAuditSink(nil)
+
+	_, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+	assert.Len(t, registered.signed, 2)
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"sync"
+)
+
+// runBatch calls fn once for each index in [0, n), using up to workers
+// concurrent goroutines, and returns when all the calls have returned.  fn
+// must only touch the state that belongs to index i.
+func runBatch(n, workers int, fn func(i int)) {
+	if workers > n {
+		workers = n
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+
+	wg.Wait()
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBatch(t *testing.T) {
+	tvs := []struct {
+		n       int
+		workers int
+	}{
+		{n: 0, workers: 4},
+		{n: 1, workers: 4},
+		{n: 100, workers: 4},
+		{n: 3, workers: 0},
+	}
+
+	for i, tv := range tvs {
+		var calls int64
+
+		seen := make([]int, tv.n)
+
+		runBatch(tv.n, tv.workers, func(j int) {
+			atomic.AddInt64(&calls, 1)
+			seen[j]++
+		})
+
+		assert.Equal(t, int64(tv.n), calls, "failed test vector at index %d", i)
+
+		for j := range seen {
+			assert.Equal(t, 1, seen[j], "failed test vector at index %d", i)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Builder assembles an AttestationResult through chained method calls, e.g.:
+//
+//	ar, err := ear.NewBuilder().
+//		WithVerifier("rrtrap-v1.0.0", "Acme Inc.").
+//		AddSubmod("PSA", appraisal).
+//		WithNonce(nonce).
+//		Build()
+//
+// Each method checks its arguments as it is called.  Problems do not stop the
+// chain: they are accumulated and reported together by Build, each prefixed by
+// the name of the offending method.  A Builder must not be used after Build.
+type Builder struct {
+	ar       AttestationResult
+	clock    Clock
+	problems []string
+}
+
+// NewBuilder returns a Builder for an AttestationResult using the EAR profile
+// implemented by this package (see EatProfile)
+func NewBuilder() *Builder {
+	profile := EatProfile
+
+	return &Builder{
+		ar:    AttestationResult{Profile: &profile},
+		clock: systemClock,
+	}
+}
+
+func (b *Builder) fail(method string, format string, args ...interface{}) *Builder {
+	b.problems = append(b.problems, method+": "+fmt.Sprintf(format, args...))
+	return b
+}
+
+// WithProfile sets the `eat_profile` claim.  Only EatProfile is supported.
+func (b *Builder) WithProfile(profile string) *Builder {
+	if profile != EatProfile {
+		return b.fail("WithProfile", "unsupported profile %q (expecting %q)", profile, EatProfile)
+	}
+
+	b.ar.Profile = &profile
+
+	return b
+}
+
+// WithVerifier sets the `ear.verifier-id` claim from the build and developer
+// of the verifier, neither of which can be empty
+func (b *Builder) WithVerifier(build, developer string) *Builder {
+	if build == "" {
+		return b.fail("WithVerifier", "empty build")
+	}
+
+	if developer == "" {
+		return b.fail("WithVerifier", "empty developer")
+	}
+
+	b.ar.VerifierID = &VerifierIdentity{Build: &build, Developer: &developer}
+
+	return b
+}
+
+// WithIssuedAt sets the `iat` claim.  If it is not called, Build sets `iat` to
+// the current time, as reported by the Builder's Clock.
+func (b *Builder) WithIssuedAt(t time.Time) *Builder {
+	iat := t.Unix()
+	b.ar.IssuedAt = &iat
+
+	return b
+}
+
+// WithClock makes Build use the supplied Clock, instead of the system time, to
+// set a missing `iat` claim
+func (b *Builder) WithClock(clock Clock) *Builder {
+	if clock == nil {
+		return b.fail("WithClock", "nil clock")
+	}
+
+	b.clock = clock
+
+	return b
+}
+
+// AddSubmod adds the supplied appraisal, which must carry at least an
+// "ear.status", under the supplied (unique, non-empty) submod name
+func (b *Builder) AddSubmod(name string, appraisal *Appraisal) *Builder {
+	method := fmt.Sprintf("AddSubmod(%q)", name)
+
+	switch {
+	case name == "":
+		return b.fail(method, "empty submod name")
+	case appraisal == nil:
+		return b.fail(method, "nil appraisal")
+	}
+
+	if _, ok := b.ar.Submods[name]; ok {
+		return b.fail(method, "duplicate submod")
+	}
+
+	if err := appraisal.validate(); err != nil {
+		return b.fail(method, "%s", err)
+	}
+
+	if b.ar.Submods == nil {
+		b.ar.Submods = map[string]*Appraisal{}
+	}
+
+	b.ar.Submods[name] = appraisal
+
+	return b
+}
+
+// WithNonce sets the `eat_nonce` claim, which must be between 8 and 88 bytes
+// long
+func (b *Builder) WithNonce(nonce string) *Builder {
+	if n := len(nonce); n < 8 || n > 88 {
+		return b.fail("WithNonce", "nonce is %d bytes long (expecting 8 to 88)", n)
+	}
+
+	b.ar.Nonce = &nonce
+
+	return b
+}
+
+// WithRawEvidence sets the `ear.raw-evidence` claim to the supplied (non-empty)
+// evidence
+func (b *Builder) WithRawEvidence(evidence []byte) *Builder {
+	if len(evidence) == 0 {
+		return b.fail("WithRawEvidence", "empty evidence")
+	}
+
+	raw := B64Url(append([]byte(nil), evidence...))
+	b.ar.RawEvidence = &raw
+
+	return b
+}
+
+// Build returns the assembled AttestationResult, after validating it.  If any
+// of the chained calls or the final validation failed, all the problems found
+// are reported in the returned error.
+func (b *Builder) Build() (*AttestationResult, error) {
+	problems := b.problems
+
+	if b.ar.IssuedAt == nil {
+		iat := b.clock.Now().Unix()
+		b.ar.IssuedAt = &iat
+	}
+
+	if b.ar.VerifierID == nil && !hasProblem(problems, "WithVerifier") {
+		problems = append(problems, "WithVerifier: not called")
+	}
+
+	if len(b.ar.Submods) == 0 && !hasProblem(problems, "AddSubmod") {
+		problems = append(problems, "AddSubmod: not called")
+	}
+
+	if len(problems) == 0 {
+		if err := b.ar.validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New("building EAR: " + strings.Join(problems, "; "))
+	}
+
+	ar := b.ar
+
+	return &ar, nil
+}
+
+func hasProblem(problems []string, method string) bool {
+	for _, p := range problems {
+		if strings.HasPrefix(p, method) {
+			return true
+		}
+	}
+
+	return false
+}
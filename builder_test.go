@@ -0,0 +1,94 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_ok(t *testing.T) {
+	ar, err := NewBuilder().
+		WithProfile(EatProfile).
+		WithVerifier("rrtrap-v1.0.0", "Acme Inc.").
+		WithIssuedAt(time.Unix(testIAT, 0)).
+		AddSubmod("test", testAttestationResultsWithVeraisonExtns.Submods["test"]).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, *ar)
+}
+
+func TestBuilder_optional_claims(t *testing.T) {
+	status := TrustTierAffirming
+
+	ar, err := NewBuilder().
+		WithClock(FixedClock(time.Unix(testIAT, 0))).
+		WithVerifier("rrtrap-v1.0.0", "Acme Inc.").
+		AddSubmod("test", &Appraisal{Status: &status}).
+		WithNonce("0123456789abcdef").
+		WithRawEvidence([]byte{0xde, 0xad, 0xbe, 0xef}).
+		Build()
+	require.NoError(t, err)
+
+	require.NotNil(t, ar.IssuedAt)
+	assert.Equal(t, testIAT, *ar.IssuedAt)
+	require.NotNil(t, ar.Nonce)
+	assert.Equal(t, "0123456789abcdef", *ar.Nonce)
+	require.NotNil(t, ar.RawEvidence)
+	assert.Equal(t, B64Url{0xde, 0xad, 0xbe, 0xef}, *ar.RawEvidence)
+}
+
+func TestBuilder_fail(t *testing.T) {
+	status := TrustTierAffirming
+
+	tvs := []struct {
+		builder  *Builder
+		expected string
+	}{
+		{
+			builder:  NewBuilder(),
+			expected: "building EAR: WithVerifier: not called; AddSubmod: not called",
+		},
+		{
+			builder: NewBuilder().
+				WithProfile("tag:example.com,2026:other").
+				WithVerifier("", "Acme Inc.").
+				AddSubmod("test", &Appraisal{}).
+				WithNonce("short").
+				WithRawEvidence(nil),
+			expected: `building EAR: WithProfile: unsupported profile "tag:example.com,2026:other" ` +
+				`(expecting "tag:github.com,2023:veraison/ear"); ` +
+				`WithVerifier: empty build; ` +
+				`AddSubmod("test"): missing mandatory 'ear.status'; ` +
+				`WithNonce: nonce is 5 bytes long (expecting 8 to 88); ` +
+				`WithRawEvidence: empty evidence`,
+		},
+		{
+			builder: NewBuilder().
+				WithVerifier("rrtrap-v1.0.0", "").
+				AddSubmod("", &Appraisal{Status: &status}).
+				AddSubmod("test", nil).
+				WithClock(nil),
+			expected: `building EAR: WithVerifier: empty developer; ` +
+				`AddSubmod(""): empty submod name; ` +
+				`AddSubmod("test"): nil appraisal; ` +
+				`WithClock: nil clock`,
+		},
+		{
+			builder: NewBuilder().
+				WithVerifier("rrtrap-v1.0.0", "Acme Inc.").
+				AddSubmod("test", &Appraisal{Status: &status}).
+				AddSubmod("test", &Appraisal{Status: &status}),
+			expected: `building EAR: AddSubmod("test"): duplicate submod`,
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := tv.builder.Build()
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
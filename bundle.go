@@ -0,0 +1,693 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// BundleVersion is the version of the bundle format produced by WriteBundle
+const BundleVersion = 1
+
+// Well-known bundle entries
+const (
+	BundleManifestFile          = "manifest.json"
+	BundleManifestSignatureFile = "manifest.jws"
+	BundleTokenFile             = "ear.jwt"
+	BundleKeySetFile            = "keys.jwks"
+	BundleCertChainFile         = "chain.pem"
+	BundleTimestampFile         = "ear.tst"
+	BundleEvidenceFile          = "evidence.bin"
+	BundleEvidenceDir           = "evidence/"
+	BundleEndorsementsDir       = "endorsements/"
+	BundleRefValuesDir          = "reference-values/"
+)
+
+// Bundle collects everything that is needed to verify an EAR offline (e.g.,
+// air-gapped, or long after issuance, when key-discovery endpoints may no
+// longer be reachable): the signed EAR, the verifier keys (as a JWKS and/or
+// an X.509 certificate chain), an optional RFC 3161 timestamp token and any
+// endorsements that were used during appraisal.  Optionally, the bundle can
+// also carry the raw evidence that was appraised and the reference values
+// that it was compared against, so that the verification decision can be
+// fully audited after the fact.
+type Bundle struct {
+	// Token is the signed EAR (mandatory)
+	Token []byte
+	// KeySet contains the verifier public keys.  Since it could be replaced
+	// together with the EAR, it is not trusted by VerifyBundle.
+	KeySet jwk.Set
+	// CertChain is the verifier certificate chain, leaf first
+	CertChain []*x509.Certificate
+	// Timestamp is a DER-encoded RFC 3161 timestamp token over the EAR
+	// signature (see RequestTimestamp)
+	Timestamp []byte
+	// Evidence is the raw evidence that was appraised.  It must match the
+	// "ear.raw-evidence" or "ear.evidence-digest" claim of the EAR.
+	Evidence []byte
+	// SubmodEvidence is the raw evidence that was appraised by each submod,
+	// keyed by submod name.  Each entry must match the "ear.evidence-digest"
+	// claim of the corresponding submod.
+	SubmodEvidence map[string][]byte
+	// Endorsements are opaque, named endorsement documents.  Since no EAR
+	// claim covers them, VerifyBundle only accepts them if the manifest is
+	// signed (see WriteSignedBundle).
+	Endorsements map[string][]byte
+	// ReferenceValues are opaque, named reference value documents.  Like
+	// Endorsements, they require the manifest to be signed.
+	ReferenceValues map[string][]byte
+}
+
+// BundleTrust holds the trust anchors, supplied by the caller, against which
+// VerifyBundle checks a bundle.  Nothing carried in the bundle is trusted
+// unless it can be tied to them.
+type BundleTrust struct {
+	// KeySet contains trusted verifier public keys
+	KeySet jwk.Set
+	// Roots are the trust anchors for the verifier certificate chain
+	Roots *x509.CertPool
+	// TSARoots are the trust anchors for the certificate of the TSA that
+	// issued the timestamp token
+	TSARoots *x509.CertPool
+}
+
+// Limits on the (uncompressed) size of the bundles read by ReadBundle
+const (
+	maxBundleEntrySize = 32 << 20
+	maxBundleSize      = 128 << 20
+)
+
+// bundleManifestType is the `typ` header of the manifest signature, which
+// tells it apart from an EAR signed with the same key
+const bundleManifestType = "ear-bundle-manifest"
+
+// bundleManifest lists the bundle entries, with their digests
+type bundleManifest struct {
+	Version int64              `json:"version"`
+	Files   map[string]*Digest `json:"files"`
+}
+
+func (o Bundle) entries() (map[string][]byte, error) {
+	if len(o.Token) == 0 {
+		return nil, errors.New("missing mandatory EAR token")
+	}
+
+	entries := map[string][]byte{
+		BundleTokenFile: o.Token,
+	}
+
+	if o.KeySet != nil && o.KeySet.Len() > 0 {
+		data, err := json.Marshal(o.KeySet)
+		if err != nil {
+			return nil, fmt.Errorf("serializing key set: %w", err)
+		}
+		entries[BundleKeySetFile] = data
+	}
+
+	if len(o.CertChain) > 0 {
+		var b bytes.Buffer
+		for _, c := range o.CertChain {
+			if err := pem.Encode(&b, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw}); err != nil {
+				return nil, fmt.Errorf("encoding certificate chain: %w", err)
+			}
+		}
+		entries[BundleCertChainFile] = b.Bytes()
+	}
+
+	if len(o.Timestamp) > 0 {
+		entries[BundleTimestampFile] = o.Timestamp
+	}
+
+	if len(o.Evidence) > 0 {
+		entries[BundleEvidenceFile] = o.Evidence
+	}
+
+	dirs := []struct {
+		dir   string
+		kind  string
+		files map[string][]byte
+	}{
+		{BundleEvidenceDir, "submod evidence", o.SubmodEvidence},
+		{BundleEndorsementsDir, "endorsement", o.Endorsements},
+		{BundleRefValuesDir, "reference value", o.ReferenceValues},
+	}
+
+	for _, d := range dirs {
+		for name, data := range d.files {
+			if name == "" || strings.ContainsAny(name, `/\`) {
+				return nil, fmt.Errorf("invalid %s name %q", d.kind, name)
+			}
+			entries[d.dir+name] = data
+		}
+	}
+
+	return entries, nil
+}
+
+// WriteBundle writes the supplied Bundle to w as a ZIP archive.  Alongside the
+// bundle entries, the archive carries a manifest with the SHA-256 digest of
+// each of them, which is checked by ReadBundle.  The manifest is not signed:
+// it detects the accidental corruption of the entries, but anyone can replace
+// it together with them.  Use WriteSignedBundle to protect it.
+func WriteBundle(w io.Writer, b Bundle) error {
+	return writeBundle(w, b, nil)
+}
+
+// WriteSignedBundle is like WriteBundle, but the manifest is also signed with
+// the supplied algorithm and key, which must be the (private) key the EAR was
+// signed with.  The signature, a JWS with a detached payload, is carried in
+// the BundleManifestSignatureFile entry, and is checked by VerifyBundle using
+// the key that verifies the EAR.  This binds all the bundle entries, not only
+// those covered by the EAR claims, to the verifier.
+func WriteSignedBundle(w io.Writer, b Bundle, alg jwa.KeyAlgorithm, key interface{}) error {
+	return writeBundle(w, b, func(manifest []byte) ([]byte, error) {
+		hdrs := jws.NewHeaders()
+		if err := hdrs.Set(jws.TypeKey, bundleManifestType); err != nil {
+			return nil, fmt.Errorf("setting header %s: %w", jws.TypeKey, err)
+		}
+
+		sig, err := jws.Sign(nil,
+			jws.WithKey(alg, key, jws.WithProtectedHeaders(hdrs)),
+			jws.WithDetachedPayload(manifest),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("signing manifest: %w", err)
+		}
+
+		return sig, nil
+	})
+}
+
+// writeBundle writes the supplied Bundle to w, signing the manifest with
+// sign, if not nil
+func writeBundle(w io.Writer, b Bundle, sign func(manifest []byte) ([]byte, error)) error {
+	entries, err := b.entries()
+	if err != nil {
+		return err
+	}
+
+	manifest := bundleManifest{
+		Version: BundleVersion,
+		Files:   map[string]*Digest{},
+	}
+
+	names := make([]string, 0, len(entries))
+	for name, data := range entries {
+		d, err := NewDigest("sha-256", data)
+		if err != nil {
+			return err
+		}
+		manifest.Files[name] = d
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing manifest: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+
+	if err := writeZipEntry(zw, BundleManifestFile, manifestData); err != nil {
+		return err
+	}
+
+	if sign != nil {
+		sig, err := sign(manifestData)
+		if err != nil {
+			return err
+		}
+
+		if err := writeZipEntry(zw, BundleManifestSignatureFile, sig); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range names {
+		if err := writeZipEntry(zw, name, entries[name]); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeZipEntry(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", name, err)
+	}
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ReadBundle parses a bundle produced by WriteBundle (or WriteSignedBundle),
+// checking its entries against the manifest.  Neither the EAR nor the
+// signature of the manifest, if any, are verified: use VerifyBundle for that.
+func ReadBundle(data []byte) (*Bundle, error) {
+	b, err := readBundle(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bundle, nil
+}
+
+// readBundleResult is a Bundle, together with its manifest and the signature
+// of the manifest, if any
+type readBundleResult struct {
+	*Bundle
+	manifest          []byte
+	manifestSignature []byte
+}
+
+func readBundle(data []byte) (*readBundleResult, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("opening bundle: %w", err)
+	}
+
+	files := map[string][]byte{}
+	total := 0
+
+	for _, f := range zr.File {
+		content, err := readBundleEntry(f)
+		if err != nil {
+			return nil, err
+		}
+
+		if total += len(content); total > maxBundleSize {
+			return nil, fmt.Errorf("bundle exceeds %d bytes", maxBundleSize)
+		}
+
+		files[f.Name] = content
+	}
+
+	manifestData, ok := files[BundleManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", BundleManifestFile)
+	}
+	delete(files, BundleManifestFile)
+
+	// the signature of the manifest cannot be listed in it
+	manifestSig := files[BundleManifestSignatureFile]
+	delete(files, BundleManifestSignatureFile)
+
+	manifest, err := parseBundleManifest(manifestData)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", BundleManifestFile, err)
+	}
+
+	for name, content := range files {
+		d, ok := manifest.Files[name]
+		if !ok {
+			return nil, fmt.Errorf("%s not listed in %s", name, BundleManifestFile)
+		}
+
+		if err := d.Verify(content); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	for name := range manifest.Files {
+		if _, ok := files[name]; !ok {
+			return nil, fmt.Errorf("%s listed in %s but not found", name, BundleManifestFile)
+		}
+	}
+
+	b, err := bundleFromFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readBundleResult{b, manifestData, manifestSig}, nil
+}
+
+// readBundleEntry reads the content of the bundle entry f, which must not
+// exceed maxBundleEntrySize bytes
+func readBundleEntry(f *zip.File) ([]byte, error) {
+	if f.UncompressedSize64 > maxBundleEntrySize {
+		return nil, fmt.Errorf("%s exceeds %d bytes", f.Name, maxBundleEntrySize)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	// the declared size is not to be trusted
+	content, err := io.ReadAll(io.LimitReader(rc, maxBundleEntrySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", f.Name, err)
+	}
+
+	if len(content) > maxBundleEntrySize {
+		return nil, fmt.Errorf("%s exceeds %d bytes", f.Name, maxBundleEntrySize)
+	}
+
+	return content, nil
+}
+
+func parseBundleManifest(data []byte) (*bundleManifest, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	version, err := int64Parser(m["version"])
+	if err != nil {
+		return nil, fmt.Errorf("version: %w", err)
+	}
+
+	if version.(int64) != BundleVersion {
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+
+	files, ok := m["files"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("files: not a JSON object")
+	}
+
+	manifest := bundleManifest{
+		Version: BundleVersion,
+		Files:   map[string]*Digest{},
+	}
+
+	for name, v := range files {
+		d, err := ToDigest(v)
+		if err != nil {
+			return nil, fmt.Errorf("files[%s]: %w", name, err)
+		}
+		manifest.Files[name] = d
+	}
+
+	return &manifest, nil
+}
+
+func bundleFromFiles(files map[string][]byte) (*Bundle, error) {
+	var b Bundle
+
+	for name, content := range files {
+		switch {
+		case name == BundleTokenFile:
+			b.Token = content
+		case name == BundleKeySetFile:
+			set, err := jwk.Parse(content)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", name, err)
+			}
+			b.KeySet = set
+		case name == BundleCertChainFile:
+			chain, err := parseCertChainPEM(content)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", name, err)
+			}
+			b.CertChain = chain
+		case name == BundleTimestampFile:
+			b.Timestamp = content
+		case name == BundleEvidenceFile:
+			b.Evidence = content
+		case strings.HasPrefix(name, BundleEvidenceDir):
+			b.SubmodEvidence = addBundleFile(b.SubmodEvidence, name, content)
+		case strings.HasPrefix(name, BundleEndorsementsDir):
+			b.Endorsements = addBundleFile(b.Endorsements, name, content)
+		case strings.HasPrefix(name, BundleRefValuesDir):
+			b.ReferenceValues = addBundleFile(b.ReferenceValues, name, content)
+		default:
+			return nil, fmt.Errorf("unexpected entry %s", name)
+		}
+	}
+
+	if len(b.Token) == 0 {
+		return nil, fmt.Errorf("%s not found", BundleTokenFile)
+	}
+
+	return &b, nil
+}
+
+func addBundleFile(m map[string][]byte, name string, content []byte) map[string][]byte {
+	if m == nil {
+		m = map[string][]byte{}
+	}
+	m[path.Base(name)] = content
+	return m
+}
+
+func parseCertChainPEM(data []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, c)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+
+	return chain, nil
+}
+
+// VerifyBundle reads the supplied bundle (see ReadBundle) and verifies the
+// EAR it contains using the supplied algorithm and the trust anchors supplied
+// by the caller.  The keys in trust.KeySet are tried first; the leaf of the
+// bundle's certificate chain is used only if the chain can be validated
+// against trust.Roots.  The bundle's key set is never used, since anyone
+// could replace it together with the EAR.  If the bundle carries a timestamp
+// token, it is verified against trust.TSARoots, and the time asserted by the
+// TSA is used as the Clock for all time-related checks (unless a different
+// one is supplied using WithClock), so that EARs remain verifiable after
+// expiry.  Any evidence carried in the bundle is cross-checked against the
+// "ear.raw-evidence" and "ear.evidence-digest" claims of the verified EAR.  If
+// the bundle carries a manifest signature (see WriteSignedBundle), it must
+// verify with the same key as the EAR.  Endorsements and reference values,
+// which are only listed in the manifest, are rejected unless it is signed.
+func VerifyBundle(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	trust BundleTrust,
+	opts ...VerifyOption,
+) (*AttestationResult, *Bundle, error) {
+	rb, err := readBundle(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b := rb.Bundle
+
+	if len(b.Timestamp) > 0 {
+		if trust.TSARoots == nil {
+			return nil, nil, errors.New("verifying timestamp: no TSA trust roots supplied")
+		}
+
+		t, err := VerifyDetachedTimestamp(b.Token, b.Timestamp, trust.TSARoots)
+		if err != nil {
+			return nil, nil, fmt.Errorf("verifying timestamp: %w", err)
+		}
+
+		opts = append([]VerifyOption{WithClock(FixedClock(t))}, opts...)
+	}
+
+	cfg := newVerifyConfig(opts)
+
+	var (
+		keys     []interface{}
+		problems []string
+	)
+
+	if trust.KeySet != nil {
+		for i := 0; i < trust.KeySet.Len(); i++ {
+			k, _ := trust.KeySet.Key(i)
+			keys = append(keys, k)
+		}
+	}
+
+	if len(b.CertChain) > 0 {
+		if err := verifyCertChain(b.CertChain, trust.Roots, cfg.clock.Now()); err != nil {
+			problems = append(problems, err.Error())
+		} else {
+			keys = append(keys, b.CertChain[0].PublicKey)
+		}
+	}
+
+	if len(keys) == 0 && len(problems) == 0 {
+		return nil, nil, errors.New("no trusted verification keys for bundle")
+	}
+
+	for _, key := range keys {
+		var ar AttestationResult
+
+		err := ar.Verify(b.Token, alg, key, opts...)
+		if err == nil {
+			if err := rb.checkManifestSignature(alg, key); err != nil {
+				return nil, nil, fmt.Errorf("verifying bundle: %w", err)
+			}
+			if err := b.checkEvidence(&ar, len(rb.manifestSignature) > 0); err != nil {
+				return nil, nil, fmt.Errorf("verifying bundle: %w", err)
+			}
+			return &ar, b, nil
+		}
+
+		problems = append(problems, err.Error())
+	}
+
+	return nil, nil, fmt.Errorf("verifying bundle: %s", strings.Join(problems, "; "))
+}
+
+// checkManifestSignature verifies the signature of the manifest, if any, with
+// the supplied algorithm and key, i.e., those that verified the EAR
+func (o readBundleResult) checkManifestSignature(alg jwa.KeyAlgorithm, key interface{}) error {
+	if len(o.manifestSignature) == 0 {
+		return nil
+	}
+
+	msg, err := jws.Parse(o.manifestSignature)
+	if err != nil {
+		return fmt.Errorf("%s: %w", BundleManifestSignatureFile, err)
+	}
+
+	if len(msg.Signatures()) == 0 {
+		return fmt.Errorf("%s: no signatures", BundleManifestSignatureFile)
+	}
+
+	if typ := msg.Signatures()[0].ProtectedHeaders().Type(); typ != bundleManifestType {
+		return fmt.Errorf("%s: unexpected type %q", BundleManifestSignatureFile, typ)
+	}
+
+	if _, err := jws.Verify(o.manifestSignature,
+		jws.WithKey(alg, key),
+		jws.WithDetachedPayload(o.manifest),
+	); err != nil {
+		return fmt.Errorf("%s: %w", BundleManifestSignatureFile, err)
+	}
+
+	return nil
+}
+
+// checkEvidence cross-checks the evidence carried in the bundle against the
+// claims in the (verified) EAR, and checks that the endorsements and reference
+// values are bound to the EAR by a (verified) manifest signature
+func (o Bundle) checkEvidence(ar *AttestationResult, manifestSigned bool) error {
+	if !manifestSigned {
+		for _, d := range []struct {
+			dir   string
+			files map[string][]byte
+		}{
+			{BundleEndorsementsDir, o.Endorsements},
+			{BundleRefValuesDir, o.ReferenceValues},
+		} {
+			names := make([]string, 0, len(d.files))
+			for name := range d.files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			if len(names) > 0 {
+				return fmt.Errorf("%s%s: not bound to the EAR, since %s is not signed",
+					d.dir, names[0], BundleManifestFile)
+			}
+		}
+	}
+
+	if len(o.Evidence) > 0 {
+		if ar.RawEvidence == nil && ar.EvidenceDigest == nil {
+			return fmt.Errorf("%s: EAR has neither %q nor %q claims",
+				BundleEvidenceFile, "ear.raw-evidence", "ear.evidence-digest")
+		}
+
+		if ar.RawEvidence != nil && !bytes.Equal(*ar.RawEvidence, o.Evidence) {
+			return fmt.Errorf("%s: %q mismatch", BundleEvidenceFile, "ear.raw-evidence")
+		}
+
+		if ar.EvidenceDigest != nil {
+			if err := ar.EvidenceDigest.Verify(o.Evidence); err != nil {
+				return fmt.Errorf("%s: %w", BundleEvidenceFile, err)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(o.SubmodEvidence))
+	for name := range o.SubmodEvidence {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entry := BundleEvidenceDir + name
+
+		a, ok := ar.Submods[name]
+		if !ok || a == nil {
+			return fmt.Errorf("%s: submod %q not found in EAR", entry, name)
+		}
+
+		if a.EvidenceDigest == nil {
+			return fmt.Errorf("%s: %q claim not found in submod %q", entry, "ear.evidence-digest", name)
+		}
+
+		if err := a.EvidenceDigest.Verify(o.SubmodEvidence[name]); err != nil {
+			return fmt.Errorf("%s: %w", entry, err)
+		}
+	}
+
+	return nil
+}
+
+func verifyCertChain(chain []*x509.Certificate, roots *x509.CertPool, t time.Time) error {
+	if roots == nil {
+		return errors.New("certificate chain: no trust roots supplied")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   t,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("certificate chain: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,396 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle_key_set_round_trip(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	otherK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	otherPub, err := jwk.PublicKeyOf(otherK)
+	require.NoError(t, err)
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(otherPub))
+	require.NoError(t, set.AddKey(vfyK))
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSignedBundle(&buf, Bundle{
+		Token:        token,
+		KeySet:       set,
+		Endorsements: map[string][]byte{"corim.cbor": {0xd9, 0x01, 0xf5}},
+	}, jwa.ES256, sigK))
+
+	trusted := jwk.NewSet()
+	require.NoError(t, trusted.AddKey(otherPub))
+	require.NoError(t, trusted.AddKey(vfyK))
+
+	ar, b, err := VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{KeySet: trusted})
+	require.NoError(t, err)
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, ar.Submods)
+	assert.Equal(t, []byte{0xd9, 0x01, 0xf5}, b.Endorsements["corim.cbor"])
+	assert.Equal(t, 2, b.KeySet.Len())
+
+	// the bundled key set is not trusted
+	_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{})
+	assert.EqualError(t, err, "no trusted verification keys for bundle")
+
+	onlyOther := jwk.NewSet()
+	require.NoError(t, onlyOther.AddKey(otherPub))
+
+	_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{KeySet: onlyOther})
+	assert.ErrorContains(t, err, "verifying bundle: failed verifying JWT message: ")
+}
+
+func TestBundle_cert_chain(t *testing.T) {
+	notBefore := time.Unix(testIAT, 0).Add(-time.Hour)
+	notAfter := notBefore.Add(2 * time.Hour)
+
+	caCert, caKey := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	leafCert, leafKey := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test Verifier"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, caCert, caKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, leafKey)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBundle(&buf, Bundle{
+		Token:     token,
+		CertChain: []*x509.Certificate{leafCert, caCert},
+	}))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	// the verifier certificate has long expired
+	_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{Roots: roots})
+	assert.ErrorContains(t, err, "certificate chain: x509: certificate has expired")
+
+	// ...but it was valid at the time of issuance
+	clock := WithClock(FixedClock(time.Unix(testIAT, 0)))
+
+	ar, _, err := VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{Roots: roots}, clock)
+	require.NoError(t, err)
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, ar.Submods)
+
+	_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{Roots: x509.NewCertPool()}, clock)
+	assert.ErrorContains(t, err, "certificate chain: x509: certificate signed by unknown authority")
+}
+
+func TestReadBundle_tampered(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var orig bytes.Buffer
+	require.NoError(t, WriteBundle(&orig, Bundle{Token: token}))
+
+	zr, err := zip.NewReader(bytes.NewReader(orig.Bytes()), int64(orig.Len()))
+	require.NoError(t, err)
+
+	var tampered bytes.Buffer
+	zw := zip.NewWriter(&tampered)
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		if f.Name == BundleTokenFile {
+			content = append(content, '.')
+		}
+
+		require.NoError(t, writeZipEntry(zw, f.Name, content))
+	}
+	require.NoError(t, zw.Close())
+
+	_, err = ReadBundle(tampered.Bytes())
+	assert.EqualError(t, err, "ear.jwt: digest mismatch")
+}
+
+// testZipEntries returns the entries of the supplied ZIP archive
+func testZipEntries(t *testing.T, data []byte) map[string][]byte {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	entries := map[string][]byte{}
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		entries[f.Name] = content
+	}
+
+	return entries
+}
+
+// testZip returns a ZIP archive with the supplied entries
+func testZip(t *testing.T, entries map[string][]byte) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for name, content := range entries {
+		require.NoError(t, writeZipEntry(zw, name, content))
+	}
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func TestBundle_signed_manifest(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	trusted := jwk.NewSet()
+	require.NoError(t, trusted.AddKey(mustParseKey(t, testECDSAPublicKey)))
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	b := Bundle{
+		Token:        token,
+		Endorsements: map[string][]byte{"corim.cbor": {0xd9, 0x01, 0xf5}},
+	}
+
+	var signed bytes.Buffer
+	require.NoError(t, WriteSignedBundle(&signed, b, jwa.ES256, sigK))
+
+	_, actual, err := VerifyBundle(signed.Bytes(), jwa.ES256, BundleTrust{KeySet: trusted})
+	require.NoError(t, err)
+	assert.Equal(t, b.Endorsements, actual.Endorsements)
+
+	// the endorsements, and the manifest with them, are replaced
+	b.Endorsements = map[string][]byte{"corim.cbor": {0xd9, 0x01, 0xf6}}
+
+	var unsigned bytes.Buffer
+	require.NoError(t, WriteBundle(&unsigned, b))
+
+	entries := testZipEntries(t, unsigned.Bytes())
+	entries[BundleManifestSignatureFile] = testZipEntries(t, signed.Bytes())[BundleManifestSignatureFile]
+
+	// ... which goes unnoticed by ReadBundle, but not by VerifyBundle
+	_, err = ReadBundle(testZip(t, entries))
+	require.NoError(t, err)
+
+	_, _, err = VerifyBundle(testZip(t, entries), jwa.ES256, BundleTrust{KeySet: trusted})
+	assert.ErrorContains(t, err, "verifying bundle: manifest.jws: ")
+
+	// the manifest is signed with a key other than the one of the EAR
+	var other bytes.Buffer
+	require.NoError(t, WriteSignedBundle(&other, b, jwa.ES256, mustParseKey(t, testHolderPrivateKey)))
+
+	_, _, err = VerifyBundle(other.Bytes(), jwa.ES256, BundleTrust{KeySet: trusted})
+	assert.ErrorContains(t, err, "verifying bundle: manifest.jws: ")
+
+	// the EAR is passed off as the manifest signature
+	entries = testZipEntries(t, unsigned.Bytes())
+	entries[BundleManifestSignatureFile] = token
+
+	_, _, err = VerifyBundle(testZip(t, entries), jwa.ES256, BundleTrust{KeySet: trusted})
+	assert.EqualError(t, err, `verifying bundle: manifest.jws: unexpected type "JWT"`)
+}
+
+func TestWriteBundle_fail(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.EqualError(t, WriteBundle(&buf, Bundle{}), "missing mandatory EAR token")
+	assert.EqualError(t, WriteBundle(&buf, Bundle{
+		Token:        []byte("token"),
+		Endorsements: map[string][]byte{"../x": nil},
+	}), `invalid endorsement name "../x"`)
+	assert.EqualError(t, WriteBundle(&buf, Bundle{
+		Token:           []byte("token"),
+		ReferenceValues: map[string][]byte{"": nil},
+	}), `invalid reference value name ""`)
+
+	_, _, err := VerifyBundle([]byte("not a zip"), jwa.ES256, BundleTrust{})
+	assert.EqualError(t, err, "opening bundle: zip: not a valid zip file")
+}
+
+func TestBundle_evidence(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(mustParseKey(t, testECDSAPublicKey)))
+
+	evidence := []byte("top-level evidence")
+	platformEvidence := []byte("platform evidence")
+
+	ar := testStreamResult("a", TrustTierAffirming)
+
+	d, err := NewDigest("sha-256", evidence)
+	require.NoError(t, err)
+	ar.EvidenceDigest = d
+
+	d, err = NewDigest("sha-256", platformEvidence)
+	require.NoError(t, err)
+	ar.Submods["platform"].EvidenceDigest = d
+
+	token, err := ar.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	tvs := []struct {
+		evidence       []byte
+		submodEvidence map[string][]byte
+		expected       string
+	}{
+		{evidence, map[string][]byte{"platform": platformEvidence}, ""},
+		{nil, nil, ""},
+		{[]byte("other"), nil, "verifying bundle: evidence.bin: digest mismatch"},
+		{nil, map[string][]byte{"platform": evidence}, "verifying bundle: evidence/platform: digest mismatch"},
+		{nil, map[string][]byte{"workload": evidence}, `verifying bundle: evidence/workload: submod "workload" not found in EAR`},
+	}
+
+	for i, tv := range tvs {
+		var buf bytes.Buffer
+		require.NoError(t, WriteSignedBundle(&buf, Bundle{
+			Token:           token,
+			KeySet:          set,
+			Evidence:        tv.evidence,
+			SubmodEvidence:  tv.submodEvidence,
+			ReferenceValues: map[string][]byte{"refvals.json": []byte("{}")},
+		}, jwa.ES256, sigK))
+
+		_, b, err := VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{KeySet: set})
+		if tv.expected != "" {
+			assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+			continue
+		}
+
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, tv.evidence, b.Evidence, "failed test vector at index %d", i)
+		assert.Equal(t, tv.submodEvidence, b.SubmodEvidence, "failed test vector at index %d", i)
+		assert.Equal(t, []byte("{}"), b.ReferenceValues["refvals.json"], "failed test vector at index %d", i)
+	}
+}
+
+func TestBundle_evidence_not_bound(t *testing.T) {
+	ar := testStreamResult("a", TrustTierAffirming)
+
+	token, err := ar.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(mustParseKey(t, testECDSAPublicKey)))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBundle(&buf, Bundle{Token: token, KeySet: set, Evidence: []byte("evidence")}))
+
+	_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{KeySet: set})
+	assert.EqualError(t, err, `verifying bundle: evidence.bin: EAR has neither "ear.raw-evidence" nor "ear.evidence-digest" claims`)
+
+	// endorsements and reference values are only bound by a signed manifest
+	tvs := []struct {
+		bundle   Bundle
+		expected string
+	}{
+		{
+			Bundle{Token: token, Endorsements: map[string][]byte{"b": nil, "a": {0x00}}},
+			"verifying bundle: endorsements/a: not bound to the EAR, since manifest.json is not signed",
+		},
+		{
+			Bundle{Token: token, ReferenceValues: map[string][]byte{"refvals.json": []byte("{}")}},
+			"verifying bundle: reference-values/refvals.json: not bound to the EAR, since manifest.json is not signed",
+		},
+	}
+
+	for i, tv := range tvs {
+		buf.Reset()
+		require.NoError(t, WriteBundle(&buf, tv.bundle))
+
+		_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{KeySet: set})
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestBundle_timestamp(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(mustParseKey(t, testECDSAPublicKey)))
+
+	tsTime := time.Now().Truncate(time.Second).UTC()
+
+	srv, tsaRoots := newTestTSA(t, tsTime)
+	defer srv.Close()
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	tst, err := RequestTimestamp(context.Background(), srv.URL, token, srv.Client())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBundle(&buf, Bundle{Token: token, KeySet: set, Timestamp: tst}))
+
+	_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{KeySet: set, TSARoots: tsaRoots})
+	require.NoError(t, err)
+
+	_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{KeySet: set})
+	assert.EqualError(t, err, "verifying timestamp: no TSA trust roots supplied")
+
+	// the verifier roots are not trusted for the TSA
+	_, _, err = VerifyBundle(buf.Bytes(), jwa.ES256, BundleTrust{
+		KeySet:   set,
+		Roots:    tsaRoots,
+		TSARoots: x509.NewCertPool(),
+	})
+	assert.ErrorContains(t, err, "verifying timestamp: verifying TSA certificate: ")
+}
+
+func TestReadBundle_too_large(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	require.NoError(t, writeZipEntry(zw, BundleTokenFile, make([]byte, maxBundleEntrySize+1)))
+	require.NoError(t, zw.Close())
+
+	_, err := ReadBundle(buf.Bytes())
+	assert.EqualError(t, err, "ear.jwt exceeds 33554432 bytes")
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// ResultCache maps the identity of each attester onto its latest verified
+// result, so that relying parties can consult it on every request instead of
+// re-verifying tokens.  Entries expire at the `exp` of the token they were
+// obtained from or, if TTL is set, TTL after their `iat`, whichever comes
+// first.  The zero value is an empty cache whose entries only expire at
+// `exp`, and can be used concurrently.
+type ResultCache struct {
+	// TTL is the maximum age of cached results.  A zero or negative TTL means
+	// that results only expire at `exp` (if set).
+	TTL time.Duration
+	// Clock is the source of the current time.  If nil, the system time is
+	// used.
+	Clock Clock
+	// AttesterID returns the identity of the attester a result is about.  If
+	// nil, DefaultAttesterID is used.
+	AttesterID func(ar *AttestationResult) string
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	result  *VerifiedResult
+	expires time.Time // zero means never
+}
+
+func (o cacheEntry) expired(now time.Time) bool {
+	return !o.expires.IsZero() && !now.Before(o.expires)
+}
+
+func (o *ResultCache) now() time.Time {
+	if o.Clock == nil {
+		return systemClock.Now()
+	}
+	return o.Clock.Now()
+}
+
+// Store verifies the supplied token (see Verify) and caches the result under
+// the identity of its attester, which is returned.  The cache Clock is used
+// during verification, unless a different one is supplied in opts.  A result
+// does not replace a cached result for the same attester with a later `iat`.
+func (o *ResultCache) Store(
+	token []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) (string, error) {
+	now := o.now()
+
+	vr, err := Verify(token, alg, key, append([]VerifyOption{WithClock(FixedClock(now))}, opts...)...)
+	if err != nil {
+		return "", err
+	}
+
+	ar := vr.AttestationResult()
+
+	attesterID := o.AttesterID
+	if attesterID == nil {
+		attesterID = DefaultAttesterID
+	}
+
+	attester := attesterID(&ar)
+	if attester == "" {
+		return "", errors.New("empty attester identity")
+	}
+
+	e := cacheEntry{result: vr}
+
+	if exp, ok := tokenExpiration(token); ok {
+		e.expires = exp
+	}
+
+	if o.TTL > 0 {
+		if t := vr.IssuedAt().Add(o.TTL); e.expires.IsZero() || t.Before(e.expires) {
+			e.expires = t
+		}
+	}
+
+	if e.expired(now) {
+		return "", fmt.Errorf("result for %q already expired at %s", attester, e.expires.UTC().Format(time.RFC3339))
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.entries == nil {
+		o.entries = map[string]cacheEntry{}
+	}
+
+	if cur, ok := o.entries[attester]; ok && !cur.expired(now) &&
+		cur.result.IssuedAt().After(vr.IssuedAt()) {
+		return attester, nil
+	}
+
+	o.entries[attester] = e
+
+	return attester, nil
+}
+
+// tokenExpiration returns the `exp` of the supplied JWS token, if any
+func tokenExpiration(token []byte) (time.Time, bool) {
+	claims, err := unverifiedClaims(token)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	v, ok := claims["exp"]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	exp, err := int64Parser(v)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(exp.(int64), 0), true
+}
+
+// Lookup returns the cached result of the supplied attester, unless it has
+// expired
+func (o *ResultCache) Lookup(attester string) (*VerifiedResult, bool) {
+	now := o.now()
+
+	o.mu.RLock()
+	e, ok := o.entries[attester]
+	o.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if e.expired(now) {
+		o.mu.Lock()
+		if cur, ok := o.entries[attester]; ok && cur.expired(now) {
+			delete(o.entries, attester)
+		}
+		o.mu.Unlock()
+
+		return nil, false
+	}
+
+	return e.result, true
+}
+
+// Evict removes the cached result of the supplied attester, if any
+func (o *ResultCache) Evict(attester string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.entries, attester)
+}
+
+// Purge removes all expired results, and returns the number of results that
+// are left
+func (o *ResultCache) Purge() int {
+	now := o.now()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for attester, e := range o.entries {
+		if e.expired(now) {
+			delete(o.entries, attester)
+		}
+	}
+
+	return len(o.entries)
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCacheToken(t *testing.T, iat time.Time, status TrustTier, opts ...SignOption) []byte {
+	ar := testStreamResult("a", status)
+	i := iat.Unix()
+	ar.IssuedAt = &i
+
+	token, err := ar.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey), opts...)
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestResultCache_expiry(t *testing.T) {
+	start := time.Unix(testIAT, 0)
+	now := start
+
+	c := ResultCache{
+		TTL:   time.Hour,
+		Clock: ClockFunc(func() time.Time { return now }),
+	}
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	// expires at exp, which comes before iat+TTL
+	attester, err := c.Store(testCacheToken(t, start, TrustTierAffirming, WithTTL(10*time.Minute)),
+		jwa.ES256, vfyK)
+	require.NoError(t, err)
+	assert.Equal(t, "ueid:AmFhYWFhYQ", attester)
+
+	vr, ok := c.Lookup(attester)
+	require.True(t, ok)
+	status, _ := vr.Status("platform")
+	assert.Equal(t, TrustTierAffirming, status)
+
+	now = start.Add(10 * time.Minute)
+	_, ok = c.Lookup(attester)
+	assert.False(t, ok)
+
+	// expires at iat+TTL
+	_, err = c.Store(testCacheToken(t, now, TrustTierWarning), jwa.ES256, vfyK)
+	require.NoError(t, err)
+
+	now = now.Add(59 * time.Minute)
+	_, ok = c.Lookup(attester)
+	assert.True(t, ok)
+	assert.Equal(t, 1, c.Purge())
+
+	now = now.Add(time.Minute)
+	_, ok = c.Lookup(attester)
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Purge())
+}
+
+func TestResultCache_ordering(t *testing.T) {
+	start := time.Unix(testIAT, 0)
+
+	c := ResultCache{Clock: FixedClock(start.Add(time.Minute))}
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	newer := testCacheToken(t, start.Add(time.Minute), TrustTierContraindicated)
+	older := testCacheToken(t, start, TrustTierAffirming)
+
+	attester, err := c.Store(newer, jwa.ES256, vfyK)
+	require.NoError(t, err)
+
+	// an older result does not replace a newer one
+	_, err = c.Store(older, jwa.ES256, vfyK)
+	require.NoError(t, err)
+
+	vr, ok := c.Lookup(attester)
+	require.True(t, ok)
+	status, _ := vr.Status("platform")
+	assert.Equal(t, TrustTierContraindicated, status)
+
+	c.Evict(attester)
+	_, ok = c.Lookup(attester)
+	assert.False(t, ok)
+}
+
+func TestResultCache_Store_fail(t *testing.T) {
+	start := time.Unix(testIAT, 0)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	c := ResultCache{TTL: time.Minute, Clock: FixedClock(start.Add(time.Hour))}
+
+	_, err := c.Store(testCacheToken(t, start, TrustTierAffirming), jwa.ES256, vfyK)
+	assert.EqualError(t, err, `result for "ueid:AmFhYWFhYQ" already expired at 2022-10-18T11:10:33Z`)
+
+	_, err = c.Store(testCacheToken(t, start, TrustTierAffirming, WithTTL(time.Minute)), jwa.ES256, vfyK)
+	assert.ErrorContains(t, err, "failed verifying JWT message: ")
+
+	c = ResultCache{AttesterID: func(*AttestationResult) string { return "" }}
+	_, err = c.Store(testCacheToken(t, start, TrustTierAffirming), jwa.ES256, vfyK)
+	assert.EqualError(t, err, "empty attester identity")
+
+	_, ok := c.Lookup("nobody")
+	assert.False(t, ok)
+}
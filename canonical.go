@@ -0,0 +1,180 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode/utf16"
+)
+
+// MarshalJSONCanonical validates and serializes to JSON an AttestationResult
+// object using the JSON Canonicalization Scheme (JCS, RFC 8785), i.e., with
+// no insignificant whitespace, object members sorted by name and a unique
+// representation of strings and numbers.  The same result always serializes
+// to the same bytes, which makes it suitable for hashing (see Digest), e.g.,
+// for transparency logs and deduplication.  (MarshalCBOR is deterministic
+// too, since it uses the core deterministic encoding of RFC 8949.)
+func (o AttestationResult) MarshalJSONCanonical() ([]byte, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	// normalize to the JSON data model first, so that custom JSON
+	// serializations (e.g. of TrustTier) are honoured
+	data, err := json.Marshal(o.AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Digest returns the digest, computed using the supplied hash function, of
+// the canonical JSON serialization of the AttestationResult (see
+// MarshalJSONCanonical)
+func (o AttestationResult) Digest(hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("hash function %v not available", hash)
+	}
+
+	data, err := o.MarshalJSONCanonical()
+	if err != nil {
+		return nil, err
+	}
+
+	h := hash.New()
+	h.Write(data)
+
+	return h.Sum(nil), nil
+}
+
+// writeCanonicalJSON writes the JCS serialization of v, which must be in the
+// JSON data model (with numbers decoded as json.Number), to buf
+func writeCanonicalJSON(buf *bytes.Buffer, v interface{}) error {
+	switch t := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if t {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return fmt.Errorf("number %s: %w", t, err)
+		}
+		// encoding/json formats floating-point numbers like ECMAScript, as
+		// required by JCS
+		data, err := json.Marshal(f)
+		if err != nil {
+			return fmt.Errorf("number %s: %w", t, err)
+		}
+		buf.Write(data)
+	case string:
+		writeCanonicalString(buf, t)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		// JCS sorts member names by their UTF-16 code units
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, t[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected type %T", v)
+	}
+
+	return nil
+}
+
+// writeCanonicalString writes s as a JSON string, escaping only the
+// characters that must be escaped, as required by JCS
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+
+	buf.WriteByte('"')
+}
+
+// lessUTF16 reports whether a sorts before b when both are compared as
+// sequences of UTF-16 code units
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+
+	return len(ua) < len(ub)
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationResult_MarshalJSONCanonical(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Extra = map[string]interface{}{
+		"x-html":     "<a&b>",
+		"x-float":    1.5e-7,
+		"x-ctrl":     "\u0001\n",
+		"\uFB01":     1,
+		"\U0001F600": 2,
+	}
+
+	data, err := ar.MarshalJSONCanonical()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(data, []byte(`{"ear.verifier-id":{"build":"rrtrap-v1.0.0","developer":"Acme Inc."},"eat_profile":`)))
+	assert.Contains(t, string(data), `"x-ctrl":"\u0001\n","x-float":1.5e-7,"x-html":"<a&b>"`)
+	// U+1F600 is encoded as a surrogate pair, which sorts before U+FB01 in
+	// UTF-16 (though not in UTF-8)
+	assert.True(t, bytes.HasSuffix(data, []byte("\"x-html\":\"<a&b>\",\"\U0001F600\":2,\"\uFB01\":1}")))
+
+	// canonical JSON is still JSON, and it decodes to the same result
+	var actual AttestationResult
+	require.NoError(t, json.Unmarshal(data, &actual))
+	assert.Equal(t, ar.Submods, actual.Submods)
+
+	again, err := ar.MarshalJSONCanonical()
+	require.NoError(t, err)
+	assert.Equal(t, data, again)
+}
+
+func TestAttestationResult_Digest(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+
+	data, err := ar.MarshalJSONCanonical()
+	require.NoError(t, err)
+
+	d, err := ar.Digest(crypto.SHA256)
+	require.NoError(t, err)
+
+	expected := sha256.Sum256(data)
+	assert.Equal(t, expected[:], d)
+
+	_, err = ar.Digest(crypto.MD4)
+	assert.EqualError(t, err, "hash function MD4 not available")
+
+	_, err = AttestationResult{}.Digest(crypto.SHA256)
+	assert.ErrorContains(t, err, "missing mandatory")
+}
+
+func TestAttestationResult_MarshalCBOR_deterministic(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Extra = map[string]interface{}{
+		"x-b": 1,
+		"x-a": 2,
+		"x-c": 3,
+	}
+
+	expected, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		actual, err := ar.MarshalCBOR()
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
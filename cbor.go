@@ -0,0 +1,444 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborClaim describes how a claim is translated between the JSON and CBOR
+// serializations of an EAR
+type cborClaim struct {
+	// Key, if set, is the integer key used for the claim in CBOR.  Claims
+	// without an integer key retain their JSON name.
+	Key *int64
+	// Bytes means that the claim value is a base64url string in JSON and a
+	// byte string in CBOR
+	Bytes bool
+	// Embedded means that the claim value is a base64url-encoded CBOR item in
+	// JSON, which is embedded as-is in CBOR
+	Embedded bool
+	// Tier means that the claim value is a TrustTier, which is a string in
+	// JSON and an integer in CBOR
+	Tier bool
+	// Fields describes the members of an object (or of the objects in an
+	// array)
+	Fields map[string]cborClaim
+	// Elem describes the values of a map with arbitrary keys
+	Elem *cborClaim
+}
+
+func intKey(k int64) *int64 {
+	return &k
+}
+
+// CBOR keys of the Veraison extension claims.  These are taken from the
+// private-use range of the CWT claims registry (i.e., less than -65536).
+// Extension claims keyed by their JSON name, as encoded by earlier releases,
+// are still accepted when decoding.
+const (
+	CBORKeyVeraisonAnnotatedEvidence int64 = -70000 - iota
+	CBORKeyVeraisonPolicyClaims
+	CBORKeyVeraisonKeyAttestation
+	CBORKeyVeraisonStatusReasons
+	CBORKeyVeraisonPolicyResults
+	CBORKeyVeraisonTeeInfo
+	CBORKeyVeraisonProvenance
+	CBORKeyVeraisonReasons
+	CBORKeyVeraisonStatusOverrides
+	CBORKeyVeraisonUnprocessedEvidence
+)
+
+var digestCBORClaims = map[string]cborClaim{
+	"value": {Bytes: true},
+}
+
+var trustVectorCBORClaims = map[string]cborClaim{
+	"instance-identity": {Key: intKey(0)},
+	"configuration":     {Key: intKey(1)},
+	"executables":       {Key: intKey(2)},
+	"file-system":       {Key: intKey(3)},
+	"hardware":          {Key: intKey(4)},
+	"runtime-opaque":    {Key: intKey(5)},
+	"storage-opaque":    {Key: intKey(6)},
+	"sourced-data":      {Key: intKey(7)},
+}
+
+var appraisalCBORClaims = map[string]cborClaim{
+	"ear.status":                 {Key: intKey(1000), Tier: true},
+	"ear.trustworthiness-vector": {Key: intKey(1001), Fields: trustVectorCBORClaims},
+	"ear.appraisal-policy-id":    {Key: intKey(1003)},
+	"ear.evidence-digest":        {Fields: digestCBORClaims},
+	"ueid":                       {Key: intKey(256), Bytes: true},
+	"oemid":                      {Key: intKey(258), Bytes: true},
+	"hwmodel":                    {Key: intKey(259), Bytes: true},
+	"hwversion":                  {Key: intKey(260)},
+	"ear.linked-results": {Fields: map[string]cborClaim{
+		"digest": {Fields: digestCBORClaims},
+	}},
+	"ear.veraison.annotated-evidence": {Key: intKey(CBORKeyVeraisonAnnotatedEvidence)},
+	"ear.veraison.policy-claims":      {Key: intKey(CBORKeyVeraisonPolicyClaims)},
+	"ear.veraison.key-attestation": {Key: intKey(CBORKeyVeraisonKeyAttestation), Fields: map[string]cborClaim{
+		"cose_key": {Embedded: true},
+	}},
+	"ear.veraison.status-reasons": {Key: intKey(CBORKeyVeraisonStatusReasons), Fields: map[string]cborClaim{
+		"from": {Tier: true},
+		"to":   {Tier: true},
+	}},
+	"ear.veraison.policy-results": {Key: intKey(CBORKeyVeraisonPolicyResults)},
+	"ear.veraison.reasons":        {Key: intKey(CBORKeyVeraisonReasons)},
+	"ear.veraison.status-overrides": {Key: intKey(CBORKeyVeraisonStatusOverrides), Fields: map[string]cborClaim{
+		"from": {Tier: true},
+		"to":   {Tier: true},
+	}},
+	"ear.veraison.unprocessed-evidence": {Key: intKey(CBORKeyVeraisonUnprocessedEvidence)},
+}
+
+// earCBORClaims describes the top-level claims of an EAR.  The integer keys
+// are those registered for CWT and EAT, or assigned by the EAR specification.
+var earCBORClaims = cborClaim{
+	Fields: map[string]cborClaim{
+		"iss":              {Key: intKey(1)},
+		"sub":              {Key: intKey(2)},
+		"aud":              {Key: intKey(3)},
+		"exp":              {Key: intKey(4)},
+		"nbf":              {Key: intKey(5)},
+		"iat":              {Key: intKey(6)},
+		"jti":              {Key: intKey(7), Bytes: true},
+		"cnf":              {Key: intKey(8), Fields: map[string]cborClaim{"cose_key": {Key: intKey(1), Embedded: true}}},
+		"eat_nonce":        {Key: intKey(10)},
+		"eat_profile":      {Key: intKey(265)},
+		"submods":          {Key: intKey(266), Elem: &cborClaim{Fields: appraisalCBORClaims}},
+		"ear.raw-evidence": {Key: intKey(1002), Bytes: true},
+		// the Veraison extensions of the verifier-id have no integer key,
+		// since the EAR specification only assigns those of build and
+		// developer
+		"ear.verifier-id": {Key: intKey(1004), Fields: map[string]cborClaim{
+			"build":     {Key: intKey(0)},
+			"developer": {Key: intKey(1)},
+		}},
+		"ear.evidence-digest": {Fields: digestCBORClaims},
+		"ear.previous-result": {Fields: digestCBORClaims},
+		"ear.raw-evidence-ref": {Fields: map[string]cborClaim{
+			"digest": {Bytes: true},
+		}},
+		"ear.veraison.provenance": {Key: intKey(CBORKeyVeraisonProvenance), Fields: map[string]cborClaim{
+			"token-digest": {Fields: digestCBORClaims},
+		}},
+		"ear.veraison.tee-info": {Key: intKey(CBORKeyVeraisonTeeInfo), Fields: map[string]cborClaim{
+			"tee-name":        {Key: intKey(0)},
+			"evidence-id":     {Key: intKey(1)},
+			"evidence":        {Key: intKey(2), Bytes: true},
+			"media-type":      {Key: intKey(3)},
+			"evidence-digest": {Key: intKey(4), Fields: digestCBORClaims},
+		}},
+		// appraisal claims mirrored at the top level (see WithTopLevelAppraisal)
+		"ear.status":                 appraisalCBORClaims["ear.status"],
+		"ear.trustworthiness-vector": appraisalCBORClaims["ear.trustworthiness-vector"],
+		"ear.appraisal-policy-id":    appraisalCBORClaims["ear.appraisal-policy-id"],
+	},
+}
+
+// CBORKey returns the integer key used in CBOR for the claim identified by
+// path, i.e., by the JSON names of the claim and of the claims enclosing it
+// (e.g., "ear.verifier-id", "build").  The appraisals in "submods" are
+// identified by "*" (e.g., "submods", "*", "ear.status"), and the objects in an
+// array by the name of the array.  Claims without an integer key retain their
+// JSON name in CBOR, in which case false is returned.
+func CBORKey(path ...string) (int64, bool) {
+	c := earCBORClaims
+
+	for _, name := range path {
+		if name == "*" {
+			if c.Elem == nil {
+				return 0, false
+			}
+			c = *c.Elem
+			continue
+		}
+
+		f, ok := c.Fields[name]
+		if !ok {
+			return 0, false
+		}
+		c = f
+	}
+
+	if c.Key == nil {
+		return 0, false
+	}
+
+	return *c.Key, true
+}
+
+var (
+	cborEncMode, _ = cbor.CoreDetEncOptions().EncMode()
+	cborDecMode, _ = cbor.DecOptions{
+		IntDec:    cbor.IntDecConvertSigned,
+		DupMapKey: cbor.DupMapKeyEnforcedAPF,
+	}.DecMode()
+)
+
+// MarshalCBOR validates and serializes to CBOR an AttestationResult object.
+// Claims with an integer key assigned by CWT, EAT or EAR are encoded using
+// it; all other claims retain their JSON name.  The encoding follows the core
+// deterministic encoding requirements of RFC 8949, Section 4.2.1, so the same
+// result always serializes to the same bytes.
+func (o AttestationResult) MarshalCBOR() ([]byte, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	return claimsToCBOR(o.AsMap())
+}
+
+// UnmarshalCBOR de-serializes an AttestationResult object from its CBOR
+// representation and validates it.  A claims-set wrapped in a UCCS tag (601)
+// is accepted too.
+func (o *AttestationResult) UnmarshalCBOR(data []byte) error {
+	claims, err := claimsFromCBOR(bytes.TrimPrefix(data, cborTagUCCSPrefix))
+	if err != nil {
+		return err
+	}
+
+	if err := o.populateFromMap(claims); err != nil {
+		return err
+	}
+
+	return o.validate()
+}
+
+// claimsToCBOR encodes the supplied claims-set, as produced by AsMap, to
+// CBOR
+func claimsToCBOR(claims map[string]interface{}) ([]byte, error) {
+	// normalize to the JSON data model first, so that custom JSON
+	// serializations (e.g. of TrustTier) are honoured
+	data, err := json.Marshal(numericDates(claims))
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonClaimsToCBOR(data)
+}
+
+// jsonClaimsToCBOR converts the JSON-encoded claims-set data to CBOR
+func jsonClaimsToCBOR(data []byte) ([]byte, error) {
+	return jsonToCBOR(data, earCBORClaims)
+}
+
+// jsonToCBOR converts the JSON-encoded object data to CBOR, using the integer
+// keys described by spec
+func jsonToCBOR(data []byte, spec cborClaim) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	c, err := jsonToCBORValue(v, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return cborEncMode.Marshal(c)
+}
+
+// numericDates replaces the time.Time values in the supplied claims-set with
+// the corresponding NumericDate (i.e., seconds since the epoch)
+func numericDates(claims map[string]interface{}) map[string]interface{} {
+	for k, v := range claims {
+		if t, ok := v.(time.Time); ok {
+			claims[k] = t.Unix()
+		}
+	}
+
+	return claims
+}
+
+func jsonToCBORValue(v interface{}, spec cborClaim) (interface{}, error) {
+	switch t := v.(type) {
+	case string:
+		switch {
+		case spec.Bytes:
+			return base64.RawURLEncoding.DecodeString(t)
+		case spec.Embedded:
+			b, err := base64.RawURLEncoding.DecodeString(t)
+			if err != nil {
+				return nil, err
+			}
+			return cbor.RawMessage(b), nil
+		case spec.Tier:
+			tier, err := ToTrustTier(t)
+			if err != nil {
+				return nil, err
+			}
+			return int64(*tier), nil
+		}
+		return t, nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	case map[string]interface{}:
+		ret := make(map[interface{}]interface{}, len(t))
+
+		for k, mv := range t {
+			child := spec.child(k)
+
+			cv, err := jsonToCBORValue(mv, child)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+
+			if child.Key != nil {
+				ret[*child.Key] = cv
+			} else {
+				ret[k] = cv
+			}
+		}
+
+		return ret, nil
+	case []interface{}:
+		ret := make([]interface{}, len(t))
+
+		for i, e := range t {
+			cv, err := jsonToCBORValue(e, spec)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			ret[i] = cv
+		}
+
+		return ret, nil
+	default:
+		return t, nil
+	}
+}
+
+// claimsFromCBOR decodes a CBOR-encoded claims-set into the same form that
+// is obtained when decoding its JSON counterpart.  Unknown integer keys are
+// mapped to their decimal representation.
+func claimsFromCBOR(data []byte) (map[string]interface{}, error) {
+	data, err := cborToJSON(data, earCBORClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, errors.New("claims-set is not a CBOR map")
+	}
+
+	return claims, nil
+}
+
+// cborToJSON converts the CBOR-encoded data to JSON, mapping the integer keys
+// described by spec onto their JSON names
+func cborToJSON(data []byte, spec cborClaim) ([]byte, error) {
+	var c interface{}
+	if err := cborDecMode.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	v, err := cborToJSONValue(c, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+func cborToJSONValue(v interface{}, spec cborClaim) (interface{}, error) {
+	if spec.Embedded {
+		b, err := cborEncMode.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return base64.RawURLEncoding.EncodeToString(b), nil
+	}
+
+	switch t := v.(type) {
+	case []byte:
+		return base64.RawURLEncoding.EncodeToString(t), nil
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(t))
+
+		for k, mv := range t {
+			name, child, err := spec.lookup(k)
+			if err != nil {
+				return nil, err
+			}
+
+			// a claim can be found under both its integer key and its
+			// name, which are distinct CBOR keys
+			if _, ok := ret[name]; ok {
+				return nil, fmt.Errorf("%s: duplicate claim", name)
+			}
+
+			jv, err := cborToJSONValue(mv, child)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			ret[name] = jv
+		}
+
+		return ret, nil
+	case []interface{}:
+		ret := make([]interface{}, len(t))
+
+		for i, e := range t {
+			jv, err := cborToJSONValue(e, spec)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			ret[i] = jv
+		}
+
+		return ret, nil
+	default:
+		return t, nil
+	}
+}
+
+// child returns the specification of the member of an object with the
+// supplied name
+func (o cborClaim) child(name string) cborClaim {
+	if o.Elem != nil {
+		return *o.Elem
+	}
+
+	return o.Fields[name]
+}
+
+// lookup returns the JSON name and specification of the member of an object
+// with the supplied CBOR key
+func (o cborClaim) lookup(k interface{}) (string, cborClaim, error) {
+	switch t := k.(type) {
+	case string:
+		return t, o.child(t), nil
+	case int64:
+		if o.Elem == nil {
+			for name, spec := range o.Fields {
+				if spec.Key != nil && *spec.Key == t {
+					return name, spec, nil
+				}
+			}
+		}
+		return strconv.FormatInt(t, 10), cborClaim{}, nil
+	default:
+		return "", cborClaim{}, fmt.Errorf("unexpected CBOR key type %T", k)
+	}
+}
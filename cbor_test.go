@@ -0,0 +1,187 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationResult_CBOR_round_trip(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.RawEvidence = (*B64Url)(&testEvidence)
+	ar.Submods = map[string]*Appraisal{
+		"test": {
+			Status:      &testStatus,
+			TrustVector: &TrustVector{InstanceIdentity: TrustworthyInstanceClaim},
+		},
+	}
+	require.NoError(t, ar.SetEvidenceDigest("sha-256", testEvidence))
+
+	data, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	var m map[interface{}]interface{}
+	require.NoError(t, cbor.Unmarshal(data, &m))
+
+	// registered claims use their integer keys...
+	assert.Equal(t, EatProfile, m[uint64(265)])
+	assert.Equal(t, testEvidence, m[uint64(1002)])
+
+	submod := m[uint64(266)].(map[interface{}]interface{})["test"].(map[interface{}]interface{})
+	assert.Equal(t, uint64(TrustTierAffirming), submod[uint64(1000)])
+	assert.Equal(t, uint64(TrustworthyInstanceClaim),
+		submod[uint64(1001)].(map[interface{}]interface{})[uint64(0)])
+
+	// ...while the others retain their names
+	assert.Contains(t, m, "ear.evidence-digest")
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalCBOR(data))
+	assert.Equal(t, ar, actual)
+}
+
+func TestAttestationResult_CBOR_verifier_id(t *testing.T) {
+	instance, version, endpoint := "verifier-eu-west-1a", "1.2.3", "https://verifier.example/"
+
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.VerifierID = &VerifierIdentity{
+		Build:     testVerifierID.Build,
+		Developer: testVerifierID.Developer,
+		Instance:  &instance,
+		Version:   &version,
+		Endpoint:  &endpoint,
+	}
+
+	data, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	var m map[interface{}]interface{}
+	require.NoError(t, cbor.Unmarshal(data, &m))
+
+	vid := m[uint64(1004)].(map[interface{}]interface{})
+	assert.Equal(t, instance, vid["instance"])
+	assert.Equal(t, version, vid["version"])
+	assert.Equal(t, endpoint, vid["endpoint"])
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalCBOR(data))
+	assert.Equal(t, ar.VerifierID, actual.VerifierID)
+}
+
+func TestAttestationResult_CBOR_Veraison_extensions(t *testing.T) {
+	data, err := testAttestationResultsWithVeraisonExtns.MarshalJSON()
+	require.NoError(t, err)
+
+	var ar AttestationResult
+	require.NoError(t, ar.UnmarshalJSON(data))
+
+	a := ar.Submods["test"]
+	require.NoError(t, a.Downgrade(TrustTierWarning, "stale-endorsements"))
+	require.NoError(t, a.AddPolicyResult("rule-1", PolicyOutcomeFail, "executables"))
+	require.NoError(t, a.AddReason("unknown-kernel-hash", ReasonSeverityError, "ear.trustworthiness-vector/executables", ""))
+	require.NoError(t, a.AddUnprocessedEvidence("application/eat+cwt", "", "unsupported-media-type"))
+
+	teeName, evidenceID := "aws-nitro", "evidence-01"
+	ar.VeraisonTeeInfo = &VeraisonTeeInfo{TeeName: &teeName, EvidenceID: &evidenceID}
+	require.NoError(t, ar.VeraisonTeeInfo.SetEvidence(testEvidence, ""))
+
+	digest, err := NewDigest("sha-256", testEvidence)
+	require.NoError(t, err)
+	ar.VeraisonProvenance = &Provenance{TokenDigest: digest}
+
+	c, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	var m map[interface{}]interface{}
+	require.NoError(t, cbor.Unmarshal(c, &m))
+
+	assert.Contains(t, m, CBORKeyVeraisonTeeInfo)
+	assert.Contains(t, m, CBORKeyVeraisonProvenance)
+
+	submod := m[uint64(266)].(map[interface{}]interface{})["test"].(map[interface{}]interface{})
+	for _, k := range []int64{
+		CBORKeyVeraisonAnnotatedEvidence,
+		CBORKeyVeraisonPolicyClaims,
+		CBORKeyVeraisonKeyAttestation,
+		CBORKeyVeraisonStatusReasons,
+		CBORKeyVeraisonPolicyResults,
+		CBORKeyVeraisonReasons,
+		CBORKeyVeraisonUnprocessedEvidence,
+	} {
+		assert.Contains(t, submod, k)
+	}
+
+	// no extension retains its JSON name
+	for _, mm := range []map[interface{}]interface{}{m, submod} {
+		for k := range mm {
+			if name, ok := k.(string); ok {
+				assert.False(t, strings.HasPrefix(name, "ear.veraison."), name)
+			}
+		}
+	}
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalCBOR(c))
+	assert.Equal(t, ar, actual)
+}
+
+func TestAttestationResult_UnmarshalCBOR_fail(t *testing.T) {
+	var ar AttestationResult
+
+	assert.ErrorContains(t, ar.UnmarshalCBOR([]byte{0xff}), "cbor: ")
+	assert.EqualError(t, ar.UnmarshalCBOR([]byte{0x01}), "claims-set is not a CBOR map")
+	assert.EqualError(t, ar.UnmarshalCBOR([]byte{0xa0}),
+		"missing mandatory 'eat_profile', 'ear.verifier-id', 'iat', 'submods'")
+
+	// {6: 1, 6: 2}
+	assert.ErrorContains(t, ar.UnmarshalCBOR([]byte{0xa2, 0x06, 0x01, 0x06, 0x02}),
+		"cbor: found duplicate map key")
+
+	// {6: 1, "iat": 2}
+	assert.EqualError(t, ar.UnmarshalCBOR([]byte{0xa2, 0x06, 0x01, 0x63, 'i', 'a', 't', 0x02}),
+		"iat: duplicate claim")
+
+	// {266: {"test": {1000: 2, "ear.status": 2}}}
+	data, err := cborEncMode.Marshal(map[interface{}]interface{}{
+		int64(266): map[interface{}]interface{}{
+			"test": map[interface{}]interface{}{
+				int64(1000):  int64(2),
+				"ear.status": int64(2),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.EqualError(t, ar.UnmarshalCBOR(data), "submods: test: ear.status: duplicate claim")
+}
+
+func TestCBORKey(t *testing.T) {
+	tvs := []struct {
+		path        []string
+		expectedKey int64
+		expectedOK  bool
+	}{
+		{[]string{"iat"}, 6, true},
+		{[]string{"ear.verifier-id", "developer"}, 1, true},
+		{[]string{"submods", "*", "ear.status"}, 1000, true},
+		{[]string{"submods", "*", "ear.trustworthiness-vector", "sourced-data"}, 7, true},
+		{[]string{"submods", "*", "ear.veraison.policy-results"}, CBORKeyVeraisonPolicyResults, true},
+		{[]string{"ear.verifier-id", "instance"}, 0, false},
+		{[]string{"ear.verifier-id", "endpoint"}, 0, false},
+		{[]string{"submods", "*"}, 0, false},
+		{[]string{"submods", "test", "ear.status"}, 0, false},
+		{[]string{"no-such-claim"}, 0, false},
+		{nil, 0, false},
+	}
+
+	for i, tv := range tvs {
+		key, ok := CBORKey(tv.path...)
+		assert.Equal(t, tv.expectedOK, ok, "failed test vector at index %d", i)
+		assert.Equal(t, tv.expectedKey, key, "failed test vector at index %d", i)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// CBORSeqMediaType is the media type of a CBOR sequence (RFC 8742), e.g., of
+// a batch of CWT-wrapped EARs
+const CBORSeqMediaType = "application/cbor-seq"
+
+// CBORSeqEncoder writes signed EARs (CWTs) to a CBOR sequence.  Since a CBOR
+// sequence is just the concatenation of its items, there is no header or
+// trailer, and an encoder can be used to append to an existing sequence.
+type CBORSeqEncoder struct {
+	w io.Writer
+}
+
+// NewCBORSeqEncoder returns an encoder that writes to w
+func NewCBORSeqEncoder(w io.Writer) *CBORSeqEncoder {
+	return &CBORSeqEncoder{w: w}
+}
+
+// Encode appends the supplied signed EAR to the sequence.  The token must be
+// exactly one well-formed CBOR data item (e.g., as returned by SignCWT).
+func (o *CBORSeqEncoder) Encode(token []byte) error {
+	var item cbor.RawMessage
+
+	dec := cborDecMode.NewDecoder(bytes.NewReader(token))
+
+	if err := dec.Decode(&item); err != nil {
+		return fmt.Errorf("not a single CBOR data item: %w", err)
+	}
+
+	if dec.NumBytesRead() != len(token) {
+		return errors.New("not a single CBOR data item: extraneous data")
+	}
+
+	if _, err := o.w.Write(token); err != nil {
+		return fmt.Errorf("writing CBOR sequence item: %w", err)
+	}
+
+	return nil
+}
+
+// EncodeResult signs the supplied attestation result using SignCWT and
+// appends it to the sequence
+func (o *CBORSeqEncoder) EncodeResult(
+	ar *AttestationResult,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...SignOption,
+) error {
+	if ar == nil {
+		return errors.New("nil attestation result")
+	}
+
+	token, err := ar.SignCWT(alg, key, opts...)
+	if err != nil {
+		return err
+	}
+
+	return o.Encode(token)
+}
+
+// CBORSeqDecoder incrementally reads signed EARs (CWTs) from a CBOR sequence,
+// so that arbitrarily large sequences can be processed without buffering
+// them in full
+type CBORSeqDecoder struct {
+	r     *countingReader
+	dec   *cbor.Decoder
+	index int
+}
+
+// countingReader keeps track of the number of bytes read from r, so that a
+// truncated final item can be told apart from the end of the sequence
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (o *countingReader) Read(p []byte) (int, error) {
+	n, err := o.r.Read(p)
+	o.n += n
+	return n, err
+}
+
+// NewCBORSeqDecoder returns a decoder that reads from r
+func NewCBORSeqDecoder(r io.Reader) *CBORSeqDecoder {
+	cr := &countingReader{r: r}
+	return &CBORSeqDecoder{r: cr, dec: cborDecMode.NewDecoder(cr)}
+}
+
+// Next returns the next signed EAR in the sequence.  io.EOF is returned when
+// the end of the sequence is reached.  A truncated final item is reported as
+// io.ErrUnexpectedEOF.
+func (o *CBORSeqDecoder) Next() ([]byte, error) {
+	var item cbor.RawMessage
+
+	if err := o.dec.Decode(&item); err != nil {
+		if err == io.EOF && o.dec.NumBytesRead() == o.r.n {
+			return nil, io.EOF
+		}
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("cbor-seq[%d]: %w", o.index, err)
+	}
+
+	o.index++
+
+	return item, nil
+}
+
+// NextResult returns the next EAR in the sequence, after verifying it using
+// VerifyCWT.  io.EOF is returned when the end of the sequence is reached.
+func (o *CBORSeqDecoder) NextResult(
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) (*AttestationResult, error) {
+	token, err := o.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	var ar AttestationResult
+
+	if err := ar.VerifyCWT(token, alg, key, opts...); err != nil {
+		return nil, fmt.Errorf("cbor-seq[%d]: %w", o.index-1, err)
+	}
+
+	return &ar, nil
+}
+
+// WriteCBORSeq writes the supplied signed EARs (CWTs) to w as a CBOR sequence
+func WriteCBORSeq(w io.Writer, tokens [][]byte) error {
+	enc := NewCBORSeqEncoder(w)
+
+	for i, token := range tokens {
+		if err := enc.Encode(token); err != nil {
+			return fmt.Errorf("cbor-seq[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadCBORSeq reads all the signed EARs (CWTs) in the CBOR sequence in r.
+// The EARs are not verified.
+func ReadCBORSeq(r io.Reader) ([][]byte, error) {
+	var tokens [][]byte
+
+	dec := NewCBORSeqDecoder(r)
+
+	for {
+		token, err := dec.Next()
+		if err == io.EOF {
+			return tokens, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBORSeq_round_trip(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	var b bytes.Buffer
+
+	enc := NewCBORSeqEncoder(&b)
+
+	for _, c := range []string{"a", "b", "c"} {
+		ar := testStreamResult(c, TrustTierAffirming)
+		require.NoError(t, enc.EncodeResult(&ar, jwa.ES256, sigK))
+	}
+
+	dec := NewCBORSeqDecoder(bytes.NewReader(b.Bytes()))
+
+	var ids []string
+
+	for {
+		ar, err := dec.NextResult(jwa.ES256, vfyK)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, DefaultAttesterID(ar))
+	}
+
+	assert.Equal(t, []string{"ueid:AmFhYWFhYQ", "ueid:AmJiYmJiYg", "ueid:AmNjY2NjYw"}, ids)
+
+	tokens, err := ReadCBORSeq(bytes.NewReader(b.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, tokens, 3)
+
+	var b2 bytes.Buffer
+	require.NoError(t, WriteCBORSeq(&b2, tokens))
+	assert.Equal(t, b.Bytes(), b2.Bytes())
+}
+
+func TestCBORSeq_empty(t *testing.T) {
+	tokens, err := ReadCBORSeq(bytes.NewReader(nil))
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestCBORSeq_errors(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	ar := testStreamResult("a", TrustTierAffirming)
+	token, err := ar.SignCWT(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+
+	err = WriteCBORSeq(&b, [][]byte{token, append(token, token...)})
+	assert.ErrorContains(t, err, "cbor-seq[1]: not a single CBOR data item")
+
+	err = WriteCBORSeq(&b, [][]byte{{0xff}})
+	assert.ErrorContains(t, err, "cbor-seq[0]: not a single CBOR data item")
+
+	truncated := append(append([]byte{}, token...), token[:len(token)/2]...)
+	_, err = ReadCBORSeq(bytes.NewReader(truncated))
+	assert.ErrorContains(t, err, "cbor-seq[1]: unexpected EOF")
+
+	dec := NewCBORSeqDecoder(bytes.NewReader(token))
+	_, err = dec.NextResult(jwa.EdDSA, mustParseKey(t, testEd25519PublicKey))
+	assert.ErrorContains(t, err, "cbor-seq[0]: ")
+}
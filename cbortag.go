@@ -0,0 +1,93 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// CBOR tags used to wrap EARs
+const (
+	// CBORTagCOSESign1 is the tag of a COSE_Sign1 message (RFC 9052)
+	CBORTagCOSESign1 = 18
+	// CBORTagCWT is the tag of a CWT (RFC 8392)
+	CBORTagCWT = 61
+	// CBORTagUCCS is the tag of an Unprotected CWT Claims Set (RFC 9781)
+	CBORTagUCCS = 601
+)
+
+var (
+	// cborTagCWTPrefix is the CBOR encoding of the CWT tag (61)
+	cborTagCWTPrefix = []byte{0xd8, 0x3d}
+	// cborTagUCCSPrefix is the CBOR encoding of the UCCS tag (601)
+	cborTagUCCSPrefix = []byte{0xd9, 0x02, 0x59}
+)
+
+// CBORTagging controls how the COSE_Sign1 message produced by SignCWT is
+// tagged
+type CBORTagging int
+
+const (
+	// CBORTaggingCOSE tags the message as a COSE_Sign1 (tag 18).  This is the
+	// default.
+	CBORTaggingCOSE CBORTagging = iota
+	// CBORTaggingCWT tags the message as a COSE_Sign1 (tag 18), and then as
+	// a CWT (tag 61), as some EAT implementations expect
+	CBORTaggingCWT
+	// CBORTaggingNone leaves the message untagged, for protocols where the
+	// message type is implied by the context
+	CBORTaggingNone
+)
+
+func (o CBORTagging) String() string {
+	switch o {
+	case CBORTaggingCOSE:
+		return "COSE"
+	case CBORTaggingCWT:
+		return "CWT"
+	case CBORTaggingNone:
+		return "none"
+	default:
+		return fmt.Sprintf("CBORTagging(%d)", o)
+	}
+}
+
+// WithCBORTagging instructs SignCWT to tag the COSE_Sign1 message as
+// requested.  VerifyCWT accepts all the variants regardless.
+func WithCBORTagging(t CBORTagging) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.cborTagging = t
+	})
+}
+
+// applyCBORTagging re-tags the (tag 18) COSE_Sign1 message token as requested
+func applyCBORTagging(token []byte, t CBORTagging) ([]byte, error) {
+	if len(token) == 0 || token[0] != cborTagSign1 {
+		return nil, fmt.Errorf("expecting a tagged COSE_Sign1 message")
+	}
+
+	switch t {
+	case CBORTaggingCOSE:
+		return token, nil
+	case CBORTaggingCWT:
+		return append(append([]byte{}, cborTagCWTPrefix...), token...), nil
+	case CBORTaggingNone:
+		return token[1:], nil
+	default:
+		return nil, fmt.Errorf("unsupported CBOR tagging %s", t)
+	}
+}
+
+// untagCWT strips the CWT tag (61), if any, from data, and tags a bare
+// COSE_Sign1 message as such, so that it can be parsed by go-cose
+func untagCWT(data []byte) []byte {
+	data = bytes.TrimPrefix(data, cborTagCWTPrefix)
+
+	if len(data) > 0 && data[0] == cborArray4 {
+		data = append([]byte{cborTagSign1}, data...)
+	}
+
+	return data
+}
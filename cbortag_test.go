@@ -0,0 +1,88 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCBORTagging(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	tvs := []struct {
+		tagging  CBORTagging
+		expected []uint64
+	}{
+		{CBORTaggingCOSE, []uint64{CBORTagCOSESign1}},
+		{CBORTaggingCWT, []uint64{CBORTagCWT, CBORTagCOSESign1}},
+		{CBORTaggingNone, nil},
+	}
+
+	for _, tv := range tvs {
+		t.Run(tv.tagging.String(), func(t *testing.T) {
+			token, err := testAttestationResultsWithVeraisonExtns.SignCWT(
+				jwa.ES256, sigK, WithCBORTagging(tv.tagging),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, tv.expected, cborTags(token))
+
+			var actual AttestationResult
+			require.NoError(t, actual.VerifyCWT(token, jwa.ES256, vfyK))
+			assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+			results, errs := VerifyCWTBatch([][]byte{token}, jwa.ES256, vfyK)
+			require.NoError(t, errs[0])
+			assert.Equal(t, testAttestationResultsWithVeraisonExtns, *results[0])
+		})
+	}
+
+	_, err := testAttestationResultsWithVeraisonExtns.SignCWT(
+		jwa.ES256, sigK, WithCBORTagging(CBORTagging(42)),
+	)
+	assert.EqualError(t, err, "unsupported CBOR tagging CBORTagging(42)")
+}
+
+func TestWithCBORTagging_CWTSigner(t *testing.T) {
+	signer, err := NewCWTSigner(jwa.ES256, mustParseKey(t, testECDSAPrivateKey),
+		WithCBORTagging(CBORTaggingCWT))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignWith(signer)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{CBORTagCWT, CBORTagCOSESign1}, cborTags(token))
+}
+
+func TestUnmarshalCBOR_UCCS_tag(t *testing.T) {
+	data, err := testAttestationResultsWithVeraisonExtns.MarshalCBOR()
+	require.NoError(t, err)
+
+	tagged, err := cbor.Marshal(cbor.Tag{Number: CBORTagUCCS, Content: cbor.RawMessage(data)})
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalCBOR(tagged))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+}
+
+// cborTags returns the (nested) tags wrapping the CBOR item in data
+func cborTags(data []byte) []uint64 {
+	var tags []uint64
+
+	for {
+		var tag cbor.RawTag
+		if err := cbor.Unmarshal(data, &tag); err != nil {
+			return tags
+		}
+
+		tags = append(tags, tag.Number)
+		data = tag.Content
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// ChainTo records the SHA-256 digest of previous, which must be the signed EAR
+// issued for the same attester immediately before this one, in the
+// "ear.previous-result" claim.  Chaining periodic re-appraisals this way
+// allows relying parties to detect results that have been dropped, replayed or
+// re-ordered.
+func (o *AttestationResult) ChainTo(previous []byte) error {
+	if len(previous) == 0 {
+		return errors.New("empty previous result")
+	}
+
+	d, err := NewDigest("sha-256", previous)
+	if err != nil {
+		return err
+	}
+
+	o.PreviousResult = d
+
+	return nil
+}
+
+// GetPreviousResult returns the "ear.previous-result" claim
+func (o AttestationResult) GetPreviousResult() (*Digest, error) {
+	if o.PreviousResult == nil {
+		return nil, errors.New(`"ear.previous-result" claim not found`)
+	}
+
+	return o.PreviousResult, nil
+}
+
+// VerifyPreviousResult checks that previous is the signed EAR referenced by
+// the "ear.previous-result" claim
+func (o AttestationResult) VerifyPreviousResult(previous []byte) error {
+	d, err := o.GetPreviousResult()
+	if err != nil {
+		return err
+	}
+
+	if err := d.Verify(previous); err != nil {
+		return fmt.Errorf(`"ear.previous-result": %w`, err)
+	}
+
+	return nil
+}
+
+// VerifyChain verifies the supplied signed EARs, which must be in issuance
+// order (oldest first), using the supplied algorithm, key and VerifyOption.
+// Each EAR but the first must reference its predecessor in the
+// "ear.previous-result" claim, and must not have been issued before it.  The
+// "ear.previous-result" claim of the first EAR, if any, is not checked, so
+// that a chain can be verified starting at any point.  On success, the
+// decoded attestation results are returned in the same order.
+func VerifyChain(
+	tokens [][]byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) ([]*AttestationResult, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("empty chain")
+	}
+
+	results := make([]*AttestationResult, 0, len(tokens))
+
+	for i, token := range tokens {
+		var ar AttestationResult
+		if err := ar.Verify(token, alg, key, opts...); err != nil {
+			return nil, fmt.Errorf("chain[%d]: %w", i, err)
+		}
+
+		if i > 0 {
+			if err := ar.VerifyPreviousResult(tokens[i-1]); err != nil {
+				return nil, fmt.Errorf("chain[%d]: broken link: %w", i, err)
+			}
+
+			if prev := results[i-1]; *ar.IssuedAt < *prev.IssuedAt {
+				return nil, fmt.Errorf("chain[%d]: issued before its predecessor", i)
+			}
+		}
+
+		results = append(results, &ar)
+	}
+
+	return results, nil
+}
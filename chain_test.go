@@ -0,0 +1,129 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain returns n signed EARs, each chained to the previous one
+func testChain(t *testing.T, n int) [][]byte {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	var tokens [][]byte
+
+	for i := 0; i < n; i++ {
+		ar := testAttestationResultsWithVeraisonExtns
+		iat := testIAT + int64(i)*60
+		ar.IssuedAt = &iat
+
+		if i > 0 {
+			require.NoError(t, ar.ChainTo(tokens[i-1]))
+		}
+
+		token, err := ar.Sign(jwa.ES256, sigK)
+		require.NoError(t, err)
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+func TestAttestationResult_ChainTo_ok(t *testing.T) {
+	tokens := testChain(t, 2)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	var ar AttestationResult
+	require.NoError(t, ar.Verify(tokens[1], jwa.ES256, vfyK))
+
+	d, err := ar.GetPreviousResult()
+	require.NoError(t, err)
+	assert.Equal(t, "sha-256", *d.Alg)
+
+	assert.NoError(t, ar.VerifyPreviousResult(tokens[0]))
+	assert.EqualError(t, ar.VerifyPreviousResult(tokens[1]),
+		`"ear.previous-result": digest mismatch`)
+
+	data, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalCBOR(data))
+	assert.Equal(t, ar.PreviousResult, actual.PreviousResult)
+}
+
+func TestAttestationResult_ChainTo_fail(t *testing.T) {
+	var ar AttestationResult
+
+	assert.EqualError(t, ar.ChainTo(nil), "empty previous result")
+
+	_, err := ar.GetPreviousResult()
+	assert.EqualError(t, err, `"ear.previous-result" claim not found`)
+
+	assert.EqualError(t, ar.VerifyPreviousResult([]byte("x")),
+		`"ear.previous-result" claim not found`)
+}
+
+func TestVerifyChain_ok(t *testing.T) {
+	tokens := testChain(t, 3)
+
+	results, err := VerifyChain(tokens, jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Nil(t, results[0].PreviousResult)
+	assert.Equal(t, testIAT+120, *results[2].IssuedAt)
+
+	// a chain can be verified starting at any point
+	_, err = VerifyChain(tokens[1:], jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	assert.NoError(t, err)
+}
+
+func TestVerifyChain_fail(t *testing.T) {
+	tokens := testChain(t, 3)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	// signed before its predecessor
+	ar := testAttestationResultsWithVeraisonExtns
+	iat := testIAT - 60
+	ar.IssuedAt = &iat
+	require.NoError(t, ar.ChainTo(tokens[2]))
+	early, err := ar.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	tvs := []struct {
+		tokens   [][]byte
+		expected string
+	}{
+		{
+			nil,
+			"empty chain",
+		},
+		{
+			[][]byte{tokens[0], tokens[2]},
+			`chain[1]: broken link: "ear.previous-result": digest mismatch`,
+		},
+		{
+			[][]byte{tokens[1], tokens[0]},
+			`chain[1]: broken link: "ear.previous-result" claim not found`,
+		},
+		{
+			[][]byte{tokens[2], early},
+			"chain[1]: issued before its predecessor",
+		},
+		{
+			[][]byte{tokens[0], []byte("garbage")},
+			"chain[1]: failed verifying JWT message: ",
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := VerifyChain(tv.tokens, jwa.ES256, vfyK)
+		assert.ErrorContains(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
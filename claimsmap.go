@@ -0,0 +1,106 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// The types that are (de)serialized most often, i.e., AttestationResult,
+// Appraisal, TrustVector and VerifierIdentity, are converted to and from maps
+// by hand-written code built on the helpers below, rather than by the
+// reflective structAsMap and populateStructFromMap.  The hand-written code
+// produces exactly the same results (and errors), and still uses the
+// reflective functions for the extensions.  When adding a field to one of
+// these types, remember to update its AsMap and populateFromMap methods too.
+
+// putClaim adds the value of a pointer field to m, as doStructAsMap does:
+// nil optional fields are omitted, and nil mandatory ones are set to nil
+func putClaim[T any](m map[string]interface{}, name string, v *T, mandatory bool) {
+	if v == nil {
+		if mandatory {
+			m[name] = nil
+		}
+		return
+	}
+
+	m[name] = *v
+}
+
+// putStructClaims adds the claims corresponding to the fields of the struct s
+// to m, using the reflective doStructAsMap
+func putStructClaims(m map[string]interface{}, s interface{}) {
+	if err := doStructAsMap(reflect.TypeOf(s), reflect.ValueOf(s), m, "json"); err != nil {
+		// An error can only be returned if there is issue in implementation of
+		// s; specifically, if any of its constituents incorrectly implement
+		// AsMap() themselves.
+		panic(err)
+	}
+}
+
+// claimsReader reads the claims of a JSON object into the fields of a struct,
+// as populateStructFromMap does.  Claims must be read in the order in which the
+// corresponding fields are defined, so that problems are reported in the same
+// order.
+type claimsReader struct {
+	m                map[string]interface{}
+	missing, invalid []string
+	// found is the number of claims in m that have been read
+	found int
+}
+
+// read returns the value of the claim called name, as returned by parse.  If
+// the claim is absent or its value is invalid, the problem is recorded and
+// false is returned.
+func (o *claimsReader) read(name string, mandatory bool, parse parser) (interface{}, bool) {
+	raw, ok := o.m[name]
+	if !ok {
+		if mandatory {
+			o.missing = append(o.missing, "'"+name+"'")
+		}
+		return nil, false
+	}
+
+	o.found++
+
+	v, err := parse(raw)
+	if err != nil {
+		o.invalid = append(o.invalid, fmt.Sprintf("'%s' (%s)", name, err.Error()))
+		return nil, false
+	}
+
+	return v, true
+}
+
+// readString reads an optional (or mandatory) string claim into *dst
+func (o *claimsReader) readString(dst **string, name string, mandatory bool) {
+	if v, ok := o.read(name, mandatory, stringPtrParser); ok {
+		*dst = v.(*string)
+	}
+}
+
+// readExtensions reads the claims corresponding to the fields of the
+// extensions struct pointed to by ext
+func (o *claimsReader) readExtensions(ext interface{}, parsers map[string]parser) {
+	found := doPopulateStructFromMap(reflect.TypeOf(ext), reflect.ValueOf(ext),
+		o.m, "json", parsers, stringPtrParser, &o.missing, &o.invalid)
+
+	for _, name := range found {
+		if _, ok := o.m[name]; ok {
+			o.found++
+		}
+	}
+}
+
+// err returns the problems found, if any.  If expected is not nil, claims other
+// than those in expected are reported as unexpected.
+func (o *claimsReader) err(expected []string) error {
+	var extra []string
+	if expected != nil && o.found < len(o.m) {
+		extra = getExtraKeys(o.m, expected)
+	}
+
+	return mapProblems(o.missing, o.invalid, extra)
+}
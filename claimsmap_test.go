@@ -0,0 +1,159 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFullAttestationResult returns a result in which (nearly) every claim is
+// set
+func testFullAttestationResult(t testing.TB) AttestationResult {
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Submods = map[string]*Appraisal{}
+
+	require.NoError(t, ar.SetEvidenceDigest("sha-256", []byte("evidence")))
+	ar.SetRawEvidence([]byte("evidence"))
+	ar.SetNonce([]byte(testNonce))
+	require.NoError(t, ar.SetConfirmationCOSEKey([]byte{0xa1, 0x01, 0x02}))
+
+	epoch := "epoch-1"
+	ar.EpochID = &epoch
+
+	teeName, evidenceID := "aws-nitro", "evidence-1"
+	ar.VeraisonTeeInfo = &VeraisonTeeInfo{TeeName: &teeName, EvidenceID: &evidenceID}
+
+	a := *testAttestationResultsWithVeraisonExtns.Submods["test"]
+	a.SetTrustVector(TrustVector{Executables: ApprovedRuntimeClaim, Hardware: GenuineHardwareClaim})
+	require.NoError(t, a.SetUEID([]byte{UEIDTypeRAND, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	require.NoError(t, a.SetHardwareVersion("1.0", nil))
+	require.NoError(t, a.AddLinkedResult([]byte("a.b.c"), "https://veraison.example/ear/1"))
+
+	ar.Submods["test"] = &a
+	ar.Submods["other"] = &Appraisal{Status: &testStatus}
+
+	return ar
+}
+
+func TestAsMap_same_as_structAsMap(t *testing.T) {
+	tvs := []AttestationResult{
+		testFullAttestationResult(t),
+		testAttestationResultsWithVeraisonExtns,
+		{},
+		{Submods: map[string]*Appraisal{"empty": {}}},
+	}
+
+	for i, tv := range tvs {
+		expected, err := structAsMap(tv, "json")
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, tv.AsMap(), "failed test vector at index %d", i)
+	}
+}
+
+func TestTrustVector_populateFromMap_same_as_populateStructFromMap(t *testing.T) {
+	tvs := []map[string]interface{}{
+		{"executables": 2, "hardware": "genuine"},
+		{"executables": "what?", "hardware": 2, "firmware": 2},
+		{"sourced-data": 1000, "configuration": "bogus"},
+		{},
+	}
+
+	for i, tv := range tvs {
+		var expected, actual TrustVector
+
+		expectedErr := populateStructFromMap(&expected, tv, "json", nil, trustClaimParser, false)
+		actualErr := actual.populateFromMap(tv)
+
+		assert.Equal(t, expected, actual, "failed test vector at index %d", i)
+		assert.Equal(t, expectedErr, actualErr, "failed test vector at index %d", i)
+	}
+}
+
+func TestVerifierIdentity_populateFromMap_same_as_populateStructFromMap(t *testing.T) {
+	tvs := []map[string]interface{}{
+		{"build": "b", "developer": "d", "instance": "i", "version": "v", "endpoint": "https://e"},
+		{"developer": 1, "version": "v", "colour": "blue"},
+		{},
+	}
+
+	for i, tv := range tvs {
+		var expected, actual VerifierIdentity
+
+		expectedErr := populateStructFromMap(&expected, tv, "json", nil, stringPtrParser, false)
+		actualErr := actual.populateFromMap(tv)
+
+		assert.Equal(t, expected, actual, "failed test vector at index %d", i)
+		assert.Equal(t, expectedErr, actualErr, "failed test vector at index %d", i)
+	}
+}
+
+func TestToAppraisal_problems_order(t *testing.T) {
+	m := map[string]interface{}{
+		"ear.trustworthiness-vector":  map[string]interface{}{"executables": "bogus"},
+		"ueid":                        "not base64!",
+		"ear.veraison.policy-claims":  "not a map",
+		"ear.appraisal-policy-id":     42,
+		"ear.veraison.status-reasons": []interface{}{},
+	}
+
+	_, err := ToAppraisal(m)
+	assert.EqualError(t, err, "missing mandatory 'ear.status'; "+
+		"invalid value(s) for 'ear.trustworthiness-vector' (invalid value(s) for 'executables' (not a valid TrustClaim value: \"bogus\")), "+
+		"'ear.appraisal-policy-id' (not a string), "+
+		"'ueid' (illegal base64 data at input byte 3), "+
+		"'ear.veraison.policy-claims' (not a map[string]interface{})")
+}
+
+func TestAttestationResult_round_trip_full(t *testing.T) {
+	ar := testFullAttestationResult(t)
+
+	data, err := ar.MarshalJSON()
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Equal(t, ar, actual)
+}
+
+func BenchmarkAsMap(b *testing.B) {
+	ar := testFullAttestationResult(b)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ar.AsMap()
+	}
+}
+
+func BenchmarkAsMap_reflective(b *testing.B) {
+	ar := testFullAttestationResult(b)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := structAsMap(ar, "json"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkToAppraisal(b *testing.B) {
+	ar := testFullAttestationResult(b)
+
+	data, err := json.Marshal(ar.Submods["test"])
+	require.NoError(b, err)
+
+	var m map[string]interface{}
+	require.NoError(b, json.Unmarshal(data, &m))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ToAppraisal(m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"time"
+)
+
+// Clock is the source of "now" used when stamping time-related claims at
+// signing time and when checking them at verification time.  It is
+// structurally identical to jwt.Clock, so a Clock can be passed to jwx as-is.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is an adapter that allows the use of an ordinary function as a
+// Clock.
+type ClockFunc func() time.Time
+
+// Now returns f()
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// systemClock is the Clock used when none is supplied by the caller
+var systemClock Clock = ClockFunc(time.Now)
+
+// FixedClock returns a Clock that always reports t.  It is mostly useful in
+// tests and simulations.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock_Sign_stamps_iat(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.IssuedAt = nil
+
+	token, err := ar.Sign(jwa.ES256, sigK, WithClock(FixedClock(now)), WithIssuedAtNow())
+	require.NoError(t, err)
+
+	// the receiver is not modified
+	assert.Nil(t, ar.IssuedAt)
+
+	var actual AttestationResult
+
+	err = actual.Verify(token, jwa.ES256, vfyK, WithClock(FixedClock(now)))
+	require.NoError(t, err)
+	assert.Equal(t, now.Unix(), *actual.IssuedAt)
+}
+
+func TestClock_Verify_iat_in_the_future(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	past := time.Unix(testIAT, 0).Add(-time.Hour)
+
+	var actual AttestationResult
+
+	err = actual.Verify(token, jwa.ES256, vfyK, WithClock(FixedClock(past)))
+	assert.ErrorContains(t, err, `"iat" not satisfied`)
+}
+
+func TestClock_Verify_max_age(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	iat := time.Unix(testIAT, 0)
+
+	var actual AttestationResult
+
+	err = actual.Verify(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(iat.Add(time.Minute))), WithMaxAge(5*time.Minute))
+	assert.NoError(t, err)
+
+	err = actual.Verify(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(iat.Add(time.Hour))), WithMaxAge(5*time.Minute))
+	assert.EqualError(t, err, "freshness check failed: result issued 1h0m0s ago (max age 5m0s)")
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// Confirmation is the `cnf` claim defined in RFC 7800 (and RFC 8747 for
+// CWT), which binds the attestation result to a key held by the presenter.
+// Exactly one of the confirmation methods must be used:
+//
+//   - JWK carries the public key in JWK format (RFC 7800 §3.2)
+//   - JKT carries the base64url-encoded SHA-256 JWK thumbprint of the public
+//     key (RFC 9449 §6.1)
+//   - COSEKey carries the public key as a serialized COSE_Key (RFC 8747 §3.1)
+type Confirmation struct {
+	JWK     *map[string]interface{} `json:"jwk,omitempty"`
+	JKT     *string                 `json:"jkt,omitempty"`
+	COSEKey *B64Url                 `json:"cose_key,omitempty"`
+}
+
+func (o Confirmation) validate() error {
+	n := 0
+
+	if o.JWK != nil {
+		n++
+
+		if _, err := o.GetKey(); err != nil {
+			return err
+		}
+	}
+
+	if o.JKT != nil {
+		n++
+
+		jkt, err := base64.RawURLEncoding.DecodeString(*o.JKT)
+		if err != nil {
+			return fmt.Errorf(`decoding "jkt": %w`, err)
+		}
+
+		if len(jkt) != crypto.SHA256.Size() {
+			return fmt.Errorf(`"jkt" must be a SHA-256 thumbprint, got %d bytes`, len(jkt))
+		}
+	}
+
+	if o.COSEKey != nil {
+		n++
+
+		if len(*o.COSEKey) == 0 {
+			return errors.New(`empty "cose_key"`)
+		}
+	}
+
+	if n != 1 {
+		return fmt.Errorf("exactly one confirmation method must be present, found %d", n)
+	}
+
+	return nil
+}
+
+// GetKey returns the public key carried in the "jwk" or "cose_key"
+// confirmation method
+func (o Confirmation) GetKey() (jwk.Key, error) {
+	if o.COSEKey != nil {
+		return o.getCOSEKey()
+	}
+
+	if o.JWK == nil {
+		return nil, errors.New(`"jwk" confirmation method not found`)
+	}
+
+	// private (or symmetric) key material must never be disclosed
+	for _, member := range []string{"d", "p", "q", "dp", "dq", "qi", "k"} {
+		if _, ok := (*o.JWK)[member]; ok {
+			return nil, errors.New(`"jwk" must be an asymmetric public key`)
+		}
+	}
+
+	data, err := json.Marshal(*o.JWK)
+	if err != nil {
+		return nil, fmt.Errorf(`serializing "jwk": %w`, err)
+	}
+
+	key, err := jwk.ParseKey(data)
+	if err != nil {
+		return nil, fmt.Errorf(`parsing "jwk": %w`, err)
+	}
+
+	return key, nil
+}
+
+func (o Confirmation) getCOSEKey() (jwk.Key, error) {
+	key, err := JWKFromCOSEKey(*o.COSEKey)
+	if err != nil {
+		return nil, fmt.Errorf(`parsing "cose_key": %w`, err)
+	}
+
+	// private key material must never be disclosed
+	switch key.(type) {
+	case jwk.ECDSAPrivateKey, jwk.OKPPrivateKey:
+		return nil, errors.New(`"cose_key" must be an asymmetric public key`)
+	}
+
+	return key, nil
+}
+
+// MatchesKey reports whether key is the one bound by the confirmation claim.
+// This is possible for the "jwk", "jkt" and "cose_key" confirmation methods.
+func (o Confirmation) MatchesKey(key jwk.Key) (bool, error) {
+	pub, err := jwk.PublicKeyOf(key)
+	if err != nil {
+		return false, fmt.Errorf("extracting public key: %w", err)
+	}
+
+	tp, err := pub.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return false, fmt.Errorf("computing thumbprint: %w", err)
+	}
+
+	switch {
+	case o.JWK != nil, o.COSEKey != nil:
+		cnfKey, err := o.GetKey()
+		if err != nil {
+			return false, err
+		}
+
+		cnfTP, err := cnfKey.Thumbprint(crypto.SHA256)
+		if err != nil {
+			return false, fmt.Errorf("computing thumbprint: %w", err)
+		}
+
+		return string(cnfTP) == string(tp), nil
+	case o.JKT != nil:
+		return *o.JKT == base64.RawURLEncoding.EncodeToString(tp), nil
+	default:
+		return false, errors.New("confirmation method does not allow key matching")
+	}
+}
+
+func ToConfirmation(v interface{}) (*Confirmation, error) {
+	var c Confirmation
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON object")
+	}
+
+	parsers := map[string]parser{
+		"jwk":      stringMapPtrParser,
+		"cose_key": b64urlBytesPtrParser,
+	}
+
+	if err := populateStructFromMap(&c, m, "json", parsers, stringPtrParser, false); err != nil {
+		return nil, err
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// SetConfirmationKey binds the attestation result to the supplied key using
+// the "jwk" confirmation method.  If key is a private key, only its public
+// part is used.
+func (o *AttestationResult) SetConfirmationKey(key jwk.Key) error {
+	pub, err := jwk.PublicKeyOf(key)
+	if err != nil {
+		return fmt.Errorf("extracting public key: %w", err)
+	}
+
+	if _, ok := pub.(jwk.SymmetricKey); ok {
+		return errors.New("symmetric keys cannot be used for confirmation")
+	}
+
+	data, err := json.Marshal(pub)
+	if err != nil {
+		return fmt.Errorf("serializing key: %w", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("serializing key: %w", err)
+	}
+
+	o.Confirmation = &Confirmation{JWK: &m}
+
+	return nil
+}
+
+// SetConfirmationThumbprint binds the attestation result to the supplied key
+// using the "jkt" confirmation method, i.e., using the SHA-256 JWK thumbprint
+// of its public part.
+func (o *AttestationResult) SetConfirmationThumbprint(key jwk.Key) error {
+	pub, err := jwk.PublicKeyOf(key)
+	if err != nil {
+		return fmt.Errorf("extracting public key: %w", err)
+	}
+
+	tp, err := pub.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("computing thumbprint: %w", err)
+	}
+
+	jkt := base64.RawURLEncoding.EncodeToString(tp)
+
+	o.Confirmation = &Confirmation{JKT: &jkt}
+
+	return nil
+}
+
+// SetConfirmationCOSEKey binds the attestation result to the public key in
+// the supplied serialized COSE_Key.
+func (o *AttestationResult) SetConfirmationCOSEKey(coseKey []byte) error {
+	if len(coseKey) == 0 {
+		return errors.New("empty COSE_Key")
+	}
+
+	v := B64Url(coseKey)
+
+	o.Confirmation = &Confirmation{COSEKey: &v}
+
+	return nil
+}
+
+// GetConfirmation returns the `cnf` claim
+func (o AttestationResult) GetConfirmation() (*Confirmation, error) {
+	if o.Confirmation == nil {
+		return nil, errors.New(`"cnf" claim not found`)
+	}
+
+	return o.Confirmation, nil
+}
+
+// WithRequiredConfirmation instructs Verify to reject results that do not
+// carry proof-of-possession material in the `cnf` claim.
+func WithRequiredConfirmation() VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.requireConfirmation = true
+	})
+}
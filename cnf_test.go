@@ -0,0 +1,166 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testHolderPrivateKey = `{
+	"kty": "EC",
+	"crv": "P-256",
+	"x": "MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4",
+	"y": "4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM",
+	"d": "870MB6gfuTJ4HtUnUvYMyJpr5eUZNP4Bk43bVdj3eAE"
+}`
+
+func TestConfirmation_jwk_round_trip(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	holderK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	require.NoError(t, ar.SetConfirmationKey(holderK))
+
+	// private key material is never disclosed
+	_, ok := (*ar.Confirmation.JWK)["d"]
+	assert.False(t, ok)
+
+	token, err := ar.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK, WithRequiredConfirmation()))
+
+	cnf, err := actual.GetConfirmation()
+	require.NoError(t, err)
+
+	match, err := cnf.MatchesKey(holderK)
+	require.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = cnf.MatchesKey(vfyK)
+	require.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestConfirmation_jkt(t *testing.T) {
+	holderK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	var ar AttestationResult
+	require.NoError(t, ar.SetConfirmationThumbprint(holderK))
+	require.NoError(t, ar.Confirmation.validate())
+
+	match, err := ar.Confirmation.MatchesKey(holderK)
+	require.NoError(t, err)
+	assert.True(t, match)
+}
+
+func TestConfirmation_cose_key(t *testing.T) {
+	var ar AttestationResult
+
+	assert.EqualError(t, ar.SetConfirmationCOSEKey(nil), "empty COSE_Key")
+
+	require.NoError(t, ar.SetConfirmationCOSEKey([]byte{0xa1, 0x01, 0x02}))
+	require.NoError(t, ar.Confirmation.validate())
+
+	holderK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	_, err = ar.Confirmation.MatchesKey(holderK)
+	assert.EqualError(t, err, `parsing "cose_key": parsing COSE_Key: unsupported EC2 curve 0`)
+
+	holderPub, err := jwk.PublicKeyOf(holderK)
+	require.NoError(t, err)
+
+	coseKey, err := COSEKeyFromJWK(holderPub)
+	require.NoError(t, err)
+
+	require.NoError(t, ar.SetConfirmationCOSEKey(coseKey))
+
+	match, err := ar.Confirmation.MatchesKey(holderK)
+	require.NoError(t, err)
+	assert.True(t, match)
+
+	// private key material is rejected
+	coseKey, err = COSEKeyFromJWK(holderK)
+	require.NoError(t, err)
+
+	require.NoError(t, ar.SetConfirmationCOSEKey(coseKey))
+
+	_, err = ar.Confirmation.GetKey()
+	assert.EqualError(t, err, `"cose_key" must be an asymmetric public key`)
+}
+
+func TestConfirmation_Verify_required(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+
+	err = actual.Verify(token, jwa.ES256, vfyK, WithRequiredConfirmation())
+	assert.EqualError(t, err, `missing mandatory "cnf" (proof-of-possession material required)`)
+
+	_, err = actual.GetConfirmation()
+	assert.EqualError(t, err, `"cnf" claim not found`)
+}
+
+func TestToConfirmation_fail(t *testing.T) {
+	tvs := []struct {
+		v        interface{}
+		expected string
+	}{
+		{
+			v:        "jwk",
+			expected: "not a JSON object",
+		},
+		{
+			v:        map[string]interface{}{},
+			expected: "exactly one confirmation method must be present, found 0",
+		},
+		{
+			v: map[string]interface{}{
+				"jkt":      "0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I",
+				"cose_key": "oQEC",
+			},
+			expected: "exactly one confirmation method must be present, found 2",
+		},
+		{
+			v:        map[string]interface{}{"jkt": "3q2-7w"},
+			expected: `"jkt" must be a SHA-256 thumbprint, got 4 bytes`,
+		},
+		{
+			v: map[string]interface{}{
+				"jwk": map[string]interface{}{"kty": "oct", "k": "c2VjcmV0"},
+			},
+			expected: `"jwk" must be an asymmetric public key`,
+		},
+		{
+			v:        map[string]interface{}{"jwk": map[string]interface{}{"kty": "XYZ"}},
+			expected: `parsing "jwk": invalid key type from JSON (XYZ)`,
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToConfirmation(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
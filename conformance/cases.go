@@ -0,0 +1,216 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+// Case is a single entry of the conformance table: a JSON claims-set together
+// with the verdict a conforming implementation must reach about it
+type Case struct {
+	// Name is a short, file-name friendly identifier of the case
+	Name string `json:"name"`
+	// Description explains what the case exercises
+	Description string `json:"description"`
+	// Claims is the JSON claims-set, in the normal form produced by the
+	// reference implementation
+	Claims string `json:"claims"`
+	// Valid is true if the claims-set is a well-formed EAR, i.e., it must be
+	// signed, verified and round-tripped without loss.  Otherwise, it must be
+	// rejected both on signing and on verification.
+	Valid bool `json:"valid"`
+}
+
+var cases = []Case{
+	{
+		Name:        "minimal",
+		Description: "only the mandatory claims",
+		Claims: `{
+			"eat_profile": "tag:github.com,2023:veraison/ear",
+			"iat": 1666091373,
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0",
+				"developer": "Acme Inc."
+			},
+			"submods": {
+				"test": {
+					"ear.status": "affirming"
+				}
+			}
+		}`,
+		Valid: true,
+	},
+	{
+		Name:        "full",
+		Description: "all the standard EAR claims",
+		Claims: `{
+			"eat_profile": "tag:github.com,2023:veraison/ear",
+			"iat": 1666091373,
+			"eat_nonce": "0123456789abcdef",
+			"ear.raw-evidence": "ZXZpZGVuY2U",
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0",
+				"developer": "Acme Inc.",
+				"instance": "vfy-1"
+			},
+			"submods": {
+				"test": {
+					"ear.status": "warning",
+					"ear.trustworthiness-vector": {
+						"instance-identity": 2,
+						"configuration": 2,
+						"executables": 33,
+						"file-system": 0,
+						"hardware": 2,
+						"runtime-opaque": 0,
+						"storage-opaque": 0,
+						"sourced-data": 0
+					},
+					"ear.appraisal-policy-id": "policy://test/01234"
+				}
+			}
+		}`,
+		Valid: true,
+	},
+	{
+		Name:        "multiple-submods",
+		Description: "appraisals of more than one attester component",
+		Claims: `{
+			"eat_profile": "tag:github.com,2023:veraison/ear",
+			"iat": 1666091373,
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0",
+				"developer": "Acme Inc."
+			},
+			"submods": {
+				"platform": {
+					"ear.status": "affirming"
+				},
+				"realm": {
+					"ear.status": "contraindicated",
+					"ear.trustworthiness-vector": {
+						"instance-identity": 0,
+						"configuration": 0,
+						"executables": 99,
+						"file-system": 0,
+						"hardware": 0,
+						"runtime-opaque": 0,
+						"storage-opaque": 0,
+						"sourced-data": 0
+					}
+				}
+			}
+		}`,
+		Valid: true,
+	},
+	{
+		Name:        "veraison-extensions",
+		Description: "Veraison-specific appraisal extensions",
+		Claims: `{
+			"eat_profile": "tag:github.com,2023:veraison/ear",
+			"iat": 1666091373,
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0",
+				"developer": "Acme Inc."
+			},
+			"submods": {
+				"test": {
+					"ear.status": "affirming",
+					"ear.veraison.annotated-evidence": {
+						"k1": "v1"
+					},
+					"ear.veraison.policy-claims": {
+						"bar": "baz"
+					}
+				}
+			}
+		}`,
+		Valid: true,
+	},
+	{
+		Name:        "missing-eat_profile",
+		Description: "the mandatory eat_profile claim is missing",
+		Claims: `{
+			"iat": 1666091373,
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0",
+				"developer": "Acme Inc."
+			},
+			"submods": {
+				"test": {
+					"ear.status": "affirming"
+				}
+			}
+		}`,
+	},
+	{
+		Name:        "string-iat",
+		Description: "iat is not a NumericDate",
+		Claims: `{
+			"eat_profile": "tag:github.com,2023:veraison/ear",
+			"iat": "yesterday",
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0",
+				"developer": "Acme Inc."
+			},
+			"submods": {
+				"test": {
+					"ear.status": "affirming"
+				}
+			}
+		}`,
+	},
+	{
+		Name:        "missing-developer",
+		Description: "ear.verifier-id lacks the mandatory developer",
+		Claims: `{
+			"eat_profile": "tag:github.com,2023:veraison/ear",
+			"iat": 1666091373,
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0"
+			},
+			"submods": {
+				"test": {
+					"ear.status": "affirming"
+				}
+			}
+		}`,
+	},
+	{
+		Name:        "submods-array",
+		Description: "submods is not a JSON object",
+		Claims: `{
+			"eat_profile": "tag:github.com,2023:veraison/ear",
+			"iat": 1666091373,
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0",
+				"developer": "Acme Inc."
+			},
+			"submods": [
+				{
+					"ear.status": "affirming"
+				}
+			]
+		}`,
+	},
+	{
+		Name:        "unknown-status",
+		Description: "an appraisal has an ear.status that is not a trust tier",
+		Claims: `{
+			"eat_profile": "tag:github.com,2023:veraison/ear",
+			"iat": 1666091373,
+			"ear.verifier-id": {
+				"build": "rrtrap-v1.0.0",
+				"developer": "Acme Inc."
+			},
+			"submods": {
+				"test": {
+					"ear.status": "excellent"
+				}
+			}
+		}`,
+	},
+}
+
+// Cases returns (a copy of) the conformance table
+func Cases() []Case {
+	return append([]Case(nil), cases...)
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conformance packages the EAR validation and round-trip checks of
+// the reference implementation (package ear) as a reusable test suite, so
+// that alternative EAR implementations, and services built on top of them,
+// can assert conformance from their own test binaries:
+//
+//	func TestEARConformance(t *testing.T) {
+//		conformance.Run(t, myImplementation{})
+//	}
+//
+// The suite is driven by a table of claims-sets (see Cases) and by the
+// negative test vectors returned by ear.NegativeTestVectors.
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/veraison/ear"
+)
+
+// Implementation is the EAR implementation under test.  Keys are supplied as
+// jwk.Key objects.
+type Implementation interface {
+	// Sign validates the JSON claims-set and wraps it in a JWT signed with
+	// the supplied algorithm and private key
+	Sign(claims []byte, alg jwa.KeyAlgorithm, key jwk.Key) ([]byte, error)
+	// Verify cryptographically verifies the JWT using the supplied algorithm
+	// and public key, validates it and returns its JSON claims-set
+	Verify(token []byte, alg jwa.KeyAlgorithm, key jwk.Key) ([]byte, error)
+}
+
+// Reference is the reference implementation, i.e., package ear
+var Reference Implementation = reference{}
+
+type reference struct{}
+
+func (reference) Sign(claims []byte, alg jwa.KeyAlgorithm, key jwk.Key) ([]byte, error) {
+	var ar ear.AttestationResult
+	if err := ar.UnmarshalJSON(claims); err != nil {
+		return nil, err
+	}
+
+	return ar.Sign(alg, key)
+}
+
+func (reference) Verify(token []byte, alg jwa.KeyAlgorithm, key jwk.Key) ([]byte, error) {
+	vr, err := ear.Verify(token, alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// ear.Verify fills in a missing iat, so the claims-set as signed is
+	// validated separately
+	msg, err := jws.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var ar ear.AttestationResult
+	if err := ar.UnmarshalJSON(msg.Payload()); err != nil {
+		return nil, err
+	}
+
+	return vr.MarshalJSON()
+}
+
+var (
+	signingKey = `{
+		"kty": "EC",
+		"crv": "P-256",
+		"x": "usWxHK2PmfnHKwXPS54m0kTcGJ90UiglWiGahtagnv8",
+		"y": "IBOL-C3BttVivg-lSreASjpkttcsz-1rb7btKLv8EX4",
+		"d": "V8kgd2ZBRuh2dgyVINBUqpPDr7BOMGcF22CQMIUHtNM"
+	}`
+
+	verificationKey = `{
+		"kty": "EC",
+		"crv": "P-256",
+		"x": "usWxHK2PmfnHKwXPS54m0kTcGJ90UiglWiGahtagnv8",
+		"y": "IBOL-C3BttVivg-lSreASjpkttcsz-1rb7btKLv8EX4"
+	}`
+
+	alg = jwa.ES256
+)
+
+// Run runs the conformance suite against impl, one sub-test per check.  For
+// each valid entry of the table, EARs signed by impl must be accepted by the
+// reference implementation and EARs signed by the reference implementation
+// must be accepted by impl, with both yielding the original claims-set.  For
+// each invalid entry, impl must refuse to sign the claims-set and must reject
+// it when signed.  Finally, impl must reject all the negative test vectors.
+func Run(t *testing.T, impl Implementation) {
+	cs, err := checks(impl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range cs {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.run(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// check is a named conformance check
+type check struct {
+	name string
+	run  func() error
+}
+
+func checks(impl Implementation) ([]check, error) {
+	skey, err := jwk.ParseKey([]byte(signingKey))
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key: %w", err)
+	}
+
+	pkey, err := jwk.ParseKey([]byte(verificationKey))
+	if err != nil {
+		return nil, fmt.Errorf("parsing verification key: %w", err)
+	}
+
+	var cs []check
+
+	for _, c := range cases {
+		c := c
+
+		if c.Valid {
+			cs = append(cs,
+				check{c.Name + "/sign", func() error { return checkSign(impl, c, skey, pkey) }},
+				check{c.Name + "/verify", func() error { return checkVerify(impl, c, skey, pkey) }},
+			)
+		} else {
+			cs = append(cs,
+				check{c.Name + "/sign", func() error { return checkRejectSign(impl, c, skey) }},
+				check{c.Name + "/verify", func() error { return checkRejectVerify(impl, c, skey, pkey) }},
+			)
+		}
+	}
+
+	var base ear.AttestationResult
+	if err := base.UnmarshalJSON([]byte(cases[0].Claims)); err != nil {
+		return nil, fmt.Errorf("parsing base claims-set: %w", err)
+	}
+
+	tvs, err := ear.NegativeTestVectors(base, alg, skey)
+	if err != nil {
+		return nil, fmt.Errorf("generating negative test vectors: %w", err)
+	}
+
+	for _, tv := range tvs {
+		tv := tv
+
+		cs = append(cs, check{"negative/" + tv.Name, func() error {
+			if _, err := impl.Verify(tv.Token, alg, pkey); err == nil {
+				return fmt.Errorf("accepted EAR where %s", tv.Description)
+			}
+			return nil
+		}})
+	}
+
+	return cs, nil
+}
+
+func checkSign(impl Implementation, c Case, skey, pkey jwk.Key) error {
+	token, err := impl.Sign([]byte(c.Claims), alg, skey)
+	if err != nil {
+		return fmt.Errorf("signing: %w", err)
+	}
+
+	claims, err := Reference.Verify(token, alg, pkey)
+	if err != nil {
+		return fmt.Errorf("reference implementation rejected EAR: %w", err)
+	}
+
+	return equalJSON([]byte(c.Claims), claims)
+}
+
+func checkVerify(impl Implementation, c Case, skey, pkey jwk.Key) error {
+	token, err := Reference.Sign([]byte(c.Claims), alg, skey)
+	if err != nil {
+		return fmt.Errorf("reference implementation failed signing: %w", err)
+	}
+
+	claims, err := impl.Verify(token, alg, pkey)
+	if err != nil {
+		return fmt.Errorf("verifying: %w", err)
+	}
+
+	return equalJSON([]byte(c.Claims), claims)
+}
+
+func checkRejectSign(impl Implementation, c Case, skey jwk.Key) error {
+	if _, err := impl.Sign([]byte(c.Claims), alg, skey); err == nil {
+		return fmt.Errorf("signed claims-set where %s", c.Description)
+	}
+
+	return nil
+}
+
+func checkRejectVerify(impl Implementation, c Case, skey, pkey jwk.Key) error {
+	// sign the claims-set as is, bypassing any validation
+	var claims bytes.Buffer
+	if err := json.Compact(&claims, []byte(c.Claims)); err != nil {
+		return fmt.Errorf("compacting claims-set: %w", err)
+	}
+
+	token, err := jws.Sign(claims.Bytes(), jws.WithKey(alg, skey))
+	if err != nil {
+		return fmt.Errorf("signing claims-set: %w", err)
+	}
+
+	if _, err := impl.Verify(token, alg, pkey); err == nil {
+		return fmt.Errorf("accepted EAR where %s", c.Description)
+	}
+
+	return nil
+}
+
+func equalJSON(expected, actual []byte) error {
+	var e, a interface{}
+
+	if err := json.Unmarshal(expected, &e); err != nil {
+		return fmt.Errorf("decoding expected claims-set: %w", err)
+	}
+
+	if err := json.Unmarshal(actual, &a); err != nil {
+		return fmt.Errorf("decoding actual claims-set: %w", err)
+	}
+
+	if !reflect.DeepEqual(e, a) {
+		return fmt.Errorf("claims-set not round-tripped: want %s, got %s", expected, actual)
+	}
+
+	return nil
+}
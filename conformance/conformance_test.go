@@ -0,0 +1,49 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_reference(t *testing.T) {
+	Run(t, Reference)
+}
+
+// lax is a broken implementation that signs and accepts anything
+type lax struct{}
+
+func (lax) Sign(claims []byte, alg jwa.KeyAlgorithm, key jwk.Key) ([]byte, error) {
+	return claims, nil
+}
+
+func (lax) Verify(token []byte, alg jwa.KeyAlgorithm, key jwk.Key) ([]byte, error) {
+	return token, nil
+}
+
+func TestChecks_nonconforming(t *testing.T) {
+	cs, err := checks(lax{})
+	require.NoError(t, err)
+
+	for _, c := range cs {
+		assert.Error(t, c.run(), "check %s", c.name)
+	}
+}
+
+func TestCases(t *testing.T) {
+	cs := Cases()
+	assert.Len(t, cs, len(cases))
+
+	cs[0].Name = "changed"
+	assert.Equal(t, "minimal", cases[0].Name)
+
+	for i, c := range cs {
+		assert.NotEmpty(t, c.Description, "failed test vector at index %d", i)
+	}
+}
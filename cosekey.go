@@ -0,0 +1,255 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// COSE_Key parameters (RFC 9052, §7 and RFC 9053, §7)
+const (
+	coseKeyKty int64 = 1
+	coseKeyKid int64 = 2
+	coseKeyAlg int64 = 3
+	coseKeyCrv int64 = -1
+	coseKeyX   int64 = -2
+	coseKeyY   int64 = -3
+	coseKeyD   int64 = -4
+)
+
+// COSE_Key RSA parameters (RFC 8230, §4)
+const (
+	coseKeyN    int64 = -1
+	coseKeyE    int64 = -2
+	coseKeyRSAD int64 = -3
+)
+
+// COSE key types
+const (
+	coseKtyOKP int64 = 1
+	coseKtyEC2 int64 = 2
+	coseKtyRSA int64 = 3
+)
+
+// COSE elliptic curves
+var coseCurves = map[int64]elliptic.Curve{
+	1: elliptic.P256(),
+	2: elliptic.P384(),
+	3: elliptic.P521(),
+}
+
+const coseCrvEd25519 int64 = 6
+
+// COSEKeyFromJWK serializes the supplied JWK as a COSE_Key.  EC (P-256, P-384
+// and P-521), OKP (Ed25519) and RSA public keys are supported.  EC and OKP
+// private keys are serialized with their private part.
+func COSEKeyFromJWK(key jwk.Key) ([]byte, error) {
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+
+	var raw interface{}
+	if err := key.Raw(&raw); err != nil {
+		return nil, fmt.Errorf("extracting raw key: %w", err)
+	}
+
+	m := map[int64]interface{}{}
+
+	switch k := raw.(type) {
+	case ed25519.PublicKey:
+		m[coseKeyKty], m[coseKeyCrv], m[coseKeyX] = coseKtyOKP, coseCrvEd25519, []byte(k)
+	case ed25519.PrivateKey:
+		m[coseKeyKty], m[coseKeyCrv] = coseKtyOKP, coseCrvEd25519
+		m[coseKeyX], m[coseKeyD] = []byte(k.Public().(ed25519.PublicKey)), k.Seed()
+	case *ecdsa.PublicKey:
+		if err := setCOSEKeyEC2(m, k, nil); err != nil {
+			return nil, err
+		}
+	case *ecdsa.PrivateKey:
+		if err := setCOSEKeyEC2(m, &k.PublicKey, k.D); err != nil {
+			return nil, err
+		}
+	case *rsa.PublicKey:
+		m[coseKeyKty], m[coseKeyN] = coseKtyRSA, k.N.Bytes()
+		m[coseKeyE] = big.NewInt(int64(k.E)).Bytes()
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", raw)
+	}
+
+	if kid := key.KeyID(); kid != "" {
+		m[coseKeyKid] = []byte(kid)
+	}
+
+	if alg := key.Algorithm(); alg != nil && alg.String() != "" {
+		coseAlg, err := coseAlgorithm(alg)
+		if err != nil {
+			return nil, err
+		}
+		m[coseKeyAlg] = int64(coseAlg)
+	}
+
+	return cborEncMode.Marshal(m)
+}
+
+func setCOSEKeyEC2(m map[int64]interface{}, pub *ecdsa.PublicKey, d *big.Int) error {
+	var crv int64
+
+	for id, c := range coseCurves {
+		if c == pub.Curve {
+			crv = id
+		}
+	}
+
+	if crv == 0 {
+		return fmt.Errorf("unsupported curve %s", pub.Curve.Params().Name)
+	}
+
+	size := (pub.Curve.Params().BitSize + 7) / 8
+
+	m[coseKeyKty], m[coseKeyCrv] = coseKtyEC2, crv
+	m[coseKeyX] = pub.X.FillBytes(make([]byte, size))
+	m[coseKeyY] = pub.Y.FillBytes(make([]byte, size))
+
+	if d != nil {
+		m[coseKeyD] = d.FillBytes(make([]byte, size))
+	}
+
+	return nil
+}
+
+// JWKFromCOSEKey parses the supplied COSE_Key into a JWK.  EC2 (P-256, P-384
+// and P-521), OKP (Ed25519) and RSA public keys are supported.
+func JWKFromCOSEKey(data []byte) (jwk.Key, error) {
+	var m map[int64]interface{}
+	if err := cborDecMode.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decoding COSE_Key: %w", err)
+	}
+
+	var (
+		raw interface{}
+		err error
+	)
+
+	kty, _ := m[coseKeyKty].(int64)
+
+	switch kty {
+	case coseKtyOKP:
+		crv, _ := m[coseKeyCrv].(int64)
+		x, _ := m[coseKeyX].([]byte)
+		d, _ := m[coseKeyD].([]byte)
+		raw, err = okpRawKey(crv, x, d)
+	case coseKtyEC2:
+		crv, _ := m[coseKeyCrv].(int64)
+		x, _ := m[coseKeyX].([]byte)
+		y, _ := m[coseKeyY].([]byte)
+		d, _ := m[coseKeyD].([]byte)
+		raw, err = ec2RawKey(crv, x, y, d)
+	case coseKtyRSA:
+		n, _ := m[coseKeyN].([]byte)
+		e, _ := m[coseKeyE].([]byte)
+		raw, err = rsaRawKey(n, e, m[coseKeyRSAD] != nil)
+	default:
+		err = fmt.Errorf("unsupported kty %v", m[coseKeyKty])
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing COSE_Key: %w", err)
+	}
+
+	key, err := jwk.FromRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if kid, ok := m[coseKeyKid].([]byte); ok {
+		if err := key.Set(jwk.KeyIDKey, string(kid)); err != nil {
+			return nil, err
+		}
+	}
+
+	if a, ok := m[coseKeyAlg].(int64); ok {
+		alg, err := jwsAlgorithm(a)
+		if err != nil {
+			return nil, fmt.Errorf("parsing COSE_Key: %w", err)
+		}
+
+		if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+func okpRawKey(crv int64, x, d []byte) (interface{}, error) {
+	if crv != coseCrvEd25519 {
+		return nil, fmt.Errorf("unsupported OKP curve %d", crv)
+	}
+
+	if d != nil {
+		if len(d) != ed25519.SeedSize {
+			return nil, errors.New("invalid Ed25519 private key size")
+		}
+		return ed25519.NewKeyFromSeed(d), nil
+	}
+
+	if len(x) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid Ed25519 public key size")
+	}
+
+	return ed25519.PublicKey(x), nil
+}
+
+func ec2RawKey(crv int64, x, y, d []byte) (interface{}, error) {
+	curve, ok := coseCurves[crv]
+	if !ok {
+		return nil, fmt.Errorf("unsupported EC2 curve %d", crv)
+	}
+
+	if x == nil || y == nil {
+		return nil, errors.New("missing EC2 coordinates")
+	}
+
+	pub := ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+
+	if !curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("point is not on the curve")
+	}
+
+	if d != nil {
+		return &ecdsa.PrivateKey{PublicKey: pub, D: new(big.Int).SetBytes(d)}, nil
+	}
+
+	return &pub, nil
+}
+
+func rsaRawKey(n, e []byte, private bool) (interface{}, error) {
+	if private {
+		return nil, errors.New("RSA private keys are not supported")
+	}
+
+	if n == nil || e == nil {
+		return nil, errors.New("missing RSA modulus or exponent")
+	}
+
+	exp := new(big.Int).SetBytes(e)
+	if !exp.IsInt64() || exp.Int64() > math.MaxInt32 {
+		return nil, errors.New("RSA exponent too large")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCOSEKey_JWK_round_trip(t *testing.T) {
+	for i, tv := range []string{
+		testECDSAPublicKey,
+		testECDSAPrivateKey,
+		testEd25519PublicKey,
+		testEd25519PrivateKey,
+	} {
+		k, err := jwk.ParseKey([]byte(tv))
+		require.NoError(t, err)
+
+		coseKey, err := COSEKeyFromJWK(k)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		actual, err := JWKFromCOSEKey(coseKey)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		expectedTP, err := k.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+
+		actualTP, err := actual.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedTP, actualTP, "failed test vector at index %d", i)
+	}
+}
+
+func TestCOSEKey_RSA(t *testing.T) {
+	rsaK, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	k, err := jwk.FromRaw(&rsaK.PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, k.Set(jwk.AlgorithmKey, jwa.PS256))
+
+	coseKey, err := COSEKeyFromJWK(k)
+	require.NoError(t, err)
+
+	actual, err := JWKFromCOSEKey(coseKey)
+	require.NoError(t, err)
+	assert.Equal(t, jwa.PS256, actual.Algorithm())
+
+	var raw rsa.PublicKey
+	require.NoError(t, actual.Raw(&raw))
+	assert.True(t, rsaK.PublicKey.Equal(&raw))
+
+	priv, err := jwk.FromRaw(rsaK)
+	require.NoError(t, err)
+
+	_, err = COSEKeyFromJWK(priv)
+	assert.EqualError(t, err, "unsupported key type *rsa.PrivateKey")
+}
+
+func TestCOSEKey_kid_alg(t *testing.T) {
+	k, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+	require.NoError(t, k.Set(jwk.KeyIDKey, "key-1"))
+	require.NoError(t, k.Set(jwk.AlgorithmKey, jwa.EdDSA))
+
+	coseKey, err := COSEKeyFromJWK(k)
+	require.NoError(t, err)
+
+	// {1: 1, 2: h'6B65792D31', 3: -8, -1: 6, -2: h'...'}
+	assert.Equal(t, []byte{0xa5, 0x01, 0x01, 0x02, 0x45, 'k', 'e', 'y', '-', '1', 0x03, 0x27}, coseKey[:12])
+
+	actual, err := JWKFromCOSEKey(coseKey)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", actual.KeyID())
+	assert.Equal(t, jwa.EdDSA, actual.Algorithm())
+}
+
+func TestCOSEKey_fail(t *testing.T) {
+	sym, err := jwk.FromRaw([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = COSEKeyFromJWK(sym)
+	assert.EqualError(t, err, "unsupported key type []uint8")
+
+	_, err = COSEKeyFromJWK(nil)
+	assert.EqualError(t, err, "nil key")
+
+	tvs := []struct {
+		coseKey  []byte
+		expected string
+	}{
+		{
+			// {1: 4}
+			coseKey:  []byte{0xa1, 0x01, 0x04},
+			expected: "parsing COSE_Key: unsupported kty 4",
+		},
+		{
+			// {1: 1, -1: 4}
+			coseKey:  []byte{0xa2, 0x01, 0x01, 0x20, 0x04},
+			expected: "parsing COSE_Key: unsupported OKP curve 4",
+		},
+		{
+			// {1: 2, -1: 1}
+			coseKey:  []byte{0xa2, 0x01, 0x02, 0x20, 0x01},
+			expected: "parsing COSE_Key: missing EC2 coordinates",
+		},
+		{
+			// {1: 1, -1: 6, -2: h'00'}
+			coseKey:  []byte{0xa3, 0x01, 0x01, 0x20, 0x06, 0x21, 0x41, 0x00},
+			expected: "parsing COSE_Key: invalid Ed25519 public key size",
+		},
+		{
+			// {1: 3, -1: h'01', -2: h'03', -3: h'01'}
+			coseKey:  []byte{0xa4, 0x01, 0x03, 0x20, 0x41, 0x01, 0x21, 0x41, 0x03, 0x22, 0x41, 0x01},
+			expected: "parsing COSE_Key: RSA private keys are not supported",
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := JWKFromCOSEKey(tv.coseKey)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
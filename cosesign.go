@@ -0,0 +1,179 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/veraison/go-cose"
+)
+
+// CWTKey pairs a signing or verification key with its algorithm, for use with
+// multi-signed CWTs.  The key can either be a jwk.Key or a crypto key.
+type CWTKey struct {
+	Alg jwa.KeyAlgorithm
+	Key interface{}
+}
+
+// coseSignPrefix is the CBOR encoding of the COSE_Sign tag (98)
+var coseSignPrefix = []byte{0xd8, 0x62}
+
+// isCOSESign reports whether data is a tagged COSE_Sign message
+func isCOSESign(data []byte) bool {
+	return bytes.HasPrefix(data, coseSignPrefix)
+}
+
+// SignCWTMulti is like SignCWT, but the claims-set is wrapped in a COSE_Sign
+// message carrying one signature per supplied key, e.g., so that an EAR can
+// be co-signed by the members of a cluster of verifiers.  Each signature
+// carries its own `alg` and, if known, `kid` protected header parameters.
+// The result is checked using VerifyCWTAny or VerifyCWTAll.
+func (o AttestationResult) SignCWTMulti(keys []CWTKey, opts ...SignOption) ([]byte, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys supplied")
+	}
+
+	cfg := newSignConfig(opts)
+
+	claims, err := o.claimsSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := claimsToCBOR(claims)
+	if err != nil {
+		return nil, fmt.Errorf("encoding claims-set: %w", err)
+	}
+
+	msg := cose.NewSignMessage()
+	msg.Payload = payload
+
+	signers := make([]cose.Signer, 0, len(keys))
+
+	for i, k := range keys {
+		signer, err := newCOSESigner(k.Alg, k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+
+		sig := cose.NewSignature()
+		sig.Headers.Protected.SetAlgorithm(signer.Algorithm())
+
+		if jk, ok := k.Key.(jwk.Key); ok && jk.KeyID() != "" {
+			sig.Headers.Protected[cose.HeaderLabelKeyID] = []byte(jk.KeyID())
+		}
+
+		msg.Signatures = append(msg.Signatures, sig)
+		signers = append(signers, signer)
+	}
+
+	if err := msg.Sign(rand.Reader, nil, signers...); err != nil {
+		return nil, err
+	}
+
+	token, err := msg.MarshalCBOR()
+	if err != nil {
+		return nil, err
+	}
+
+	o.auditSigned(AuditFormatCWT, token, cfg)
+
+	return token, nil
+}
+
+// VerifyCWTAny verifies a COSE_Sign EAR, as produced by SignCWTMulti, and
+// accepts it if at least one of its signatures can be verified using one of
+// the supplied keys.  The payload is then parsed and validated as with
+// VerifyCWT.
+func (o *AttestationResult) VerifyCWTAny(data []byte, keys []CWTKey, opts ...VerifyOption) error {
+	return o.verifyCOSESign(data, keys, false, newVerifyConfig(opts))
+}
+
+// VerifyCWTAll is like VerifyCWTAny, but it accepts the EAR only if each of
+// the supplied keys verifies one of its signatures, i.e., only if all the
+// expected verifiers have co-signed it.  Signatures by other keys are
+// ignored.
+func (o *AttestationResult) VerifyCWTAll(data []byte, keys []CWTKey, opts ...VerifyOption) error {
+	return o.verifyCOSESign(data, keys, true, newVerifyConfig(opts))
+}
+
+func (o *AttestationResult) verifyCOSESign(
+	data []byte,
+	keys []CWTKey,
+	requireAll bool,
+	cfg *verifyConfig,
+) error {
+	if len(keys) == 0 {
+		return errors.New("no verification keys supplied")
+	}
+
+	claims, err := parseCOSESign(data, keys, requireAll, cfg)
+	if err != nil {
+		return err
+	}
+
+	iss, _ := claims["iss"].(string)
+
+	if _, err := o.populateFromClaims(claims, iss, cfg); err != nil {
+		return err
+	}
+
+	o.auditVerified(AuditFormatCWT, data, cfg)
+
+	return nil
+}
+
+func parseCOSESign(
+	data []byte,
+	keys []CWTKey,
+	requireAll bool,
+	cfg *verifyConfig,
+) (map[string]interface{}, error) {
+	var msg cose.SignMessage
+	if err := msg.UnmarshalCBOR(untagCWT(data)); err != nil {
+		return nil, fmt.Errorf("failed parsing CWT message: %w", err)
+	}
+
+	protected, err := msg.Headers.MarshalProtected()
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing CWT message: %w", err)
+	}
+
+	verified := 0
+
+	for i, k := range keys {
+		verifier, err := newCOSEVerifier(k.Alg, k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("verifier %d: %w", i, err)
+		}
+
+		ok := false
+
+		for _, sig := range msg.Signatures {
+			if sig.Verify(verifier, protected, msg.Payload, nil) == nil {
+				ok = true
+				break
+			}
+		}
+
+		if ok {
+			verified++
+		} else if requireAll {
+			return nil, fmt.Errorf(
+				"failed verifying CWT message: no valid signature by key %d (%s)", i, k.Alg)
+		}
+	}
+
+	if verified == 0 {
+		return nil, errors.New("failed verifying CWT message: " +
+			"could not verify message using any of the signatures or keys")
+	}
+
+	return cwtClaims(msg.Payload, cfg)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCWTMultiKeys(t *testing.T) (signers, verifiers []CWTKey) {
+	signers = []CWTKey{
+		{jwa.ES256, mustParseKey(t, testECDSAPrivateKey)},
+		{jwa.EdDSA, mustParseKey(t, testEd25519PrivateKey)},
+	}
+
+	verifiers = []CWTKey{
+		{jwa.ES256, mustParseKey(t, testECDSAPublicKey)},
+		{jwa.EdDSA, mustParseKey(t, testEd25519PublicKey)},
+	}
+
+	return signers, verifiers
+}
+
+func TestSignCWTMulti_round_trip(t *testing.T) {
+	signers, verifiers := testCWTMultiKeys(t)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWTMulti(signers)
+	require.NoError(t, err)
+	assert.True(t, isCOSESign(token))
+
+	var actual AttestationResult
+
+	require.NoError(t, actual.VerifyCWTAll(token, verifiers))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.VerifyCWTAny(token, verifiers))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+	// a single signature is enough for VerifyCWT
+	for i, v := range verifiers {
+		actual = AttestationResult{}
+		require.NoError(t, actual.VerifyCWT(token, v.Alg, v.Key), "failed test vector at index %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual, "failed test vector at index %d", i)
+	}
+
+	ar, f, err := Decode(token)
+	require.NoError(t, err)
+	assert.Equal(t, FormatCWT, f)
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, *ar)
+}
+
+func TestVerifyCWTAll_missing_signer(t *testing.T) {
+	signers, verifiers := testCWTMultiKeys(t)
+
+	// only the first verifier signs
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWTMulti(signers[:1])
+	require.NoError(t, err)
+
+	var actual AttestationResult
+
+	err = actual.VerifyCWTAll(token, verifiers)
+	assert.EqualError(t, err, "failed verifying CWT message: no valid signature by key 1 (EdDSA)")
+
+	assert.NoError(t, actual.VerifyCWTAny(token, verifiers))
+
+	err = actual.VerifyCWTAny(token, verifiers[1:])
+	assert.EqualError(t, err, "failed verifying CWT message: "+
+		"could not verify message using any of the signatures or keys")
+
+	err = actual.VerifyCWTAny(token, nil)
+	assert.EqualError(t, err, "no verification keys supplied")
+}
+
+func TestSignCWTMulti_fail(t *testing.T) {
+	_, err := testAttestationResultsWithVeraisonExtns.SignCWTMulti(nil)
+	assert.EqualError(t, err, "no signing keys supplied")
+
+	_, err = testAttestationResultsWithVeraisonExtns.SignCWTMulti([]CWTKey{
+		{jwa.HS256, []byte("secret")},
+	})
+	assert.ErrorContains(t, err, "signer 0: ")
+}
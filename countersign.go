@@ -0,0 +1,515 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	cose "github.com/veraison/go-cose"
+)
+
+// jwsJSONSignature is a signature in the JWS JSON serialization (RFC 7515
+// §7.2).  Protected headers are kept in their original encoding, so that
+// existing signatures are never invalidated by re-serialization.
+type jwsJSONSignature struct {
+	Protected string                 `json:"protected"`
+	Header    map[string]interface{} `json:"header,omitempty"`
+	Signature string                 `json:"signature"`
+}
+
+// jwsJSON is the general JWS JSON serialization (RFC 7515 §7.2.1)
+type jwsJSON struct {
+	Payload    string             `json:"payload"`
+	Signatures []jwsJSONSignature `json:"signatures"`
+}
+
+// parseJWSAsJSON accepts a JWS in compact, flattened JSON or general JSON
+// serialization and returns its general JSON form
+func parseJWSAsJSON(token []byte) (*jwsJSON, error) {
+	token = bytes.TrimSpace(token)
+
+	if len(token) == 0 {
+		return nil, errors.New("empty token")
+	}
+
+	if token[0] != '{' {
+		parts := bytes.Split(token, []byte{'.'})
+		if len(parts) != 3 {
+			return nil, errors.New("malformed compact JWS: expecting 3 segments")
+		}
+
+		return &jwsJSON{
+			Payload: string(parts[1]),
+			Signatures: []jwsJSONSignature{
+				{Protected: string(parts[0]), Signature: string(parts[2])},
+			},
+		}, nil
+	}
+
+	var flattened struct {
+		jwsJSON
+		jwsJSONSignature
+	}
+
+	if err := json.Unmarshal(token, &flattened.jwsJSON); err != nil {
+		return nil, fmt.Errorf("parsing JWS JSON serialization: %w", err)
+	}
+
+	if len(flattened.Signatures) == 0 {
+		if err := json.Unmarshal(token, &flattened.jwsJSONSignature); err != nil {
+			return nil, fmt.Errorf("parsing JWS JSON serialization: %w", err)
+		}
+
+		if flattened.jwsJSONSignature.Signature == "" {
+			return nil, errors.New("no signatures found")
+		}
+
+		flattened.Signatures = []jwsJSONSignature{flattened.jwsJSONSignature}
+	}
+
+	return &flattened.jwsJSON, nil
+}
+
+// Countersign adds a countersignature made with the supplied algorithm and
+// key to an already-signed EAR, so that, e.g., auditors or brokers can
+// endorse a result without re-issuing it.  The token can be in compact or
+// JSON serialization; the countersigned token is returned in the general JWS
+// JSON serialization, with the original signature first.  Verify still
+// accepts the countersigned token using the key of the original issuer, while
+// countersignatures are checked using VerifyCountersignature.  Note that
+// Verify accepts the token if any of its signatures can be verified with the
+// supplied key, so it must only be given the original issuer's key.  CWTs are
+// countersigned using CountersignCWT.
+func Countersign(token []byte, alg jwa.KeyAlgorithm, key interface{}) ([]byte, error) {
+	msg, err := parseJWSAsJSON(token)
+	if err != nil {
+		return nil, err
+	}
+
+	sign, err := newJWSSignFunc(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := msg.sign(alg, key, sign)
+	if err != nil {
+		return nil, fmt.Errorf("countersigning: %w", err)
+	}
+
+	msg.Signatures = append(msg.Signatures, *sig)
+
+	return json.Marshal(msg)
+}
+
+// VerifyCountersignature checks that the supplied (countersigned) token
+// carries a countersignature that can be verified using the supplied
+// algorithm and key.  The original (i.e., first) signature is not considered:
+// use Verify for that.
+func VerifyCountersignature(token []byte, alg jwa.KeyAlgorithm, key interface{}) error {
+	msg, err := parseJWSAsJSON(token)
+	if err != nil {
+		return err
+	}
+
+	if len(msg.Signatures) < 2 {
+		return errors.New("no countersignatures found")
+	}
+
+	verify, err := newJWSVerifyFunc(alg, key)
+	if err != nil {
+		return err
+	}
+
+	if !msg.verifiedBy(msg.Signatures[1:], alg, verify) {
+		return errors.New("no countersignature could be verified with the supplied key")
+	}
+
+	return nil
+}
+
+// newJWSSignFunc returns a function that signs JWS signing inputs using the
+// supplied algorithm (either natively supported or custom) and key
+func newJWSSignFunc(alg jwa.KeyAlgorithm, key interface{}) (func([]byte) ([]byte, error), error) {
+	if a, ok := lookupCustomAlgorithm(alg); ok {
+		raw, err := rawKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(input []byte) ([]byte, error) {
+			return a.Sign(raw, input)
+		}, nil
+	}
+
+	sigAlg, ok := alg.(jwa.SignatureAlgorithm)
+	if !ok {
+		return nil, fmt.Errorf("expecting a signature algorithm, got %q", alg)
+	}
+
+	signer, err := jws.NewSigner(sigAlg)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s signer: %w", sigAlg, err)
+	}
+
+	return func(input []byte) ([]byte, error) {
+		return signer.Sign(input, key)
+	}, nil
+}
+
+// newJWSVerifyFunc returns a function that verifies signatures over JWS
+// signing inputs using the supplied algorithm (either natively supported or
+// custom) and key
+func newJWSVerifyFunc(alg jwa.KeyAlgorithm, key interface{}) (func(input, sig []byte) error, error) {
+	if a, ok := lookupCustomAlgorithm(alg); ok {
+		raw, err := rawKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(input, sig []byte) error {
+			return a.Verify(raw, input, sig)
+		}, nil
+	}
+
+	sigAlg, ok := alg.(jwa.SignatureAlgorithm)
+	if !ok {
+		return nil, fmt.Errorf("expecting a signature algorithm, got %q", alg)
+	}
+
+	verifier, err := jws.NewVerifier(sigAlg)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s verifier: %w", sigAlg, err)
+	}
+
+	return func(input, sig []byte) error {
+		return verifier.Verify(input, sig, key)
+	}, nil
+}
+
+// sign returns a new signature over the JWS payload, made using the supplied
+// sign function.  The key is only used to populate the "kid" header.
+func (o jwsJSON) sign(
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	sign func([]byte) ([]byte, error),
+) (*jwsJSONSignature, error) {
+	hdr := map[string]interface{}{jws.AlgorithmKey: alg.String()}
+	if k, ok := key.(jwk.Key); ok && k.KeyID() != "" {
+		hdr[jws.KeyIDKey] = k.KeyID()
+	}
+
+	hdrData, err := json.Marshal(hdr)
+	if err != nil {
+		return nil, fmt.Errorf("serializing protected header: %w", err)
+	}
+
+	protected := base64.RawURLEncoding.EncodeToString(hdrData)
+
+	sig, err := sign([]byte(protected + "." + o.Payload))
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwsJSONSignature{
+		Protected: protected,
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	}, nil
+}
+
+// verifiedBy reports whether any of the supplied signatures (which must
+// belong to the JWS) declares the supplied algorithm and can be verified
+func (o jwsJSON) verifiedBy(
+	sigs []jwsJSONSignature,
+	alg jwa.KeyAlgorithm,
+	verify func(input, sig []byte) error,
+) bool {
+	for _, s := range sigs {
+		hdrData, err := base64.RawURLEncoding.DecodeString(s.Protected)
+		if err != nil {
+			continue
+		}
+
+		var hdr map[string]interface{}
+		if err := json.Unmarshal(hdrData, &hdr); err != nil {
+			continue
+		}
+
+		if hdr[jws.AlgorithmKey] != alg.String() {
+			continue
+		}
+
+		sig, err := base64.RawURLEncoding.DecodeString(s.Signature)
+		if err != nil {
+			continue
+		}
+
+		if verify([]byte(s.Protected+"."+o.Payload), sig) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// claims returns the (JSON) claims-set carried in the JWS payload, after
+// checking its validity period against the Clock in cfg
+func (o jwsJSON) claims(cfg *verifyConfig) (map[string]interface{}, error) {
+	claims, err := o.payloadClaims()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if err := checkValidityPeriod(claims, cfg.clock.Now(), cfg.clockSkew); err != nil {
+		return nil, fmt.Errorf("failed verifying JWT message: %w", err)
+	}
+
+	return claims, nil
+}
+
+// payloadClaims decodes the JWS payload as a claims-set, without checking any
+// of the signatures
+func (o jwsJSON) payloadClaims() (map[string]interface{}, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(o.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// coseHeaderLabelCountersignature is the COSE header parameter carrying full
+// countersignatures ("Countersignature version 2", RFC 9338 §3.1)
+const coseHeaderLabelCountersignature int64 = 11
+
+// coseCountersignContext is the context of the Countersign_structure of a
+// full countersignature (RFC 9338 §3.3)
+const coseCountersignContext = "CounterSignatureV2"
+
+// coseSign1 is a COSE_Sign1 message (RFC 9052 §4.2).  The protected header,
+// payload and signature are kept in their original encoding, so that
+// existing signatures are never invalidated by re-serialization.
+type coseSign1 struct {
+	_           struct{} `cbor:",toarray"`
+	Protected   []byte
+	Unprotected map[interface{}]interface{}
+	Payload     []byte
+	Signature   []byte
+}
+
+// coseCountersignature is a COSE_Countersignature (RFC 9338 §3.1)
+type coseCountersignature struct {
+	_           struct{} `cbor:",toarray"`
+	Protected   []byte
+	Unprotected map[interface{}]interface{}
+	Signature   []byte
+}
+
+// parseCOSESign1 decodes a COSE_Sign1 message, which may be tagged in any of
+// the ways accepted by VerifyCWT, and returns it along with its tagging
+func parseCOSESign1(token []byte) (*coseSign1, CBORTagging, error) {
+	tagging := CBORTaggingCOSE
+
+	switch {
+	case bytes.HasPrefix(token, cborTagCWTPrefix):
+		tagging = CBORTaggingCWT
+	case len(token) > 0 && token[0] == cborArray4:
+		tagging = CBORTaggingNone
+	}
+
+	var tag cbor.RawTag
+	if err := cborDecMode.Unmarshal(untagCWT(token), &tag); err != nil {
+		return nil, tagging, fmt.Errorf("parsing COSE_Sign1 message: %w", err)
+	}
+
+	if tag.Number != CBORTagCOSESign1 {
+		return nil, tagging, fmt.Errorf("expecting a COSE_Sign1 message, found tag %d", tag.Number)
+	}
+
+	var msg coseSign1
+	if err := cborDecMode.Unmarshal(tag.Content, &msg); err != nil {
+		return nil, tagging, fmt.Errorf("parsing COSE_Sign1 message: %w", err)
+	}
+
+	if msg.Payload == nil {
+		return nil, tagging, errors.New("COSE_Sign1 message has a detached payload")
+	}
+
+	if msg.Unprotected == nil {
+		msg.Unprotected = map[interface{}]interface{}{}
+	}
+
+	return &msg, tagging, nil
+}
+
+// countersignatures returns the full countersignatures carried in the
+// unprotected header, which can either be a single COSE_Countersignature or
+// an array of them
+func (o coseSign1) countersignatures() ([]coseCountersignature, error) {
+	v, ok := o.Unprotected[coseHeaderLabelCountersignature]
+	if !ok {
+		return nil, nil
+	}
+
+	a, ok := v.([]interface{})
+	if !ok || len(a) == 0 {
+		return nil, errors.New("malformed countersignature header")
+	}
+
+	items := []interface{}{v}
+	if _, ok := a[0].([]interface{}); ok {
+		items = a
+	}
+
+	sigs := make([]coseCountersignature, 0, len(items))
+
+	for i, item := range items {
+		data, err := cborEncMode.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("countersignature %d: %w", i, err)
+		}
+
+		var cs coseCountersignature
+		if err := cborDecMode.Unmarshal(data, &cs); err != nil {
+			return nil, fmt.Errorf("countersignature %d: %w", i, err)
+		}
+
+		sigs = append(sigs, cs)
+	}
+
+	return sigs, nil
+}
+
+// toBeCountersigned returns the Countersign_structure (RFC 9338 §3.3) of a
+// full countersignature with the supplied protected header
+func (o coseSign1) toBeCountersigned(protected []byte) ([]byte, error) {
+	return cborEncMode.Marshal([]interface{}{
+		coseCountersignContext,
+		o.Protected,
+		protected,
+		[]byte{},
+		o.Payload,
+		[]interface{}{o.Signature},
+	})
+}
+
+// CountersignCWT is like Countersign, but for EARs signed using SignCWT: a
+// full countersignature (RFC 9338) made with the supplied algorithm and key
+// is added to the unprotected header of the COSE_Sign1 message, which is
+// otherwise left untouched.  The algorithm is carried in the protected header
+// of the countersignature; if the key has a key ID, it is carried in its
+// unprotected header.  VerifyCWT still accepts the countersigned token using
+// the key of the original issuer, while countersignatures are checked using
+// VerifyCountersignatureCWT.  COSE_Sign messages (see SignCWTMulti) are not
+// supported.
+func CountersignCWT(token []byte, alg jwa.KeyAlgorithm, key interface{}) ([]byte, error) {
+	msg, tagging, err := parseCOSESign1(token)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := msg.countersignatures()
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := newCOSESigner(alg, key)
+	if err != nil {
+		return nil, fmt.Errorf("countersigning: %w", err)
+	}
+
+	cs := coseCountersignature{Unprotected: map[interface{}]interface{}{}}
+
+	cs.Protected, err = cborEncMode.Marshal(map[int64]interface{}{
+		cose.HeaderLabelAlgorithm: int64(signer.Algorithm()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("serializing protected header: %w", err)
+	}
+
+	if k, ok := key.(jwk.Key); ok && k.KeyID() != "" {
+		cs.Unprotected[cose.HeaderLabelKeyID] = []byte(k.KeyID())
+	}
+
+	tbs, err := msg.toBeCountersigned(cs.Protected)
+	if err != nil {
+		return nil, err
+	}
+
+	if cs.Signature, err = signer.Sign(rand.Reader, tbs); err != nil {
+		return nil, fmt.Errorf("countersigning: %w", err)
+	}
+
+	if len(existing) == 0 {
+		msg.Unprotected[coseHeaderLabelCountersignature] = cs
+	} else {
+		msg.Unprotected[coseHeaderLabelCountersignature] = append(existing, cs)
+	}
+
+	data, err := cborEncMode.Marshal(cbor.Tag{Number: CBORTagCOSESign1, Content: msg})
+	if err != nil {
+		return nil, fmt.Errorf("serializing COSE_Sign1 message: %w", err)
+	}
+
+	return applyCBORTagging(data, tagging)
+}
+
+// VerifyCountersignatureCWT is like VerifyCountersignature, but for EARs
+// countersigned using CountersignCWT.  The original signature is not
+// considered: use VerifyCWT for that.
+func VerifyCountersignatureCWT(token []byte, alg jwa.KeyAlgorithm, key interface{}) error {
+	msg, _, err := parseCOSESign1(token)
+	if err != nil {
+		return err
+	}
+
+	sigs, err := msg.countersignatures()
+	if err != nil {
+		return err
+	}
+
+	if len(sigs) == 0 {
+		return errors.New("no countersignatures found")
+	}
+
+	verifier, err := newCOSEVerifier(alg, key)
+	if err != nil {
+		return err
+	}
+
+	for _, cs := range sigs {
+		var hdr map[int64]interface{}
+		if err := cborDecMode.Unmarshal(cs.Protected, &hdr); err != nil {
+			continue
+		}
+
+		if a, ok := hdr[cose.HeaderLabelAlgorithm].(int64); !ok || a != int64(verifier.Algorithm()) {
+			continue
+		}
+
+		tbs, err := msg.toBeCountersigned(cs.Protected)
+		if err != nil {
+			return err
+		}
+
+		if verifier.Verify(tbs, cs.Signature) == nil {
+			return nil
+		}
+	}
+
+	return errors.New("no countersignature could be verified with the supplied key")
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountersign_ok(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	auditorPub, auditorPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	brokerK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	brokerPub, err := jwk.PublicKeyOf(brokerK)
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	_, err = Countersign(token, jwa.ES256, nil)
+	assert.ErrorContains(t, err, "countersigning: ")
+
+	countersigned, err := Countersign(token, jwa.EdDSA, auditorPriv)
+	require.NoError(t, err)
+
+	countersigned, err = Countersign(countersigned, jwa.ES256, brokerK)
+	require.NoError(t, err)
+
+	msg, err := parseJWSAsJSON(countersigned)
+	require.NoError(t, err)
+	assert.Len(t, msg.Signatures, 3)
+
+	// the original signature is still valid
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(countersigned, jwa.ES256, vfyK))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, actual.Submods)
+
+	assert.NoError(t, VerifyCountersignature(countersigned, jwa.EdDSA, auditorPub))
+	assert.NoError(t, VerifyCountersignature(countersigned, jwa.ES256, brokerPub))
+
+	// the original signature is not a countersignature
+	assert.EqualError(t, VerifyCountersignature(countersigned, jwa.ES256, vfyK),
+		"no countersignature could be verified with the supplied key")
+}
+
+func TestVerifyCountersignature_fail(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	assert.EqualError(t, VerifyCountersignature(token, jwa.ES256, sigK),
+		"no countersignatures found")
+	assert.EqualError(t, VerifyCountersignature([]byte("a.b"), jwa.ES256, sigK),
+		"malformed compact JWS: expecting 3 segments")
+	assert.EqualError(t, VerifyCountersignature([]byte(`{"payload": "e30"}`), jwa.ES256, sigK),
+		"no signatures found")
+	assert.EqualError(t, VerifyCountersignature(nil, jwa.ES256, sigK), "empty token")
+}
+
+func TestCountersignCWT_ok(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	auditorPub, auditorPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	brokerK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+	require.NoError(t, brokerK.Set(jwk.KeyIDKey, "broker"))
+
+	brokerPub, err := jwk.PublicKeyOf(brokerK)
+	require.NoError(t, err)
+
+	for _, tagging := range []CBORTagging{CBORTaggingCOSE, CBORTaggingCWT, CBORTaggingNone} {
+		token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK, WithCBORTagging(tagging))
+		require.NoError(t, err)
+
+		countersigned, err := CountersignCWT(token, jwa.EdDSA, auditorPriv)
+		require.NoError(t, err, tagging)
+
+		// a single countersignature is carried as is
+		msg, _, err := parseCOSESign1(countersigned)
+		require.NoError(t, err)
+		assert.IsType(t, []byte{}, msg.Unprotected[coseHeaderLabelCountersignature].([]interface{})[0])
+
+		countersigned, err = CountersignCWT(countersigned, jwa.ES256, brokerK)
+		require.NoError(t, err, tagging)
+
+		msg, actualTagging, err := parseCOSESign1(countersigned)
+		require.NoError(t, err)
+		assert.Equal(t, tagging, actualTagging)
+
+		sigs, err := msg.countersignatures()
+		require.NoError(t, err)
+		require.Len(t, sigs, 2)
+		assert.Equal(t, []byte("broker"), sigs[1].Unprotected[int64(4)])
+
+		// the original signature is still valid
+		var actual AttestationResult
+		require.NoError(t, actual.VerifyCWT(countersigned, jwa.ES256, vfyK), tagging)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, actual.Submods)
+
+		assert.NoError(t, VerifyCountersignatureCWT(countersigned, jwa.EdDSA, auditorPub))
+		assert.NoError(t, VerifyCountersignatureCWT(countersigned, jwa.ES256, brokerPub))
+
+		// the original signature is not a countersignature
+		assert.EqualError(t, VerifyCountersignatureCWT(countersigned, jwa.ES256, vfyK),
+			"no countersignature could be verified with the supplied key")
+	}
+}
+
+func TestVerifyCountersignatureCWT_fail(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	assert.EqualError(t, VerifyCountersignatureCWT(token, jwa.ES256, sigK),
+		"no countersignatures found")
+
+	_, err = CountersignCWT(token, jwa.ES256, nil)
+	assert.ErrorContains(t, err, "countersigning: ")
+
+	notSign1, err := cbor.Marshal(cbor.Tag{Number: 98, Content: []interface{}{}})
+	require.NoError(t, err)
+
+	assert.EqualError(t, VerifyCountersignatureCWT(notSign1, jwa.ES256, sigK),
+		"expecting a COSE_Sign1 message, found tag 98")
+	assert.ErrorContains(t, VerifyCountersignatureCWT([]byte{0xff}, jwa.ES256, sigK),
+		"parsing COSE_Sign1 message: ")
+
+	// tamper with the signed payload
+	countersigned, err := CountersignCWT(token, jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	msg, _, err := parseCOSESign1(countersigned)
+	require.NoError(t, err)
+	msg.Payload = append(msg.Payload, 0x00)
+
+	tampered, err := cbor.Marshal(cbor.Tag{Number: CBORTagCOSESign1, Content: msg})
+	require.NoError(t, err)
+
+	assert.EqualError(t, VerifyCountersignatureCWT(tampered, jwa.ES256, sigK),
+		"no countersignature could be verified with the supplied key")
+}
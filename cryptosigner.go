@@ -0,0 +1,90 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// SignWithCryptoSigner is like Sign, but the JWT is signed using signer,
+// e.g., a key held in a PKCS#11 token, a cloud KMS or a TPM, so that the
+// private key need not be exportable.  The signing algorithm is inferred from
+// the public key: ES256, ES384 or ES512 for ECDSA keys on the P-256, P-384
+// and P-521 curves respectively, EdDSA for Ed25519 keys and PS256 for RSA
+// keys.  (Sign accepts a crypto.Signer too, for picking a different RSA
+// algorithm.)  Unless a key ID is supplied using WithKeyID, the `kid`
+// protected header parameter is set to the base64url-encoded SHA-256 JWK
+// thumbprint (RFC 7638) of the public key.
+func (o AttestationResult) SignWithCryptoSigner(
+	signer crypto.Signer,
+	opts ...SignOption,
+) ([]byte, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+
+	pub := signer.Public()
+
+	alg, err := signerAlgorithm(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := publicKeyThumbprint(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	// a key ID supplied by the caller takes precedence
+	opts = append([]SignOption{WithKeyID(kid)}, opts...)
+
+	return o.Sign(alg, signer, opts...)
+}
+
+// signerAlgorithm returns the JWS algorithm to be used with the public key
+// pub
+func signerAlgorithm(pub crypto.PublicKey) (jwa.SignatureAlgorithm, error) {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		crv := jwa.EllipticCurveAlgorithm(k.Curve.Params().Name)
+
+		alg, ok := ecdsaCurveAlgorithms[crv]
+		if !ok {
+			return "", fmt.Errorf("unsupported curve %s", crv)
+		}
+
+		return alg, nil
+	case ed25519.PublicKey:
+		return jwa.EdDSA, nil
+	case *rsa.PublicKey:
+		return jwa.PS256, nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// publicKeyThumbprint returns the base64url-encoded SHA-256 JWK thumbprint of
+// pub
+func publicKeyThumbprint(pub crypto.PublicKey) (string, error) {
+	k, err := jwk.FromRaw(pub)
+	if err != nil {
+		return "", fmt.Errorf("converting public key to JWK: %w", err)
+	}
+
+	tp, err := k.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("computing key thumbprint: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(tp), nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// opaqueSigner hides the private key behind the crypto.Signer interface, as
+// an HSM or KMS would
+type opaqueSigner struct {
+	signer crypto.Signer
+}
+
+func (o opaqueSigner) Public() crypto.PublicKey { return o.signer.Public() }
+
+func (o opaqueSigner) Sign(r io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return o.signer.Sign(r, digest, opts)
+}
+
+func TestSignWithCryptoSigner(t *testing.T) {
+	ecK, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edK, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	rsaK, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		signer crypto.Signer
+		alg    jwa.SignatureAlgorithm
+	}{
+		{"ecdsa", ecK, jwa.ES384},
+		{"ed25519", edK, jwa.EdDSA},
+		{"rsa", rsaK, jwa.PS256},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			signer := opaqueSigner{tc.signer}
+
+			token, err := testAttestationResultsWithVeraisonExtns.SignWithCryptoSigner(signer)
+			require.NoError(t, err)
+
+			msg, err := jws.Parse(token)
+			require.NoError(t, err)
+
+			hdrs := msg.Signatures()[0].ProtectedHeaders()
+			assert.Equal(t, tc.alg, hdrs.Algorithm())
+
+			kid, err := publicKeyThumbprint(signer.Public())
+			require.NoError(t, err)
+			assert.Equal(t, kid, hdrs.KeyID())
+
+			var actual AttestationResult
+			require.NoError(t, actual.Verify(token, tc.alg, signer.Public()))
+			assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, actual.Submods)
+		})
+	}
+}
+
+func TestSignWithCryptoSigner_key_id(t *testing.T) {
+	ecK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignWithCryptoSigner(
+		opaqueSigner{ecK}, WithKeyID("hsm-slot-1"),
+	)
+	require.NoError(t, err)
+
+	msg, err := jws.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, "hsm-slot-1", msg.Signatures()[0].ProtectedHeaders().KeyID())
+}
+
+func TestSignWithCryptoSigner_fail(t *testing.T) {
+	_, err := testAttestationResultsWithVeraisonExtns.SignWithCryptoSigner(nil)
+	assert.EqualError(t, err, "nil signer")
+
+	ecK, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = testAttestationResultsWithVeraisonExtns.SignWithCryptoSigner(ecK)
+	assert.EqualError(t, err, "unsupported curve P-224")
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	cose "github.com/veraison/go-cose"
+)
+
+// coseAlgorithms maps the JWS algorithms that can be used to sign CWTs onto
+// the corresponding COSE algorithms
+var coseAlgorithms = map[jwa.SignatureAlgorithm]cose.Algorithm{
+	jwa.ES256: cose.AlgorithmES256,
+	jwa.ES384: cose.AlgorithmES384,
+	jwa.ES512: cose.AlgorithmES512,
+	jwa.PS256: cose.AlgorithmPS256,
+	jwa.PS384: cose.AlgorithmPS384,
+	jwa.PS512: cose.AlgorithmPS512,
+	jwa.EdDSA: cose.AlgorithmEd25519,
+}
+
+func coseAlgorithm(alg jwa.KeyAlgorithm) (cose.Algorithm, error) {
+	if a, ok := coseAlgorithms[jwa.SignatureAlgorithm(alg.String())]; ok {
+		return a, nil
+	}
+
+	if a, ok := lookupCustomAlgorithm(alg); ok {
+		return a.COSEAlgorithm(), nil
+	}
+
+	return 0, fmt.Errorf("unsupported CWT signing algorithm %q", alg)
+}
+
+func jwsAlgorithm(alg int64) (jwa.SignatureAlgorithm, error) {
+	for k, v := range coseAlgorithms {
+		if int64(v) == alg {
+			return k, nil
+		}
+	}
+
+	if a, ok := lookupCustomCOSEAlgorithm(alg); ok {
+		return a.JWSAlgorithm(), nil
+	}
+
+	return "", fmt.Errorf("unsupported COSE algorithm %d", alg)
+}
+
+// rawKey returns the raw key wrapped by a jwk.Key, or key itself otherwise
+func rawKey(key interface{}) (interface{}, error) {
+	k, ok := key.(jwk.Key)
+	if !ok {
+		return key, nil
+	}
+
+	var raw interface{}
+	if err := k.Raw(&raw); err != nil {
+		return nil, fmt.Errorf("extracting raw key: %w", err)
+	}
+
+	return raw, nil
+}
+
+// checkCurve makes sure that ECDSA keys are only used with the algorithm
+// matching their curve
+func checkCurve(pub crypto.PublicKey, alg jwa.KeyAlgorithm) error {
+	k, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil
+	}
+
+	crv := jwa.EllipticCurveAlgorithm(k.Curve.Params().Name)
+	if ecdsaCurveAlgorithms[crv].String() != alg.String() {
+		return fmt.Errorf("%s key cannot be used with %s", crv, alg)
+	}
+
+	return nil
+}
+
+func newCOSESigner(alg jwa.KeyAlgorithm, key interface{}) (cose.Signer, error) {
+	raw, err := rawKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if a, ok := lookupCustomAlgorithm(alg); ok {
+		return coseCustomSigner{alg: a, key: raw}, nil
+	}
+
+	coseAlg, err := coseAlgorithm(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, ok := raw.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a signing key", raw)
+	}
+
+	if err := checkCurve(priv.Public(), alg); err != nil {
+		return nil, err
+	}
+
+	signer, err := cose.NewSigner(coseAlg, priv)
+	if err != nil {
+		return nil, fmt.Errorf("creating COSE signer: %w", err)
+	}
+
+	return signer, nil
+}
+
+func newCOSEVerifier(alg jwa.KeyAlgorithm, key interface{}) (cose.Verifier, error) {
+	raw, err := rawKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if a, ok := lookupCustomAlgorithm(alg); ok {
+		return coseCustomVerifier{alg: a, key: raw}, nil
+	}
+
+	coseAlg, err := coseAlgorithm(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	if priv, ok := raw.(crypto.Signer); ok {
+		raw = priv.Public()
+	}
+
+	if err := checkCurve(raw, alg); err != nil {
+		return nil, err
+	}
+
+	verifier, err := cose.NewVerifier(coseAlg, raw)
+	if err != nil {
+		return nil, fmt.Errorf("creating COSE verifier: %w", err)
+	}
+
+	return verifier, nil
+}
+
+// SignCWT is like Sign, but the AttestationResult is serialized to CBOR (see
+// MarshalCBOR) and wrapped in a CWT, i.e., a tagged COSE_Sign1 message (see
+// WithCBORTagging for the alternatives).  The same algorithm identifiers are
+// used for both serializations: ES256, ES384, ES512, PS256, PS384, PS512 and
+// EdDSA (Ed25519) are supported.  The key can
+// either be a jwk.Key or a crypto.Signer, and must be suitable for the
+// algorithm (e.g., an ECDSA key on the matching curve, or an RSA key of at
+// least 2048 bits for PS*).  The COSE algorithm is carried in the protected
+// `alg` header; if the key has a key ID, it is carried in the protected `kid`
+// header.  Algorithms plugged in using RegisterAlgorithm can be used as well.
+func (o AttestationResult) SignCWT(
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...SignOption,
+) ([]byte, error) {
+	signer, err := newCOSESigner(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := newSignConfig(opts)
+
+	claims, err := o.claimsSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := claimsToCBOR(claims)
+	if err != nil {
+		return nil, fmt.Errorf("encoding claims-set: %w", err)
+	}
+
+	token, err := signCWTPayload(payload, signer, key, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	o.auditSigned(AuditFormatCWT, token, cfg)
+
+	return token, nil
+}
+
+// signCWTPayload wraps the (CBOR-encoded) claims-set payload in a tagged
+// COSE_Sign1 message, signed using signer.  key is the key signer has been
+// created from, whose key ID (if any) is carried in the protected header,
+// unless a different one is requested in cfg.
+func signCWTPayload(
+	payload []byte,
+	signer cose.Signer,
+	key interface{},
+	cfg *signConfig,
+) ([]byte, error) {
+	headers := cose.Headers{
+		Protected: cose.ProtectedHeader{
+			cose.HeaderLabelAlgorithm: signer.Algorithm(),
+		},
+	}
+
+	if cfg.keyID != "" {
+		headers.Protected[cose.HeaderLabelKeyID] = []byte(cfg.keyID)
+	} else if k, ok := key.(jwk.Key); ok && k.KeyID() != "" {
+		headers.Protected[cose.HeaderLabelKeyID] = []byte(k.KeyID())
+	}
+
+	if len(cfg.certChain) > 0 {
+		headers.Protected[cose.HeaderLabelX5Chain] = coseX5Chain(cfg.certChain)
+	}
+
+	token, err := cose.Sign1(rand.Reader, signer, headers, payload, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyCBORTagging(token, cfg.cborTagging)
+}
+
+// VerifyCWT is like Verify, but for EARs signed using SignCWT.  The key can
+// either be a jwk.Key or a crypto.PublicKey.  COSE_Sign EARs (see
+// SignCWTMulti) are accepted too, if one of their signatures is verified by
+// key.
+func (o *AttestationResult) VerifyCWT(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) error {
+	cfg := newVerifyConfig(opts)
+
+	claims, err := parseCWT(data, alg, key, cfg)
+	if err != nil {
+		return err
+	}
+
+	iss, _ := claims["iss"].(string)
+
+	if _, err := o.populateFromClaims(claims, iss, cfg); err != nil {
+		return err
+	}
+
+	o.auditVerified(AuditFormatCWT, data, cfg)
+
+	return nil
+}
+
+func parseCWT(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	cfg *verifyConfig,
+) (map[string]interface{}, error) {
+	data = untagCWT(data)
+
+	if isCOSESign(data) {
+		return parseCOSESign(data, []CWTKey{{Alg: alg, Key: key}}, false, cfg)
+	}
+
+	verifier, err := newCOSEVerifier(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCWTWithVerifier(data, verifier, cfg)
+}
+
+func parseCWTWithVerifier(
+	data []byte,
+	verifier cose.Verifier,
+	cfg *verifyConfig,
+) (map[string]interface{}, error) {
+	var msg cose.Sign1Message
+	if err := msg.UnmarshalCBOR(untagCWT(data)); err != nil {
+		return nil, fmt.Errorf("failed parsing CWT message: %w", err)
+	}
+
+	if err := msg.Verify(nil, verifier); err != nil {
+		return nil, fmt.Errorf("failed verifying CWT message: %w", err)
+	}
+
+	return cwtClaims(msg.Payload, cfg)
+}
+
+// cwtClaims decodes the (verified) payload of a CWT and checks its validity
+// period
+func cwtClaims(payload []byte, cfg *verifyConfig) (map[string]interface{}, error) {
+	claims, err := claimsFromCBOR(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding claims-set: %w", err)
+	}
+
+	if err := checkValidityPeriod(claims, cfg.clock.Now(), cfg.clockSkew); err != nil {
+		return nil, fmt.Errorf("failed verifying CWT message: %w", err)
+	}
+
+	return claims, nil
+}
+
+// checkValidityPeriod checks the `exp`, `nbf` and `iat` claims (if present)
+// against time t, tolerating the supplied clock skew, the same way jwx does
+// for JWTs: a result must not be expired, nor be used before its `nbf`, nor
+// have been issued in the future.
+func checkValidityPeriod(claims map[string]interface{}, t time.Time, skew time.Duration) error {
+	for _, name := range []string{"exp", "nbf", "iat"} {
+		v, ok := claims[name]
+		if !ok {
+			continue
+		}
+
+		i, err := int64Parser(v)
+		if err != nil {
+			return fmt.Errorf("%q: %w", name, err)
+		}
+
+		bound := time.Unix(i.(int64), 0)
+
+		var satisfied bool
+
+		switch name {
+		case "exp":
+			satisfied = t.Before(bound.Add(skew))
+		case "nbf", "iat":
+			satisfied = !t.Before(bound.Add(-skew))
+		}
+
+		if !satisfied {
+			return fmt.Errorf("%q not satisfied", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+// Ed25519 signatures and deterministically-encoded CBOR make the CWT
+// reproducible
+var testEdDSACWT = `d28443a10127a058aca4061a634e896d19010978207461673a6769746875622e636f6d2c323032333a7665726169736f6e2f65617219010aa16474657374a51903e8021903eb73706f6c6963793a2f2f746573742f30313233343a0001116fa2626b31627631626b326276323a00011170a2636261726362617a63666f6f636261723a00011171a165616b70756268595774776457494b1903eca2006d7272747261702d76312e302e30016941636d6520496e632e584079c04b0e048bbd6aa5672ebe520c04b6d02b88e0bfd29d5b2047b3eea220e720078d34489e14dd2c2cbfb59619cdf24c12d1a515e57181023a0c7f19a3693009`
+
+// testEdDSALegacyCWT is the same as testEdDSACWT, as encoded by releases that
+// predate the CBOR keys of the Veraison extensions
+var testEdDSALegacyCWT = `d28443a10127a058f8a4061a634e896d19010978207461673a6769746875622e636f6d2c323032333a7665726169736f6e2f65617219010aa16474657374a51903e8021903eb73706f6c6963793a2f2f746573742f3031323334781a6561722e7665726169736f6e2e706f6c6963792d636c61696d73a2636261726362617a63666f6f63626172781c6561722e7665726169736f6e2e6b65792d6174746573746174696f6ea165616b70756268595774776457494b781f6561722e7665726169736f6e2e616e6e6f74617465642d65766964656e6365a2626b31627631626b326276321903eca2006d7272747261702d76312e302e30016941636d6520496e632e5840ef25a9496bb468fc2ceb5df52fb3dc338cdee895b05dbb9749d119c620a9197967ee68dad3c180fefd60f507c44c89860ea39aa4a8d672c65d354f902bbc2a0c`
+
+func TestCWT_round_trip(t *testing.T) {
+	tvs := []struct {
+		alg    jwa.SignatureAlgorithm
+		sigKey string
+		vfyKey string
+	}{
+		{jwa.ES256, testECDSAPrivateKey, testECDSAPublicKey},
+		{jwa.EdDSA, testEd25519PrivateKey, testEd25519PublicKey},
+	}
+
+	for i, tv := range tvs {
+		sigK, err := jwk.ParseKey([]byte(tv.sigKey))
+		require.NoError(t, err)
+
+		vfyK, err := jwk.ParseKey([]byte(tv.vfyKey))
+		require.NoError(t, err)
+
+		token, err := testAttestationResultsWithVeraisonExtns.SignCWT(tv.alg, sigK)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		var actual AttestationResult
+		err = actual.VerifyCWT(token, tv.alg, vfyK)
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+		// raw keys can be used too
+		var raw interface{}
+		require.NoError(t, vfyK.Raw(&raw))
+
+		err = actual.VerifyCWT(token, tv.alg, raw)
+		assert.NoError(t, err, "failed test vector at index %d", i)
+	}
+}
+
+func TestCWT_algorithms(t *testing.T) {
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	p521, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+
+	rsaK, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tvs := []struct {
+		alg     jwa.SignatureAlgorithm
+		coseAlg cose.Algorithm
+		key     crypto.Signer
+	}{
+		{jwa.ES384, cose.AlgorithmES384, p384},
+		{jwa.ES512, cose.AlgorithmES512, p521},
+		{jwa.PS256, cose.AlgorithmPS256, rsaK},
+		{jwa.PS384, cose.AlgorithmPS384, rsaK},
+		{jwa.PS512, cose.AlgorithmPS512, rsaK},
+	}
+
+	for i, tv := range tvs {
+		token, err := testAttestationResultsWithVeraisonExtns.SignCWT(tv.alg, tv.key)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		var msg cose.Sign1Message
+		require.NoError(t, msg.UnmarshalCBOR(token))
+
+		alg, err := msg.Headers.Protected.Algorithm()
+		require.NoError(t, err)
+		assert.Equal(t, tv.coseAlg, alg, "failed test vector at index %d", i)
+
+		var actual AttestationResult
+		err = actual.VerifyCWT(token, tv.alg, tv.key.Public())
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+	}
+
+	// the key must match the algorithm
+	_, err = testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, p384)
+	assert.EqualError(t, err, "P-384 key cannot be used with ES256")
+
+	_, err = testAttestationResultsWithVeraisonExtns.SignCWT(jwa.PS256, p384)
+	assert.EqualError(t, err, "P-384 key cannot be used with PS256")
+
+	_, err = testAttestationResultsWithVeraisonExtns.SignCWT(jwa.EdDSA, rsaK)
+	assert.ErrorContains(t, err, "creating COSE signer: ")
+}
+
+func TestCWT_EdDSA_test_vector(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.EdDSA, sigK)
+	require.NoError(t, err)
+	assert.Equal(t, testEdDSACWT, hex.EncodeToString(token))
+
+	expected, err := hex.DecodeString(testEdDSACWT)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifyCWT(expected, jwa.EdDSA, vfyK))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+	legacy, err := hex.DecodeString(testEdDSALegacyCWT)
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.VerifyCWT(legacy, jwa.EdDSA, vfyK))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+}
+
+func TestCWT_claims(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	now := time.Unix(testIAT, 0)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.EdDSA, sigK,
+		WithClock(FixedClock(now)),
+		WithIssuedAtNow(),
+		WithTTL(time.Hour),
+		WithTokenID(),
+		WithIssuerFromVerifierID(),
+	)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+
+	err = actual.VerifyCWT(token, jwa.EdDSA, sigK,
+		WithClock(FixedClock(now.Add(time.Minute))),
+		WithIssuerVerifierIDCheck(),
+	)
+	require.NoError(t, err)
+
+	err = actual.VerifyCWT(token, jwa.EdDSA, sigK, WithClock(FixedClock(now.Add(time.Hour))))
+	assert.EqualError(t, err, `failed verifying CWT message: "exp" not satisfied`)
+}
+
+func TestCWT_registered_claim_keys(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	now := time.Unix(testIAT, 0)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.EdDSA, sigK,
+		WithClock(FixedClock(now)),
+		WithTTL(time.Hour),
+		WithNotBefore(0),
+		WithTokenID(),
+		WithIssuerFromVerifierID(),
+	)
+	require.NoError(t, err)
+
+	var msg cose.Sign1Message
+	require.NoError(t, msg.UnmarshalCBOR(token))
+
+	var payload map[int64]interface{}
+	require.NoError(t, cborDecMode.Unmarshal(msg.Payload, &payload))
+
+	assert.IsType(t, "", payload[1])          // iss
+	assert.Equal(t, testIAT+3600, payload[4]) // exp
+	assert.Equal(t, testIAT, payload[5])      // nbf
+	assert.Equal(t, testIAT, payload[6])      // iat
+	assert.IsType(t, []byte{}, payload[7])    // cti
+	assert.Len(t, payload[7], 16)
+
+	data, err := claimsToCBOR(map[string]interface{}{"sub": "attester", "aud": "rp"})
+	require.NoError(t, err)
+
+	payload = nil
+	require.NoError(t, cborDecMode.Unmarshal(data, &payload))
+	assert.Equal(t, map[int64]interface{}{2: "attester", 3: "rp"}, payload)
+
+	claims, err := claimsFromCBOR(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"sub": "attester", "aud": "rp"}, claims)
+}
+
+func TestCWT_issued_in_the_future(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	now := time.Unix(testIAT, 0)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.EdDSA, sigK)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+
+	err = actual.VerifyCWT(token, jwa.EdDSA, sigK, WithClock(FixedClock(now.Add(-time.Minute))))
+	assert.EqualError(t, err, `failed verifying CWT message: "iat" not satisfied`)
+
+	assert.NoError(t, actual.VerifyCWT(token, jwa.EdDSA, sigK,
+		WithClock(FixedClock(now.Add(-time.Minute))),
+		WithClockSkew(time.Minute),
+	))
+}
+
+func TestCWT_fail(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	ecK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	_, err = testAttestationResultsWithVeraisonExtns.SignCWT(jwa.HS256, sigK)
+	assert.EqualError(t, err, `unsupported CWT signing algorithm "HS256"`)
+
+	_, err = testAttestationResultsWithVeraisonExtns.SignCWT(jwa.EdDSA, []byte("secret"))
+	assert.EqualError(t, err, "[]uint8 is not a signing key")
+
+	var ar AttestationResult
+	_, err = ar.SignCWT(jwa.EdDSA, sigK)
+	assert.EqualError(t, err, `missing mandatory 'eat_profile', 'iat', 'verifier-id', 'submods' (at least one appraisal must be present)`)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.EdDSA, sigK)
+	require.NoError(t, err)
+
+	err = ar.VerifyCWT(token, jwa.EdDSA, ecK)
+	assert.EqualError(t, err, "P-256 key cannot be used with EdDSA")
+
+	err = ar.VerifyCWT(token, jwa.ES256, ecK)
+	assert.EqualError(t, err, "failed verifying CWT message: algorithm mismatch: verifier ES256: header EdDSA")
+
+	token[len(token)-1] ^= 1
+
+	err = ar.VerifyCWT(token, jwa.EdDSA, sigK)
+	assert.EqualError(t, err, "failed verifying CWT message: verification error")
+
+	err = ar.VerifyCWT([]byte{0xa0}, jwa.EdDSA, sigK)
+	assert.ErrorContains(t, err, "failed parsing CWT message: ")
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"runtime"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// VerifyCWTBatch is like VerifyCWT, but verifies many tokens signed with the
+// same algorithm and key.  The COSE verifier is constructed once and shared,
+// and the tokens are verified concurrently, using up to GOMAXPROCS workers.
+// The returned slices have the same length as tokens: for each token, either
+// the decoded attestation result or the verification error is set.  An error
+// in setting up the verifier (e.g., an unsupported algorithm) is reported for
+// every token.
+func VerifyCWTBatch(
+	tokens [][]byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) ([]*AttestationResult, []error) {
+	results := make([]*AttestationResult, len(tokens))
+	errs := make([]error, len(tokens))
+
+	verifier, err := newCOSEVerifier(alg, key)
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return results, errs
+	}
+
+	runBatch(len(tokens), runtime.GOMAXPROCS(0), func(i int) {
+		// each token gets a config of its own, so that no state is shared
+		// between workers
+		cfg := newVerifyConfig(opts)
+
+		claims, err := parseCWTWithVerifier(tokens[i], verifier, cfg)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+
+		iss, _ := claims["iss"].(string)
+
+		var ar AttestationResult
+		if _, err := ar.populateFromClaims(claims, iss, cfg); err != nil {
+			errs[i] = err
+			return
+		}
+
+		ar.auditVerified(AuditFormatCWT, tokens[i], cfg)
+
+		results[i] = &ar
+	})
+
+	return results, errs
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCWTBatch(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	var tokens [][]byte
+
+	for i := 0; i < 20; i++ {
+		ar := testAttestationResultsWithVeraisonExtns
+		iat := testIAT + int64(i)
+		ar.IssuedAt = &iat
+
+		token, err := ar.SignCWT(jwa.ES256, sigK)
+		require.NoError(t, err)
+
+		tokens = append(tokens, token)
+	}
+
+	// tamper with one token, and let another one expire
+	tokens[3] = append([]byte(nil), tokens[3]...)
+	tokens[3][len(tokens[3])-1] ^= 0xff
+
+	expiring, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK, WithTTL(time.Second))
+	require.NoError(t, err)
+	tokens[7] = expiring
+
+	results, errs := VerifyCWTBatch(tokens, jwa.ES256, vfyK,
+		WithClock(FixedClock(time.Unix(testIAT, 0).Add(time.Hour))))
+	require.Len(t, results, len(tokens))
+	require.Len(t, errs, len(tokens))
+
+	for i := range tokens {
+		switch i {
+		case 3:
+			assert.ErrorContains(t, errs[i], "failed verifying CWT message: ")
+			assert.Nil(t, results[i])
+		case 7:
+			assert.EqualError(t, errs[i], `failed verifying CWT message: "exp" not satisfied`)
+			assert.Nil(t, results[i])
+		default:
+			require.NoError(t, errs[i], "token %d", i)
+			assert.Equal(t, testIAT+int64(i), *results[i].IssuedAt)
+		}
+	}
+}
+
+func TestVerifyCWTBatch_concurrent(t *testing.T) {
+	// make sure that the tokens are verified in parallel, so that any state
+	// shared by the workers shows up when running with -race
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	tokens := make([][]byte, 64)
+	for i := range tokens {
+		tokens[i] = token
+	}
+
+	results, errs := VerifyCWTBatch(tokens, jwa.ES256, vfyK,
+		WithClock(FixedClock(time.Unix(testIAT, 0))), WithStrictDecoding())
+
+	for i := range tokens {
+		require.NoError(t, errs[i], "token %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, *results[i])
+	}
+}
+
+func TestVerifyCWTBatch_bad_key(t *testing.T) {
+	tokens := [][]byte{{0x00}, {0x01}}
+
+	results, errs := VerifyCWTBatch(tokens, jwa.HS256, mustParseKey(t, testECDSAPublicKey))
+	assert.Equal(t, []*AttestationResult{nil, nil}, results)
+	require.Len(t, errs, 2)
+	assert.Error(t, errs[0])
+	assert.Equal(t, errs[0], errs[1])
+
+	results, errs = VerifyCWTBatch(nil, jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	assert.Empty(t, results)
+	assert.Empty(t, errs)
+}
+
+func BenchmarkVerifyCWTBatch(b *testing.B) {
+	sigK := mustParseKey(b, testECDSAPrivateKey)
+	vfyK := mustParseKey(b, testECDSAPublicKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	tokens := make([][]byte, 100)
+	for i := range tokens {
+		tokens[i] = token
+	}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		VerifyCWTBatch(tokens, jwa.ES256, vfyK)
+	}
+}
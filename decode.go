@@ -0,0 +1,228 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/veraison/go-cose"
+)
+
+// Format is the serialization of an EAR, as detected by Decode
+type Format int
+
+const (
+	// FormatJWT is a compact JWS (see Sign)
+	FormatJWT Format = iota
+	// FormatCWT is a COSE_Sign1 message, either tagged or untagged (see
+	// SignCWT), or a tagged COSE_Sign message (see SignCWTMulti), possibly
+	// wrapped in a CWT tag (61)
+	FormatCWT
+	// FormatJSON is a bare JSON claims-set (see MarshalJSON)
+	FormatJSON
+	// FormatCBOR is a bare CBOR claims-set (see MarshalCBOR), possibly
+	// wrapped in a UCCS tag (601)
+	FormatCBOR
+)
+
+func (o Format) String() string {
+	switch o {
+	case FormatJWT:
+		return "JWT"
+	case FormatCWT:
+		return "CWT"
+	case FormatJSON:
+		return "JSON"
+	case FormatCBOR:
+		return "CBOR"
+	default:
+		return fmt.Sprintf("Format(%d)", o)
+	}
+}
+
+// decodeConfig collects the settings that can be tweaked via DecodeOption
+type decodeConfig struct {
+	alg              jwa.KeyAlgorithm
+	key              interface{}
+	verifyOpts       []VerifyOption
+	requireSignature bool
+}
+
+// DecodeOption configures the behaviour of Decode
+type DecodeOption interface {
+	applyDecodeOption(*decodeConfig)
+}
+
+type decodeOptionFunc func(*decodeConfig)
+
+func (f decodeOptionFunc) applyDecodeOption(c *decodeConfig) { f(c) }
+
+// WithVerificationKey makes Decode verify signed EARs (JWT or CWT) using the
+// supplied algorithm and key, and reject bare claims-sets
+func WithVerificationKey(alg jwa.KeyAlgorithm, key interface{}) DecodeOption {
+	return decodeOptionFunc(func(c *decodeConfig) {
+		c.alg, c.key = alg, key
+	})
+}
+
+// WithDecodeVerifyOptions passes the supplied options to the verification of
+// signed EARs (see WithVerificationKey)
+func WithDecodeVerifyOptions(opts ...VerifyOption) DecodeOption {
+	return decodeOptionFunc(func(c *decodeConfig) {
+		c.verifyOpts = append(c.verifyOpts, opts...)
+	})
+}
+
+// WithSignatureRequired makes Decode fail unless the input is a signed EAR
+// that has been verified, i.e., unless WithVerificationKey is supplied too
+func WithSignatureRequired() DecodeOption {
+	return decodeOptionFunc(func(c *decodeConfig) {
+		c.requireSignature = true
+	})
+}
+
+// Decode detects whether data is a compact JWT, a CWT (COSE_Sign1), a bare
+// JSON claims-set or a bare CBOR claims-set, and decodes it accordingly.  If
+// a key is supplied using WithVerificationKey, JWTs and CWTs are verified
+// (exactly as Verify and VerifyCWT do) and bare claims-sets are rejected.
+// Otherwise, the signature of JWTs and CWTs is NOT verified, unless
+// WithSignatureRequired is supplied, in which case they are rejected too.
+// In all cases, the decoded claims-set is validated.  On success, the result
+// is returned together with the detected format.
+func Decode(data []byte, opts ...DecodeOption) (*AttestationResult, Format, error) {
+	cfg := &decodeConfig{}
+	for _, opt := range opts {
+		opt.applyDecodeOption(cfg)
+	}
+
+	f, err := detectFormat(data)
+	if err != nil {
+		return nil, f, err
+	}
+
+	verify := cfg.key != nil
+
+	if cfg.requireSignature && !verify {
+		return nil, f, errors.New("signature required, but no verification key supplied")
+	}
+
+	if verify && (f == FormatJSON || f == FormatCBOR) {
+		return nil, f, fmt.Errorf("bare %s claims-set found, expecting a signed EAR", f)
+	}
+
+	if f == FormatJWT || f == FormatJSON {
+		data = bytes.TrimSpace(data)
+	}
+
+	var ar AttestationResult
+
+	switch f {
+	case FormatJWT:
+		if verify {
+			err = ar.Verify(data, cfg.alg, cfg.key, cfg.verifyOpts...)
+		} else {
+			err = decodeUnverifiedJWT(&ar, data)
+		}
+	case FormatCWT:
+		data = untagCWT(data)
+
+		if verify {
+			err = ar.VerifyCWT(data, cfg.alg, cfg.key, cfg.verifyOpts...)
+		} else {
+			err = decodeUnverifiedCWT(&ar, data)
+		}
+	case FormatJSON:
+		err = ar.UnmarshalJSON(data)
+	case FormatCBOR:
+		err = ar.UnmarshalCBOR(data)
+	}
+
+	if err != nil {
+		return nil, f, fmt.Errorf("decoding %s: %w", f, err)
+	}
+
+	return &ar, f, nil
+}
+
+const (
+	// cborTagSign1 is the CBOR initial byte of a tagged COSE_Sign1 message
+	cborTagSign1 = 0xd2
+	// cborArray4 is the CBOR initial byte of an array of 4 items, i.e., an
+	// untagged COSE_Sign1 message
+	cborArray4 = 0x84
+)
+
+func detectFormat(data []byte) (Format, error) {
+	text := bytes.TrimSpace(data)
+
+	switch {
+	case len(text) == 0:
+		return FormatJWT, errors.New("empty input")
+	case text[0] == '{':
+		return FormatJSON, nil
+	case isCompactJWS(text):
+		return FormatJWT, nil
+	}
+
+	switch b := data[0]; {
+	case b == cborTagSign1, b == cborArray4, isCOSESign(data),
+		bytes.HasPrefix(data, cborTagCWTPrefix):
+		return FormatCWT, nil
+	case b >= 0xa0 && b <= 0xbf, bytes.HasPrefix(data, cborTagUCCSPrefix):
+		// CBOR map (major type 5)
+		return FormatCBOR, nil
+	}
+
+	return FormatJWT, errors.New("unrecognized EAR format")
+}
+
+// isCompactJWS reports whether data looks like three base64url-encoded parts
+// separated by dots
+func isCompactJWS(data []byte) bool {
+	if bytes.Count(data, []byte(".")) != 2 {
+		return false
+	}
+
+	for _, c := range data {
+		switch {
+		case c == '.', c == '-', c == '_':
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodeUnverifiedJWT(ar *AttestationResult, data []byte) error {
+	msg, err := jws.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	return ar.UnmarshalJSON(msg.Payload())
+}
+
+func decodeUnverifiedCWT(ar *AttestationResult, data []byte) error {
+	if isCOSESign(data) {
+		var msg cose.SignMessage
+		if err := msg.UnmarshalCBOR(data); err != nil {
+			return err
+		}
+
+		return ar.UnmarshalCBOR(msg.Payload)
+	}
+
+	var msg cose.Sign1Message
+	if err := msg.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+
+	return ar.UnmarshalCBOR(msg.Payload)
+}
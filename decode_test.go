@@ -0,0 +1,116 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDecodeInputs(t *testing.T) map[Format][]byte {
+	ar := testAttestationResultsWithVeraisonExtns
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	jwt, err := ar.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	cwt, err := ar.SignCWT(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	j, err := ar.MarshalJSON()
+	require.NoError(t, err)
+
+	c, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	return map[Format][]byte{FormatJWT: jwt, FormatCWT: cwt, FormatJSON: j, FormatCBOR: c}
+}
+
+func TestDecode(t *testing.T) {
+	inputs := testDecodeInputs(t)
+
+	// untagged COSE_Sign1, CWT- and UCCS-tagged messages and surrounding white
+	// space are tolerated too
+	untagged := inputs[FormatCWT][1:]
+	padded := append(append([]byte("\n "), inputs[FormatJWT]...), '\n')
+
+	tvs := []struct {
+		data     []byte
+		expected Format
+	}{
+		{inputs[FormatJWT], FormatJWT},
+		{padded, FormatJWT},
+		{inputs[FormatCWT], FormatCWT},
+		{untagged, FormatCWT},
+		{append([]byte{0xd8, 0x3d}, inputs[FormatCWT]...), FormatCWT},
+		{inputs[FormatJSON], FormatJSON},
+		{inputs[FormatCBOR], FormatCBOR},
+		{append([]byte{0xd9, 0x02, 0x59}, inputs[FormatCBOR]...), FormatCBOR},
+	}
+
+	for i, tv := range tvs {
+		ar, f, err := Decode(tv.data)
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, tv.expected, f, "failed test vector at index %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, *ar, "failed test vector at index %d", i)
+	}
+}
+
+func TestDecode_verify(t *testing.T) {
+	inputs := testDecodeInputs(t)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+	otherK := mustParseKey(t, testEd25519PublicKey)
+
+	for _, f := range []Format{FormatJWT, FormatCWT} {
+		ar, actual, err := Decode(inputs[f], WithVerificationKey(jwa.ES256, vfyK), WithSignatureRequired())
+		require.NoError(t, err, f)
+		assert.Equal(t, f, actual)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, *ar)
+
+		_, _, err = Decode(inputs[f], WithVerificationKey(jwa.EdDSA, otherK))
+		assert.ErrorContains(t, err, "decoding "+f.String()+": ", f)
+
+		_, _, err = Decode(inputs[f], WithVerificationKey(jwa.ES256, vfyK),
+			WithDecodeVerifyOptions(WithMaxAge(1)))
+		assert.ErrorContains(t, err, "freshness check failed", f)
+	}
+
+	for _, f := range []Format{FormatJSON, FormatCBOR} {
+		_, actual, err := Decode(inputs[f], WithVerificationKey(jwa.ES256, vfyK))
+		assert.EqualError(t, err, "bare "+f.String()+" claims-set found, expecting a signed EAR")
+		assert.Equal(t, f, actual)
+	}
+
+	_, _, err := Decode(inputs[FormatJWT], WithSignatureRequired())
+	assert.EqualError(t, err, "signature required, but no verification key supplied")
+}
+
+func TestDecode_fail(t *testing.T) {
+	tvs := []struct {
+		data     []byte
+		expected string
+	}{
+		{nil, "empty input"},
+		{[]byte(" \n"), "empty input"},
+		{[]byte("hello"), "unrecognized EAR format"},
+		{[]byte{0x82, 0x01, 0x02}, "unrecognized EAR format"},
+		{[]byte(`{"eat_profile": "tag:github.com,2023:veraison/ear"}`), "decoding JSON: missing mandatory"},
+		{[]byte{0xa0}, "decoding CBOR: missing mandatory"},
+		{[]byte("e30.e30.e30"), "decoding JWT: "},
+		{[]byte{0xd2, 0x80}, "decoding CWT: "},
+	}
+
+	for i, tv := range tvs {
+		_, _, err := Decode(tv.data)
+		assert.ErrorContains(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestFormat_String(t *testing.T) {
+	assert.Equal(t, "CWT", FormatCWT.String())
+	assert.Equal(t, "Format(7)", Format(7).String())
+}
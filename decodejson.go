@@ -0,0 +1,168 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DecodeJSON is like UnmarshalJSON, but it reads the JSON claims-set from r,
+// and it decodes the appraisals in "submods" one at a time, as they are read,
+// rather than building an intermediate representation of the whole document
+// first.  This keeps the memory footprint low when decoding results that
+// carry hundreds of submods.  Trailing data after the claims-set is an error.
+func (o *AttestationResult) DecodeJSON(r io.Reader) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	m := map[string]interface{}{}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		// object keys are always strings
+		key := tok.(string)
+
+		if key == "submods" {
+			if m[key], err = decodeSubmods(dec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+
+		m[key] = v
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after the claims-set")
+	}
+
+	if err := o.populateFromAnyMap(m); err != nil {
+		return err
+	}
+
+	return o.validate()
+}
+
+// decodeSubmods reads the value of the "submods" claim.  If it is an object,
+// its appraisals are decoded one by one into a submodsDecoder.  Otherwise, the
+// value is skipped and returned as-is (if it is a scalar) or as nil, for
+// populateFromMap to report it.
+func decodeSubmods(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+	case json.Delim('['):
+		return nil, skipJSONArray(dec)
+	default:
+		return tok, nil
+	}
+
+	d := newSubmodsDecoder()
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+
+		d.add(tok.(string), v)
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// skipJSONArray consumes the rest of an array, whose opening bracket has
+// already been read
+func skipJSONArray(dec *json.Decoder) error {
+	for depth := 1; depth > 0; {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+	}
+
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expecting %q, found %v", string(delim), tok)
+	}
+
+	return nil
+}
+
+// submodsDecoder accumulates the appraisals of the "submods" claim, and the
+// problems found decoding them
+type submodsDecoder struct {
+	submods  map[string]*Appraisal
+	problems []string
+}
+
+func newSubmodsDecoder() *submodsDecoder {
+	return &submodsDecoder{submods: map[string]*Appraisal{}}
+}
+
+func (o *submodsDecoder) add(name string, v interface{}) {
+	appraisal, err := ToAppraisal(v)
+	if err != nil {
+		o.problems = append(o.problems, fmt.Sprintf("%s: %s", name, err.Error()))
+		return
+	}
+
+	o.submods[name] = appraisal
+}
+
+func (o *submodsDecoder) result() (map[string]*Appraisal, error) {
+	if len(o.problems) > 0 {
+		return nil, errors.New(strings.Join(o.problems, "; "))
+	}
+
+	return o.submods, nil
+}
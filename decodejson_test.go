@@ -0,0 +1,127 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testManySubmodsClaimsSet returns a JSON claims-set with n submods
+func testManySubmodsClaimsSet(t testing.TB, n int) []byte {
+	ar := NewAttestationResult("submod-0", "rrtrap-v1.0.0", "Acme Inc.")
+
+	for i := 0; i < n; i++ {
+		tv := TrustVector{Executables: ApprovedRuntimeClaim, Hardware: GenuineHardwareClaim}
+		policyID := fmt.Sprintf("policy://test/%d", i)
+		status := TrustTierAffirming
+
+		ar.Submods[fmt.Sprintf("submod-%d", i)] = &Appraisal{
+			Status:            &status,
+			TrustVector:       &tv,
+			AppraisalPolicyID: &policyID,
+		}
+	}
+
+	data, err := ar.MarshalJSON()
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestDecodeJSON_same_as_UnmarshalJSON(t *testing.T) {
+	ext, err := testAttestationResultsWithVeraisonExtns.MarshalJSON()
+	require.NoError(t, err)
+
+	tvs := [][]byte{
+		ext,
+		testManySubmodsClaimsSet(t, 100),
+		testLegacyTokenClaimsSet,
+	}
+
+	for i, tv := range tvs {
+		var expected, actual AttestationResult
+
+		require.NoError(t, expected.UnmarshalJSON(tv), "failed test vector at index %d", i)
+		require.NoError(t, actual.DecodeJSON(bytes.NewReader(tv)), "failed test vector at index %d", i)
+		assert.Equal(t, expected, actual, "failed test vector at index %d", i)
+	}
+}
+
+func TestDecodeJSON_fail(t *testing.T) {
+	tvs := []struct {
+		input       string
+		expectedErr string
+	}{
+		{
+			`[]`,
+			`expecting "{", found [`,
+		},
+		{
+			`{"submods": {"a": {"ear.status": "affirming"}}} {}`,
+			`unexpected data after the claims-set`,
+		},
+		{
+			`{"eat_profile": "tag:github.com,2023:veraison/ear", "iat": 1, ` +
+				`"ear.verifier-id": {"build": "b", "developer": "d"}, ` +
+				`"submods": {"a": {"ear.status": "excellent"}}}`,
+			`invalid value(s) for 'submods' (a: invalid value(s) for 'ear.status' (not a valid TrustTier name: "excellent"))`,
+		},
+		{
+			`{"eat_profile": "tag:github.com,2023:veraison/ear", "iat": 1, ` +
+				`"ear.verifier-id": {"build": "b", "developer": "d"}, ` +
+				`"submods": [{"a": {}}]}`,
+			`invalid value(s) for 'submods' (not a map object)`,
+		},
+		{
+			`{"eat_profile": "tag:github.com,2023:veraison/ear", "iat": 1, ` +
+				`"ear.verifier-id": {"build": "b", "developer": "d"}, ` +
+				`"submods": "a"}`,
+			`invalid value(s) for 'submods' (not a map object)`,
+		},
+	}
+
+	for i, tv := range tvs {
+		var ar AttestationResult
+		err := ar.DecodeJSON(strings.NewReader(tv.input))
+		assert.EqualError(t, err, tv.expectedErr, "failed test vector at index %d", i)
+	}
+
+	var ar AttestationResult
+	err := ar.DecodeJSON(strings.NewReader(`{"submods": {"a": {"ear.status": "affirming"}`))
+	assert.Error(t, err, "truncated input")
+}
+
+func BenchmarkUnmarshalJSON_500_submods(b *testing.B) {
+	data := testManySubmodsClaimsSet(b, 500)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var ar AttestationResult
+		if err := ar.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeJSON_500_submods(b *testing.B) {
+	data := testManySubmodsClaimsSet(b, 500)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var ar AttestationResult
+		if err := ar.DecodeJSON(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
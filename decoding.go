@@ -0,0 +1,110 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DecodingMode controls how claims that are neither defined by EAR nor
+// registered for the `eat_profile` of the result (see RegisterExtension) are
+// treated when decoding
+type DecodingMode int
+
+const (
+	// DecodingModeDefault is lenient: it accepts results carrying unknown
+	// claims, and collects them for inspection in the Extra of the
+	// AttestationResult or of the Appraisal they were found in
+	DecodingModeDefault DecodingMode = iota
+	// DecodingModeStrict rejects results carrying unknown claims, at the top
+	// level or in any submod
+	DecodingModeStrict
+)
+
+// WithDecodingMode instructs Verify to treat unknown claims according to the
+// supplied DecodingMode
+func WithDecodingMode(mode DecodingMode) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.decodingMode = mode
+	})
+}
+
+// WithStrictDecoding instructs Verify to reject results carrying unknown
+// claims.  It is a shorthand for WithDecodingMode(DecodingModeStrict).
+func WithStrictDecoding() VerifyOption {
+	return WithDecodingMode(DecodingModeStrict)
+}
+
+// UnmarshalJSONWithMode is like UnmarshalJSON, but unknown claims are treated
+// according to the supplied DecodingMode
+func (o *AttestationResult) UnmarshalJSONWithMode(data []byte, mode DecodingMode) error {
+	var oMap map[string]interface{}
+	if err := json.Unmarshal(data, &oMap); err != nil {
+		return err
+	}
+
+	if err := o.populateFromAnyMap(oMap); err != nil {
+		return err
+	}
+
+	if err := o.applyDecodingMode(mode); err != nil {
+		return err
+	}
+
+	return o.validate()
+}
+
+// applyDecodingMode checks the target, once populated, for unknown claims
+// (i.e., for Extra claims), and handles them as requested by mode
+func (o *AttestationResult) applyDecodingMode(mode DecodingMode) error {
+	if mode != DecodingModeStrict {
+		return nil
+	}
+
+	var unexpected []string
+
+	for k := range o.Extra {
+		unexpected = append(unexpected, "'"+k+"'")
+	}
+
+	for name, a := range o.Submods {
+		if a == nil {
+			continue
+		}
+
+		for k := range a.Extra {
+			unexpected = append(unexpected, "'submods/"+name+"/"+k+"'")
+		}
+	}
+
+	if len(unexpected) != 0 {
+		sort.Strings(unexpected)
+		return fmt.Errorf("unexpected claim(s): %s", strings.Join(unexpected, ", "))
+	}
+
+	return nil
+}
+
+// unknownClaims returns the claims in m whose names are not known, or nil if
+// there are none
+func unknownClaims(m map[string]interface{}, known map[string]bool) map[string]interface{} {
+	var unknown map[string]interface{}
+
+	for k, v := range m {
+		if known[k] {
+			continue
+		}
+
+		if unknown == nil {
+			unknown = map[string]interface{}{}
+		}
+
+		unknown[k] = v
+	}
+
+	return unknown
+}
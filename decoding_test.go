@@ -0,0 +1,82 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testClaimsWithUnknownClaims = []byte(`{
+	"eat_profile": "tag:github.com,2023:veraison/ear",
+	"iat": 1666091373,
+	"ear.verifier-id": {"build": "rrtrap-v1.0.0", "developer": "Acme Inc."},
+	"x-top": "t",
+	"submods": {
+		"test": {
+			"ear.status": "affirming",
+			"x-sub": 42
+		}
+	}
+}`)
+
+func TestAttestationResult_UnmarshalJSONWithMode(t *testing.T) {
+	var ar AttestationResult
+	require.NoError(t, ar.UnmarshalJSONWithMode(testClaimsWithUnknownClaims, DecodingModeDefault))
+	assert.Equal(t, map[string]interface{}{"x-top": "t"}, ar.Extra)
+	assert.Equal(t, map[string]interface{}{"x-sub": float64(42)}, ar.Submods["test"].Extra)
+
+	ar = AttestationResult{}
+	err := ar.UnmarshalJSONWithMode(testClaimsWithUnknownClaims, DecodingModeStrict)
+	assert.EqualError(t, err, "unexpected claim(s): 'submods/test/x-sub', 'x-top'")
+}
+
+func TestVerify_WithStrictDecoding(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	token := jwt.New()
+	for k, v := range testAttestationResultsWithVeraisonExtns.AsMap() {
+		require.NoError(t, token.Set(k, v))
+	}
+	require.NoError(t, token.Set("x-top", "t"))
+	require.NoError(t, token.Set(jwt.AudienceKey, "relying-party"))
+
+	data, err := jwt.Sign(token, jwt.WithKey(jwa.ES256, sigK))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	err = actual.Verify(data, jwa.ES256, vfyK, WithStrictDecoding())
+	assert.EqualError(t, err, "unexpected claim(s): 'x-top'")
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.Verify(data, jwa.ES256, vfyK, WithDecodingMode(DecodingModeDefault)))
+	assert.Equal(t, map[string]interface{}{"x-top": "t"}, actual.Extra)
+
+	// well-formed results pass strict decoding
+	data, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK, WithTokenID())
+	require.NoError(t, err)
+	assert.NoError(t, actual.Verify(data, jwa.ES256, vfyK, WithStrictDecoding()))
+}
+
+func TestVerify_WithDecodingMode_top_level_appraisal(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK,
+		WithTopLevelAppraisal("test"))
+	require.NoError(t, err)
+
+	// mirrored appraisal claims are not unknown claims, in any mode
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK, WithDecodingMode(DecodingModeDefault)))
+	assert.Nil(t, actual.Extra)
+
+	actual = AttestationResult{}
+	assert.NoError(t, actual.Verify(token, jwa.ES256, vfyK, WithStrictDecoding()))
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// SignDetached is like Sign, but the claims-set is not carried in the JWS.
+// Instead, it is signed as an unencoded payload (RFC 7797) and returned
+// separately, as JSON, alongside the detached JWS (i.e., a compact
+// serialization with an empty payload section).  The same payload must be
+// supplied to VerifyDetached.  Custom algorithms are not supported.
+func (o AttestationResult) SignDetached(
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...SignOption,
+) (payload []byte, sig []byte, err error) {
+	if _, ok := lookupCustomAlgorithm(alg); ok {
+		return nil, nil, fmt.Errorf("detached signing not supported for algorithm %q", alg)
+	}
+
+	cfg := newSignConfig(opts)
+
+	claims, err := o.claimsSet(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload, err = json.Marshal(numericDates(claims))
+	if err != nil {
+		return nil, nil, fmt.Errorf("serializing claims-set: %w", err)
+	}
+
+	hdrs := jws.NewHeaders()
+	for k, v := range cfg.protectedHeaders() {
+		if k == jws.AlgorithmKey {
+			continue
+		}
+		if err := hdrs.Set(k, v); err != nil {
+			return nil, nil, fmt.Errorf("setting header %s: %w", k, err)
+		}
+	}
+
+	if err := hdrs.Set("b64", false); err != nil {
+		return nil, nil, fmt.Errorf("setting header b64: %w", err)
+	}
+
+	if err := hdrs.Set(jws.CriticalKey, []string{"b64"}); err != nil {
+		return nil, nil, fmt.Errorf("setting header %s: %w", jws.CriticalKey, err)
+	}
+
+	sig, err = jws.Sign(nil,
+		jws.WithKey(alg, key, jws.WithProtectedHeaders(hdrs)),
+		jws.WithDetachedPayload(payload),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return payload, sig, nil
+}
+
+// VerifyDetached is the counterpart of SignDetached: it verifies the detached
+// JWS sig over payload and, if successful, populates the target
+// AttestationResult from payload.  Otherwise, it behaves like Verify.
+func (o *AttestationResult) VerifyDetached(
+	sig []byte,
+	payload []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) error {
+	cfg := newVerifyConfig(opts)
+
+	if _, err := jws.Verify(sig,
+		jws.WithKey(alg, key),
+		jws.WithDetachedPayload(payload),
+	); err != nil {
+		return fmt.Errorf("failed verifying JWT message: %w", err)
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if err := checkValidityPeriod(claims, cfg.clock.Now(), cfg.clockSkew); err != nil {
+		return fmt.Errorf("failed verifying JWT message: %w", err)
+	}
+
+	iss, _ := claims["iss"].(string)
+
+	_, err := o.populateFromClaims(claims, iss, cfg)
+	return err
+}
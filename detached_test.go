@@ -0,0 +1,77 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetached_round_trip(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	payload, sig, err := testAttestationResultsWithVeraisonExtns.SignDetached(jwa.ES256, sigK,
+		WithKeyID("key#1"))
+	require.NoError(t, err)
+
+	// the payload is the plain JSON claims-set
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, EatProfile, claims["eat_profile"])
+
+	// the JWS carries no payload
+	parts := bytes.Split(sig, []byte("."))
+	require.Len(t, parts, 3)
+	assert.Empty(t, parts[1])
+
+	msg, err := jws.Parse(sig)
+	require.NoError(t, err)
+	assert.Equal(t, "key#1", msg.Signatures()[0].ProtectedHeaders().KeyID())
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifyDetached(sig, payload, jwa.ES256, vfyK))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+}
+
+func TestDetached_tampered_payload(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	payload, sig, err := testAttestationResultsWithVeraisonExtns.SignDetached(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	tampered := bytes.Replace(payload, []byte(`"affirming"`), []byte(`"warning"`), 1)
+	require.NotEqual(t, payload, tampered)
+
+	var actual AttestationResult
+	err = actual.VerifyDetached(sig, tampered, jwa.ES256, vfyK)
+	assert.ErrorContains(t, err, "failed verifying JWT message")
+}
+
+func TestDetached_expired(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	now := time.Unix(testIAT, 0)
+
+	payload, sig, err := testAttestationResultsWithVeraisonExtns.SignDetached(jwa.ES256, sigK,
+		WithTTL(time.Hour))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifyDetached(sig, payload, jwa.ES256, vfyK,
+		WithClock(FixedClock(now))))
+
+	err = actual.VerifyDetached(sig, payload, jwa.ES256, vfyK,
+		WithClock(FixedClock(now.Add(time.Hour))))
+	assert.ErrorContains(t, err, "failed verifying JWT message")
+}
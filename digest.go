@@ -0,0 +1,191 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	_ "crypto/sha256" // register SHA-256
+	_ "crypto/sha512" // register SHA-384 and SHA-512
+)
+
+// Digest is a cryptographic digest, together with the identifier of the hash
+// algorithm used to compute it.  The algorithm identifiers are the "Hash Name
+// String" values in the IANA Named Information Hash Algorithm Registry (e.g.,
+// "sha-256").
+type Digest struct {
+	Alg   *string `json:"alg"`
+	Value *B64Url `json:"value"`
+}
+
+var (
+	digestAlgToHash = map[string]crypto.Hash{
+		"sha-256": crypto.SHA256,
+		"sha-384": crypto.SHA384,
+		"sha-512": crypto.SHA512,
+	}
+)
+
+// NewDigest computes the digest of data using the hash algorithm identified
+// by alg.
+func NewDigest(alg string, data []byte) (*Digest, error) {
+	h, ok := digestAlgToHash[alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %q", alg)
+	}
+
+	hasher := h.New()
+	hasher.Write(data)
+	value := B64Url(hasher.Sum(nil))
+
+	return &Digest{
+		Alg:   &alg,
+		Value: &value,
+	}, nil
+}
+
+// Validate checks that the Digest uses a supported hash algorithm and that
+// its value has the expected length.
+func (o Digest) Validate() error {
+	if o.Alg == nil {
+		return errors.New(`missing "alg"`)
+	}
+
+	h, ok := digestAlgToHash[*o.Alg]
+	if !ok {
+		return fmt.Errorf("unsupported hash algorithm %q", *o.Alg)
+	}
+
+	if o.Value == nil {
+		return errors.New(`missing "value"`)
+	}
+
+	if len(*o.Value) != h.Size() {
+		return fmt.Errorf("%s digest has wrong length: want %d bytes, got %d",
+			*o.Alg, h.Size(), len(*o.Value))
+	}
+
+	return nil
+}
+
+// Verify checks that data matches the Digest
+func (o Digest) Verify(data []byte) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	actual, err := NewDigest(*o.Alg, data)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(*actual.Value, *o.Value) != 1 {
+		return errors.New("digest mismatch")
+	}
+
+	return nil
+}
+
+func ToDigest(v interface{}) (*Digest, error) {
+	var d Digest
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON object")
+	}
+
+	parsers := map[string]parser{
+		"value": b64urlBytesPtrParser,
+	}
+
+	if err := populateStructFromMap(&d, m, "json", parsers, stringPtrParser, false); err != nil {
+		return nil, err
+	}
+
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// SetEvidenceDigest computes the digest of evidence using the hash algorithm
+// identified by alg (e.g., "sha-256"), and sets it in the top-level
+// "ear.evidence-digest" claim.  This can be used in place of
+// "ear.raw-evidence" when embedding the evidence is not desirable.
+func (o *AttestationResult) SetEvidenceDigest(alg string, evidence []byte) error {
+	d, err := NewDigest(alg, evidence)
+	if err != nil {
+		return err
+	}
+
+	o.EvidenceDigest = d
+
+	return nil
+}
+
+// GetEvidenceDigest returns the top-level "ear.evidence-digest" claim
+func (o AttestationResult) GetEvidenceDigest() (*Digest, error) {
+	if o.EvidenceDigest == nil {
+		return nil, errors.New(`"ear.evidence-digest" claim not found`)
+	}
+
+	return o.EvidenceDigest, nil
+}
+
+// VerifyEvidenceDigest checks the supplied evidence against the top-level
+// "ear.evidence-digest" claim.
+func (o AttestationResult) VerifyEvidenceDigest(evidence []byte) error {
+	d, err := o.GetEvidenceDigest()
+	if err != nil {
+		return err
+	}
+
+	if err := d.Verify(evidence); err != nil {
+		return fmt.Errorf(`"ear.evidence-digest": %w`, err)
+	}
+
+	return nil
+}
+
+// SetEvidenceDigest computes the digest of evidence using the hash algorithm
+// identified by alg (e.g., "sha-256"), and sets it in the appraisal's
+// "ear.evidence-digest" claim.
+func (o *Appraisal) SetEvidenceDigest(alg string, evidence []byte) error {
+	d, err := NewDigest(alg, evidence)
+	if err != nil {
+		return err
+	}
+
+	o.EvidenceDigest = d
+
+	return nil
+}
+
+// GetEvidenceDigest returns the appraisal's "ear.evidence-digest" claim
+func (o Appraisal) GetEvidenceDigest() (*Digest, error) {
+	if o.EvidenceDigest == nil {
+		return nil, errors.New(`"ear.evidence-digest" claim not found`)
+	}
+
+	return o.EvidenceDigest, nil
+}
+
+// VerifyEvidenceDigest checks the supplied evidence against the appraisal's
+// "ear.evidence-digest" claim.
+func (o Appraisal) VerifyEvidenceDigest(evidence []byte) error {
+	d, err := o.GetEvidenceDigest()
+	if err != nil {
+		return err
+	}
+
+	if err := d.Verify(evidence); err != nil {
+		return fmt.Errorf(`"ear.evidence-digest": %w`, err)
+	}
+
+	return nil
+}
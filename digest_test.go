@@ -0,0 +1,99 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDigest(t *testing.T) {
+	d, err := NewDigest("sha-256", []byte("evidence"))
+	require.NoError(t, err)
+	assert.Equal(t, "sha-256", *d.Alg)
+	assert.Len(t, *d.Value, 32)
+
+	assert.NoError(t, d.Verify([]byte("evidence")))
+	assert.EqualError(t, d.Verify([]byte("tampered")), "digest mismatch")
+
+	_, err = NewDigest("md5", []byte("evidence"))
+	assert.EqualError(t, err, `unsupported hash algorithm "md5"`)
+}
+
+func TestDigest_Validate_fail(t *testing.T) {
+	alg := "sha-384"
+	short := B64Url{0xde, 0xad}
+
+	assert.EqualError(t, Digest{}.Validate(), `missing "alg"`)
+	assert.EqualError(t, Digest{Alg: &alg}.Validate(), `missing "value"`)
+	assert.EqualError(t, Digest{Alg: &alg, Value: &short}.Validate(),
+		"sha-384 digest has wrong length: want 48 bytes, got 2")
+}
+
+func TestToDigest_fail(t *testing.T) {
+	tvs := []struct {
+		v        interface{}
+		expected string
+	}{
+		{
+			v:        "sha-256",
+			expected: "not a JSON object",
+		},
+		{
+			v:        map[string]interface{}{"alg": "sha-256", "value": "%%%"},
+			expected: "invalid value(s) for 'value' (illegal base64 data at input byte 0)",
+		},
+		{
+			v:        map[string]interface{}{"alg": "sha-1", "value": "3q2-7w"},
+			expected: `unsupported hash algorithm "sha-1"`,
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToDigest(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestEvidenceDigest_round_trip(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	ar := NewAttestationResult("test", testVidBuild, testVidDeveloper)
+	require.NoError(t, ar.SetEvidenceDigest("sha-256", testEvidence))
+	require.NoError(t, ar.Submods["test"].SetEvidenceDigest("sha-512", testEvidence))
+
+	token, err := ar.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK))
+
+	assert.Equal(t, ar.EvidenceDigest, actual.EvidenceDigest)
+	assert.NoError(t, actual.VerifyEvidenceDigest(testEvidence))
+	assert.EqualError(t, actual.VerifyEvidenceDigest([]byte("other")),
+		`"ear.evidence-digest": digest mismatch`)
+
+	assert.NoError(t, actual.Submods["test"].VerifyEvidenceDigest(testEvidence))
+	assert.EqualError(t, actual.Submods["test"].VerifyEvidenceDigest([]byte("other")),
+		`"ear.evidence-digest": digest mismatch`)
+}
+
+func TestEvidenceDigest_missing(t *testing.T) {
+	var ar AttestationResult
+	assert.EqualError(t, ar.VerifyEvidenceDigest(testEvidence), `"ear.evidence-digest" claim not found`)
+
+	var appraisal Appraisal
+	assert.EqualError(t, appraisal.VerifyEvidenceDigest(testEvidence), `"ear.evidence-digest" claim not found`)
+
+	assert.EqualError(t, ar.SetEvidenceDigest("sha-1", testEvidence), `unsupported hash algorithm "sha-1"`)
+	assert.EqualError(t, appraisal.SetEvidenceDigest("sha-1", testEvidence), `unsupported hash algorithm "sha-1"`)
+}
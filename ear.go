@@ -4,6 +4,7 @@
 package ear
 
 import (
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 )
 
@@ -21,19 +23,50 @@ const EatProfile = "tag:github.com,2023:veraison/ear"
 // AttestationResult represents the result of one or more evidence Appraisals
 // by the verifier.  It is serialized to JSON and signed by the verifier using
 // JWT.
+//
+// `eat_nonce` is held in Nonce when it is a single nonce, and in Nonces when it
+// is in the array form that EAT uses to echo more than one freshness challenge
+// (see SetNonces).  At most one of Nonce and Nonces can be set.
+//
+// The optional `iss`, `sub` and `jti` JWT claims are held in Issuer, Subject
+// and TokenID.  Since `jti` is a byte string in CWT, TokenID must be base64url
+// encoded for the result to be serializable to CBOR (see WithTokenID).
 type AttestationResult struct {
-	Profile     *string               `json:"eat_profile"`
-	VerifierID  *VerifierIdentity     `json:"ear.verifier-id"`
-	RawEvidence *B64Url               `json:"ear.raw-evidence,omitempty"`
-	IssuedAt    *int64                `json:"iat"`
-	Nonce       *string               `json:"eat_nonce,omitempty"`
-	Submods     map[string]*Appraisal `json:"submods"`
+	Profile        *string               `json:"eat_profile"`
+	VerifierID     *VerifierIdentity     `json:"ear.verifier-id"`
+	RawEvidence    *B64Url               `json:"ear.raw-evidence,omitempty"`
+	EvidenceDigest *Digest               `json:"ear.evidence-digest,omitempty"`
+	RawEvidenceRef *RawEvidenceRef       `json:"ear.raw-evidence-ref,omitempty"`
+	PreviousResult *Digest               `json:"ear.previous-result,omitempty"`
+	Confirmation   *Confirmation         `json:"cnf,omitempty"`
+	IssuedAt       *int64                `json:"iat"`
+	Expiry         *int64                `json:"exp,omitempty"`
+	NotBefore      *int64                `json:"nbf,omitempty"`
+	Issuer         *string               `json:"iss,omitempty"`
+	Subject        *string               `json:"sub,omitempty"`
+	TokenID        *string               `json:"jti,omitempty"`
+	Nonce          *string               `json:"eat_nonce,omitempty"`
+	Nonces         []string              `json:"-"`
+	EpochID        *string               `json:"epoch-id,omitempty"`
+	Submods        map[string]*Appraisal `json:"submods"`
 
 	AttestationResultExtensions
+
+	// Extra holds the top-level claims that are neither defined by EAR nor
+	// registered for the profile of the result, as found when decoding.  They
+	// are serialized as they are, so that a result can be re-signed (e.g., by
+	// a gateway) without losing data.
+	Extra map[string]interface{} `json:"-"`
 }
 
 type AttestationResultExtensions struct {
-	VeraisonTeeInfo *VeraisonTeeInfo `json:"ear.veraison.tee-info,omitempty"`
+	VeraisonTeeInfo    *VeraisonTeeInfo `json:"ear.veraison.tee-info,omitempty"`
+	VeraisonProvenance *Provenance      `json:"ear.veraison.provenance,omitempty"`
+
+	// ProfileExtensions holds the values of the extension claims registered
+	// for the `eat_profile` of the result (see RegisterExtension), keyed by
+	// claim name
+	ProfileExtensions map[string]interface{} `json:"-"`
 }
 
 // B64Url is base64url (§5 of RFC4648) without padding.
@@ -95,14 +128,15 @@ func (o AttestationResult) MarshalJSONIndent(prefix, indent string) ([]byte, err
 }
 
 // UnmarshalJSON de-serializes an AttestationResult object from its JSON
-// representation and validates it.
+// representation and validates it.  Legacy AR4SI results (see
+// IsLegacyAR4SIClaimsSet) are detected and converted using FromAR4SI.
 func (o *AttestationResult) UnmarshalJSON(data []byte) error {
 	var oMap map[string]interface{}
 	if err := json.Unmarshal(data, &oMap); err != nil {
 		return err
 	}
 
-	if err := o.populateFromMap(oMap); err != nil {
+	if err := o.populateFromAnyMap(oMap); err != nil {
 		return err
 	}
 
@@ -112,13 +146,44 @@ func (o *AttestationResult) UnmarshalJSON(data []byte) error {
 // AsMap returns a map[string]interface{} with EAR claim names mapped onto
 // corresponding values.
 func (o AttestationResult) AsMap() map[string]interface{} {
-	m, err := structAsMap(o, "json")
-	if err != nil {
-		// An error can only be returned if there is issue in implementation of
-		// AttestationResult; specifically, if any of its
-		// constituents incorrectly implement AsMap() themselves.
-		panic(err)
+	m := make(map[string]interface{}, 16)
+
+	putClaim(m, "eat_profile", o.Profile, true)
+	putClaim(m, "ear.verifier-id", o.VerifierID, true)
+	putClaim(m, "ear.raw-evidence", o.RawEvidence, false)
+	putClaim(m, "ear.evidence-digest", o.EvidenceDigest, false)
+	putClaim(m, "ear.raw-evidence-ref", o.RawEvidenceRef, false)
+	putClaim(m, "ear.previous-result", o.PreviousResult, false)
+	putClaim(m, "cnf", o.Confirmation, false)
+	putClaim(m, "iat", o.IssuedAt, true)
+	putClaim(m, "exp", o.Expiry, false)
+	putClaim(m, "nbf", o.NotBefore, false)
+	putClaim(m, "iss", o.Issuer, false)
+	putClaim(m, "sub", o.Subject, false)
+	putClaim(m, "jti", o.TokenID, false)
+	putClaim(m, "eat_nonce", o.Nonce, false)
+	if len(o.Nonces) != 0 {
+		nonces := make([]interface{}, len(o.Nonces))
+		for i, n := range o.Nonces {
+			nonces[i] = n
+		}
+		m["eat_nonce"] = nonces
 	}
+	putClaim(m, "epoch-id", o.EpochID, false)
+
+	submods := make(map[string]interface{}, len(o.Submods))
+	for name, a := range o.Submods {
+		if a == nil {
+			a = &Appraisal{}
+		}
+		submods[name] = a.AsMap()
+	}
+	m["submods"] = submods
+
+	putStructClaims(m, o.AttestationResultExtensions)
+	putProfileExtensions(m, o.ProfileExtensions)
+	putExtraClaims(m, o.Extra)
+
 	return m
 }
 
@@ -140,7 +205,7 @@ func (o AttestationResult) validate() error {
 
 	if o.Profile == nil {
 		missing = append(missing, "'eat_profile'")
-	} else if *o.Profile != EatProfile {
+	} else if *o.Profile != EatProfile && !isExtendedProfile(*o.Profile) {
 		invalid = append(invalid, fmt.Sprintf("eat_profile (%s)", *o.Profile))
 	}
 
@@ -148,8 +213,14 @@ func (o AttestationResult) validate() error {
 		missing = append(missing, "'iat'")
 	}
 
+	if err := o.validateLifetime(); err != nil {
+		invalid = append(invalid, err.Error())
+	}
+
 	if o.VerifierID == nil {
 		missing = append(missing, "'verifier-id'")
+	} else if err := o.VerifierID.validate(); err != nil {
+		invalid = append(invalid, fmt.Sprintf("verifier-id (%s)", err.Error()))
 	}
 
 	if o.Nonce != nil {
@@ -159,6 +230,62 @@ func (o AttestationResult) validate() error {
 		}
 	}
 
+	if o.Nonces != nil {
+		if err := o.validateNonces(); err != nil {
+			invalid = append(invalid, fmt.Sprintf("eat_nonce (%s)", err.Error()))
+		}
+	}
+
+	for _, c := range []struct {
+		v    *string
+		name string
+	}{
+		{o.Issuer, "iss"},
+		{o.Subject, "sub"},
+		{o.TokenID, "jti"},
+		{o.EpochID, "epoch-id"},
+	} {
+		if c.v != nil && *c.v == "" {
+			invalid = append(invalid, c.name+" (empty)")
+		}
+	}
+
+	if o.EvidenceDigest != nil {
+		if err := o.EvidenceDigest.Validate(); err != nil {
+			invalid = append(invalid, fmt.Sprintf("ear.evidence-digest (%s)", err.Error()))
+		}
+	}
+
+	if o.RawEvidenceRef != nil {
+		if err := o.RawEvidenceRef.Validate(); err != nil {
+			invalid = append(invalid, fmt.Sprintf("ear.raw-evidence-ref (%s)", err.Error()))
+		} else if o.RawEvidence != nil {
+			if err := o.RawEvidenceRef.Verify(*o.RawEvidence); err != nil {
+				invalid = append(invalid, fmt.Sprintf("ear.raw-evidence-ref (ear.raw-evidence %s)", err.Error()))
+			}
+		}
+	}
+
+	if o.PreviousResult != nil {
+		if err := o.PreviousResult.Validate(); err != nil {
+			invalid = append(invalid, fmt.Sprintf("ear.previous-result (%s)", err.Error()))
+		}
+	}
+
+	if o.Confirmation != nil {
+		if err := o.Confirmation.validate(); err != nil {
+			invalid = append(invalid, fmt.Sprintf("cnf (%s)", err.Error()))
+		}
+	}
+
+	if o.VeraisonProvenance != nil {
+		if err := o.VeraisonProvenance.validate(); err != nil {
+			invalid = append(invalid, fmt.Sprintf("ear.veraison.provenance (%s)", err.Error()))
+		}
+	}
+
+	invalid = append(invalid, o.validateProfileExtensions()...)
+
 	if len(o.Submods) == 0 {
 		missing = append(missing, "'submods' (at least one appraisal must be present)")
 	} else {
@@ -188,79 +315,576 @@ func (o AttestationResult) validate() error {
 // Verify cryptographically verifies the JWT data using the supplied key and
 // algorithm.  The payload is then parsed and validated.  On success, the target
 // AttestationResult object is populated with the decoded claims (possibly
-// including the Trustworthiness vector).
-func (o *AttestationResult) Verify(data []byte, alg jwa.KeyAlgorithm, key interface{}) error {
-	token, err := jwt.Parse(data, jwt.WithKey(alg, key))
+// including the Trustworthiness vector).  Results issued in the legacy AR4SI
+// shape by older Veraison releases are converted using FromAR4SI.
+// Time-related checks are made against the system time, unless a different
+// Clock is supplied using WithClock.
+func (o *AttestationResult) Verify(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) error {
+	_, err := o.verify(data, alg, key, newVerifyConfig(opts))
+	return err
+}
+
+func (o *AttestationResult) verify(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	cfg *verifyConfig,
+) (*verification, error) {
+	var v *verification
+
+	if a, ok := lookupCustomAlgorithm(alg); ok {
+		claims, err := verifyCustomJWT(data, a, key, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		iss, _ := claims["iss"].(string)
+
+		if v, err = o.populateFromClaims(claims, iss, cfg); err != nil {
+			return nil, err
+		}
+	} else {
+		token, err := parseToken(data, alg, key, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		if v, err = o.populateFromToken(token, token.PrivateClaims(), cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	o.auditVerified(AuditFormatJWT, data, cfg)
+
+	return v, nil
+}
+
+func parseToken(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	cfg *verifyConfig,
+) (jwt.Token, error) {
+	token, err := jwt.Parse(data,
+		jwt.WithKey(alg, key),
+		jwt.WithClock(cfg.clock),
+		jwt.WithAcceptableSkew(cfg.clockSkew),
+	)
 	if err != nil {
-		return fmt.Errorf("failed verifying JWT message: %w", err)
+		return nil, fmt.Errorf("failed verifying JWT message: %w", err)
 	}
 
-	claims := token.PrivateClaims()
+	return token, nil
+}
+
+// populateFromToken populates the target AttestationResult from the
+// (verified) token and its private claims, and applies the checks requested
+// in cfg
+func (o *AttestationResult) populateFromToken(
+	token jwt.Token,
+	claims map[string]interface{},
+	cfg *verifyConfig,
+) (*verification, error) {
 	claims["iat"] = token.IssuedAt().Unix()
 
-	return o.populateFromMap(claims)
+	if _, ok := token.Get(jwt.ExpirationKey); ok {
+		claims["exp"] = token.Expiration().Unix()
+	}
+
+	if _, ok := token.Get(jwt.NotBeforeKey); ok {
+		claims["nbf"] = token.NotBefore().Unix()
+	}
+
+	for _, k := range []string{jwt.IssuerKey, jwt.SubjectKey, jwt.JwtIDKey} {
+		if v, ok := token.Get(k); ok {
+			claims[k] = v
+		}
+	}
+
+	return o.populateFromClaims(claims, token.Issuer(), cfg)
+}
+
+// verification holds the by-products of verifying a claims-set, which are
+// specific to the token at hand.  They are returned, rather than recorded in
+// the verifyConfig, which is read-only, and possibly shared by concurrent
+// verifications.
+type verification struct {
+	// policies are the appraisal policies resolved during verification
+	policies map[string]*Policy
+	// warnings are the warnings found in the verified claims-set
+	warnings []Warning
+}
+
+// populateFromClaims populates the target AttestationResult from the
+// (verified) claims-set, and applies the checks requested in cfg.  iss is the
+// value of the `iss` claim, if any.
+func (o *AttestationResult) populateFromClaims(
+	claims map[string]interface{},
+	iss string,
+	cfg *verifyConfig,
+) (*verification, error) {
+	if cfg.topLevelAppraisal != "" {
+		if err := foldTopLevelAppraisal(claims, cfg.topLevelAppraisal); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := o.populateFromAnyMap(claims); err != nil {
+		return nil, err
+	}
+
+	if err := o.applyDecodingMode(cfg.decodingMode); err != nil {
+		return nil, err
+	}
+
+	if err := o.checkProfile(cfg.acceptedProfiles); err != nil {
+		return nil, err
+	}
+
+	if cfg.requireConfirmation && o.Confirmation == nil {
+		return nil, errors.New(`missing mandatory "cnf" (proof-of-possession material required)`)
+	}
+
+	if cfg.checkIssuerVerifierID {
+		if err := o.checkIssuer(iss); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := o.checkExpectedClaims(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := o.checkFreshness(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := o.checkLinkedResults(cfg); err != nil {
+		return nil, err
+	}
+
+	policies, err := o.checkPolicies(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.checkAcceptance(cfg); err != nil {
+		return nil, err
+	}
+
+	v := &verification{
+		policies: policies,
+		warnings: claimsWarnings(claims),
+	}
+
+	if cfg.warningHandler != nil {
+		for _, w := range v.warnings {
+			cfg.warningHandler(w)
+		}
+	}
+
+	return v, nil
+}
+
+func (o AttestationResult) checkIssuer(iss string) error {
+	if iss == "" {
+		return errors.New("issuer check failed: missing 'iss'")
+	}
+
+	if o.VerifierID == nil {
+		return errors.New("issuer check failed: missing 'ear.verifier-id'")
+	}
+
+	expected, err := o.VerifierID.Issuer()
+	if err != nil {
+		return fmt.Errorf("issuer check failed: %w", err)
+	}
+
+	if iss != expected {
+		return fmt.Errorf("issuer check failed: 'iss' (%q) does not match 'ear.verifier-id' (%q)",
+			iss, expected)
+	}
+
+	return nil
+}
+
+// checkExpectedClaims checks the `iss` and `sub` claims against the values
+// requested using WithExpectedIssuer and WithExpectedSubject
+func (o AttestationResult) checkExpectedClaims(cfg *verifyConfig) error {
+	for _, c := range []struct {
+		actual   *string
+		expected string
+		name     string
+	}{
+		{o.Issuer, cfg.expectedIssuer, "iss"},
+		{o.Subject, cfg.expectedSubject, "sub"},
+	} {
+		if c.expected == "" {
+			continue
+		}
+
+		if c.actual == nil {
+			return fmt.Errorf("%s check failed: missing '%s'", c.name, c.name)
+		}
+
+		if *c.actual != c.expected {
+			return fmt.Errorf("%s check failed: want %q, got %q", c.name, c.expected, *c.actual)
+		}
+	}
+
+	return nil
+}
+
+func (o AttestationResult) checkFreshness(cfg *verifyConfig) error {
+	if cfg.freshnessPolicy != nil {
+		if err := cfg.freshnessPolicy.CheckFreshness(o); err != nil {
+			return fmt.Errorf("freshness check failed: %w", err)
+		}
+	}
+
+	if len(cfg.expectedNonces) != 0 {
+		if err := nonceBytesFreshness(cfg.expectedNonces).CheckFreshness(o); err != nil {
+			return fmt.Errorf("freshness check failed: %w", err)
+		}
+	}
+
+	if cfg.maxAge <= 0 {
+		return nil
+	}
+
+	if o.IssuedAt == nil {
+		return errors.New("freshness check failed: missing 'iat'")
+	}
+
+	iat := time.Unix(*o.IssuedAt, 0)
+	if age := cfg.clock.Now().Sub(iat); age > cfg.maxAge {
+		return fmt.Errorf("freshness check failed: result issued %s ago (max age %s)",
+			age.Round(time.Second), cfg.maxAge)
+	}
+
+	return nil
 }
 
 // Sign validates the AttestationResult object, encodes it to JSON and wraps it
 // in a JWT using the supplied private key for signing.  The key must be
-// compatible with the requested signing algorithm.  On success, the complete
-// JWT token is returned.
-func (o AttestationResult) Sign(alg jwa.KeyAlgorithm, key interface{}) ([]byte, error) {
-	if err := o.validate(); err != nil {
+// compatible with the requested signing algorithm, and can either be a
+// jwk.Key, a raw private key or a crypto.Signer (see also
+// SignWithCryptoSigner).  On success, the complete JWT token is returned.
+// Algorithms plugged in using RegisterAlgorithm can be used as well.
+// If WithIssuedAtNow is supplied, `iat` is stamped using the Clock in use
+// (system time, unless a different Clock is supplied using WithClock).
+func (o AttestationResult) Sign(
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...SignOption,
+) ([]byte, error) {
+	cfg := newSignConfig(opts)
+
+	claims, err := o.claimsSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var token []byte
+
+	if a, ok := lookupCustomAlgorithm(alg); ok {
+		token, err = signCustomJWT(claims, a, key, cfg.protectedHeaders())
+	} else {
+		token, err = signClaimsSet(claims, alg, key, cfg.protectedHeaders())
+	}
+
+	if err != nil {
 		return nil, err
 	}
 
+	o.auditSigned(AuditFormatJWT, token, cfg)
+
+	return token, nil
+}
+
+// claimsSet validates the AttestationResult object and returns the claims-set
+// to be signed, including any claim requested in cfg
+func (o AttestationResult) claimsSet(cfg *signConfig) (map[string]interface{}, error) {
+	if cfg.stampIssuedAt {
+		iat := cfg.clock.Now().Unix()
+		o.IssuedAt = &iat
+	}
+
+	if cfg.expiry != nil {
+		o.SetExpiry(*cfg.expiry)
+	}
+
+	if ref := cfg.rawEvidenceRef; ref != nil {
+		if err := o.SetRawEvidenceRef(ref.alg, ref.evidence, ref.uri); err != nil {
+			return nil, fmt.Errorf("deriving raw evidence reference: %w", err)
+		}
+	}
+
+	if o.IssuedAt != nil {
+		if cfg.ttl > 0 && cfg.expiry == nil {
+			exp := time.Unix(*o.IssuedAt, 0).Add(cfg.ttl).Unix()
+			o.Expiry = &exp
+		}
+
+		if cfg.notBefore != nil {
+			nbf := time.Unix(*o.IssuedAt, 0).Add(*cfg.notBefore).Unix()
+			o.NotBefore = &nbf
+		}
+	}
+
+	if cfg.issuerFromVerifierID {
+		if o.VerifierID == nil {
+			return nil, errors.New("deriving issuer from verifier-id: missing 'ear.verifier-id'")
+		}
+
+		iss, err := o.VerifierID.Issuer()
+		if err != nil {
+			return nil, fmt.Errorf("deriving issuer from verifier-id: %w", err)
+		}
+
+		o.Issuer = &iss
+	}
+
+	if cfg.generateTokenID {
+		jti, err := newTokenID()
+		if err != nil {
+			return nil, err
+		}
+
+		o.TokenID = &jti
+	}
+
+	if !cfg.skipValidation {
+		if err := o.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	claims := o.AsMap()
+
+	if cfg.skipValidation {
+		dropMissingClaims(claims)
+	}
+
+	if cfg.topLevelAppraisal != "" {
+		if err := o.mirrorTopLevelAppraisal(claims, cfg.topLevelAppraisal); err != nil {
+			return nil, err
+		}
+	}
+
+	return claims, nil
+}
+
+// dropMissingClaims removes the mandatory claims that AsMap sets to null
+// because they are missing, both at the top level and in the submods, so that
+// an unvalidated claims-set does not carry claims that no verifier can decode
+func dropMissingClaims(claims map[string]interface{}) {
+	for k, v := range claims {
+		if v == nil {
+			delete(claims, k)
+		}
+	}
+
+	submods, _ := claims["submods"].(map[string]interface{})
+	for _, v := range submods {
+		if appraisal, ok := v.(map[string]interface{}); ok {
+			dropMissingClaims(appraisal)
+		}
+	}
+}
+
+func newTokenID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("generating jti: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(id), nil
+}
+
+func signClaimsSet(
+	claims map[string]interface{},
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	headers map[string]interface{},
+) ([]byte, error) {
 	token := jwt.New()
-	for k, v := range o.AsMap() {
+	for k, v := range claims {
 		if err := token.Set(k, v); err != nil {
 			return nil, fmt.Errorf("setting %s: %w", k, err)
 		}
 	}
 
-	return jwt.Sign(token, jwt.WithKey(alg, key))
+	hdrs := jws.NewHeaders()
+	for k, v := range headers {
+		if k == jws.AlgorithmKey {
+			continue
+		}
+		if err := hdrs.Set(k, v); err != nil {
+			return nil, fmt.Errorf("setting header %s: %w", k, err)
+		}
+	}
+
+	return jwt.Sign(token, jwt.WithKey(alg, key, jws.WithProtectedHeaders(hdrs)))
+}
+
+// populateFromAnyMap is like populateFromMap, except that legacy AR4SI
+// claims-sets are detected and converted into the current shape
+func (o *AttestationResult) populateFromAnyMap(m map[string]interface{}) error {
+	if isLegacyAR4SIMap(m) {
+		return o.populateFromLegacyMap(m)
+	}
+
+	return o.populateFromMap(m)
 }
 
 func (o *AttestationResult) populateFromMap(m map[string]interface{}) error {
-	// entries not explicitly listed will use the stringPtrParser
-	parsers := map[string]parser{
-		"iat": int64PtrParser,
-		"ear.trustworthiness-vector": func(v interface{}) (interface{}, error) {
-			return ToTrustVector(v)
-		},
-		"ear.verifier-id": func(v interface{}) (interface{}, error) {
-			return ToVerifierIdentity(v)
-		},
-		"ear.raw-evidence": b64urlBytesPtrParser,
-		"submods": func(v interface{}) (interface{}, error) {
-			vMap, ok := v.(map[string]interface{})
-			if !ok {
-				return nil, errors.New("not a map object")
-			}
+	r := claimsReader{m: m}
+
+	r.readString(&o.Profile, "eat_profile", true)
 
-			ret := map[string]*Appraisal{}
-			var problems []string
+	if v, ok := r.read("ear.verifier-id", true, func(v interface{}) (interface{}, error) {
+		return ToVerifierIdentity(v)
+	}); ok {
+		o.VerifierID = v.(*VerifierIdentity)
+	}
 
-			for key, val := range vMap {
-				appraisal, err := ToAppraisal(val)
-				if err != nil {
-					problems = append(problems,
-						fmt.Sprintf("%s: %s", key, err.Error()))
-					continue
-				}
+	if v, ok := r.read("ear.raw-evidence", false, b64urlBytesPtrParser); ok {
+		o.RawEvidence = v.(*B64Url)
+	}
 
-				ret[key] = appraisal
-			}
+	if v, ok := r.read("ear.evidence-digest", false, digestParser); ok {
+		o.EvidenceDigest = v.(*Digest)
+	}
 
-			if len(problems) > 0 {
-				return nil, errors.New(strings.Join(problems, "; "))
-			}
+	if v, ok := r.read("ear.raw-evidence-ref", false, func(v interface{}) (interface{}, error) {
+		return ToRawEvidenceRef(v)
+	}); ok {
+		o.RawEvidenceRef = v.(*RawEvidenceRef)
+	}
 
-			return ret, nil
-		},
+	if v, ok := r.read("ear.previous-result", false, digestParser); ok {
+		o.PreviousResult = v.(*Digest)
+	}
+
+	if v, ok := r.read("cnf", false, func(v interface{}) (interface{}, error) {
+		return ToConfirmation(v)
+	}); ok {
+		o.Confirmation = v.(*Confirmation)
+	}
+
+	for _, c := range []struct {
+		dst       **int64
+		name      string
+		mandatory bool
+	}{
+		{&o.IssuedAt, "iat", true},
+		{&o.Expiry, "exp", false},
+		{&o.NotBefore, "nbf", false},
+	} {
+		if v, ok := r.read(c.name, c.mandatory, int64PtrParser); ok {
+			*c.dst = v.(*int64)
+		}
+	}
+
+	if v, ok := r.read("eat_nonce", false, nonceParser); ok {
+		switch t := v.(type) {
+		case *string:
+			o.Nonce = t
+		case []string:
+			o.Nonces = t
+		}
+	}
+
+	r.readString(&o.Issuer, "iss", false)
+	r.readString(&o.Subject, "sub", false)
+	r.readString(&o.TokenID, "jti", false)
+	r.readString(&o.EpochID, "epoch-id", false)
+
+	if v, ok := r.read("submods", true, submodsParser); ok {
+		o.Submods = v.(map[string]*Appraisal)
+	}
+
+	r.readExtensions(&o.AttestationResultExtensions, map[string]parser{
 		"ear.veraison.tee-info": func(v interface{}) (interface{}, error) {
 			return ToVeraisonTeeInfo(v)
 		},
+		"ear.veraison.provenance": func(v interface{}) (interface{}, error) {
+			return ToProvenance(v)
+		},
+	})
+
+	o.parseProfileExtensions(&r)
+	o.retainExtraClaims(m)
+
+	return r.err(nil)
+}
+
+// validateNonces checks the array form of `eat_nonce`, which must carry at
+// least two nonces, each of which must be between 8 and 88 bytes long
+func (o AttestationResult) validateNonces() error {
+	if o.Nonce != nil {
+		return errors.New("both single and array forms set")
+	}
+
+	if len(o.Nonces) < 2 {
+		return fmt.Errorf("array of %d nonce(s), expecting at least 2", len(o.Nonces))
+	}
+
+	for i, n := range o.Nonces {
+		if nLen := len(n); nLen > 88 || nLen < 8 {
+			return fmt.Errorf("nonce %d is %d bytes", i, nLen)
+		}
+	}
+
+	return nil
+}
+
+// nonceParser parses `eat_nonce`, which is either a single nonce or an array
+// of nonces
+func nonceParser(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case string:
+		return &t, nil
+	case []interface{}:
+		nonces := make([]string, len(t))
+		for i, e := range t {
+			n, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("nonce %d: not a string", i)
+			}
+			nonces[i] = n
+		}
+		return nonces, nil
+	default:
+		return nil, errors.New("not a string or an array of strings")
+	}
+}
+
+func digestParser(v interface{}) (interface{}, error) {
+	return ToDigest(v)
+}
+
+func submodsParser(v interface{}) (interface{}, error) {
+	// already decoded by DecodeJSON
+	if d, ok := v.(*submodsDecoder); ok {
+		return d.result()
+	}
+
+	vMap, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a map object")
+	}
+
+	d := newSubmodsDecoder()
+	for key, val := range vMap {
+		d.add(key, val)
 	}
 
-	return populateStructFromMap(o, m, "json", parsers, stringPtrParser, true)
+	return d.result()
 }
@@ -18,28 +18,123 @@ import (
 // other metadata that are relevant to establish the appraisal context - the
 // evidence itself, the appraisal policy used, the time of appraisal.
 type Appraisal struct {
-	Status            *TrustTier   `json:"ear.status"`
-	TrustVector       *TrustVector `json:"ear.trustworthiness-vector,omitempty"`
-	AppraisalPolicyID *string      `json:"ear.appraisal-policy-id,omitempty"`
+	Status            *TrustTier       `json:"ear.status"`
+	TrustVector       *TrustVector     `json:"ear.trustworthiness-vector,omitempty"`
+	AppraisalPolicyID *string          `json:"ear.appraisal-policy-id,omitempty"`
+	EvidenceDigest    *Digest          `json:"ear.evidence-digest,omitempty"`
+	UEID              *B64Url          `json:"ueid,omitempty"`
+	OEMID             *B64Url          `json:"oemid,omitempty"`
+	HardwareModel     *B64Url          `json:"hwmodel,omitempty"`
+	HardwareVersion   *HardwareVersion `json:"hwversion,omitempty"`
+	LinkedResults     *[]LinkedResult  `json:"ear.linked-results,omitempty"`
 
 	AppraisalExtensions
+
+	// Extra holds the claims that are neither defined by EAR nor registered
+	// for the profile of the enclosing result, as found when decoding.  They
+	// are serialized as they are.
+	Extra map[string]interface{} `json:"-"`
+
+	// profileClaims holds the (unparsed) registered extension claims found
+	// while decoding, until the profile of the enclosing result is known
+	profileClaims map[string]interface{}
 }
 
 // AppraisalExtensions contains any proprietary claims that can be optionally
 // attached to the Appraisal.  For now only veraison-specific extensions are
 // supported.
 type AppraisalExtensions struct {
-	VeraisonAnnotatedEvidence *map[string]interface{} `json:"ear.veraison.annotated-evidence,omitempty"`
-	VeraisonPolicyClaims      *map[string]interface{} `json:"ear.veraison.policy-claims,omitempty"`
-	VeraisonKeyAttestation    *map[string]interface{} `json:"ear.veraison.key-attestation,omitempty"`
+	VeraisonAnnotatedEvidence   *map[string]interface{} `json:"ear.veraison.annotated-evidence,omitempty"`
+	VeraisonPolicyClaims        *map[string]interface{} `json:"ear.veraison.policy-claims,omitempty"`
+	VeraisonKeyAttestation      *map[string]interface{} `json:"ear.veraison.key-attestation,omitempty"`
+	VeraisonStatusReasons       *[]StatusReason         `json:"ear.veraison.status-reasons,omitempty"`
+	VeraisonPolicyResults       *[]PolicyResult         `json:"ear.veraison.policy-results,omitempty"`
+	VeraisonReasons             *[]Reason               `json:"ear.veraison.reasons,omitempty"`
+	VeraisonStatusOverrides     *[]StatusOverride       `json:"ear.veraison.status-overrides,omitempty"`
+	VeraisonUnprocessedEvidence *[]UnprocessedEvidence  `json:"ear.veraison.unprocessed-evidence,omitempty"`
+
+	// ProfileExtensions holds the values of the extension claims registered
+	// for the `eat_profile` of the enclosing result (see RegisterExtension),
+	// keyed by claim name
+	ProfileExtensions map[string]interface{} `json:"-"`
+}
+
+// StatusReason records a downgrade of the appraisal status, together with the
+// reason for it.  Entries are accumulated in the
+// "ear.veraison.status-reasons" claim by Downgrade.
+type StatusReason struct {
+	// From is the status before the downgrade
+	From *TrustTier `json:"from"`
+	// To is the status after the downgrade
+	To *TrustTier `json:"to"`
+	// Reason is a machine-readable explanation of the downgrade
+	Reason *string `json:"reason"`
+}
+
+func (o StatusReason) validate() error {
+	if o.From == nil || o.To == nil || o.Reason == nil {
+		return errors.New("missing mandatory 'from', 'to' or 'reason'")
+	}
+
+	if *o.Reason == "" {
+		return errors.New("empty 'reason'")
+	}
+
+	if *o.To < *o.From {
+		return fmt.Errorf("'to' (%s) is better than 'from' (%s)", o.To, o.From)
+	}
+
+	return nil
+}
+
+func ToStatusReasons(v interface{}) (*[]StatusReason, error) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON array")
+	}
+
+	ret := make([]StatusReason, 0, len(l))
+
+	parsers := map[string]parser{
+		"from": func(v interface{}) (interface{}, error) {
+			return ToTrustTier(v)
+		},
+		"to": func(v interface{}) (interface{}, error) {
+			return ToTrustTier(v)
+		},
+	}
+
+	for i, e := range l {
+		var reason StatusReason
+
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry %d: not a JSON object", i)
+		}
+
+		if err := populateStructFromMap(&reason, m, "json", parsers, stringPtrParser, false); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		if err := reason.validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		ret = append(ret, reason)
+	}
+
+	return &ret, nil
 }
 
 // SetKeyAttestation sets the value of `akpub` in the
 // "ear.veraison.key-attestation" claim.
 // The following key types are currently supported: *rsa.PublicKey,
 // *ecdsa.PublicKey, ed25519.PublicKey (not a pointer).
-// Unsupported key types result in an error.
-func (o *AppraisalExtensions) SetKeyAttestation(pub any) error {
+// Unsupported key types result in an error.  The key identifier, the
+// attestation nonce and the key usage constraints can optionally be carried
+// alongside `akpub` (see WithAttestedKeyID, WithAttestedKeyNonce and
+// WithAttestedKeyUsage).
+func (o *AppraisalExtensions) SetKeyAttestation(pub any, opts ...KeyAttestationOption) error {
 	switch v := pub.(type) {
 	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
 	default:
@@ -53,22 +148,34 @@ func (o *AppraisalExtensions) SetKeyAttestation(pub any) error {
 
 	akpub := base64.RawURLEncoding.EncodeToString(k)
 
-	o.VeraisonKeyAttestation = &map[string]interface{}{
-		"akpub": akpub,
-	}
-
-	return nil
+	return o.setKeyAttestation("akpub", akpub, opts)
 }
 
 // GetKeyAttestation returns the decoded public key carried in the
 // "ear.veraison.key-attestation" claim.
-// The returned key type is one supported by x509.ParsePKIXPublicKey.
+// The returned key type is one supported by x509.ParsePKIXPublicKey.  Keys
+// carried in JWK or COSE_Key format (see SetKeyAttestationJWK and
+// SetKeyAttestationCOSEKey) are converted.
 func (o AppraisalExtensions) GetKeyAttestation() (any, error) {
 	if o.VeraisonKeyAttestation == nil {
 		return nil, errors.New(`"ear.veraison.key-attestation" claim not found`)
 	}
 
 	v, ok := (*o.VeraisonKeyAttestation)["akpub"]
+	if !ok && o.hasNativeKeyAttestation() {
+		k, err := o.GetKeyAttestationJWK()
+		if err != nil {
+			return nil, err
+		}
+
+		var pub any
+		if err := k.Raw(&pub); err != nil {
+			return nil, fmt.Errorf("extracting raw key: %w", err)
+		}
+
+		return pub, nil
+	}
+
 	if !ok {
 		return nil, errors.New(`"akpub" claim not found in "ear.veraison.key-attestation"`)
 	}
@@ -98,25 +205,110 @@ func (o AppraisalExtensions) GetKeyAttestation() (any, error) {
 // that the overall result will not assert to be more trustworthy than
 // individual vector claims (though it could be less trustworthy if had been
 // manually set that way).
+//
+// Any downgrade recorded in "ear.veraison.status-reasons" is also honored,
+// i.e., the status will not be better than the worst recorded downgrade.
+//
+// If the status has been overridden by a policy (see OverrideStatus), it is
+// set to the tier of the last recorded override instead, regardless of the
+// trust vector.
 func (o *Appraisal) UpdateStatusFromTrustVector() {
+	if to, ok := o.overriddenStatus(); ok {
+		o.Status = &to
+		return
+	}
+
 	for _, claimValue := range o.TrustVector.AsMap() {
 		claimTier := claimValue.GetTier()
 		if *o.Status < claimTier {
 			*o.Status = claimTier
 		}
 	}
+
+	if o.VeraisonStatusReasons != nil {
+		for _, r := range *o.VeraisonStatusReasons {
+			if r.To != nil && *o.Status < *r.To {
+				*o.Status = *r.To
+			}
+		}
+	}
+}
+
+// Downgrade lowers the appraisal status to the specified tier and records the
+// reason in the "ear.veraison.status-reasons" claim.  The status is never
+// raised: if the current status is already at, or below, the requested tier,
+// Downgrade is a no-op.  An error is returned if the tier is not valid or the
+// reason is empty.
+func (o *Appraisal) Downgrade(to TrustTier, reason string) error {
+	if _, ok := TrustTierToString[to]; !ok {
+		return fmt.Errorf("not a valid TrustTier value: %d", to)
+	}
+
+	if reason == "" {
+		return errors.New("empty reason")
+	}
+
+	from := TrustTierNone
+	if o.Status != nil {
+		from = *o.Status
+	}
+
+	if o.Status != nil && from >= to {
+		return nil
+	}
+
+	status := to
+	o.Status = &status
+
+	if o.VeraisonStatusReasons == nil {
+		o.VeraisonStatusReasons = &[]StatusReason{}
+	}
+
+	*o.VeraisonStatusReasons = append(*o.VeraisonStatusReasons, StatusReason{
+		From:   &from,
+		To:     &to,
+		Reason: &reason,
+	})
+
+	return nil
 }
 
 // AsMap returns a map[string]interface{} with EAR Appraisal claim names mapped
 // onto corresponding values.
 func (o Appraisal) AsMap() map[string]interface{} {
-	m, err := structAsMap(o, "json")
-	if err != nil {
-		// An error can only be returned if there is issue in implementation of
-		// AttestationResult; specifically, if any of its
-		// constituents incorrectly implement AsMap() themselves.
-		panic(err)
+	m := make(map[string]interface{}, 4)
+
+	putClaim(m, "ear.status", o.Status, true)
+
+	if o.TrustVector != nil {
+		tv := make(map[string]interface{}, len(trustVectorClaimNames))
+		for name, c := range o.TrustVector.AsMap() {
+			tv[name] = c
+		}
+		m["ear.trustworthiness-vector"] = tv
+	}
+
+	putClaim(m, "ear.appraisal-policy-id", o.AppraisalPolicyID, false)
+	putClaim(m, "ear.evidence-digest", o.EvidenceDigest, false)
+	putClaim(m, "ueid", o.UEID, false)
+	putClaim(m, "oemid", o.OEMID, false)
+	putClaim(m, "hwmodel", o.HardwareModel, false)
+	putClaim(m, "hwversion", o.HardwareVersion, false)
+
+	if o.LinkedResults != nil {
+		links := make([]interface{}, 0, len(*o.LinkedResults))
+		for _, l := range *o.LinkedResults {
+			lm := map[string]interface{}{}
+			putStructClaims(lm, l)
+			links = append(links, lm)
+		}
+		m["ear.linked-results"] = links
 	}
+
+	putStructClaims(m, o.AppraisalExtensions)
+	putProfileExtensions(m, o.ProfileExtensions)
+	putExtraClaims(m, o.Extra)
+
 	return m
 }
 
@@ -125,6 +317,70 @@ func (o Appraisal) validate() error {
 		return errors.New("missing mandatory 'ear.status'")
 	}
 
+	if o.EvidenceDigest != nil {
+		if err := o.EvidenceDigest.Validate(); err != nil {
+			return fmt.Errorf("invalid value for 'ear.evidence-digest': %w", err)
+		}
+	}
+
+	if err := o.validateHardwareIdentity(); err != nil {
+		return err
+	}
+
+	if o.LinkedResults != nil {
+		for i, r := range *o.LinkedResults {
+			if err := r.validate(); err != nil {
+				return fmt.Errorf("'ear.linked-results' entry %d: %w", i, err)
+			}
+		}
+	}
+
+	if o.VeraisonKeyAttestation != nil {
+		if err := validateKeyAttestation(*o.VeraisonKeyAttestation); err != nil {
+			return fmt.Errorf("invalid value for 'ear.veraison.key-attestation': %w", err)
+		}
+	}
+
+	if o.VeraisonStatusReasons != nil {
+		for i, r := range *o.VeraisonStatusReasons {
+			if err := r.validate(); err != nil {
+				return fmt.Errorf("'ear.veraison.status-reasons' entry %d: %w", i, err)
+			}
+		}
+	}
+
+	if o.VeraisonPolicyResults != nil {
+		for i, r := range *o.VeraisonPolicyResults {
+			if err := r.validate(); err != nil {
+				return fmt.Errorf("'ear.veraison.policy-results' entry %d: %w", i, err)
+			}
+		}
+	}
+
+	if o.VeraisonReasons != nil {
+		for i, r := range *o.VeraisonReasons {
+			if err := r.validate(); err != nil {
+				return fmt.Errorf("'ear.veraison.reasons' entry %d: %w", i, err)
+			}
+		}
+	}
+
+	if o.VeraisonStatusOverrides != nil {
+		for i, r := range *o.VeraisonStatusOverrides {
+			if err := r.validate(); err != nil {
+				return fmt.Errorf("'ear.veraison.status-overrides' entry %d: %w", i, err)
+			}
+		}
+	}
+
+	if o.VeraisonUnprocessedEvidence != nil {
+		for i, u := range *o.VeraisonUnprocessedEvidence {
+			if err := u.validate(); err != nil {
+				return fmt.Errorf("'ear.veraison.unprocessed-evidence' entry %d: %w", i, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -136,19 +392,83 @@ func ToAppraisal(v interface{}) (*Appraisal, error) {
 		return nil, errors.New("not a JSON object")
 	}
 
-	parsers := map[string]parser{
-		"ear.status": func(v interface{}) (interface{}, error) {
-			return ToTrustTier(v)
-		},
-		"ear.trustworthiness-vector": func(v interface{}) (interface{}, error) {
-			return ToTrustVector(v)
-		},
-		"ear.veraison.annotated-evidence": stringMapPtrParser,
-		"ear.veraison.policy-claims":      stringMapPtrParser,
-		"ear.veraison.key-attestation":    stringMapPtrParser,
+	err := appraisal.populateFromMap(m)
+
+	return &appraisal, err
+}
+
+// appraisalExtensionsParsers are the parsers of the claims in
+// AppraisalExtensions
+var appraisalExtensionsParsers = map[string]parser{
+	"ear.veraison.annotated-evidence": stringMapPtrParser,
+	"ear.veraison.policy-claims":      stringMapPtrParser,
+	"ear.veraison.key-attestation":    stringMapPtrParser,
+	"ear.veraison.status-reasons": func(v interface{}) (interface{}, error) {
+		return ToStatusReasons(v)
+	},
+	"ear.veraison.policy-results": func(v interface{}) (interface{}, error) {
+		return ToPolicyResults(v)
+	},
+	"ear.veraison.reasons": func(v interface{}) (interface{}, error) {
+		return ToReasons(v)
+	},
+	"ear.veraison.status-overrides": func(v interface{}) (interface{}, error) {
+		return ToStatusOverrides(v)
+	},
+	"ear.veraison.unprocessed-evidence": func(v interface{}) (interface{}, error) {
+		return ToUnprocessedEvidence(v)
+	},
+}
+
+func (o *Appraisal) populateFromMap(m map[string]interface{}) error {
+	r := claimsReader{m: m}
+
+	if v, ok := r.read("ear.status", true, func(v interface{}) (interface{}, error) {
+		return ToTrustTier(v)
+	}); ok {
+		o.Status = v.(*TrustTier)
 	}
 
-	err := populateStructFromMap(&appraisal, m, "json", parsers, stringPtrParser, true)
+	if v, ok := r.read("ear.trustworthiness-vector", false, func(v interface{}) (interface{}, error) {
+		return ToTrustVector(v)
+	}); ok {
+		o.TrustVector = v.(*TrustVector)
+	}
 
-	return &appraisal, err
+	r.readString(&o.AppraisalPolicyID, "ear.appraisal-policy-id", false)
+
+	if v, ok := r.read("ear.evidence-digest", false, digestParser); ok {
+		o.EvidenceDigest = v.(*Digest)
+	}
+
+	for _, c := range []struct {
+		dst  **B64Url
+		name string
+	}{
+		{&o.UEID, "ueid"},
+		{&o.OEMID, "oemid"},
+		{&o.HardwareModel, "hwmodel"},
+	} {
+		if v, ok := r.read(c.name, false, b64urlBytesPtrParser); ok {
+			*c.dst = v.(*B64Url)
+		}
+	}
+
+	if v, ok := r.read("hwversion", false, func(v interface{}) (interface{}, error) {
+		return ToHardwareVersion(v)
+	}); ok {
+		o.HardwareVersion = v.(*HardwareVersion)
+	}
+
+	if v, ok := r.read("ear.linked-results", false, func(v interface{}) (interface{}, error) {
+		return ToLinkedResults(v)
+	}); ok {
+		o.LinkedResults = v.(*[]LinkedResult)
+	}
+
+	r.readExtensions(&o.AppraisalExtensions, appraisalExtensionsParsers)
+	o.readProfileClaims(m)
+	o.Extra = unknownClaims(m, appraisalClaimNames)
+
+	return r.err(nil)
 }
@@ -6,10 +6,12 @@ package ear
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"encoding/json"
 	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAppraisalExtensions_SetGetKeyAttestation_ok(t *testing.T) {
@@ -91,3 +93,106 @@ func TestAppraisalExtensions_GetKeyAttestation_fail_akpub_no_b64url(t *testing.T
 	_, err := tv.GetKeyAttestation()
 	assert.EqualError(t, err, `"ear.veraison.key-attestation" malformed: decoding "akpub": illegal base64 data at input byte 84`)
 }
+
+func TestAppraisal_Downgrade(t *testing.T) {
+	status := TrustTierAffirming
+	appraisal := Appraisal{
+		Status:      &status,
+		TrustVector: &TrustVector{},
+	}
+
+	err := appraisal.Downgrade(TrustTierWarning, "stale-reference-values")
+	require.NoError(t, err)
+	assert.Equal(t, TrustTierWarning, *appraisal.Status)
+
+	// never raises the status
+	err = appraisal.Downgrade(TrustTierAffirming, "whatever")
+	require.NoError(t, err)
+	assert.Equal(t, TrustTierWarning, *appraisal.Status)
+
+	err = appraisal.Downgrade(TrustTierContraindicated, "revoked-key")
+	require.NoError(t, err)
+	assert.Equal(t, TrustTierContraindicated, *appraisal.Status)
+
+	require.NotNil(t, appraisal.VeraisonStatusReasons)
+	reasons := *appraisal.VeraisonStatusReasons
+	require.Len(t, reasons, 2)
+	assert.Equal(t, TrustTierAffirming, *reasons[0].From)
+	assert.Equal(t, TrustTierWarning, *reasons[0].To)
+	assert.Equal(t, "stale-reference-values", *reasons[0].Reason)
+	assert.Equal(t, TrustTierWarning, *reasons[1].From)
+	assert.Equal(t, TrustTierContraindicated, *reasons[1].To)
+	assert.Equal(t, "revoked-key", *reasons[1].Reason)
+
+	// UpdateStatusFromTrustVector honors recorded downgrades
+	*appraisal.Status = TrustTierAffirming
+	appraisal.TrustVector.Hardware = GenuineHardwareClaim
+	appraisal.UpdateStatusFromTrustVector()
+	assert.Equal(t, TrustTierContraindicated, *appraisal.Status)
+}
+
+func TestAppraisal_Downgrade_fail(t *testing.T) {
+	var appraisal Appraisal
+
+	err := appraisal.Downgrade(TrustTier(42), "reason")
+	assert.EqualError(t, err, "not a valid TrustTier value: 42")
+
+	err = appraisal.Downgrade(TrustTierWarning, "")
+	assert.EqualError(t, err, "empty reason")
+}
+
+func TestAppraisal_StatusReasons_round_trip(t *testing.T) {
+	status := TrustTierAffirming
+	appraisal := Appraisal{Status: &status}
+
+	require.NoError(t, appraisal.Downgrade(TrustTierWarning, "stale-reference-values"))
+
+	data, err := json.Marshal(appraisal.AsMap())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ear.status": "warning",
+		"ear.veraison.status-reasons": [
+			{"from": "affirming", "to": "warning", "reason": "stale-reference-values"}
+		]
+	}`, string(data))
+
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	actual, err := ToAppraisal(m)
+	require.NoError(t, err)
+	assert.Equal(t, appraisal, *actual)
+}
+
+func TestToStatusReasons_fail(t *testing.T) {
+	tvs := []struct {
+		v        interface{}
+		expected string
+	}{
+		{
+			v:        "foo",
+			expected: "not a JSON array",
+		},
+		{
+			v:        []interface{}{"foo"},
+			expected: "entry 0: not a JSON object",
+		},
+		{
+			v: []interface{}{
+				map[string]interface{}{"from": "warning", "to": "affirming", "reason": "x"},
+			},
+			expected: "entry 0: 'to' (affirming) is better than 'from' (warning)",
+		},
+		{
+			v: []interface{}{
+				map[string]interface{}{"from": "warning", "to": "nope", "reason": "x"},
+			},
+			expected: `entry 0: invalid value(s) for 'to' (not a valid TrustTier name: "nope")`,
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToStatusReasons(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
@@ -29,6 +29,21 @@ var (
 		"d": "V8kgd2ZBRuh2dgyVINBUqpPDr7BOMGcF22CQMIUHtNM"
 	}`
 
+	testEd25519PublicKey = `{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"x": "SIQqFgRxE3KpxwptCkDkxaGH2QYrhbIrSOnqbElY6N8"
+	}`
+
+	testEd25519PrivateKey = `{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"x": "SIQqFgRxE3KpxwptCkDkxaGH2QYrhbIrSOnqbElY6N8",
+		"d": "Farn_D3yos_3e2TkKTsc_Vu5nAI2oZFJXXGs81xodpE"
+	}`
+
+	testEdDSAToken = `eyJhbGciOiJFZERTQSIsInR5cCI6IkpXVCJ9.eyJlYXIudmVyaWZpZXItaWQiOnsiYnVpbGQiOiJycnRyYXAtdjEuMC4wIiwiZGV2ZWxvcGVyIjoiQWNtZSBJbmMuIn0sImVhdF9wcm9maWxlIjoidGFnOmdpdGh1Yi5jb20sMjAyMzp2ZXJhaXNvbi9lYXIiLCJpYXQiOjE2NjYwOTEzNzMsInN1Ym1vZHMiOnsidGVzdCI6eyJlYXIuYXBwcmFpc2FsLXBvbGljeS1pZCI6InBvbGljeTovL3Rlc3QvMDEyMzQiLCJlYXIuc3RhdHVzIjoiYWZmaXJtaW5nIiwiZWFyLnZlcmFpc29uLmFubm90YXRlZC1ldmlkZW5jZSI6eyJrMSI6InYxIiwiazIiOiJ2MiJ9LCJlYXIudmVyYWlzb24ua2V5LWF0dGVzdGF0aW9uIjp7ImFrcHViIjoiWVd0d2RXSUsifSwiZWFyLnZlcmFpc29uLnBvbGljeS1jbGFpbXMiOnsiYmFyIjoiYmF6IiwiZm9vIjoiYmFyIn19fX0.RpykFMGbcRtZ3fc6W-rqTe9OHowl2Y0OnGqWxRFr7XpuonzY8ajUxAZ4EG-5S5GKD8xcES-vXJL-sCMS7D5IAQ`
+
 	testVidBuild     = "rrtrap-v1.0.0"
 	testVidDeveloper = "Acme Inc."
 
@@ -129,6 +144,43 @@ func TestToJSON_fail(t *testing.T) {
 			},
 			expected: `invalid value(s) for eat_nonce (4 bytes)`,
 		},
+		{
+			ar: AttestationResult{
+				IssuedAt:   &testIAT,
+				Profile:    &testProfile,
+				VerifierID: &testVerifierID,
+				Nonces:     []string{testNonce, testBadNonce},
+				Submods: map[string]*Appraisal{
+					"test": {Status: &testTrustTier},
+				},
+			},
+			expected: `invalid value(s) for eat_nonce (nonce 1 is 4 bytes)`,
+		},
+		{
+			ar: AttestationResult{
+				IssuedAt:   &testIAT,
+				Profile:    &testProfile,
+				VerifierID: &testVerifierID,
+				Nonces:     []string{testNonce},
+				Submods: map[string]*Appraisal{
+					"test": {Status: &testTrustTier},
+				},
+			},
+			expected: `invalid value(s) for eat_nonce (array of 1 nonce(s), expecting at least 2)`,
+		},
+		{
+			ar: AttestationResult{
+				IssuedAt:   &testIAT,
+				Profile:    &testProfile,
+				VerifierID: &testVerifierID,
+				Nonce:      &testNonce,
+				Nonces:     []string{testNonce, testNonce},
+				Submods: map[string]*Appraisal{
+					"test": {Status: &testTrustTier},
+				},
+			},
+			expected: `invalid value(s) for eat_nonce (both single and array forms set)`,
+		},
 	}
 
 	for i, tv := range tvs {
@@ -261,6 +313,29 @@ func TestRoundTrip_pass(t *testing.T) {
 	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
 }
 
+func TestRoundTrip_EdDSA(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.EdDSA, sigK)
+	require.NoError(t, err)
+
+	// Ed25519 signatures are deterministic
+	assert.Equal(t, testEdDSAToken, string(token))
+
+	var actual AttestationResult
+
+	err = actual.Verify(token, jwa.EdDSA, vfyK)
+	require.NoError(t, err)
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+	err = actual.Verify(token, jwa.ES256, vfyK)
+	assert.ErrorContains(t, err, "failed verifying JWT message")
+}
+
 func TestRoundTrip_tampering(t *testing.T) {
 	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
 	require.NoError(t, err)
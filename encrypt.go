@@ -0,0 +1,242 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+)
+
+// Encrypt wraps the signed JWT token (see Sign) in a JWE, i.e., it produces a
+// nested signed-then-encrypted token (RFC 7519, Section 5.2) for confidential
+// delivery to the relying party.  The content encryption key is protected
+// using the key management algorithm keyAlg (e.g., ECDH-ES+A256KW or
+// RSA-OAEP-256) and the recipient's key, which can either be a jwk.Key or a
+// raw key suitable for keyAlg.  The content is encrypted using enc (e.g.,
+// A256GCM).  The `cty` protected header parameter is set to "JWT".
+func Encrypt(
+	token []byte,
+	keyAlg jwa.KeyEncryptionAlgorithm,
+	key interface{},
+	enc jwa.ContentEncryptionAlgorithm,
+) ([]byte, error) {
+	hdrs := jwe.NewHeaders()
+	if err := hdrs.Set(jwe.ContentTypeKey, "JWT"); err != nil {
+		return nil, fmt.Errorf("setting header %s: %w", jwe.ContentTypeKey, err)
+	}
+
+	data, err := jwe.Encrypt(token,
+		jwe.WithKey(keyAlg, key),
+		jwe.WithContentEncryption(enc),
+		jwe.WithProtectedHeaders(hdrs),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed encrypting JWT: %w", err)
+	}
+
+	return data, nil
+}
+
+// Decrypt decrypts a JWE produced by Encrypt using the key management
+// algorithm keyAlg and the recipient's (private) key, and returns the nested
+// JWT, which is still to be verified (see Verify)
+func Decrypt(
+	data []byte,
+	keyAlg jwa.KeyEncryptionAlgorithm,
+	key interface{},
+) ([]byte, error) {
+	token, err := jwe.Decrypt(data, jwe.WithKey(keyAlg, key))
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting JWE message: %w", err)
+	}
+
+	return token, nil
+}
+
+// COSE header labels and algorithms used by COSE_Encrypt0 (RFC 9052, RFC 9053)
+const (
+	coseEncrypt0Tag        = 16
+	coseHeaderAlgorithm    = 1
+	coseHeaderContentType  = 3
+	coseHeaderIV           = 5
+	coseContentFormatCWT   = 61
+	coseAESGCMNonceSize    = 12
+	coseEncrypt0EncContext = "Encrypt0"
+)
+
+// coseContentAlgorithms maps the content encryption algorithms that can be
+// used to encrypt CWTs onto the corresponding COSE algorithms
+var coseContentAlgorithms = map[jwa.ContentEncryptionAlgorithm]int64{
+	jwa.A128GCM: 1,
+	jwa.A192GCM: 2,
+	jwa.A256GCM: 3,
+}
+
+// coseContentKeySizes maps the COSE content encryption algorithms onto the
+// size of their keys
+var coseContentKeySizes = map[int64]int{
+	1: 16,
+	2: 24,
+	3: 32,
+}
+
+// coseEncrypt0 is the COSE_Encrypt0 structure (without the tag)
+type coseEncrypt0 struct {
+	_           struct{} `cbor:",toarray"`
+	Protected   []byte
+	Unprotected map[int64]interface{}
+	Ciphertext  []byte
+}
+
+// EncryptCWT wraps the signed CWT token (see SignCWT) in a tagged
+// COSE_Encrypt0 message, i.e., it produces a nested signed-then-encrypted
+// token for confidential delivery to the relying party.  The content is
+// encrypted using enc, which must be one of A128GCM, A192GCM or A256GCM, and
+// the symmetric key shared with the relying party, which can either be a
+// jwk.Key or a []byte of the matching length.  The content type protected
+// header parameter is set to application/cwt.
+func EncryptCWT(
+	token []byte,
+	enc jwa.ContentEncryptionAlgorithm,
+	key interface{},
+) ([]byte, error) {
+	alg, ok := coseContentAlgorithms[enc]
+	if !ok {
+		return nil, fmt.Errorf("unsupported content encryption algorithm %q", enc)
+	}
+
+	aead, err := newCOSEAEAD(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	protected, err := cborEncMode.Marshal(map[int64]interface{}{
+		coseHeaderAlgorithm:   alg,
+		coseHeaderContentType: coseContentFormatCWT,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding protected header: %w", err)
+	}
+
+	aad, err := coseEncrypt0AAD(protected)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := make([]byte, coseAESGCMNonceSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("generating IV: %w", err)
+	}
+
+	msg := coseEncrypt0{
+		Protected:   protected,
+		Unprotected: map[int64]interface{}{coseHeaderIV: iv},
+		Ciphertext:  aead.Seal(nil, iv, token, aad),
+	}
+
+	return cborEncMode.Marshal(cbor.Tag{Number: coseEncrypt0Tag, Content: msg})
+}
+
+// DecryptCWT decrypts a COSE_Encrypt0 message produced by EncryptCWT using
+// the shared symmetric key, and returns the nested CWT, which is still to be
+// verified (see VerifyCWT)
+func DecryptCWT(data []byte, key interface{}) ([]byte, error) {
+	var tag cbor.RawTag
+	if err := cborDecMode.Unmarshal(data, &tag); err != nil {
+		return nil, fmt.Errorf("failed parsing COSE_Encrypt0 message: %w", err)
+	}
+
+	if tag.Number != coseEncrypt0Tag {
+		return nil, fmt.Errorf("failed parsing COSE_Encrypt0 message: unexpected tag %d", tag.Number)
+	}
+
+	var msg coseEncrypt0
+	if err := cborDecMode.Unmarshal(tag.Content, &msg); err != nil {
+		return nil, fmt.Errorf("failed parsing COSE_Encrypt0 message: %w", err)
+	}
+
+	var protected map[int64]interface{}
+	if err := cborDecMode.Unmarshal(msg.Protected, &protected); err != nil {
+		return nil, fmt.Errorf("failed parsing protected header: %w", err)
+	}
+
+	alg, ok := protected[coseHeaderAlgorithm].(int64)
+	if !ok {
+		return nil, errors.New("missing or invalid algorithm in protected header")
+	}
+
+	iv, ok := msg.Unprotected[coseHeaderIV].([]byte)
+	if !ok || len(iv) != coseAESGCMNonceSize {
+		return nil, errors.New("missing or invalid IV in unprotected header")
+	}
+
+	aead, err := newCOSEAEAD(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	aad, err := coseEncrypt0AAD(msg.Protected)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := aead.Open(nil, iv, msg.Ciphertext, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting COSE_Encrypt0 message: %w", err)
+	}
+
+	return token, nil
+}
+
+// newCOSEAEAD returns the AES-GCM cipher for the COSE algorithm alg, keyed
+// with key
+func newCOSEAEAD(alg int64, key interface{}) (cipher.AEAD, error) {
+	raw, err := rawKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	k, ok := raw.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T: want a symmetric key", raw)
+	}
+
+	size, ok := coseContentKeySizes[alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported COSE content encryption algorithm %d", alg)
+	}
+
+	if len(k) != size {
+		return nil, fmt.Errorf("key is %d bytes, want %d", len(k), size)
+	}
+
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// coseEncrypt0AAD returns the Enc_structure of a COSE_Encrypt0 message with
+// the supplied (serialized) protected header and no external AAD
+func coseEncrypt0AAD(protected []byte) ([]byte, error) {
+	aad, err := cborEncMode.Marshal([]interface{}{
+		coseEncrypt0EncContext,
+		protected,
+		[]byte{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding Enc_structure: %w", err)
+	}
+
+	return aad, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncrypt_Decrypt(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	// the relying party's key pair (the test key is reused for convenience)
+	encK, decK := vfyK, sigK
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	data, err := Encrypt(token, jwa.ECDH_ES_A256KW, encK, jwa.A256GCM)
+	require.NoError(t, err)
+	assert.Len(t, bytes.Split(data, []byte(".")), 5)
+	assert.NotContains(t, string(data), string(token))
+
+	hdr, err := decodeProtectedHeader(string(bytes.Split(data, []byte("."))[0]))
+	require.NoError(t, err)
+	assert.Equal(t, "JWT", hdr["cty"])
+
+	decrypted, err := Decrypt(data, jwa.ECDH_ES_A256KW, decK)
+	require.NoError(t, err)
+	assert.Equal(t, token, decrypted)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(decrypted, jwa.ES256, vfyK))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, actual.Submods)
+
+	_, err = Decrypt(data, jwa.ECDH_ES_A256KW, encK)
+	assert.ErrorContains(t, err, "failed decrypting JWE message")
+}
+
+func TestEncryptCWT_DecryptCWT(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		enc     jwa.ContentEncryptionAlgorithm
+		keySize int
+	}{
+		{jwa.A128GCM, 16},
+		{jwa.A192GCM, 24},
+		{jwa.A256GCM, 32},
+	} {
+		t.Run(tc.enc.String(), func(t *testing.T) {
+			key := bytes.Repeat([]byte{0x42}, tc.keySize)
+
+			data, err := EncryptCWT(token, tc.enc, key)
+			require.NoError(t, err)
+			// tagged COSE_Encrypt0
+			assert.Equal(t, byte(0xd0), data[0])
+
+			decrypted, err := DecryptCWT(data, key)
+			require.NoError(t, err)
+			assert.Equal(t, token, decrypted)
+
+			var actual AttestationResult
+			require.NoError(t, actual.VerifyCWT(decrypted, jwa.ES256, vfyK))
+
+			// a symmetric JWK works too
+			symK, err := jwk.FromRaw(key)
+			require.NoError(t, err)
+
+			decrypted, err = DecryptCWT(data, symK)
+			require.NoError(t, err)
+			assert.Equal(t, token, decrypted)
+
+			// tampering is detected
+			data[len(data)-1] ^= 1
+			_, err = DecryptCWT(data, key)
+			assert.ErrorContains(t, err, "failed decrypting COSE_Encrypt0 message")
+		})
+	}
+}
+
+func TestEncryptCWT_fail(t *testing.T) {
+	_, err := EncryptCWT([]byte("token"), jwa.A128CBC_HS256, make([]byte, 32))
+	assert.EqualError(t, err, `unsupported content encryption algorithm "A128CBC-HS256"`)
+
+	_, err = EncryptCWT([]byte("token"), jwa.A128GCM, make([]byte, 32))
+	assert.EqualError(t, err, "key is 32 bytes, want 16")
+
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	_, err = EncryptCWT([]byte("token"), jwa.A128GCM, sigK)
+	assert.EqualError(t, err, "unsupported key type *ecdsa.PrivateKey: want a symmetric key")
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	_, err = DecryptCWT(token, make([]byte, 16))
+	assert.EqualError(t, err, "failed parsing COSE_Encrypt0 message: unexpected tag 18")
+}
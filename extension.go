@@ -0,0 +1,90 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// SetExtension serializes v and stores the result in the AppraisalExtensions
+// claim called name.  The claim must be one of those declared in
+// AppraisalExtensions (e.g., "ear.veraison.annotated-evidence"), and v must
+// serialize to a JSON object.
+func SetExtension[T any](a *AppraisalExtensions, name string, v T) error {
+	field, err := lookupExtensionField(a, name)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("serializing %q: %w", name, err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil || m == nil {
+		return fmt.Errorf("%q: value of type %T does not serialize to a JSON object", name, v)
+	}
+
+	field.Set(reflect.ValueOf(&m))
+
+	return nil
+}
+
+// GetExtension decodes the AppraisalExtensions claim called name into a value
+// of type T.  The claim must be one of those declared in AppraisalExtensions
+// (e.g., "ear.veraison.annotated-evidence").  An error is returned if the
+// claim is not set, or if its contents cannot be decoded into a T.
+func GetExtension[T any](a *AppraisalExtensions, name string) (T, error) {
+	var ret T
+
+	field, err := lookupExtensionField(a, name)
+	if err != nil {
+		return ret, err
+	}
+
+	if field.IsNil() {
+		return ret, fmt.Errorf("%q claim not found", name)
+	}
+
+	data, err := json.Marshal(field.Interface())
+	if err != nil {
+		return ret, fmt.Errorf("serializing %q: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return ret, fmt.Errorf("%q malformed: %w", name, err)
+	}
+
+	return ret, nil
+}
+
+// lookupExtensionField returns the (settable) AppraisalExtensions field
+// associated with the claim name
+func lookupExtensionField(a *AppraisalExtensions, name string) (reflect.Value, error) {
+	if a == nil {
+		return reflect.Value{}, fmt.Errorf("%q: nil AppraisalExtensions", name)
+	}
+
+	structVal := reflect.ValueOf(a).Elem()
+	structType := structVal.Type()
+	mapPtrType := reflect.TypeOf(&map[string]interface{}{})
+
+	for i := 0; i < structType.NumField(); i++ {
+		tagSpec, ok := parseTag(structType.Field(i).Tag, "json")
+		if !ok || tagSpec.Name != name {
+			continue
+		}
+
+		if structType.Field(i).Type != mapPtrType {
+			return reflect.Value{}, fmt.Errorf("%q: not a map-typed extension", name)
+		}
+
+		return structVal.Field(i), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("%q: unknown extension claim", name)
+}
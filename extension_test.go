@@ -0,0 +1,64 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPSAEvidence struct {
+	ImplementationID  []byte `json:"psa-implementation-id"`
+	SecurityLifecycle int    `json:"psa-security-lifecycle"`
+}
+
+func TestExtension_SetGet_ok(t *testing.T) {
+	tv := testPSAEvidence{
+		ImplementationID:  []byte{0xde, 0xad, 0xbe, 0xef},
+		SecurityLifecycle: 12288,
+	}
+
+	var a AppraisalExtensions
+
+	err := SetExtension(&a, "ear.veraison.annotated-evidence", tv)
+	require.NoError(t, err)
+	require.NotNil(t, a.VeraisonAnnotatedEvidence)
+	assert.Equal(t, "3q2+7w==", (*a.VeraisonAnnotatedEvidence)["psa-implementation-id"])
+
+	actual, err := GetExtension[testPSAEvidence](&a, "ear.veraison.annotated-evidence")
+	require.NoError(t, err)
+	assert.Equal(t, tv, actual)
+}
+
+func TestExtension_Set_fail(t *testing.T) {
+	var a AppraisalExtensions
+
+	err := SetExtension(&a, "ear.veraison.unknown", testPSAEvidence{})
+	assert.EqualError(t, err, `"ear.veraison.unknown": unknown extension claim`)
+
+	err = SetExtension(&a, "ear.veraison.policy-claims", []string{"not", "an", "object"})
+	assert.EqualError(t, err, `"ear.veraison.policy-claims": value of type []string does not serialize to a JSON object`)
+
+	err = SetExtension(&a, "ear.veraison.policy-claims", func() {})
+	assert.ErrorContains(t, err, `serializing "ear.veraison.policy-claims"`)
+
+	err = SetExtension[testPSAEvidence](nil, "ear.veraison.policy-claims", testPSAEvidence{})
+	assert.EqualError(t, err, `"ear.veraison.policy-claims": nil AppraisalExtensions`)
+}
+
+func TestExtension_Get_fail(t *testing.T) {
+	a := AppraisalExtensions{
+		VeraisonPolicyClaims: &map[string]interface{}{
+			"psa-security-lifecycle": "not a number",
+		},
+	}
+
+	_, err := GetExtension[testPSAEvidence](&a, "ear.veraison.annotated-evidence")
+	assert.EqualError(t, err, `"ear.veraison.annotated-evidence" claim not found`)
+
+	_, err = GetExtension[testPSAEvidence](&a, "ear.veraison.policy-claims")
+	assert.ErrorContains(t, err, `"ear.veraison.policy-claims" malformed`)
+}
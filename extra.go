@@ -0,0 +1,51 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"reflect"
+)
+
+var (
+	// resultClaimNames are the names of the top-level claims defined by EAR,
+	// plus the registered JWT claims
+	resultClaimNames = jsonClaimNames(reflect.TypeOf(AttestationResult{}), jwtClaimNames...)
+	// appraisalClaimNames are the names of the appraisal claims defined by
+	// EAR
+	appraisalClaimNames = jsonClaimNames(reflect.TypeOf(Appraisal{}))
+)
+
+// retainExtraClaims sets Extra to the top-level claims in m that are neither
+// defined by EAR nor registered for the profile of the target.  Appraisal
+// claims mirrored at the top level (see WithTopLevelAppraisal) are not
+// retained, since they are only copies of those of a submod.
+func (o *AttestationResult) retainExtraClaims(m map[string]interface{}) {
+	var profile string
+	if o.Profile != nil {
+		profile = *o.Profile
+	}
+
+	known, _ := knownClaimNames(profile)
+	extra := unknownClaims(m, known)
+
+	for _, name := range TopLevelAppraisalClaims {
+		delete(extra, name)
+	}
+
+	if len(extra) == 0 {
+		extra = nil
+	}
+
+	o.Extra = extra
+}
+
+// putExtraClaims adds the retained extra claims to m.  Claims that are already
+// in m, i.e., those that are now known, take precedence.
+func putExtraClaims(m map[string]interface{}, extra map[string]interface{}) {
+	for name, v := range extra {
+		if _, ok := m[name]; !ok {
+			m[name] = v
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtra_round_trip(t *testing.T) {
+	var ar AttestationResult
+	require.NoError(t, ar.UnmarshalJSON(testClaimsWithUnknownClaims))
+	assert.Equal(t, map[string]interface{}{"x-top": "t"}, ar.Extra)
+	assert.Equal(t, map[string]interface{}{"x-sub": float64(42)}, ar.Submods["test"].Extra)
+
+	data, err := ar.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(testClaimsWithUnknownClaims), string(data))
+
+	// a gateway re-signing the result does not lose the extra claims
+	token, err := ar.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, mustParseKey(t, testECDSAPublicKey)))
+	assert.Equal(t, ar.Extra, actual.Extra)
+	assert.Equal(t, ar.Submods["test"].Extra, actual.Submods["test"].Extra)
+}
+
+func TestExtra_known_claims_take_precedence(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Extra = map[string]interface{}{
+		"eat_profile": "tag:example.com,2026:spoofed",
+		"x-top":       "t",
+	}
+
+	m := ar.AsMap()
+	assert.Equal(t, EatProfile, m["eat_profile"])
+	assert.Equal(t, "t", m["x-top"])
+}
+
+func TestExtra_registered_claims_not_retained(t *testing.T) {
+	registerTestExtensions(t)
+
+	data, err := testExtendedResult().MarshalJSON()
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Nil(t, actual.Extra)
+	assert.Nil(t, actual.Submods["test"].Extra)
+
+	// claims registered for a different profile are retained as they are
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &m))
+	m["eat_profile"] = EatProfile
+
+	data, err = json.Marshal(m)
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Equal(t, map[string]interface{}{"example.level": float64(2)}, actual.Extra)
+	assert.Contains(t, actual.Submods["test"].Extra, "example.platform")
+}
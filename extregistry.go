@@ -0,0 +1,377 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ExtensionParser converts the (JSON-decoded) value of a registered extension
+// claim into its typed representation, e.g., a profile-specific struct
+type ExtensionParser func(v interface{}) (interface{}, error)
+
+// ExtensionValidator checks the typed value of a registered extension claim,
+// as returned by the associated ExtensionParser (or as set using
+// SetProfileExtension)
+type ExtensionValidator func(v interface{}) error
+
+type registeredExtension struct {
+	parse    ExtensionParser
+	validate ExtensionValidator
+}
+
+var (
+	extensionsMu sync.RWMutex
+	// extensions maps profiles onto their registered claims
+	extensions = map[string]map[string]registeredExtension{}
+	// knownNames caches, by profile, the names of the known claims (see
+	// knownClaimNames).  It is reset whenever an extension is registered.
+	knownNames = map[string]knownClaims{}
+)
+
+// knownClaims are the names of the top-level and of the appraisal claims that
+// are known for a profile
+type knownClaims struct {
+	result    map[string]bool
+	appraisal map[string]bool
+}
+
+// RegisterExtension attaches the extension claim called claimName to the
+// supplied profile, so that third-party profiles can carry their own typed
+// claims.  The claim is recognized in both the AttestationResult and its
+// Appraisals, but only in results whose `eat_profile` is profile.  (Results
+// using a profile with registered extensions pass validation, even though
+// their `eat_profile` is not EatProfile.)  When decoding, the value of the
+// claim is converted using parser, and the result is stored in the
+// ProfileExtensions of the AttestationResult or the Appraisal.  When
+// validating, it is checked using validator, if not nil.  Registering a claim
+// again replaces the previous registration.  Claims defined by EAR cannot be
+// registered.
+func RegisterExtension(
+	profile string,
+	claimName string,
+	parser ExtensionParser,
+	validator ExtensionValidator,
+) error {
+	if profile == "" {
+		return errors.New("empty profile")
+	}
+
+	if claimName == "" {
+		return errors.New("empty claim name")
+	}
+
+	if parser == nil {
+		return fmt.Errorf("%q: nil parser", claimName)
+	}
+
+	if builtinClaimNames()[claimName] {
+		return fmt.Errorf("%q is defined by EAR", claimName)
+	}
+
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+
+	if extensions[profile] == nil {
+		extensions[profile] = map[string]registeredExtension{}
+	}
+
+	extensions[profile][claimName] = registeredExtension{parser, validator}
+	knownNames = map[string]knownClaims{}
+
+	return nil
+}
+
+// builtinClaimNames returns the names of the claims defined by EAR (and its
+// Veraison extensions) at either scope, plus the registered JWT claims
+func builtinClaimNames() map[string]bool {
+	names := map[string]bool{}
+
+	for n := range resultClaimNames {
+		names[n] = true
+	}
+
+	for n := range appraisalClaimNames {
+		names[n] = true
+	}
+
+	return names
+}
+
+// knownClaimNames returns the names of the top-level and of the appraisal
+// claims that are known for the supplied profile, i.e., those defined by EAR
+// and the extension claims registered for the profile.  The returned maps
+// are shared, and must not be modified.
+func knownClaimNames(profile string) (map[string]bool, map[string]bool) {
+	extensionsMu.RLock()
+	k, ok := knownNames[profile]
+	extensionsMu.RUnlock()
+
+	if ok {
+		return k.result, k.appraisal
+	}
+
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+
+	if k, ok := knownNames[profile]; ok {
+		return k.result, k.appraisal
+	}
+
+	k = knownClaims{resultClaimNames, appraisalClaimNames}
+
+	if registered := extensions[profile]; len(registered) != 0 {
+		k.result = copyNames(resultClaimNames)
+		k.appraisal = copyNames(appraisalClaimNames)
+
+		for n := range registered {
+			k.result[n] = true
+			k.appraisal[n] = true
+		}
+	}
+
+	knownNames[profile] = k
+
+	return k.result, k.appraisal
+}
+
+func copyNames(names map[string]bool) map[string]bool {
+	c := make(map[string]bool, len(names))
+
+	for n := range names {
+		c[n] = true
+	}
+
+	return c
+}
+
+func lookupExtension(profile, claimName string) (registeredExtension, bool) {
+	extensionsMu.RLock()
+	defer extensionsMu.RUnlock()
+
+	ext, ok := extensions[profile][claimName]
+
+	return ext, ok
+}
+
+// isExtendedProfile reports whether any extension is registered for profile
+func isExtendedProfile(profile string) bool {
+	extensionsMu.RLock()
+	defer extensionsMu.RUnlock()
+
+	return len(extensions[profile]) != 0
+}
+
+// registeredClaimNames returns the (sorted) names of the claims registered for
+// profile
+func registeredClaimNames(profile string) []string {
+	extensionsMu.RLock()
+	defer extensionsMu.RUnlock()
+
+	names := make([]string, 0, len(extensions[profile]))
+	for n := range extensions[profile] {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// anyRegisteredClaimNames returns the (sorted) names of the claims registered
+// for any profile
+func anyRegisteredClaimNames() []string {
+	extensionsMu.RLock()
+	defer extensionsMu.RUnlock()
+
+	set := map[string]bool{}
+
+	for _, claims := range extensions {
+		for n := range claims {
+			set[n] = true
+		}
+	}
+
+	names := make([]string, 0, len(set))
+	for n := range set {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ProfileExtension returns the value of the registered extension claim called
+// name (see RegisterExtension)
+func (o AttestationResultExtensions) ProfileExtension(name string) (interface{}, bool) {
+	v, ok := o.ProfileExtensions[name]
+	return v, ok
+}
+
+// SetProfileExtension sets the value of the registered extension claim called
+// name (see RegisterExtension).  The claim is serialized as v serializes to
+// JSON.
+func (o *AttestationResultExtensions) SetProfileExtension(name string, v interface{}) {
+	if o.ProfileExtensions == nil {
+		o.ProfileExtensions = map[string]interface{}{}
+	}
+	o.ProfileExtensions[name] = v
+}
+
+// ProfileExtension returns the value of the registered extension claim called
+// name (see RegisterExtension)
+func (o AppraisalExtensions) ProfileExtension(name string) (interface{}, bool) {
+	v, ok := o.ProfileExtensions[name]
+	return v, ok
+}
+
+// SetProfileExtension sets the value of the registered extension claim called
+// name (see RegisterExtension).  The claim is serialized as v serializes to
+// JSON.
+func (o *AppraisalExtensions) SetProfileExtension(name string, v interface{}) {
+	if o.ProfileExtensions == nil {
+		o.ProfileExtensions = map[string]interface{}{}
+	}
+	o.ProfileExtensions[name] = v
+}
+
+// putProfileExtensions adds the registered extension claims to m
+func putProfileExtensions(m map[string]interface{}, exts map[string]interface{}) {
+	for name, v := range exts {
+		m[name] = v
+	}
+}
+
+// readProfileClaims sets aside the claims that are registered for any profile,
+// so that they can be parsed once the profile of the enclosing result is known
+// (see parseProfileExtensions)
+func (o *Appraisal) readProfileClaims(m map[string]interface{}) {
+	for _, name := range anyRegisteredClaimNames() {
+		v, ok := m[name]
+		if !ok {
+			continue
+		}
+
+		if o.profileClaims == nil {
+			o.profileClaims = map[string]interface{}{}
+		}
+		o.profileClaims[name] = v
+	}
+}
+
+// parseProfileExtensions parses the extension claims registered for the
+// profile of the target AttestationResult, both top-level (using r) and in the
+// submods (as set aside by readProfileClaims)
+func (o *AttestationResult) parseProfileExtensions(r *claimsReader) {
+	var profile string
+	if o.Profile != nil {
+		profile = *o.Profile
+	}
+
+	names := registeredClaimNames(profile)
+
+	for _, name := range names {
+		ext, _ := lookupExtension(profile, name)
+
+		if v, ok := r.read(name, false, parser(ext.parse)); ok {
+			o.SetProfileExtension(name, v)
+		}
+	}
+
+	submods := make([]string, 0, len(o.Submods))
+	for name := range o.Submods {
+		submods = append(submods, name)
+	}
+	sort.Strings(submods)
+
+	for _, submod := range submods {
+		a := o.Submods[submod]
+		if a == nil {
+			continue
+		}
+
+		for _, name := range names {
+			raw, ok := a.profileClaims[name]
+			if !ok {
+				continue
+			}
+
+			delete(a.Extra, name)
+
+			ext, _ := lookupExtension(profile, name)
+
+			v, err := ext.parse(raw)
+			if err != nil {
+				r.invalid = append(r.invalid,
+					fmt.Sprintf("'submods/%s/%s' (%s)", submod, name, err.Error()))
+				continue
+			}
+
+			a.SetProfileExtension(name, v)
+		}
+
+		a.profileClaims = nil
+
+		if len(a.Extra) == 0 {
+			a.Extra = nil
+		}
+	}
+}
+
+// validateProfileExtensions checks the registered extension claims of the
+// target AttestationResult and of its Appraisals, and returns the problems
+// found
+func (o AttestationResult) validateProfileExtensions() []string {
+	var (
+		profile  string
+		problems []string
+	)
+
+	if o.Profile != nil {
+		profile = *o.Profile
+	}
+
+	check := func(path string, exts map[string]interface{}) {
+		names := make([]string, 0, len(exts))
+		for name := range exts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			ext, ok := lookupExtension(profile, name)
+			if !ok {
+				problems = append(problems,
+					fmt.Sprintf("'%s%s' (not registered for profile %q)", path, name, profile))
+				continue
+			}
+
+			if ext.validate == nil {
+				continue
+			}
+
+			if err := ext.validate(exts[name]); err != nil {
+				problems = append(problems, fmt.Sprintf("'%s%s' (%s)", path, name, err.Error()))
+			}
+		}
+	}
+
+	check("", o.ProfileExtensions)
+
+	submods := make([]string, 0, len(o.Submods))
+	for name := range o.Submods {
+		submods = append(submods, name)
+	}
+	sort.Strings(submods)
+
+	for _, submod := range submods {
+		if a := o.Submods[submod]; a != nil {
+			check("submods/"+submod+"/", a.ProfileExtensions)
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testExtProfile = "tag:example.com,2026:test-profile"
+
+type testPlatform struct {
+	Vendor   string `json:"vendor"`
+	Revision int    `json:"revision"`
+}
+
+func testPlatformParser(v interface{}) (interface{}, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON object")
+	}
+
+	vendor, _ := m["vendor"].(string)
+
+	var revision int
+	switch t := m["revision"].(type) {
+	case float64:
+		revision = int(t)
+	case int64:
+		revision = int(t)
+	}
+
+	return testPlatform{Vendor: vendor, Revision: revision}, nil
+}
+
+func testPlatformValidator(v interface{}) error {
+	p, ok := v.(testPlatform)
+	if !ok {
+		return errors.New("not a testPlatform")
+	}
+
+	if p.Vendor == "" {
+		return errors.New("empty vendor")
+	}
+
+	return nil
+}
+
+func registerTestExtensions(t *testing.T) {
+	require.NoError(t, RegisterExtension(testExtProfile, "example.platform",
+		testPlatformParser, testPlatformValidator))
+	require.NoError(t, RegisterExtension(testExtProfile, "example.level",
+		func(v interface{}) (interface{}, error) { return int64Parser(v) }, nil))
+}
+
+func testExtendedResult() AttestationResult {
+	profile := testExtProfile
+
+	a := &Appraisal{Status: &testStatus}
+	a.SetProfileExtension("example.platform", testPlatform{Vendor: "ACME", Revision: 3})
+
+	ar := AttestationResult{
+		Profile:    &profile,
+		IssuedAt:   &testIAT,
+		VerifierID: &testVerifierID,
+		Submods:    map[string]*Appraisal{"test": a},
+	}
+	ar.SetProfileExtension("example.level", int64(2))
+
+	return ar
+}
+
+func TestRegisterExtension_fail(t *testing.T) {
+	tvs := []struct {
+		profile  string
+		name     string
+		parser   ExtensionParser
+		expected string
+	}{
+		{"", "example.platform", testPlatformParser, "empty profile"},
+		{testExtProfile, "", testPlatformParser, "empty claim name"},
+		{testExtProfile, "example.platform", nil, `"example.platform": nil parser`},
+		{testExtProfile, "ear.status", testPlatformParser, `"ear.status" is defined by EAR`},
+		{testExtProfile, "eat_nonce", testPlatformParser, `"eat_nonce" is defined by EAR`},
+		{testExtProfile, "iss", testPlatformParser, `"iss" is defined by EAR`},
+	}
+
+	for i, tv := range tvs {
+		err := RegisterExtension(tv.profile, tv.name, tv.parser, nil)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestRegisterExtension_round_trip(t *testing.T) {
+	registerTestExtensions(t)
+
+	ar := testExtendedResult()
+
+	data, err := ar.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"example.level":2`)
+	assert.Contains(t, string(data), `"example.platform":{"vendor":"ACME","revision":3}`)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Equal(t, ar, actual)
+
+	v, ok := actual.Submods["test"].ProfileExtension("example.platform")
+	require.True(t, ok)
+	assert.Equal(t, testPlatform{Vendor: "ACME", Revision: 3}, v)
+
+	data, err = ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.UnmarshalCBOR(data))
+	assert.Equal(t, ar, actual)
+}
+
+func TestRegisterExtension_other_profile_ignored(t *testing.T) {
+	registerTestExtensions(t)
+
+	data := []byte(`{
+		"eat_profile": "tag:github.com,2023:veraison/ear",
+		"iat": 1666091373,
+		"ear.verifier-id": {"build": "b", "developer": "d"},
+		"example.level": 2,
+		"submods": {"test": {"ear.status": "affirming", "example.platform": {}}}
+	}`)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Nil(t, actual.ProfileExtensions)
+	assert.Nil(t, actual.Submods["test"].ProfileExtensions)
+
+	ws, err := actual.UnmarshalJSONWithWarnings(data)
+	require.NoError(t, err)
+	assert.Len(t, ws, 2)
+}
+
+func TestRegisterExtension_known_claim_names(t *testing.T) {
+	profile := "tag:example.com,2026:cached-profile"
+
+	known, knownAppraisal := knownClaimNames(profile)
+	assert.False(t, known["example.cached"])
+	assert.False(t, knownAppraisal["example.cached"])
+
+	require.NoError(t, RegisterExtension(profile, "example.cached",
+		func(v interface{}) (interface{}, error) { return v, nil }, nil))
+
+	known, knownAppraisal = knownClaimNames(profile)
+	assert.True(t, known["example.cached"])
+	assert.True(t, knownAppraisal["example.cached"])
+
+	// the names defined by EAR are not affected
+	assert.False(t, resultClaimNames["example.cached"])
+	assert.False(t, appraisalClaimNames["example.cached"])
+}
+
+func TestRegisterExtension_invalid(t *testing.T) {
+	registerTestExtensions(t)
+
+	ar := testExtendedResult()
+	ar.Submods["test"].SetProfileExtension("example.platform", testPlatform{})
+	ar.SetProfileExtension("example.unknown", "x")
+
+	_, err := ar.MarshalJSON()
+	assert.EqualError(t, err, "invalid value(s) for "+
+		`'example.unknown' (not registered for profile "`+testExtProfile+`"), `+
+		"'submods/test/example.platform' (empty vendor)")
+
+	data := []byte(`{
+		"eat_profile": "` + testExtProfile + `",
+		"iat": 1666091373,
+		"ear.verifier-id": {"build": "b", "developer": "d"},
+		"example.level": "two",
+		"submods": {"test": {"ear.status": "affirming", "example.platform": []}}
+	}`)
+
+	var actual AttestationResult
+	err = actual.UnmarshalJSON(data)
+	assert.EqualError(t, err, "invalid value(s) for "+
+		"'example.level' (not an int64), "+
+		"'submods/test/example.platform' (not a JSON object)")
+}
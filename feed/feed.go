@@ -0,0 +1,277 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package feed implements a client that subscribes to a feed of signed EARs,
+// such as the one published by a Veraison verifier, verifies the incoming
+// results and hands them to the application.
+//
+// The feed is consumed as a stream of Server-Sent Events (SSE), where the
+// data of each event is a signed EAR (JWT).  Only events of type "message"
+// (the default) and "ear" are processed.  WebSocket feeds are not supported.
+package feed
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/veraison/ear"
+)
+
+// Default reconnection backoff
+const (
+	DefaultMinBackoff = time.Second
+	DefaultMaxBackoff = time.Minute
+)
+
+// KeyFunc returns the algorithm and key that must be used to verify the
+// results in the feed.  It is called when the client starts and, to pick up
+// rotated keys, whenever a result fails verification.
+type KeyFunc func(ctx context.Context) (jwa.KeyAlgorithm, interface{}, error)
+
+// Client subscribes to a feed of signed EARs
+type Client struct {
+	// URL is the location of the feed
+	URL string
+	// HTTPClient is used to connect to the feed.  If nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+	// KeyFunc returns the verification algorithm and key (mandatory)
+	KeyFunc KeyFunc
+	// Options are applied when verifying each result
+	Options []ear.VerifyOption
+	// OnResult is called for each verified result (mandatory)
+	OnResult func(ar *ear.AttestationResult)
+	// OnError, if set, is notified of the errors the client recovers from,
+	// e.g., dropped connections or results that fail verification
+	OnError func(err error)
+	// MinBackoff and MaxBackoff bound the exponential backoff between
+	// reconnection attempts.  If zero, DefaultMinBackoff and
+	// DefaultMaxBackoff are used.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	alg         jwa.KeyAlgorithm
+	key         interface{}
+	lastEventID string
+}
+
+// Run connects to the feed and processes results until ctx is done, which is
+// the only way for Run to return once the client has been validated.  The
+// connection is re-established (with exponential backoff) whenever it drops,
+// resuming from the last received event, if the server supports it.
+func (o *Client) Run(ctx context.Context) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+
+	if err := o.refreshKey(ctx); err != nil {
+		o.notify(err)
+	}
+
+	backoff := o.minBackoff()
+
+	for {
+		received, err := o.subscribe(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err == nil {
+			err = errors.New("feed closed by server")
+		}
+		o.notify(err)
+
+		if received {
+			backoff = o.minBackoff()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > o.maxBackoff() {
+			backoff = o.maxBackoff()
+		}
+	}
+}
+
+func (o Client) validate() error {
+	if o.URL == "" {
+		return errors.New("missing feed URL")
+	}
+
+	if o.KeyFunc == nil {
+		return errors.New("missing key function")
+	}
+
+	if o.OnResult == nil {
+		return errors.New("missing result callback")
+	}
+
+	return nil
+}
+
+func (o Client) minBackoff() time.Duration {
+	if o.MinBackoff <= 0 {
+		return DefaultMinBackoff
+	}
+	return o.MinBackoff
+}
+
+func (o Client) maxBackoff() time.Duration {
+	if o.MaxBackoff <= 0 {
+		return DefaultMaxBackoff
+	}
+	return o.MaxBackoff
+}
+
+func (o Client) notify(err error) {
+	if o.OnError != nil {
+		o.OnError(err)
+	}
+}
+
+func (o *Client) refreshKey(ctx context.Context) error {
+	alg, key, err := o.KeyFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("refreshing key: %w", err)
+	}
+
+	o.alg, o.key = alg, key
+
+	return nil
+}
+
+// subscribe consumes the feed until the connection drops, and reports whether
+// any event has been received
+func (o *Client) subscribe(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.URL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	if o.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", o.lastEventID)
+	}
+
+	client := o.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("connecting to feed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("connecting to feed: unexpected status %s", res.Status)
+	}
+
+	received := false
+
+	err = readEvents(res.Body, func(e event) {
+		received = true
+
+		if e.id != "" {
+			o.lastEventID = e.id
+		}
+
+		if e.typ != "" && e.typ != "message" && e.typ != "ear" {
+			return
+		}
+
+		if err := o.handle(ctx, []byte(e.data)); err != nil {
+			o.notify(err)
+		}
+	})
+
+	return received, err
+}
+
+func (o *Client) handle(ctx context.Context, token []byte) error {
+	var ar ear.AttestationResult
+
+	err := errors.New("no verification key")
+	if o.alg != nil {
+		err = ar.Verify(token, o.alg, o.key, o.Options...)
+	}
+
+	if err != nil {
+		// the verifier may have rotated its key: refresh and retry once
+		if kerr := o.refreshKey(ctx); kerr != nil {
+			return fmt.Errorf("verifying result: %v (%w)", err, kerr)
+		}
+
+		if err = ar.Verify(token, o.alg, o.key, o.Options...); err != nil {
+			return fmt.Errorf("verifying result: %w", err)
+		}
+	}
+
+	o.OnResult(&ar)
+
+	return nil
+}
+
+// event is a Server-Sent Event
+type event struct {
+	id   string
+	typ  string
+	data string
+}
+
+// readEvents parses the Server-Sent Events in r, and calls dispatch for each
+// of them.  The returned error is nil at EOF.
+func readEvents(r io.Reader, dispatch func(event)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	var (
+		e    event
+		data []string
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if len(data) > 0 {
+				e.data = strings.Join(data, "\n")
+				dispatch(e)
+			}
+			e, data = event{id: e.id}, nil
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue // comment
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "data":
+			data = append(data, value)
+		case "event":
+			e.typ = value
+		case "id":
+			e.id = value
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/ear"
+)
+
+var (
+	testPrivateKey = `{
+		"kty": "EC",
+		"crv": "P-256",
+		"x": "usWxHK2PmfnHKwXPS54m0kTcGJ90UiglWiGahtagnv8",
+		"y": "IBOL-C3BttVivg-lSreASjpkttcsz-1rb7btKLv8EX4",
+		"d": "V8kgd2ZBRuh2dgyVINBUqpPDr7BOMGcF22CQMIUHtNM"
+	}`
+
+	testPublicKey = `{
+		"kty": "EC",
+		"crv": "P-256",
+		"x": "usWxHK2PmfnHKwXPS54m0kTcGJ90UiglWiGahtagnv8",
+		"y": "IBOL-C3BttVivg-lSreASjpkttcsz-1rb7btKLv8EX4"
+	}`
+)
+
+func testToken(t *testing.T, submod string) string {
+	sigK, err := jwk.ParseKey([]byte(testPrivateKey))
+	require.NoError(t, err)
+
+	ar := ear.NewAttestationResult(submod, "test-build", "test-developer")
+
+	token, err := ar.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	return string(token)
+}
+
+func testKeyFunc(t *testing.T) KeyFunc {
+	vfyK, err := jwk.ParseKey([]byte(testPublicKey))
+	require.NoError(t, err)
+
+	return func(context.Context) (jwa.KeyAlgorithm, interface{}, error) {
+		return jwa.ES256, vfyK, nil
+	}
+}
+
+func TestReadEvents(t *testing.T) {
+	input := ": comment\n" +
+		"id: 1\n" +
+		"data: a\n" +
+		"data: b\n" +
+		"\n" +
+		"event: ping\n" +
+		"data:c\n" +
+		"\n" +
+		"data: d\n" +
+		"\n" +
+		"data: incomplete\n"
+
+	var events []event
+	require.NoError(t, readEvents(strings.NewReader(input), func(e event) {
+		events = append(events, e)
+	}))
+
+	assert.Equal(t, []event{
+		{id: "1", data: "a\nb"},
+		{id: "1", typ: "ping", data: "c"},
+		{id: "1", data: "d"},
+	}, events)
+}
+
+func TestClient_Run(t *testing.T) {
+	tokens := []string{testToken(t, "first"), testToken(t, "second")}
+
+	var (
+		mu           sync.Mutex
+		lastEventIDs []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		n := len(lastEventIDs)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		// one result per connection, then hang up
+		if n <= len(tokens) {
+			fmt.Fprintf(w, "event: ping\ndata: -\n\nid: %d\ndata: %s\n\n", n, tokens[n-1])
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var submods []string
+
+	c := Client{
+		URL:     srv.URL,
+		KeyFunc: testKeyFunc(t),
+		OnResult: func(ar *ear.AttestationResult) {
+			for name := range ar.Submods {
+				submods = append(submods, name)
+			}
+			if len(submods) == len(tokens) {
+				cancel()
+			}
+		},
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	}
+
+	assert.ErrorIs(t, c.Run(ctx), context.Canceled)
+	assert.Equal(t, []string{"first", "second"}, submods)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"", "1"}, lastEventIDs[:2])
+}
+
+func TestClient_Run_key_refresh(t *testing.T) {
+	token := testToken(t, "rotated")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "data: %s\n\n", token)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	good := testKeyFunc(t)
+	calls := 0
+
+	c := Client{
+		URL: srv.URL,
+		KeyFunc: func(ctx context.Context) (jwa.KeyAlgorithm, interface{}, error) {
+			calls++
+			if calls == 1 {
+				// stale key
+				return jwa.HS256, []byte("stale"), nil
+			}
+			return good(ctx)
+		},
+		OnResult:   func(*ear.AttestationResult) { cancel() },
+		MinBackoff: time.Millisecond,
+	}
+
+	assert.ErrorIs(t, c.Run(ctx), context.Canceled)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_Run_errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "go away", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var errs []error
+
+	c := Client{
+		URL:      srv.URL,
+		KeyFunc:  testKeyFunc(t),
+		OnResult: func(*ear.AttestationResult) {},
+		OnError: func(err error) {
+			errs = append(errs, err)
+			if len(errs) == 3 {
+				cancel()
+			}
+		},
+		MinBackoff: time.Millisecond,
+	}
+
+	assert.ErrorIs(t, c.Run(ctx), context.Canceled)
+	require.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "connecting to feed: unexpected status 503 Service Unavailable")
+
+	assert.EqualError(t, (&Client{}).Run(ctx), "missing feed URL")
+	assert.EqualError(t, (&Client{URL: srv.URL}).Run(ctx), "missing key function")
+	assert.EqualError(t, (&Client{URL: srv.URL, KeyFunc: testKeyFunc(t)}).Run(ctx),
+		"missing result callback")
+}
+
+func TestClient_handle_fail(t *testing.T) {
+	c := Client{
+		KeyFunc: func(context.Context) (jwa.KeyAlgorithm, interface{}, error) {
+			return nil, nil, errors.New("key server down")
+		},
+		OnResult: func(*ear.AttestationResult) {},
+	}
+
+	err := c.handle(context.Background(), []byte("garbage"))
+	assert.EqualError(t, err, "verifying result: no verification key (refreshing key: key server down)")
+}
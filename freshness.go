@@ -0,0 +1,159 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FreshnessPolicy decides whether a (verified) attestation result is fresh
+// enough for the relying party.  The two models supported by EAT are
+// nonce-based freshness (see NonceFreshness), where the result echoes a
+// challenge supplied by the relying party in "eat_nonce", and epoch-based
+// freshness (see EpochFreshness), where the result carries the "epoch-id"
+// that was current when it was issued.
+type FreshnessPolicy interface {
+	CheckFreshness(ar AttestationResult) error
+}
+
+// FreshnessPolicyFunc is an adapter that allows the use of an ordinary
+// function as a FreshnessPolicy.
+type FreshnessPolicyFunc func(ar AttestationResult) error
+
+// CheckFreshness returns f(ar)
+func (f FreshnessPolicyFunc) CheckFreshness(ar AttestationResult) error {
+	return f(ar)
+}
+
+// NonceFreshness returns a FreshnessPolicy that accepts results whose
+// "eat_nonce" matches one of the supplied nonces.  If "eat_nonce" is an array,
+// it is enough that one of its nonces matches.
+func NonceFreshness(nonces ...string) FreshnessPolicy {
+	return FreshnessPolicyFunc(func(ar AttestationResult) error {
+		actual := ar.rawNonces()
+		if len(actual) == 0 {
+			return errors.New("missing 'eat_nonce'")
+		}
+
+		for _, a := range actual {
+			for _, n := range nonces {
+				if subtle.ConstantTimeCompare([]byte(a), []byte(n)) == 1 {
+					return nil
+				}
+			}
+		}
+
+		return errors.New("'eat_nonce' does not match any expected nonce")
+	})
+}
+
+// nonceBytesFreshness is like NonceFreshness, except that the expected nonces
+// are compared with the decoded "eat_nonce" (see GetNonces)
+func nonceBytesFreshness(nonces [][]byte) FreshnessPolicy {
+	return FreshnessPolicyFunc(func(ar AttestationResult) error {
+		if len(ar.rawNonces()) == 0 {
+			return errors.New("missing 'eat_nonce'")
+		}
+
+		actual, ok := ar.GetNonces()
+		if !ok {
+			return errors.New("'eat_nonce' is not base64url-encoded")
+		}
+
+		for _, a := range actual {
+			for _, n := range nonces {
+				if subtle.ConstantTimeCompare(a, n) == 1 {
+					return nil
+				}
+			}
+		}
+
+		return errors.New("'eat_nonce' does not match any expected nonce")
+	})
+}
+
+// GenerateNonce returns a random nonce of the specified size, which must be
+// between 8 and 64 bytes, suitable for use as a freshness challenge.  The
+// relying party hands the nonce to the attester, and then checks that the
+// result echoes it using WithExpectedNonce.
+func GenerateNonce(size int) ([]byte, error) {
+	if size < 8 || size > 64 {
+		return nil, fmt.Errorf("invalid nonce size %d (expecting 8 to 64)", size)
+	}
+
+	nonce := make([]byte, size)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	return nonce, nil
+}
+
+// EpochFreshness returns a FreshnessPolicy that accepts results whose
+// "epoch-id" is one of the supplied epochs.  Typically, these are the current
+// epoch and, to allow for the propagation delay of epoch markers, the
+// previous one.
+func EpochFreshness(epochs ...string) FreshnessPolicy {
+	return FreshnessPolicyFunc(func(ar AttestationResult) error {
+		if ar.EpochID == nil {
+			return errors.New("missing 'epoch-id'")
+		}
+
+		for _, e := range epochs {
+			if *ar.EpochID == e {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("'epoch-id' %q not among the accepted epochs (%s)",
+			*ar.EpochID, strings.Join(epochs, ", "))
+	})
+}
+
+// AnyFreshness returns a FreshnessPolicy that accepts results that satisfy at
+// least one of the supplied policies, e.g., to accept either nonce-based or
+// epoch-based freshness.  If none is satisfied, all the failures are reported.
+func AnyFreshness(policies ...FreshnessPolicy) FreshnessPolicy {
+	return FreshnessPolicyFunc(func(ar AttestationResult) error {
+		if len(policies) == 0 {
+			return errors.New("no freshness policy supplied")
+		}
+
+		problems := make([]string, 0, len(policies))
+
+		for _, p := range policies {
+			err := p.CheckFreshness(ar)
+			if err == nil {
+				return nil
+			}
+			problems = append(problems, err.Error())
+		}
+
+		return errors.New(strings.Join(problems, "; "))
+	})
+}
+
+// WithFreshnessPolicy instructs Verify to check the result against the
+// supplied FreshnessPolicy.  This is in addition to any maximum age requested
+// using WithMaxAge.  A nil policy disables the check.
+func WithFreshnessPolicy(p FreshnessPolicy) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.freshnessPolicy = p
+	})
+}
+
+// WithExpectedNonce instructs Verify to reject results whose (decoded)
+// `eat_nonce` does not match one of the supplied nonces, i.e., the freshness
+// challenges issued by the relying party (see GenerateNonce and SetNonce).
+// This is in addition to any policy set using WithFreshnessPolicy.  An empty
+// list disables the check.
+func WithExpectedNonce(nonces [][]byte) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.expectedNonces = nonces
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreshnessPolicies(t *testing.T) {
+	nonce := testNonce
+	epoch := "epoch-42"
+
+	withNonce := AttestationResult{Nonce: &nonce}
+	withEpoch := AttestationResult{EpochID: &epoch}
+
+	tvs := []struct {
+		policy   FreshnessPolicy
+		ar       AttestationResult
+		expected string
+	}{
+		{NonceFreshness("x", testNonce), withNonce, ""},
+		{NonceFreshness("x"), withNonce, "'eat_nonce' does not match any expected nonce"},
+		{NonceFreshness(testNonce), withEpoch, "missing 'eat_nonce'"},
+		{EpochFreshness("epoch-41", "epoch-42"), withEpoch, ""},
+		{
+			EpochFreshness("epoch-43", "epoch-44"), withEpoch,
+			`'epoch-id' "epoch-42" not among the accepted epochs (epoch-43, epoch-44)`,
+		},
+		{EpochFreshness("epoch-42"), withNonce, "missing 'epoch-id'"},
+		{AnyFreshness(NonceFreshness(testNonce), EpochFreshness("epoch-42")), withNonce, ""},
+		{AnyFreshness(NonceFreshness(testNonce), EpochFreshness("epoch-42")), withEpoch, ""},
+		{
+			AnyFreshness(NonceFreshness(testNonce), EpochFreshness("epoch-42")),
+			AttestationResult{},
+			"missing 'eat_nonce'; missing 'epoch-id'",
+		},
+		{AnyFreshness(), withNonce, "no freshness policy supplied"},
+	}
+
+	for i, tv := range tvs {
+		err := tv.policy.CheckFreshness(tv.ar)
+		if tv.expected == "" {
+			assert.NoError(t, err, "failed test vector at index %d", i)
+		} else {
+			assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+		}
+	}
+}
+
+func TestVerify_WithFreshnessPolicy(t *testing.T) {
+	epoch := "epoch-42"
+
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.EpochID = &epoch
+
+	token, err := ar.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	var actual AttestationResult
+
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK,
+		WithFreshnessPolicy(EpochFreshness("epoch-41", "epoch-42"))))
+	assert.Equal(t, epoch, *actual.EpochID)
+
+	err = actual.Verify(token, jwa.ES256, vfyK,
+		WithFreshnessPolicy(NonceFreshness(testNonce)))
+	assert.EqualError(t, err, "freshness check failed: missing 'eat_nonce'")
+
+	// a nil policy disables the check
+	assert.NoError(t, actual.Verify(token, jwa.ES256, vfyK, WithFreshnessPolicy(nil)))
+}
+
+func TestGenerateNonce(t *testing.T) {
+	nonce, err := GenerateNonce(32)
+	require.NoError(t, err)
+	assert.Len(t, nonce, 32)
+
+	other, err := GenerateNonce(32)
+	require.NoError(t, err)
+	assert.NotEqual(t, nonce, other)
+
+	_, err = GenerateNonce(7)
+	assert.EqualError(t, err, "invalid nonce size 7 (expecting 8 to 64)")
+
+	_, err = GenerateNonce(65)
+	assert.EqualError(t, err, "invalid nonce size 65 (expecting 8 to 64)")
+}
+
+func TestVerify_WithExpectedNonce(t *testing.T) {
+	nonce, err := GenerateNonce(16)
+	require.NoError(t, err)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.SetNonce(nonce)
+
+	token, err := ar.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	var actual AttestationResult
+
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK,
+		WithExpectedNonce([][]byte{[]byte("stale-challenge"), nonce})))
+
+	err = actual.Verify(token, jwa.ES256, vfyK,
+		WithExpectedNonce([][]byte{[]byte("stale-challenge")}))
+	assert.EqualError(t, err, "freshness check failed: 'eat_nonce' does not match any expected nonce")
+
+	// an empty list disables the check
+	assert.NoError(t, actual.Verify(token, jwa.ES256, vfyK, WithExpectedNonce(nil)))
+
+	token, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	var unbound AttestationResult
+	err = unbound.Verify(token, jwa.ES256, vfyK, WithExpectedNonce([][]byte{nonce}))
+	assert.EqualError(t, err, "freshness check failed: missing 'eat_nonce'")
+}
+
+func TestAttestationResult_nonces_round_trip(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.SetNonces([]byte("challenge-from-rp-1"), []byte("challenge-from-rp-2"))
+
+	data, err := ar.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data),
+		`"eat_nonce":["Y2hhbGxlbmdlLWZyb20tcnAtMQ","Y2hhbGxlbmdlLWZyb20tcnAtMg"]`)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Equal(t, ar.Nonces, actual.Nonces)
+	assert.Nil(t, actual.Nonce)
+
+	c, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.UnmarshalCBOR(c))
+	assert.Equal(t, ar.Nonces, actual.Nonces)
+
+	// either nonce satisfies the freshness check
+	assert.NoError(t, NonceFreshness("Y2hhbGxlbmdlLWZyb20tcnAtMg").CheckFreshness(actual))
+	assert.NoError(t, nonceBytesFreshness([][]byte{[]byte("challenge-from-rp-1")}).CheckFreshness(actual))
+
+	err = actual.UnmarshalJSON([]byte(`{"eat_nonce": ["Y2hhbGxlbmdlLWZyb20tcnAtMQ", 42]}`))
+	assert.ErrorContains(t, err, "'eat_nonce' (nonce 1: not a string)")
+}
+
+func TestAttestationResult_validate_empty_epoch_id(t *testing.T) {
+	epoch := ""
+
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.EpochID = &epoch
+
+	_, err := ar.MarshalJSON()
+	assert.EqualError(t, err, "invalid value(s) for epoch-id (empty)")
+}
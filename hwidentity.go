@@ -0,0 +1,249 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// UEID types, see §4.2.1 of draft-ietf-rats-eat
+const (
+	UEIDTypeRAND = 0x01
+	UEIDTypeEUI  = 0x02
+	UEIDTypeIMEI = 0x03
+)
+
+// HardwareVersion is the EAT hardware version claim (hwversion): a version
+// string, optionally qualified by the version scheme that should be used to
+// interpret it (see CoSWID's version-scheme)
+type HardwareVersion struct {
+	Version string
+	Scheme  *int64
+}
+
+// MarshalJSON serializes a HardwareVersion as a JSON array
+func (o HardwareVersion) MarshalJSON() ([]byte, error) {
+	a := []interface{}{o.Version}
+	if o.Scheme != nil {
+		a = append(a, *o.Scheme)
+	}
+
+	return json.Marshal(a)
+}
+
+func (o HardwareVersion) validate() error {
+	if o.Version == "" {
+		return errors.New("empty version")
+	}
+	return nil
+}
+
+func ToHardwareVersion(v interface{}) (*HardwareVersion, error) {
+	var hv HardwareVersion
+
+	a, ok := v.([]interface{})
+	if !ok || len(a) == 0 || len(a) > 2 {
+		return nil, errors.New("not a JSON array of one or two elements")
+	}
+
+	version, ok := a[0].(string)
+	if !ok {
+		return nil, errors.New("version is not a string")
+	}
+	hv.Version = version
+
+	if len(a) == 2 {
+		scheme, err := int64PtrParser(a[1])
+		if err != nil {
+			return nil, errors.New("version scheme is not an integer")
+		}
+		hv.Scheme = scheme.(*int64)
+	}
+
+	return &hv, hv.validate()
+}
+
+func validateUEID(ueid []byte) error {
+	if len(ueid) == 0 {
+		return errors.New("empty UEID")
+	}
+
+	id := ueid[1:]
+
+	switch ueid[0] {
+	case UEIDTypeRAND:
+		if l := len(id); l != 16 && l != 24 && l != 32 {
+			return fmt.Errorf("RAND UEID must be 16, 24 or 32 bytes long (plus type byte), got %d", l)
+		}
+	case UEIDTypeEUI:
+		// EUI-48 or EUI-64
+		if l := len(id); l != 6 && l != 8 {
+			return fmt.Errorf("EUI UEID must be 6 or 8 bytes long (plus type byte), got %d", l)
+		}
+	case UEIDTypeIMEI:
+		return validateIMEI(id)
+	default:
+		return fmt.Errorf("unknown UEID type 0x%02x", ueid[0])
+	}
+
+	return nil
+}
+
+// validateIMEI checks that imei holds the 14 decimal digits of an IMEI
+// (without check digit), either packed as BCD, two digits per byte, or one
+// digit per byte
+func validateIMEI(imei []byte) error {
+	var digits []byte
+
+	switch len(imei) {
+	case 7:
+		for _, b := range imei {
+			digits = append(digits, b>>4, b&0x0f)
+		}
+	case 14:
+		digits = imei
+	default:
+		return fmt.Errorf("IMEI UEID must be 7 (packed BCD) or 14 bytes long (plus type byte), got %d",
+			len(imei))
+	}
+
+	for i, d := range digits {
+		if d > 9 {
+			return fmt.Errorf("IMEI UEID digit %d is not a decimal digit", i)
+		}
+	}
+
+	return nil
+}
+
+func validateOEMID(oemid []byte) error {
+	// IEEE OUI (3 bytes) or random (16 bytes)
+	if l := len(oemid); l != 3 && l != 16 {
+		return fmt.Errorf("OEMID must be 3 (IEEE OUI) or 16 (random) bytes long, got %d", l)
+	}
+	return nil
+}
+
+func validateHardwareModel(hwmodel []byte) error {
+	if l := len(hwmodel); l < 1 || l > 32 {
+		return fmt.Errorf("hardware model must be between 1 and 32 bytes long, got %d", l)
+	}
+	return nil
+}
+
+func (o Appraisal) validateHardwareIdentity() error {
+	if o.UEID != nil {
+		if err := validateUEID(*o.UEID); err != nil {
+			return fmt.Errorf("invalid value for 'ueid': %w", err)
+		}
+	}
+
+	if o.OEMID != nil {
+		if err := validateOEMID(*o.OEMID); err != nil {
+			return fmt.Errorf("invalid value for 'oemid': %w", err)
+		}
+	}
+
+	if o.HardwareModel != nil {
+		if err := validateHardwareModel(*o.HardwareModel); err != nil {
+			return fmt.Errorf("invalid value for 'hwmodel': %w", err)
+		}
+	}
+
+	if o.HardwareVersion != nil {
+		if err := o.HardwareVersion.validate(); err != nil {
+			return fmt.Errorf("invalid value for 'hwversion': %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SetUEID sets the attester's Universal Entity ID.  The first byte of ueid
+// must be a valid UEID type (e.g., UEIDTypeRAND), followed by an identifier of
+// the appropriate length.
+func (o *Appraisal) SetUEID(ueid []byte) error {
+	if err := validateUEID(ueid); err != nil {
+		return err
+	}
+
+	v := B64Url(ueid)
+	o.UEID = &v
+
+	return nil
+}
+
+// GetUEID returns the attester's Universal Entity ID
+func (o Appraisal) GetUEID() ([]byte, error) {
+	if o.UEID == nil {
+		return nil, errors.New(`"ueid" claim not found`)
+	}
+	return *o.UEID, nil
+}
+
+// SetOEMID sets the attester's OEM ID.  oemid must be either a 3-byte IEEE
+// OUI or a 16-byte random identifier.
+func (o *Appraisal) SetOEMID(oemid []byte) error {
+	if err := validateOEMID(oemid); err != nil {
+		return err
+	}
+
+	v := B64Url(oemid)
+	o.OEMID = &v
+
+	return nil
+}
+
+// GetOEMID returns the attester's OEM ID
+func (o Appraisal) GetOEMID() ([]byte, error) {
+	if o.OEMID == nil {
+		return nil, errors.New(`"oemid" claim not found`)
+	}
+	return *o.OEMID, nil
+}
+
+// SetHardwareModel sets the attester's hardware model identifier (1 to 32
+// bytes).
+func (o *Appraisal) SetHardwareModel(hwmodel []byte) error {
+	if err := validateHardwareModel(hwmodel); err != nil {
+		return err
+	}
+
+	v := B64Url(hwmodel)
+	o.HardwareModel = &v
+
+	return nil
+}
+
+// GetHardwareModel returns the attester's hardware model identifier
+func (o Appraisal) GetHardwareModel() ([]byte, error) {
+	if o.HardwareModel == nil {
+		return nil, errors.New(`"hwmodel" claim not found`)
+	}
+	return *o.HardwareModel, nil
+}
+
+// SetHardwareVersion sets the attester's hardware version.  scheme is
+// optional and can be nil.
+func (o *Appraisal) SetHardwareVersion(version string, scheme *int64) error {
+	hv := HardwareVersion{Version: version, Scheme: scheme}
+
+	if err := hv.validate(); err != nil {
+		return err
+	}
+
+	o.HardwareVersion = &hv
+
+	return nil
+}
+
+// GetHardwareVersion returns the attester's hardware version
+func (o Appraisal) GetHardwareVersion() (*HardwareVersion, error) {
+	if o.HardwareVersion == nil {
+		return nil, errors.New(`"hwversion" claim not found`)
+	}
+	return o.HardwareVersion, nil
+}
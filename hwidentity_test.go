@@ -0,0 +1,163 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testUEID = []byte{
+		UEIDTypeRAND,
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+		0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+	}
+	testOEMID   = []byte{0x00, 0x50, 0xc2}
+	testHWModel = []byte("Juno r2")
+)
+
+func TestAppraisal_HardwareIdentity_round_trip(t *testing.T) {
+	status := TrustTierAffirming
+	scheme := int64(1)
+
+	appraisal := Appraisal{Status: &status}
+
+	require.NoError(t, appraisal.SetUEID(testUEID))
+	require.NoError(t, appraisal.SetOEMID(testOEMID))
+	require.NoError(t, appraisal.SetHardwareModel(testHWModel))
+	require.NoError(t, appraisal.SetHardwareVersion("1.2.3", &scheme))
+
+	data, err := json.Marshal(appraisal.AsMap())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ear.status": "affirming",
+		"ueid": "AQABAgMEBQYHCAkKCwwNDg8",
+		"oemid": "AFDC",
+		"hwmodel": "SnVubyByMg",
+		"hwversion": ["1.2.3", 1]
+	}`, string(data))
+
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	actual, err := ToAppraisal(m)
+	require.NoError(t, err)
+	require.NoError(t, actual.validate())
+
+	ueid, err := actual.GetUEID()
+	require.NoError(t, err)
+	assert.Equal(t, testUEID, ueid)
+
+	oemid, err := actual.GetOEMID()
+	require.NoError(t, err)
+	assert.Equal(t, testOEMID, oemid)
+
+	hwmodel, err := actual.GetHardwareModel()
+	require.NoError(t, err)
+	assert.Equal(t, testHWModel, hwmodel)
+
+	hwversion, err := actual.GetHardwareVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", hwversion.Version)
+	assert.Equal(t, int64(1), *hwversion.Scheme)
+}
+
+func TestAppraisal_HardwareIdentity_not_found(t *testing.T) {
+	var appraisal Appraisal
+
+	_, err := appraisal.GetUEID()
+	assert.EqualError(t, err, `"ueid" claim not found`)
+
+	_, err = appraisal.GetOEMID()
+	assert.EqualError(t, err, `"oemid" claim not found`)
+
+	_, err = appraisal.GetHardwareModel()
+	assert.EqualError(t, err, `"hwmodel" claim not found`)
+
+	_, err = appraisal.GetHardwareVersion()
+	assert.EqualError(t, err, `"hwversion" claim not found`)
+}
+
+func TestAppraisal_SetUEID(t *testing.T) {
+	tvs := [][]byte{
+		testUEID,
+		// EUI-48
+		{UEIDTypeEUI, 0x00, 0x50, 0xc2, 0x12, 0x34, 0x56},
+		// EUI-64
+		{UEIDTypeEUI, 0x00, 0x50, 0xc2, 0xff, 0xfe, 0x12, 0x34, 0x56},
+		// IMEI 35209900176148, packed BCD
+		{UEIDTypeIMEI, 0x35, 0x20, 0x99, 0x00, 0x17, 0x61, 0x48},
+		// IMEI 35209900176148, one digit per byte
+		{UEIDTypeIMEI, 3, 5, 2, 0, 9, 9, 0, 0, 1, 7, 6, 1, 4, 8},
+	}
+
+	for i, tv := range tvs {
+		var appraisal Appraisal
+
+		require.NoError(t, appraisal.SetUEID(tv), "failed test vector at index %d", i)
+
+		ueid, err := appraisal.GetUEID()
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, tv, ueid, "failed test vector at index %d", i)
+	}
+}
+
+func TestAppraisal_HardwareIdentity_invalid(t *testing.T) {
+	var appraisal Appraisal
+
+	assert.EqualError(t, appraisal.SetUEID(nil), "empty UEID")
+	assert.EqualError(t, appraisal.SetUEID([]byte{0x04, 0x00}), "unknown UEID type 0x04")
+	assert.EqualError(t, appraisal.SetUEID([]byte{UEIDTypeRAND, 0x00}),
+		"RAND UEID must be 16, 24 or 32 bytes long (plus type byte), got 1")
+	assert.EqualError(t, appraisal.SetUEID([]byte{UEIDTypeEUI, 0x00}),
+		"EUI UEID must be 6 or 8 bytes long (plus type byte), got 1")
+	assert.EqualError(t, appraisal.SetUEID([]byte{UEIDTypeIMEI, 0x35, 0x20, 0x99}),
+		"IMEI UEID must be 7 (packed BCD) or 14 bytes long (plus type byte), got 3")
+	assert.EqualError(t, appraisal.SetUEID([]byte{UEIDTypeIMEI, 0x35, 0x20, 0x99, 0x0a, 0x12, 0x34, 0x56}),
+		"IMEI UEID digit 7 is not a decimal digit")
+	assert.EqualError(t, appraisal.SetOEMID([]byte{0x00}),
+		"OEMID must be 3 (IEEE OUI) or 16 (random) bytes long, got 1")
+	assert.EqualError(t, appraisal.SetHardwareModel([]byte{}),
+		"hardware model must be between 1 and 32 bytes long, got 0")
+	assert.EqualError(t, appraisal.SetHardwareVersion("", nil), "empty version")
+
+	status := TrustTierAffirming
+	bad := B64Url{0x01}
+	appraisal = Appraisal{Status: &status, UEID: &bad}
+	assert.EqualError(t, appraisal.validate(),
+		"invalid value for 'ueid': RAND UEID must be 16, 24 or 32 bytes long (plus type byte), got 0")
+}
+
+func TestToHardwareVersion_fail(t *testing.T) {
+	tvs := []struct {
+		v        interface{}
+		expected string
+	}{
+		{
+			v:        "1.2.3",
+			expected: "not a JSON array of one or two elements",
+		},
+		{
+			v:        []interface{}{1},
+			expected: "version is not a string",
+		},
+		{
+			v:        []interface{}{"1.2.3", "semver"},
+			expected: "version scheme is not an integer",
+		},
+		{
+			v:        []interface{}{""},
+			expected: "empty version",
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToHardwareVersion(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
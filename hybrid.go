@@ -0,0 +1,136 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// HybridPolicy states which of the signatures of a hybrid (classical and
+// post-quantum) signed EAR must be verified for the EAR to be accepted
+type HybridPolicy int
+
+const (
+	// HybridRequireBoth accepts the EAR only if both the classical and the
+	// post-quantum signatures can be verified
+	HybridRequireBoth HybridPolicy = iota
+	// HybridRequireEither accepts the EAR if either the classical or the
+	// post-quantum signature can be verified, e.g., while relying parties are
+	// transitioning between the two
+	HybridRequireEither
+)
+
+func (o HybridPolicy) String() string {
+	switch o {
+	case HybridRequireBoth:
+		return "require-both"
+	case HybridRequireEither:
+		return "require-either"
+	default:
+		return fmt.Sprintf("HybridPolicy(%d)", int(o))
+	}
+}
+
+// SignHybrid signs the AttestationResult with both a classical key (e.g.,
+// ES256) and a post-quantum key (e.g., ML-DSA-65) in one call.  The returned
+// token uses the general JWS JSON serialization, with the classical signature
+// first, followed by the post-quantum one.  Hybrid tokens are checked using
+// VerifyHybrid, which makes the required signatures explicit.
+func (o AttestationResult) SignHybrid(
+	classicalAlg jwa.KeyAlgorithm,
+	classicalKey interface{},
+	pqAlg jwa.KeyAlgorithm,
+	pqKey interface{},
+	opts ...SignOption,
+) ([]byte, error) {
+	token, err := o.Sign(classicalAlg, classicalKey, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := parseJWSAsJSON(token)
+	if err != nil {
+		return nil, err
+	}
+
+	sign, err := newJWSSignFunc(pqAlg, pqKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := msg.sign(pqAlg, pqKey, sign)
+	if err != nil {
+		return nil, fmt.Errorf("signing with %s: %w", pqAlg, err)
+	}
+
+	msg.Signatures = append(msg.Signatures, *sig)
+
+	return json.Marshal(msg)
+}
+
+// VerifyHybrid cryptographically verifies a hybrid signed EAR, as produced by
+// SignHybrid, using the supplied classical and post-quantum algorithms and
+// keys.  The signatures that must be verified are stated by policy.  The
+// payload is then parsed and validated as with Verify.
+func (o *AttestationResult) VerifyHybrid(
+	data []byte,
+	classicalAlg jwa.KeyAlgorithm,
+	classicalKey interface{},
+	pqAlg jwa.KeyAlgorithm,
+	pqKey interface{},
+	policy HybridPolicy,
+	opts ...VerifyOption,
+) error {
+	cfg := newVerifyConfig(opts)
+
+	msg, err := parseJWSAsJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed verifying JWT message: %w", err)
+	}
+
+	classical, err := newJWSVerifyFunc(classicalAlg, classicalKey)
+	if err != nil {
+		return err
+	}
+
+	pq, err := newJWSVerifyFunc(pqAlg, pqKey)
+	if err != nil {
+		return err
+	}
+
+	classicalOK := msg.verifiedBy(msg.Signatures, classicalAlg, classical)
+	pqOK := msg.verifiedBy(msg.Signatures, pqAlg, pq)
+
+	switch policy {
+	case HybridRequireBoth:
+		if !classicalOK {
+			return fmt.Errorf("failed verifying JWT message: missing valid classical (%s) signature", classicalAlg)
+		}
+		if !pqOK {
+			return fmt.Errorf("failed verifying JWT message: missing valid post-quantum (%s) signature", pqAlg)
+		}
+	case HybridRequireEither:
+		if !classicalOK && !pqOK {
+			return fmt.Errorf(
+				"failed verifying JWT message: no valid classical (%s) or post-quantum (%s) signature",
+				classicalAlg, pqAlg,
+			)
+		}
+	default:
+		return fmt.Errorf("unknown hybrid policy %s", policy)
+	}
+
+	claims, err := msg.claims(cfg)
+	if err != nil {
+		return err
+	}
+
+	iss, _ := claims["iss"].(string)
+
+	_, err = o.populateFromClaims(claims, iss, cfg)
+	return err
+}
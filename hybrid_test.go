@@ -0,0 +1,121 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHybrid_round_trip(t *testing.T) {
+	require.NoError(t, RegisterAlgorithm(testCustomAlgorithm))
+
+	ecSigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	ecVfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	pqSigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	pqVfyK, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignHybrid(
+		jwa.ES256, ecSigK, testCustomAlgorithm.alg, pqSigK,
+	)
+	require.NoError(t, err)
+
+	for i, policy := range []HybridPolicy{HybridRequireBoth, HybridRequireEither} {
+		var actual AttestationResult
+		err = actual.VerifyHybrid(token, jwa.ES256, ecVfyK, testCustomAlgorithm.alg, pqVfyK, policy)
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+	}
+
+	// the classical signature alone still verifies with Verify
+	var actual AttestationResult
+	assert.NoError(t, actual.Verify(token, jwa.ES256, ecVfyK))
+}
+
+func TestHybrid_policy(t *testing.T) {
+	require.NoError(t, RegisterAlgorithm(testCustomAlgorithm))
+
+	ecSigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	ecVfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	pqSigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	// classical-only token
+	classicalOnly, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, ecSigK)
+	require.NoError(t, err)
+
+	var ar AttestationResult
+
+	err = ar.VerifyHybrid(classicalOnly, jwa.ES256, ecVfyK, testCustomAlgorithm.alg, pqSigK, HybridRequireBoth)
+	assert.EqualError(t, err, "failed verifying JWT message: missing valid post-quantum (TEST-ALG) signature")
+
+	err = ar.VerifyHybrid(classicalOnly, jwa.ES256, ecVfyK, testCustomAlgorithm.alg, pqSigK, HybridRequireEither)
+	assert.NoError(t, err)
+
+	// PQ-only token
+	pqOnly, err := testAttestationResultsWithVeraisonExtns.Sign(testCustomAlgorithm.alg, pqSigK)
+	require.NoError(t, err)
+
+	err = ar.VerifyHybrid(pqOnly, jwa.ES256, ecVfyK, testCustomAlgorithm.alg, pqSigK, HybridRequireBoth)
+	assert.EqualError(t, err, "failed verifying JWT message: missing valid classical (ES256) signature")
+
+	err = ar.VerifyHybrid(pqOnly, jwa.ES256, ecVfyK, testCustomAlgorithm.alg, pqSigK, HybridRequireEither)
+	assert.NoError(t, err)
+}
+
+func TestHybrid_fail(t *testing.T) {
+	require.NoError(t, RegisterAlgorithm(testCustomAlgorithm))
+
+	ecSigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	ecVfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	pqSigK, err := jwk.ParseKey([]byte(testEd25519PrivateKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignHybrid(
+		jwa.ES256, ecSigK, testCustomAlgorithm.alg, pqSigK,
+	)
+	require.NoError(t, err)
+
+	otherK, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var ar AttestationResult
+
+	// wrong PQ key
+	err = ar.VerifyHybrid(token, jwa.ES256, ecVfyK, testCustomAlgorithm.alg, otherK, HybridRequireEither)
+	assert.NoError(t, err)
+
+	err = ar.VerifyHybrid(token, jwa.ES256, ecVfyK, testCustomAlgorithm.alg, otherK, HybridRequireBoth)
+	assert.EqualError(t, err, "failed verifying JWT message: missing valid post-quantum (TEST-ALG) signature")
+
+	err = ar.VerifyHybrid(token, jwa.ES256, ecVfyK, jwa.ES256, ecVfyK, HybridPolicy(7))
+	assert.EqualError(t, err, "unknown hybrid policy HybridPolicy(7)")
+
+	err = ar.VerifyHybrid([]byte("a.b"), jwa.ES256, ecVfyK, testCustomAlgorithm.alg, pqSigK, HybridRequireEither)
+	assert.EqualError(t, err, "failed verifying JWT message: malformed compact JWS: expecting 3 segments")
+
+	_, err = testAttestationResultsWithVeraisonExtns.SignHybrid(jwa.ES256, ecSigK, jwa.ES256, pqSigK)
+	assert.ErrorContains(t, err, "signing with ES256: ")
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ietf
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+//go:embed examples
+var examplesFS embed.FS
+
+// Example is a published example, as found in the corpus
+type Example struct {
+	// Name is the file name of the example in the corpus, e.g.,
+	// "draft-ietf-rats-ear-jwt-claims.json"
+	Name string
+	Data []byte
+}
+
+// Examples returns the corpus of published examples, sorted by name
+func Examples() ([]Example, error) {
+	entries, err := fs.ReadDir(examplesFS, "examples")
+	if err != nil {
+		return nil, err
+	}
+
+	var es []Example
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		data, err := examplesFS.ReadFile(path.Join("examples", e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading example %q: %w", e.Name(), err)
+		}
+
+		es = append(es, Example{Name: e.Name(), Data: data})
+	}
+
+	sort.Slice(es, func(i, j int) bool { return es[i].Name < es[j].Name })
+
+	return es, nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ietf loads the example attestation results published with the
+// EAR (draft-ietf-rats-ear) and AR4SI (draft-ietf-rats-ar4si) documents,
+// normalizes them into ear.AttestationResult objects and reports where the
+// normalized form diverges from the published one.  This keeps package ear
+// demonstrably aligned with the specifications as they evolve: when a new
+// revision is published, its examples are dropped into the corpus (see
+// Examples) and any divergence shows up in the tests.
+package ietf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/veraison/ear"
+)
+
+// Format is the serialization of an example
+type Format int
+
+const (
+	// FormatJSON is a JSON claims-set
+	FormatJSON Format = iota
+	// FormatJWT is a compact JWS (JWT) wrapping a JSON claims-set
+	FormatJWT
+	// FormatCBOR is a CBOR claims-set, either binary or hex-encoded
+	FormatCBOR
+)
+
+func (o Format) String() string {
+	switch o {
+	case FormatJSON:
+		return "JSON"
+	case FormatJWT:
+		return "JWT"
+	case FormatCBOR:
+		return "CBOR"
+	default:
+		return fmt.Sprintf("Format(%d)", int(o))
+	}
+}
+
+// DivergenceKind classifies a Divergence
+type DivergenceKind string
+
+const (
+	// Dropped means that a published claim is lost in normalization
+	Dropped DivergenceKind = "dropped"
+	// Added means that normalization introduces a claim that was not
+	// published
+	Added DivergenceKind = "added"
+	// Changed means that the value of a claim is altered by normalization
+	Changed DivergenceKind = "changed"
+)
+
+// Divergence is a difference between a published example and its normalized
+// form
+type Divergence struct {
+	// Path is the slash-separated path to the claim, e.g.,
+	// "submods/PARSEC_TPM/ear.status".  CBOR integer keys are rendered in
+	// decimal.
+	Path string
+	Kind DivergenceKind
+	// Published is the published value (nil if Kind is Added)
+	Published interface{}
+	// Normalized is the normalized value (nil if Kind is Dropped)
+	Normalized interface{}
+}
+
+func (o Divergence) String() string {
+	switch o.Kind {
+	case Dropped:
+		return fmt.Sprintf("%s: dropped (published %v)", o.Path, o.Published)
+	case Added:
+		return fmt.Sprintf("%s: added (normalized %v)", o.Path, o.Normalized)
+	default:
+		return fmt.Sprintf("%s: changed (published %v, normalized %v)", o.Path, o.Published, o.Normalized)
+	}
+}
+
+// Imported is a published example normalized into an AttestationResult
+type Imported struct {
+	Format Format
+	Result *ear.AttestationResult
+	// Divergences lists the differences between the published example and
+	// the serialization of Result (in the same format), sorted by path.
+	// Legacy AR4SI examples are converted to the current shape, and
+	// therefore always diverge.
+	Divergences []Divergence
+}
+
+// Import normalizes a published example, which can be a JSON claims-set, a
+// JWT (whose signature is NOT verified), or a CBOR claims-set, either binary
+// or hex-encoded (lines starting with '#' are ignored).  CBOR diagnostic
+// notation is not supported.
+func Import(data []byte) (*Imported, error) {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case len(data) == 0:
+		return nil, errors.New("empty example")
+	case data[0] == '{':
+		return importJSON(data, FormatJSON)
+	case isCompactJWS(data):
+		msg, err := jws.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing JWT: %w", err)
+		}
+		return importJSON(msg.Payload(), FormatJWT)
+	}
+
+	if b, ok := decodeHex(data); ok {
+		data = b
+	}
+
+	return importCBOR(data)
+}
+
+func importJSON(data []byte, f Format) (*Imported, error) {
+	var ar ear.AttestationResult
+	if err := ar.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("normalizing %s example: %w", f, err)
+	}
+
+	normalized, err := ar.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("serializing normalized %s example: %w", f, err)
+	}
+
+	var p, n interface{}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(normalized, &n); err != nil {
+		return nil, err
+	}
+
+	return &Imported{Format: f, Result: &ar, Divergences: diff(p, n)}, nil
+}
+
+func importCBOR(data []byte) (*Imported, error) {
+	var ar ear.AttestationResult
+	if err := ar.UnmarshalCBOR(data); err != nil {
+		return nil, fmt.Errorf("normalizing CBOR example: %w", err)
+	}
+
+	normalized, err := ar.MarshalCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("serializing normalized CBOR example: %w", err)
+	}
+
+	var p, n interface{}
+	if err := cbor.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+
+	if err := cbor.Unmarshal(normalized, &n); err != nil {
+		return nil, err
+	}
+
+	return &Imported{Format: FormatCBOR, Result: &ar, Divergences: diff(p, n)}, nil
+}
+
+func isCompactJWS(data []byte) bool {
+	if bytes.Count(data, []byte(".")) != 2 {
+		return false
+	}
+
+	for _, c := range data {
+		if !(c == '.' || c == '-' || c == '_' ||
+			(c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// decodeHex decodes hex-encoded data, possibly split over multiple lines and
+// interspersed with '#' comment lines
+func decodeHex(data []byte) ([]byte, bool) {
+	var sb strings.Builder
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		sb.WriteString(strings.Join(strings.Fields(line), ""))
+	}
+
+	b, err := hex.DecodeString(sb.String())
+	if err != nil {
+		return nil, false
+	}
+
+	return b, true
+}
+
+func diff(published, normalized interface{}) []Divergence {
+	var ds []Divergence
+
+	diffValue("", published, normalized, &ds)
+
+	sort.Slice(ds, func(i, j int) bool { return ds[i].Path < ds[j].Path })
+
+	return ds
+}
+
+func diffValue(path string, p, n interface{}, ds *[]Divergence) {
+	pm, pok := asMap(p)
+	nm, nok := asMap(n)
+
+	if !pok || !nok {
+		if !reflect.DeepEqual(p, n) {
+			*ds = append(*ds, Divergence{Path: path, Kind: Changed, Published: p, Normalized: n})
+		}
+		return
+	}
+
+	for k, pv := range pm {
+		child := joinPath(path, k)
+
+		nv, ok := nm[k]
+		if !ok {
+			*ds = append(*ds, Divergence{Path: child, Kind: Dropped, Published: pv})
+			continue
+		}
+
+		diffValue(child, pv, nv, ds)
+	}
+
+	for k, nv := range nm {
+		if _, ok := pm[k]; !ok {
+			*ds = append(*ds, Divergence{Path: joinPath(path, k), Kind: Added, Normalized: nv})
+		}
+	}
+}
+
+// asMap returns the supplied JSON object or CBOR map keyed by the string
+// rendering of its keys
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return t, true
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = e
+		}
+		return m, true
+	default:
+		return nil, false
+	}
+}
+
+func joinPath(path, k string) string {
+	if path == "" {
+		return k
+	}
+
+	return path + "/" + k
+}
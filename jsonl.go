@@ -0,0 +1,138 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// newJSONLScanner returns a scanner over the lines of a JSON Lines stream
+func newJSONLScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+	return scanner
+}
+
+// readJSONL calls fn with each non-empty line in r, and its line number
+func readJSONL(r io.Reader, fn func(line []byte) error) error {
+	scanner := newJSONLScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := fn(line); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// WriteJSONL writes the supplied attestation results to w as JSON Lines, i.e.,
+// one (validated) claims-set per line.  This is convenient for storing and
+// replaying EAR histories using log pipelines.
+func WriteJSONL(w io.Writer, results []*AttestationResult) error {
+	for i, ar := range results {
+		if ar == nil {
+			return fmt.Errorf("result %d: nil attestation result", i)
+		}
+
+		data, err := ar.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("result %d: %w", i, err)
+		}
+
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("result %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadJSONL reads the attestation results written by WriteJSONL.  Empty lines
+// are skipped.
+func ReadJSONL(r io.Reader) ([]*AttestationResult, error) {
+	var results []*AttestationResult
+
+	err := readJSONL(r, func(line []byte) error {
+		var ar AttestationResult
+		if err := ar.UnmarshalJSON(line); err != nil {
+			return err
+		}
+		results = append(results, &ar)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// WriteSignedJSONL is like WriteJSONL, but each attestation result is signed
+// (see Sign) and written as a JWT
+func WriteSignedJSONL(
+	w io.Writer,
+	results []*AttestationResult,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...SignOption,
+) error {
+	for i, ar := range results {
+		if ar == nil {
+			return fmt.Errorf("result %d: nil attestation result", i)
+		}
+
+		token, err := ar.Sign(alg, key, opts...)
+		if err != nil {
+			return fmt.Errorf("result %d: %w", i, err)
+		}
+
+		if _, err := w.Write(append(token, '\n')); err != nil {
+			return fmt.Errorf("result %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadSignedJSONL reads the signed attestation results written by
+// WriteSignedJSONL (or any other sequence of JWTs, one per line), and
+// verifies each of them using Verify.  Empty lines are skipped.
+func ReadSignedJSONL(
+	r io.Reader,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) ([]*AttestationResult, error) {
+	var results []*AttestationResult
+
+	err := readJSONL(r, func(line []byte) error {
+		if line[0] == '{' {
+			return errors.New("found a claims-set, expecting a signed EAR")
+		}
+
+		var ar AttestationResult
+		if err := ar.Verify(line, alg, key, opts...); err != nil {
+			return err
+		}
+		results = append(results, &ar)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJSONLResults() []*AttestationResult {
+	var results []*AttestationResult
+
+	for _, c := range []string{"a", "b"} {
+		ar := testStreamResult(c, TrustTierAffirming)
+		results = append(results, &ar)
+	}
+
+	return results
+}
+
+func TestJSONL_round_trip(t *testing.T) {
+	results := testJSONLResults()
+
+	var b bytes.Buffer
+	require.NoError(t, WriteJSONL(&b, results))
+	assert.Equal(t, 2, strings.Count(b.String(), "\n"))
+
+	actual, err := ReadJSONL(strings.NewReader("\n" + b.String() + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, results, actual)
+}
+
+func TestJSONL_errors(t *testing.T) {
+	err := WriteJSONL(&bytes.Buffer{}, []*AttestationResult{nil})
+	assert.EqualError(t, err, "result 0: nil attestation result")
+
+	err = WriteJSONL(&bytes.Buffer{}, []*AttestationResult{{}})
+	assert.ErrorContains(t, err, "result 0: missing mandatory")
+
+	_, err = ReadJSONL(strings.NewReader("{}\n"))
+	assert.ErrorContains(t, err, "line 1: missing mandatory")
+
+	_, err = ReadJSONL(strings.NewReader("\n\n[]\n"))
+	assert.ErrorContains(t, err, "line 3: ")
+}
+
+func TestSignedJSONL_round_trip(t *testing.T) {
+	results := testJSONLResults()
+
+	var b bytes.Buffer
+	require.NoError(t, WriteSignedJSONL(&b, results, jwa.ES256, mustParseKey(t, testECDSAPrivateKey)))
+
+	actual, err := ReadSignedJSONL(&b, jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+
+	for i := range results {
+		assert.Equal(t, DefaultAttesterID(results[i]), DefaultAttesterID(actual[i]))
+		assert.Equal(t, results[i].Submods, actual[i].Submods)
+	}
+}
+
+func TestSignedJSONL_errors(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, WriteJSONL(&b, testJSONLResults()))
+
+	_, err := ReadSignedJSONL(&b, jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	assert.EqualError(t, err, "line 1: found a claims-set, expecting a signed EAR")
+
+	b.Reset()
+	require.NoError(t, WriteSignedJSONL(&b, testJSONLResults(), jwa.ES256, mustParseKey(t, testECDSAPrivateKey)))
+
+	_, err = ReadSignedJSONL(&b, jwa.EdDSA, mustParseKey(t, testEd25519PublicKey))
+	assert.ErrorContains(t, err, "line 1: ")
+}
@@ -0,0 +1,360 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// KeyUsage is an operation the attested key may be used for.  The values are
+// those of the JWK "key_ops" parameter (RFC 7517 §4.3).
+type KeyUsage string
+
+const (
+	KeyUsageSign       KeyUsage = "sign"
+	KeyUsageVerify     KeyUsage = "verify"
+	KeyUsageEncrypt    KeyUsage = "encrypt"
+	KeyUsageDecrypt    KeyUsage = "decrypt"
+	KeyUsageWrapKey    KeyUsage = "wrapKey"
+	KeyUsageUnwrapKey  KeyUsage = "unwrapKey"
+	KeyUsageDeriveKey  KeyUsage = "deriveKey"
+	KeyUsageDeriveBits KeyUsage = "deriveBits"
+)
+
+var validKeyUsages = map[KeyUsage]bool{
+	KeyUsageSign:       true,
+	KeyUsageVerify:     true,
+	KeyUsageEncrypt:    true,
+	KeyUsageDecrypt:    true,
+	KeyUsageWrapKey:    true,
+	KeyUsageUnwrapKey:  true,
+	KeyUsageDeriveKey:  true,
+	KeyUsageDeriveBits: true,
+}
+
+// KeyAttestationDetails are the optional members of the
+// "ear.veraison.key-attestation" claim that accompany the attested public key
+type KeyAttestationDetails struct {
+	// KeyID is the identifier of the attested key ("kid")
+	KeyID string
+	// Nonce is the nonce the key attestation was produced for, which binds it
+	// to a proof-of-possession exchange ("nonce")
+	Nonce []byte
+	// KeyUsage constrains the operations the attested key may be used for
+	// ("key-usage")
+	KeyUsage []KeyUsage
+}
+
+// KeyAttestationOption adds an optional member to the
+// "ear.veraison.key-attestation" claim (see SetKeyAttestation)
+type KeyAttestationOption func(map[string]interface{})
+
+// WithAttestedKeyID sets the identifier of the attested key ("kid")
+func WithAttestedKeyID(kid string) KeyAttestationOption {
+	return func(m map[string]interface{}) {
+		m["kid"] = kid
+	}
+}
+
+// WithAttestedKeyNonce sets the nonce the key attestation was produced for
+// ("nonce").  The nonce must be between 8 and 64 bytes long.
+func WithAttestedKeyNonce(nonce []byte) KeyAttestationOption {
+	return func(m map[string]interface{}) {
+		m["nonce"] = base64.RawURLEncoding.EncodeToString(nonce)
+	}
+}
+
+// WithAttestedKeyUsage constrains the operations the attested key may be used
+// for ("key-usage")
+func WithAttestedKeyUsage(usage ...KeyUsage) KeyAttestationOption {
+	return func(m map[string]interface{}) {
+		l := make([]interface{}, 0, len(usage))
+		for _, u := range usage {
+			l = append(l, string(u))
+		}
+		m["key-usage"] = l
+	}
+}
+
+// GetKeyAttestationDetails returns the optional members of the
+// "ear.veraison.key-attestation" claim.  Members that are absent are left
+// empty.
+func (o AppraisalExtensions) GetKeyAttestationDetails() (KeyAttestationDetails, error) {
+	var d KeyAttestationDetails
+
+	if o.VeraisonKeyAttestation == nil {
+		return d, errors.New(`"ear.veraison.key-attestation" claim not found`)
+	}
+
+	if err := validateKeyAttestation(*o.VeraisonKeyAttestation); err != nil {
+		return d, fmt.Errorf(`"ear.veraison.key-attestation" malformed: %w`, err)
+	}
+
+	m := *o.VeraisonKeyAttestation
+
+	if kid, ok := m["kid"].(string); ok {
+		d.KeyID = kid
+	}
+
+	if nonce, ok := m["nonce"].(string); ok {
+		// already validated
+		d.Nonce, _ = base64.RawURLEncoding.DecodeString(nonce)
+	}
+
+	if l, ok := m["key-usage"].([]interface{}); ok {
+		for _, u := range l {
+			d.KeyUsage = append(d.KeyUsage, KeyUsage(u.(string)))
+		}
+	}
+
+	return d, nil
+}
+
+// SetKeyAttestationJWK sets the "ear.veraison.key-attestation" claim to carry
+// the supplied key in JWK format ("jwk"), rather than as PKIX DER ("akpub").
+// If key is a private key, only its public part is used.  Options are as for
+// SetKeyAttestation.
+func (o *AppraisalExtensions) SetKeyAttestationJWK(key jwk.Key, opts ...KeyAttestationOption) error {
+	pub, err := jwk.PublicKeyOf(key)
+	if err != nil {
+		return fmt.Errorf("extracting public key: %w", err)
+	}
+
+	if _, ok := pub.(jwk.SymmetricKey); ok {
+		return errors.New("symmetric keys cannot be attested")
+	}
+
+	data, err := json.Marshal(pub)
+	if err != nil {
+		return fmt.Errorf("serializing key: %w", err)
+	}
+
+	var j map[string]interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("serializing key: %w", err)
+	}
+
+	return o.setKeyAttestation("jwk", j, opts)
+}
+
+// SetKeyAttestationCOSEKey sets the "ear.veraison.key-attestation" claim to
+// carry the public key in the supplied serialized COSE_Key ("cose_key"),
+// rather than as PKIX DER ("akpub").  In the CBOR serialization of the EAR,
+// the COSE_Key is embedded as-is.  Options are as for SetKeyAttestation.
+func (o *AppraisalExtensions) SetKeyAttestationCOSEKey(coseKey []byte, opts ...KeyAttestationOption) error {
+	if _, err := JWKFromCOSEKey(coseKey); err != nil {
+		return fmt.Errorf("parsing COSE_Key: %w", err)
+	}
+
+	return o.setKeyAttestation("cose_key", base64.RawURLEncoding.EncodeToString(coseKey), opts)
+}
+
+func (o *AppraisalExtensions) setKeyAttestation(
+	member string,
+	key interface{},
+	opts []KeyAttestationOption,
+) error {
+	m := map[string]interface{}{
+		member: key,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if err := validateKeyAttestation(m); err != nil {
+		return err
+	}
+
+	o.VeraisonKeyAttestation = &m
+
+	return nil
+}
+
+// GetKeyAttestationJWK returns the public key carried in the
+// "ear.veraison.key-attestation" claim in JWK format, converting it if it is
+// carried in a different format
+func (o AppraisalExtensions) GetKeyAttestationJWK() (jwk.Key, error) {
+	if o.VeraisonKeyAttestation == nil {
+		return nil, errors.New(`"ear.veraison.key-attestation" claim not found`)
+	}
+
+	m := *o.VeraisonKeyAttestation
+
+	if v, ok := m["jwk"]; ok {
+		k, err := parseAttestedJWK(v)
+		if err != nil {
+			return nil, fmt.Errorf(`"ear.veraison.key-attestation" malformed: %w`, err)
+		}
+		return k, nil
+	}
+
+	if v, ok := m["cose_key"]; ok {
+		coseKey, err := decodeAttestedCOSEKey(v)
+		if err != nil {
+			return nil, fmt.Errorf(`"ear.veraison.key-attestation" malformed: %w`, err)
+		}
+		return JWKFromCOSEKey(coseKey)
+	}
+
+	pub, err := o.GetKeyAttestation()
+	if err != nil {
+		return nil, err
+	}
+
+	return jwk.FromRaw(pub)
+}
+
+// GetKeyAttestationCOSEKey returns the public key carried in the
+// "ear.veraison.key-attestation" claim as a serialized COSE_Key, converting
+// it if it is carried in a different format
+func (o AppraisalExtensions) GetKeyAttestationCOSEKey() ([]byte, error) {
+	if o.VeraisonKeyAttestation != nil {
+		if v, ok := (*o.VeraisonKeyAttestation)["cose_key"]; ok {
+			coseKey, err := decodeAttestedCOSEKey(v)
+			if err != nil {
+				return nil, fmt.Errorf(`"ear.veraison.key-attestation" malformed: %w`, err)
+			}
+			return coseKey, nil
+		}
+	}
+
+	k, err := o.GetKeyAttestationJWK()
+	if err != nil {
+		return nil, err
+	}
+
+	return COSEKeyFromJWK(k)
+}
+
+// hasNativeKeyAttestation reports whether the "ear.veraison.key-attestation"
+// claim carries the key in JWK or COSE_Key format
+func (o AppraisalExtensions) hasNativeKeyAttestation() bool {
+	if o.VeraisonKeyAttestation == nil {
+		return false
+	}
+
+	_, j := (*o.VeraisonKeyAttestation)["jwk"]
+	_, c := (*o.VeraisonKeyAttestation)["cose_key"]
+
+	return j || c
+}
+
+func parseAttestedJWK(v interface{}) (jwk.Key, error) {
+	if _, ok := v.(map[string]interface{}); !ok {
+		return nil, errors.New(`"jwk" must be a JSON object`)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf(`serializing "jwk": %w`, err)
+	}
+
+	k, err := jwk.ParseKey(data)
+	if err != nil {
+		return nil, fmt.Errorf(`parsing "jwk": %w`, err)
+	}
+
+	if _, ok := k.(jwk.SymmetricKey); ok {
+		return nil, errors.New(`"jwk" must be a public key`)
+	}
+
+	return k, nil
+}
+
+func decodeAttestedCOSEKey(v interface{}) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errors.New(`"cose_key" must be a string`)
+	}
+
+	coseKey, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf(`decoding "cose_key": %w`, err)
+	}
+
+	if len(coseKey) == 0 {
+		return nil, errors.New(`empty "cose_key"`)
+	}
+
+	return coseKey, nil
+}
+
+// validateKeyAttestation checks the members of the
+// "ear.veraison.key-attestation" claim, apart from "akpub" (see
+// GetKeyAttestation)
+func validateKeyAttestation(m map[string]interface{}) error {
+	n := 0
+	for _, k := range []string{"akpub", "jwk", "cose_key"} {
+		if _, ok := m[k]; ok {
+			n++
+		}
+	}
+
+	if n > 1 {
+		return errors.New(`only one of "akpub", "jwk" and "cose_key" may be present`)
+	}
+
+	if v, ok := m["jwk"]; ok {
+		if _, err := parseAttestedJWK(v); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := m["cose_key"]; ok {
+		if _, err := decodeAttestedCOSEKey(v); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := m["kid"]; ok {
+		if kid, ok := v.(string); !ok || kid == "" {
+			return errors.New(`"kid" must be a non-empty string`)
+		}
+	}
+
+	if v, ok := m["nonce"]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return errors.New(`"nonce" must be a string`)
+		}
+
+		nonce, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf(`decoding "nonce": %w`, err)
+		}
+
+		if len(nonce) < 8 || len(nonce) > 64 {
+			return fmt.Errorf(`"nonce" must be between 8 and 64 bytes, found %d`, len(nonce))
+		}
+	}
+
+	if v, ok := m["key-usage"]; ok {
+		l, ok := v.([]interface{})
+		if !ok || len(l) == 0 {
+			return errors.New(`"key-usage" must be a non-empty array`)
+		}
+
+		seen := map[string]bool{}
+
+		for i, e := range l {
+			u, ok := e.(string)
+			if !ok || !validKeyUsages[KeyUsage(u)] {
+				return fmt.Errorf(`"key-usage" entry %d: unknown key usage %v`, i, e)
+			}
+
+			if seen[u] {
+				return fmt.Errorf(`"key-usage" entry %d: duplicate key usage %q`, i, u)
+			}
+			seen[u] = true
+		}
+	}
+
+	return nil
+}
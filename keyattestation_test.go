@@ -0,0 +1,205 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppraisalExtensions_SetKeyAttestation_with_details(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var a AppraisalExtensions
+
+	err = a.SetKeyAttestation(&key.PublicKey,
+		WithAttestedKeyID("key-1"),
+		WithAttestedKeyNonce([]byte(testNonce)),
+		WithAttestedKeyUsage(KeyUsageSign, KeyUsageDeriveKey),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "key-1", (*a.VeraisonKeyAttestation)["kid"])
+	assert.Equal(t, "MDEyMzQ1Njc4OWFiY2RlZg", (*a.VeraisonKeyAttestation)["nonce"])
+	assert.Equal(t, []interface{}{"sign", "deriveKey"}, (*a.VeraisonKeyAttestation)["key-usage"])
+
+	pub, err := a.GetKeyAttestation()
+	require.NoError(t, err)
+	assert.Equal(t, &key.PublicKey, pub)
+
+	d, err := a.GetKeyAttestationDetails()
+	require.NoError(t, err)
+	assert.Equal(t, KeyAttestationDetails{
+		KeyID:    "key-1",
+		Nonce:    []byte(testNonce),
+		KeyUsage: []KeyUsage{KeyUsageSign, KeyUsageDeriveKey},
+	}, d)
+
+	// details survive a round-trip
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Submods = map[string]*Appraisal{
+		"test": {Status: &testStatus, AppraisalExtensions: a},
+	}
+
+	data, err := ar.MarshalJSON()
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+
+	d, err = actual.Submods["test"].GetKeyAttestationDetails()
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", d.KeyID)
+}
+
+func TestAppraisalExtensions_SetKeyAttestation_fail_details(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tvs := []struct {
+		opt      KeyAttestationOption
+		expected string
+	}{
+		{WithAttestedKeyID(""), `"kid" must be a non-empty string`},
+		{WithAttestedKeyNonce([]byte("short")), `"nonce" must be between 8 and 64 bytes, found 5`},
+		{WithAttestedKeyUsage(), `"key-usage" must be a non-empty array`},
+		{WithAttestedKeyUsage("fly"), `"key-usage" entry 0: unknown key usage fly`},
+		{WithAttestedKeyUsage(KeyUsageSign, KeyUsageSign), `"key-usage" entry 1: duplicate key usage "sign"`},
+	}
+
+	for i, tv := range tvs {
+		var a AppraisalExtensions
+
+		err := a.SetKeyAttestation(&key.PublicKey, tv.opt)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+		assert.Nil(t, a.VeraisonKeyAttestation, "failed test vector at index %d", i)
+	}
+}
+
+func TestAppraisal_validate_key_attestation(t *testing.T) {
+	a := Appraisal{
+		Status: &testStatus,
+		AppraisalExtensions: AppraisalExtensions{
+			VeraisonKeyAttestation: &map[string]interface{}{
+				"akpub": "YWtwdWIK",
+				"nonce": 42,
+			},
+		},
+	}
+
+	assert.EqualError(t, a.validate(),
+		`invalid value for 'ear.veraison.key-attestation': "nonce" must be a string`)
+
+	_, err := a.GetKeyAttestationDetails()
+	assert.EqualError(t, err,
+		`"ear.veraison.key-attestation" malformed: "nonce" must be a string`)
+}
+
+func TestAppraisalExtensions_SetKeyAttestationJWK(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	priv, err := jwk.FromRaw(key)
+	require.NoError(t, err)
+
+	var a AppraisalExtensions
+
+	require.NoError(t, a.SetKeyAttestationJWK(priv, WithAttestedKeyID("key-1")))
+	assert.NotContains(t, *a.VeraisonKeyAttestation, "akpub")
+	assert.Equal(t, "EC", (*a.VeraisonKeyAttestation)["jwk"].(map[string]interface{})["kty"])
+	assert.NotContains(t, (*a.VeraisonKeyAttestation)["jwk"], "d")
+
+	k, err := a.GetKeyAttestationJWK()
+	require.NoError(t, err)
+
+	var pub ecdsa.PublicKey
+	require.NoError(t, k.Raw(&pub))
+	assert.True(t, key.PublicKey.Equal(&pub))
+
+	raw, err := a.GetKeyAttestation()
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(raw))
+
+	coseKey, err := a.GetKeyAttestationCOSEKey()
+	require.NoError(t, err)
+
+	k, err = JWKFromCOSEKey(coseKey)
+	require.NoError(t, err)
+	require.NoError(t, k.Raw(&pub))
+	assert.True(t, key.PublicKey.Equal(&pub))
+
+	sym, err := jwk.FromRaw([]byte("secret"))
+	require.NoError(t, err)
+	assert.EqualError(t, a.SetKeyAttestationJWK(sym), "symmetric keys cannot be attested")
+}
+
+func TestAppraisalExtensions_SetKeyAttestationCOSEKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	k, err := jwk.FromRaw(&key.PublicKey)
+	require.NoError(t, err)
+
+	coseKey, err := COSEKeyFromJWK(k)
+	require.NoError(t, err)
+
+	var a AppraisalExtensions
+
+	require.NoError(t, a.SetKeyAttestationCOSEKey(coseKey))
+
+	actual, err := a.GetKeyAttestationCOSEKey()
+	require.NoError(t, err)
+	assert.Equal(t, coseKey, actual)
+
+	raw, err := a.GetKeyAttestation()
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(raw))
+
+	// the COSE_Key is embedded as-is in CBOR, and survives a round-trip
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Submods = map[string]*Appraisal{
+		"test": {Status: &testStatus, AppraisalExtensions: a},
+	}
+
+	data, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+	assert.True(t, bytes.Contains(data, coseKey))
+
+	var decoded AttestationResult
+	require.NoError(t, decoded.UnmarshalCBOR(data))
+
+	actual, err = decoded.Submods["test"].GetKeyAttestationCOSEKey()
+	require.NoError(t, err)
+	assert.Equal(t, coseKey, actual)
+
+	assert.ErrorContains(t, a.SetKeyAttestationCOSEKey([]byte{0xa0}), "parsing COSE_Key")
+}
+
+func TestAppraisalExtensions_validate_key_formats(t *testing.T) {
+	tvs := []struct {
+		m        map[string]interface{}
+		expected string
+	}{
+		{
+			map[string]interface{}{"akpub": "YWtwdWIK", "cose_key": "oA"},
+			`only one of "akpub", "jwk" and "cose_key" may be present`,
+		},
+		{map[string]interface{}{"jwk": "not an object"}, `"jwk" must be a JSON object`},
+		{map[string]interface{}{"jwk": map[string]interface{}{"kty": "oct", "k": "c2VjcmV0"}}, `"jwk" must be a public key`},
+		{map[string]interface{}{"cose_key": 1}, `"cose_key" must be a string`},
+		{map[string]interface{}{"cose_key": ""}, `empty "cose_key"`},
+	}
+
+	for i, tv := range tvs {
+		assert.EqualError(t, validateKeyAttestation(tv.m), tv.expected, "failed test vector at index %d", i)
+	}
+}
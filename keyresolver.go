@@ -0,0 +1,364 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// KeyResolver supplies the candidate verification keys for an EAR, e.g., by
+// fetching the JWKS published by the verifier
+type KeyResolver interface {
+	// ResolveKeys returns the keys to try.  kid is the key ID found in the
+	// header of the EAR being verified, which can be empty.
+	ResolveKeys(kid string) (jwk.Set, error)
+}
+
+// KeyResolverFunc is an adapter that allows the use of an ordinary function
+// as a KeyResolver.
+type KeyResolverFunc func(kid string) (jwk.Set, error)
+
+// ResolveKeys returns f(kid)
+func (f KeyResolverFunc) ResolveKeys(kid string) (jwk.Set, error) {
+	return f(kid)
+}
+
+// VerifyWithKeyResolver is like VerifyWithKeySet, but the key set is obtained
+// from resolver
+func (o *AttestationResult) VerifyWithKeyResolver(
+	data []byte,
+	resolver KeyResolver,
+	opts ...VerifyOption,
+) error {
+	if resolver == nil {
+		return errors.New("nil key resolver")
+	}
+
+	hdrs, err := protectedHeaders(data)
+	if err != nil {
+		return err
+	}
+
+	keyset, err := resolver.ResolveKeys(hdrs.KeyID())
+	if err != nil {
+		return fmt.Errorf("resolving verification keys: %w", err)
+	}
+
+	return o.VerifyWithKeySet(data, keyset, opts...)
+}
+
+const (
+	// DefaultKeySetTTL is how long an HTTPSKeyResolver caches a JWKS for,
+	// unless WithKeySetTTL is supplied
+	DefaultKeySetTTL = time.Hour
+	// DefaultKeySetMinRefreshInterval is the minimum time between two
+	// fetches of a JWKS triggered by unknown key IDs, or following a failed
+	// fetch, unless WithKeySetMinRefreshInterval is supplied
+	DefaultKeySetMinRefreshInterval = time.Minute
+	// DefaultKeySetMaxStaleness is for how long past its TTL an
+	// HTTPSKeyResolver keeps serving a JWKS that cannot be refreshed, unless
+	// WithKeySetMaxStaleness is supplied
+	DefaultKeySetMaxStaleness = 24 * time.Hour
+	// DefaultKeySetFetchTimeout is the timeout of a JWKS fetch, unless the
+	// client supplied using WithHTTPClient has one
+	DefaultKeySetFetchTimeout = 10 * time.Second
+
+	jwksMediaType = "application/jwk-set+json"
+	// maxJWKSSize caps the size of a fetched JWKS
+	maxJWKSSize = 1 << 20
+)
+
+// HTTPSKeyResolver is a KeyResolver that fetches a JWKS over HTTPS and caches
+// it.  The JWKS is fetched again when it expires or, rate-limited, when the
+// EAR being verified carries a key ID that is not found in the cached set
+// (e.g., because the verifier has rotated its keys).  If fetching fails, the
+// cached JWKS keeps being served until it becomes stale (see
+// WithKeySetMaxStaleness).  Redirects to anything other than https URLs are
+// refused.  It is safe for concurrent use: concurrent fetches are coalesced,
+// and the cache is not locked while fetching.
+type HTTPSKeyResolver struct {
+	url                string
+	client             *http.Client
+	ttl                time.Duration
+	minRefreshInterval time.Duration
+	maxStaleness       time.Duration
+	clock              Clock
+
+	mu       sync.Mutex
+	keyset   jwk.Set
+	fetched  time.Time
+	failed   time.Time
+	inflight *keySetFetch
+}
+
+// keySetFetch is a JWKS fetch in progress, which the callers that need the
+// JWKS at the same time wait for, rather than fetching it again
+type keySetFetch struct {
+	done   chan struct{}
+	keyset jwk.Set
+	err    error
+}
+
+// KeyResolverOption configures an HTTPSKeyResolver
+type KeyResolverOption func(*HTTPSKeyResolver)
+
+// WithHTTPClient makes the resolver use (a copy of) the supplied client,
+// instead of a client with a DefaultKeySetFetchTimeout timeout.  If the
+// client has no timeout, DefaultKeySetFetchTimeout is used.
+func WithHTTPClient(client *http.Client) KeyResolverOption {
+	return func(r *HTTPSKeyResolver) {
+		r.client = client
+	}
+}
+
+// WithKeySetTTL sets how long the fetched JWKS is cached for.  A zero or
+// negative ttl means that the JWKS is only fetched again on key ID misses.
+func WithKeySetTTL(ttl time.Duration) KeyResolverOption {
+	return func(r *HTTPSKeyResolver) {
+		r.ttl = ttl
+	}
+}
+
+// WithKeySetMinRefreshInterval sets the minimum time between two fetches
+// triggered by key ID misses, or following a failed fetch, so that EARs
+// carrying bogus key IDs (or an unavailable endpoint) cannot be used to
+// hammer the JWKS endpoint
+func WithKeySetMinRefreshInterval(d time.Duration) KeyResolverOption {
+	return func(r *HTTPSKeyResolver) {
+		r.minRefreshInterval = d
+	}
+}
+
+// WithKeySetMaxStaleness sets for how long past its TTL the cached JWKS is
+// still served when it cannot be fetched again.  A zero or negative d means
+// that an expired JWKS is never served.  JWKSes cached with no TTL (see
+// WithKeySetTTL) never become stale.
+func WithKeySetMaxStaleness(d time.Duration) KeyResolverOption {
+	return func(r *HTTPSKeyResolver) {
+		r.maxStaleness = d
+	}
+}
+
+// WithKeyResolverClock makes the resolver use the supplied Clock to expire
+// the cached JWKS, instead of the system time
+func WithKeyResolverClock(clock Clock) KeyResolverOption {
+	return func(r *HTTPSKeyResolver) {
+		r.clock = clock
+	}
+}
+
+// NewHTTPSKeyResolver returns an HTTPSKeyResolver that fetches the JWKS found
+// at the supplied https URL
+func NewHTTPSKeyResolver(jwksURL string, opts ...KeyResolverOption) (*HTTPSKeyResolver, error) {
+	u, err := url.Parse(jwksURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JWKS URL: %w", err)
+	}
+
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("JWKS URL must use https, got %q", u.Scheme)
+	}
+
+	r := &HTTPSKeyResolver{
+		url:                jwksURL,
+		ttl:                DefaultKeySetTTL,
+		minRefreshInterval: DefaultKeySetMinRefreshInterval,
+		maxStaleness:       DefaultKeySetMaxStaleness,
+		clock:              systemClock,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.client = jwksClient(r.client)
+
+	if r.clock == nil {
+		r.clock = systemClock
+	}
+
+	return r, nil
+}
+
+// jwksClient returns a copy of client (or of http.DefaultClient, if nil) that
+// times out and only follows redirects to https URLs
+func jwksClient(client *http.Client) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	c := *client
+
+	if c.Timeout == 0 {
+		c.Timeout = DefaultKeySetFetchTimeout
+	}
+
+	checkRedirect := client.CheckRedirect
+
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "https" {
+			return fmt.Errorf("redirect to %q refused: JWKS URL must use https", req.URL)
+		}
+
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+
+		// the default policy of http.Client
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		return nil
+	}
+
+	return &c
+}
+
+// ResolveKeys returns the cached JWKS, fetching it first if needed
+func (o *HTTPSKeyResolver) ResolveKeys(kid string) (jwk.Set, error) {
+	o.mu.Lock()
+	keyset, refresh := o.keyset, o.keyset == nil || o.needsRefresh(kid, o.clock.Now())
+	o.mu.Unlock()
+
+	if !refresh {
+		return keyset, nil
+	}
+
+	keyset, err := o.refresh()
+	if err == nil {
+		return keyset, nil
+	}
+
+	// serve the last good JWKS, unless stale
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.keyset != nil && !o.isStale(o.clock.Now()) {
+		return o.keyset, nil
+	}
+
+	return nil, err
+}
+
+// Refresh fetches the JWKS, regardless of the state of the cache
+func (o *HTTPSKeyResolver) Refresh() error {
+	_, err := o.refresh()
+	return err
+}
+
+// refresh fetches the JWKS and caches it, or waits for the fetch in progress,
+// if any
+func (o *HTTPSKeyResolver) refresh() (jwk.Set, error) {
+	o.mu.Lock()
+
+	if f := o.inflight; f != nil {
+		o.mu.Unlock()
+		<-f.done
+
+		return f.keyset, f.err
+	}
+
+	f := &keySetFetch{done: make(chan struct{})}
+	o.inflight = f
+	o.mu.Unlock()
+
+	f.keyset, f.err = o.fetch()
+
+	o.mu.Lock()
+	now := o.clock.Now()
+	if f.err == nil {
+		o.keyset, o.fetched, o.failed = f.keyset, now, time.Time{}
+	} else {
+		o.failed = now
+	}
+	o.inflight = nil
+	o.mu.Unlock()
+
+	close(f.done)
+
+	return f.keyset, f.err
+}
+
+func (o *HTTPSKeyResolver) needsRefresh(kid string, now time.Time) bool {
+	if o.isStale(now) {
+		return true
+	}
+
+	lastAttempt := o.fetched
+	if !o.failed.IsZero() {
+		lastAttempt = o.failed
+	}
+
+	retry := !now.Before(lastAttempt.Add(o.minRefreshInterval))
+
+	if o.ttl > 0 && !now.Before(o.fetched.Add(o.ttl)) {
+		// expired: fetch again, unless a fetch has just failed
+		return o.failed.IsZero() || retry
+	}
+
+	if kid == "" {
+		return false
+	}
+
+	if _, ok := o.keyset.LookupKeyID(kid); ok {
+		return false
+	}
+
+	return retry
+}
+
+// isStale reports whether the cached JWKS has been expired for longer than
+// the maximum staleness, and therefore cannot be served anymore
+func (o *HTTPSKeyResolver) isStale(now time.Time) bool {
+	if o.ttl <= 0 {
+		return false
+	}
+
+	maxStaleness := o.maxStaleness
+	if maxStaleness < 0 {
+		maxStaleness = 0
+	}
+
+	return !now.Before(o.fetched.Add(o.ttl + maxStaleness))
+}
+
+func (o *HTTPSKeyResolver) fetch() (jwk.Set, error) {
+	req, err := http.NewRequest(http.MethodGet, o.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+
+	req.Header.Set("Accept", jwksMediaType)
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS: server returned status %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+
+	keyset, err := jwk.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JWKS: %w", err)
+	}
+
+	return keyset, nil
+}
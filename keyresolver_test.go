@@ -0,0 +1,279 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testJWKSServer struct {
+	*httptest.Server
+	keyset  atomic.Value
+	fetches int32
+	failing int32
+}
+
+func newTestJWKSServer(t *testing.T, keyset jwk.Set) *testJWKSServer {
+	s := &testJWKSServer{}
+	s.keyset.Store(keyset)
+
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.fetches, 1)
+
+		if atomic.LoadInt32(&s.failing) != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		data, err := json.Marshal(s.keyset.Load())
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", jwksMediaType)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (o *testJWKSServer) Fetches() int {
+	return int(atomic.LoadInt32(&o.fetches))
+}
+
+func (o *testJWKSServer) SetFailing(failing bool) {
+	var v int32
+	if failing {
+		v = 1
+	}
+	atomic.StoreInt32(&o.failing, v)
+}
+
+func TestHTTPSKeyResolver(t *testing.T) {
+	set := testKeySet(t)
+	srv := newTestJWKSServer(t, set)
+
+	now := time.Unix(testIAT, 0)
+	clock := ClockFunc(func() time.Time { return now })
+
+	r, err := NewHTTPSKeyResolver(srv.URL,
+		WithHTTPClient(srv.Client()),
+		WithKeySetTTL(time.Hour),
+		WithKeySetMinRefreshInterval(time.Minute),
+		WithKeyResolverClock(clock),
+	)
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey), WithKeyID("ec"))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifyWithKeyResolver(token, r, WithKeySetAlgorithm(jwa.ES256)))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+	assert.Equal(t, 1, srv.Fetches())
+
+	// cached
+	require.NoError(t, actual.VerifyWithKeyResolver(token, r, WithKeySetAlgorithm(jwa.ES256)))
+	assert.Equal(t, 1, srv.Fetches())
+
+	// kid miss within the minimum refresh interval
+	_, err = r.ResolveKeys("rotated")
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.Fetches())
+
+	// kid miss after the minimum refresh interval
+	now = now.Add(time.Minute)
+	_, err = r.ResolveKeys("rotated")
+	require.NoError(t, err)
+	assert.Equal(t, 2, srv.Fetches())
+
+	// expiry
+	now = now.Add(time.Hour)
+	_, err = r.ResolveKeys("ec")
+	require.NoError(t, err)
+	assert.Equal(t, 3, srv.Fetches())
+
+	require.NoError(t, r.Refresh())
+	assert.Equal(t, 4, srv.Fetches())
+}
+
+func TestHTTPSKeyResolver_rotation(t *testing.T) {
+	ecK := mustParseKey(t, testECDSAPublicKey)
+	require.NoError(t, ecK.Set(jwk.KeyIDKey, "old"))
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(ecK))
+
+	srv := newTestJWKSServer(t, set)
+
+	r, err := NewHTTPSKeyResolver(srv.URL,
+		WithHTTPClient(srv.Client()),
+		WithKeySetMinRefreshInterval(0),
+	)
+	require.NoError(t, err)
+
+	_, err = r.ResolveKeys("old")
+	require.NoError(t, err)
+
+	// the verifier rotates its key
+	srv.keyset.Store(testKeySet(t))
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.EdDSA,
+		mustParseKey(t, testEd25519PrivateKey), WithKeyID("ed"))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifyWithKeyResolver(token, r))
+	assert.Equal(t, 2, srv.Fetches())
+}
+
+func TestHTTPSKeyResolver_stale(t *testing.T) {
+	srv := newTestJWKSServer(t, testKeySet(t))
+
+	now := time.Unix(testIAT, 0)
+	clock := ClockFunc(func() time.Time { return now })
+
+	r, err := NewHTTPSKeyResolver(srv.URL,
+		WithHTTPClient(srv.Client()),
+		WithKeySetTTL(time.Hour),
+		WithKeySetMinRefreshInterval(time.Minute),
+		WithKeySetMaxStaleness(2*time.Hour),
+		WithKeyResolverClock(clock),
+	)
+	require.NoError(t, err)
+
+	expected, err := r.ResolveKeys("ec")
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.Fetches())
+
+	srv.SetFailing(true)
+
+	// expired, but the last good JWKS is served when the refresh fails
+	now = now.Add(time.Hour)
+	actual, err := r.ResolveKeys("ec")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, 2, srv.Fetches())
+
+	assert.EqualError(t, r.Refresh(), "fetching JWKS: server returned status 503")
+	assert.Equal(t, 3, srv.Fetches())
+
+	// no retry within the minimum refresh interval
+	_, err = r.ResolveKeys("ec")
+	require.NoError(t, err)
+	assert.Equal(t, 3, srv.Fetches())
+
+	now = now.Add(time.Minute)
+	_, err = r.ResolveKeys("ec")
+	require.NoError(t, err)
+	assert.Equal(t, 4, srv.Fetches())
+
+	// stale
+	now = now.Add(2 * time.Hour)
+	_, err = r.ResolveKeys("ec")
+	assert.EqualError(t, err, "fetching JWKS: server returned status 503")
+	assert.Equal(t, 5, srv.Fetches())
+
+	// recovered
+	srv.SetFailing(false)
+	_, err = r.ResolveKeys("ec")
+	require.NoError(t, err)
+	assert.Equal(t, 6, srv.Fetches())
+}
+
+func TestHTTPSKeyResolver_concurrent(t *testing.T) {
+	srv := newTestJWKSServer(t, testKeySet(t))
+
+	r, err := NewHTTPSKeyResolver(srv.URL,
+		WithHTTPClient(srv.Client()),
+		WithKeySetMinRefreshInterval(0),
+	)
+	require.NoError(t, err)
+
+	errs := make([]error, 16)
+
+	runBatch(len(errs), len(errs), func(i int) {
+		_, errs[i] = r.ResolveKeys("rotated")
+	})
+
+	for i, err := range errs {
+		assert.NoError(t, err, "failed test vector at index %d", i)
+	}
+}
+
+func TestHTTPSKeyResolver_redirect(t *testing.T) {
+	srv := newTestJWKSServer(t, testKeySet(t))
+
+	redirect := func(target string) *httptest.Server {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target, http.StatusFound)
+		}))
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	// https redirects are followed
+	r, err := NewHTTPSKeyResolver(redirect(srv.URL).URL, WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+
+	_, err = r.ResolveKeys("")
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.Fetches())
+
+	// others are not
+	target := "http://" + srv.Listener.Addr().String()
+
+	r, err = NewHTTPSKeyResolver(redirect(target).URL, WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+
+	_, err = r.ResolveKeys("")
+	assert.ErrorContains(t, err, `redirect to "`+target+`" refused: JWKS URL must use https`)
+	assert.Equal(t, 1, srv.Fetches())
+}
+
+func TestNewHTTPSKeyResolver_client(t *testing.T) {
+	r, err := NewHTTPSKeyResolver("https://veraison.example/.well-known/jwks.json")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultKeySetFetchTimeout, r.client.Timeout)
+	assert.NotNil(t, r.client.CheckRedirect)
+
+	client := &http.Client{Timeout: time.Second}
+
+	r, err = NewHTTPSKeyResolver("https://veraison.example/.well-known/jwks.json",
+		WithHTTPClient(client))
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, r.client.Timeout)
+	assert.NotNil(t, r.client.CheckRedirect)
+	assert.Nil(t, client.CheckRedirect)
+}
+
+func TestNewHTTPSKeyResolver_fail(t *testing.T) {
+	_, err := NewHTTPSKeyResolver("http://veraison.example/.well-known/jwks.json")
+	assert.EqualError(t, err, `JWKS URL must use https, got "http"`)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	r, err := NewHTTPSKeyResolver(srv.URL, WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+
+	_, err = r.ResolveKeys("")
+	assert.EqualError(t, err, "fetching JWKS: server returned status 404")
+
+	var actual AttestationResult
+	err = actual.VerifyWithKeyResolver([]byte("a.b.c"), nil)
+	assert.EqualError(t, err, "nil key resolver")
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// KeyFormat is the serialization format of a key
+type KeyFormat int
+
+const (
+	KeyFormatUnknown KeyFormat = iota
+	KeyFormatJWK
+	KeyFormatPEM
+	KeyFormatDER
+	KeyFormatCOSEKey
+)
+
+func (o KeyFormat) String() string {
+	switch o {
+	case KeyFormatJWK:
+		return "JWK"
+	case KeyFormatPEM:
+		return "PEM"
+	case KeyFormatDER:
+		return "DER"
+	case KeyFormatCOSEKey:
+		return "COSE_Key"
+	default:
+		return "unknown"
+	}
+}
+
+// DetectKeyFormat guesses the serialization format of the supplied key by
+// looking at its first bytes
+func DetectKeyFormat(data []byte) KeyFormat {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case len(data) == 0:
+		return KeyFormatUnknown
+	case data[0] == '{':
+		return KeyFormatJWK
+	case bytes.HasPrefix(data, []byte("-----BEGIN ")):
+		return KeyFormatPEM
+	case data[0] == 0x30: // ASN.1 SEQUENCE
+		return KeyFormatDER
+	case data[0]&0xe0 == 0xa0: // CBOR map
+		return KeyFormatCOSEKey
+	default:
+		return KeyFormatUnknown
+	}
+}
+
+// ParseVerificationKey parses a verification key, auto-detecting its format
+// among:
+//
+//   - JWK (JSON)
+//   - PEM, carrying a SubjectPublicKeyInfo ("PUBLIC KEY"), a PKCS#1 RSA public
+//     key ("RSA PUBLIC KEY"), an X.509 certificate ("CERTIFICATE"), a PKCS#8
+//     private key ("PRIVATE KEY") or a SEC 1 EC private key ("EC PRIVATE
+//     KEY")
+//   - DER, carrying any of the above
+//   - COSE_Key (CBOR)
+//
+// Private keys in PEM and DER are reduced to their public part, while JWKs
+// are returned unchanged.  The returned key can be used with both Verify and
+// VerifyCWT.
+func ParseVerificationKey(data []byte) (jwk.Key, error) {
+	switch DetectKeyFormat(data) {
+	case KeyFormatPEM:
+		return parsePEMVerificationKey(data)
+	case KeyFormatDER:
+		pub, err := parseDERVerificationKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing DER key: %w", err)
+		}
+		return jwk.FromRaw(pub)
+	case KeyFormatCOSEKey:
+		return JWKFromCOSEKey(bytes.TrimSpace(data))
+	default:
+		return jwk.ParseKey(data)
+	}
+}
+
+// LoadVerificationKey reads a verification key from the file at path and
+// parses it using ParseVerificationKey
+func LoadVerificationKey(path string) (jwk.Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading verification key: %w", err)
+	}
+
+	return ParseVerificationKey(data)
+}
+
+// ParseSigningKey parses a signing key, auto-detecting its format among:
+//
+//   - JWK (JSON), which can also carry a symmetric key
+//   - PEM, carrying a PKCS#8 private key ("PRIVATE KEY"), a SEC 1 EC private
+//     key ("EC PRIVATE KEY") or a PKCS#1 RSA private key ("RSA PRIVATE KEY")
+//   - DER, carrying any of the above
+//   - COSE_Key (CBOR)
+//
+// The returned key can be used with both Sign and SignCWT, and its public
+// part (see jwk.PublicKeyOf) with both Verify and VerifyCWT.
+func ParseSigningKey(data []byte) (jwk.Key, error) {
+	var (
+		k   jwk.Key
+		err error
+	)
+
+	switch DetectKeyFormat(data) {
+	case KeyFormatPEM:
+		k, err = parsePEMSigningKey(data)
+	case KeyFormatDER:
+		var priv interface{}
+		if priv, err = parseDERSigningKey(data); err != nil {
+			return nil, fmt.Errorf("parsing DER key: %w", err)
+		}
+		k, err = jwk.FromRaw(priv)
+	case KeyFormatCOSEKey:
+		k, err = JWKFromCOSEKey(bytes.TrimSpace(data))
+	default:
+		k, err = jwk.ParseKey(data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch k.(type) {
+	case jwk.ECDSAPrivateKey, jwk.OKPPrivateKey, jwk.RSAPrivateKey, jwk.SymmetricKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("%s key is not a signing key", k.KeyType())
+	}
+}
+
+// LoadSigningKey reads a signing key from the file at path and parses it
+// using ParseSigningKey
+func LoadSigningKey(path string) (jwk.Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading signing key: %w", err)
+	}
+
+	return ParseSigningKey(data)
+}
+
+func parsePEMSigningKey(data []byte) (jwk.Key, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(data))
+	if block == nil {
+		return nil, errors.New("parsing PEM key: no PEM block found")
+	}
+
+	var (
+		priv interface{}
+		err  error
+	)
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		priv, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		priv, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		priv, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("parsing PEM key: unsupported PEM block type %q", block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing PEM key: %w", err)
+	}
+
+	return jwk.FromRaw(priv)
+}
+
+// parseDERSigningKey tries the DER structures that can carry a private key
+func parseDERSigningKey(der []byte) (interface{}, error) {
+	if k, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return k, nil
+	}
+
+	if k, err := x509.ParseECPrivateKey(der); err == nil {
+		return k, nil
+	}
+
+	if k, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return k, nil
+	}
+
+	return nil, errors.New("not a PKCS#1, PKCS#8 or SEC 1 private key")
+}
+
+func parsePEMVerificationKey(data []byte) (jwk.Key, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(data))
+	if block == nil {
+		return nil, errors.New("parsing PEM key: no PEM block found")
+	}
+
+	var (
+		pub interface{}
+		err error
+	)
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		pub, err = certificatePublicKey(block.Bytes)
+	case "PRIVATE KEY":
+		pub, err = pkcs8PublicKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		var k crypto.Signer
+		if k, err = x509.ParseECPrivateKey(block.Bytes); err == nil {
+			pub = k.Public()
+		}
+	default:
+		return nil, fmt.Errorf("parsing PEM key: unsupported PEM block type %q", block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing PEM key: %w", err)
+	}
+
+	return jwk.FromRaw(pub)
+}
+
+// parseDERVerificationKey tries the DER structures that can carry a public
+// key, in order of likelihood
+func parseDERVerificationKey(der []byte) (interface{}, error) {
+	if pub, err := x509.ParsePKIXPublicKey(der); err == nil {
+		return pub, nil
+	}
+
+	if pub, err := certificatePublicKey(der); err == nil {
+		return pub, nil
+	}
+
+	if pub, err := pkcs8PublicKey(der); err == nil {
+		return pub, nil
+	}
+
+	if pub, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return pub, nil
+	}
+
+	if k, err := x509.ParseECPrivateKey(der); err == nil {
+		return k.Public(), nil
+	}
+
+	return nil, errors.New("not a SubjectPublicKeyInfo, certificate, PKCS#1, PKCS#8 or SEC 1 key")
+}
+
+func certificatePublicKey(der []byte) (interface{}, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert.PublicKey, nil
+}
+
+func pkcs8PublicKey(der []byte) (interface{}, error) {
+	k, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := k.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", k)
+	}
+
+	return s.Public(), nil
+}
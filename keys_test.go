@@ -0,0 +1,263 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testECDSARawPrivateKey(t *testing.T) *ecdsa.PrivateKey {
+	k, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	var raw ecdsa.PrivateKey
+	require.NoError(t, k.Raw(&raw))
+
+	return &raw
+}
+
+func TestParseVerificationKey(t *testing.T) {
+	priv := testECDSARawPrivateKey(t)
+
+	spki, err := x509.MarshalPKIXPublicKey(priv.Public())
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	sec1, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test verifier"},
+		NotBefore:    time.Unix(testIAT, 0),
+		NotAfter:     time.Unix(testIAT, 0).Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, priv.Public(), priv)
+	require.NoError(t, err)
+
+	pub, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	coseKey, err := COSEKeyFromJWK(pub)
+	require.NoError(t, err)
+
+	pemBlock := func(typ string, der []byte) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+	}
+
+	tvs := []struct {
+		data   []byte
+		format KeyFormat
+	}{
+		{[]byte(testECDSAPublicKey), KeyFormatJWK},
+		{pemBlock("PUBLIC KEY", spki), KeyFormatPEM},
+		{pemBlock("PRIVATE KEY", pkcs8), KeyFormatPEM},
+		{pemBlock("EC PRIVATE KEY", sec1), KeyFormatPEM},
+		{pemBlock("CERTIFICATE", cert), KeyFormatPEM},
+		{spki, KeyFormatDER},
+		{pkcs8, KeyFormatDER},
+		{sec1, KeyFormatDER},
+		{cert, KeyFormatDER},
+		{coseKey, KeyFormatCOSEKey},
+	}
+
+	expected, err := pub.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	for i, tv := range tvs {
+		assert.Equal(t, tv.format, DetectKeyFormat(tv.data), "failed test vector at index %d", i)
+
+		k, err := ParseVerificationKey(tv.data)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		actual, err := k.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "failed test vector at index %d", i)
+
+		_, isPrivate := k.(jwk.ECDSAPrivateKey)
+		assert.False(t, isPrivate, "failed test vector at index %d", i)
+	}
+}
+
+func TestParseVerificationKey_RSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(&priv.PublicKey),
+	})
+
+	k, err := ParseVerificationKey(data)
+	require.NoError(t, err)
+
+	var raw rsa.PublicKey
+	require.NoError(t, k.Raw(&raw))
+	assert.True(t, priv.PublicKey.Equal(&raw))
+}
+
+func TestParseVerificationKey_fail(t *testing.T) {
+	tvs := []struct {
+		data     []byte
+		expected string
+	}{
+		{
+			data:     []byte("-----BEGIN FOO-----\nAAAA\n-----END FOO-----\n"),
+			expected: `parsing PEM key: unsupported PEM block type "FOO"`,
+		},
+		{
+			data:     []byte("-----BEGIN PUBLIC KEY"),
+			expected: "parsing PEM key: no PEM block found",
+		},
+		{
+			data:     []byte{0x30, 0x03, 0x02, 0x01, 0x01},
+			expected: "parsing DER key: not a SubjectPublicKeyInfo, certificate, PKCS#1, PKCS#8 or SEC 1 key",
+		},
+		{
+			data:     []byte{0xa1, 0x01, 0x04},
+			expected: "parsing COSE_Key: unsupported kty 4",
+		},
+		{
+			data:     []byte{},
+			expected: "failed to unmarshal JSON into key hint: EOF",
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ParseVerificationKey(tv.data)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestParseSigningKey(t *testing.T) {
+	priv := testECDSARawPrivateKey(t)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	sec1, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(t, err)
+
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	coseKey, err := COSEKeyFromJWK(sigK)
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	tvs := [][]byte{
+		[]byte(testECDSAPrivateKey),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}),
+		pkcs8,
+		sec1,
+		coseKey,
+	}
+
+	for i, tv := range tvs {
+		k, err := ParseSigningKey(tv)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		// usable with both the JWT and the CWT APIs
+		token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, k)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		var ar AttestationResult
+		assert.NoError(t, ar.Verify(token, jwa.ES256, vfyK), "failed test vector at index %d", i)
+
+		cwt, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, k)
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.NoError(t, ar.VerifyCWT(cwt, jwa.ES256, vfyK), "failed test vector at index %d", i)
+	}
+}
+
+func TestParseSigningKey_RSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(priv),
+	})
+
+	k, err := ParseSigningKey(data)
+	require.NoError(t, err)
+
+	var raw rsa.PrivateKey
+	require.NoError(t, k.Raw(&raw))
+	assert.True(t, priv.Equal(&raw))
+}
+
+func TestParseSigningKey_fail(t *testing.T) {
+	tvs := []struct {
+		data     []byte
+		expected string
+	}{
+		{
+			data:     []byte(testECDSAPublicKey),
+			expected: "EC key is not a signing key",
+		},
+		{
+			data:     []byte("-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----\n"),
+			expected: `parsing PEM key: unsupported PEM block type "PUBLIC KEY"`,
+		},
+		{
+			data:     []byte{0x30, 0x03, 0x02, 0x01, 0x01},
+			expected: "parsing DER key: not a PKCS#1, PKCS#8 or SEC 1 private key",
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ParseSigningKey(tv.data)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	skey := filepath.Join(dir, "skey.json")
+	require.NoError(t, os.WriteFile(skey, []byte(testECDSAPrivateKey), 0600))
+
+	pkey := filepath.Join(dir, "pkey.json")
+	require.NoError(t, os.WriteFile(pkey, []byte(testECDSAPublicKey), 0600))
+
+	sigK, err := LoadSigningKey(skey)
+	require.NoError(t, err)
+
+	vfyK, err := LoadVerificationKey(pkey)
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var ar AttestationResult
+	assert.NoError(t, ar.Verify(token, jwa.ES256, vfyK))
+
+	_, err = LoadSigningKey(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "loading signing key: ")
+
+	_, err = LoadVerificationKey(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "loading verification key: ")
+}
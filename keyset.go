@@ -0,0 +1,127 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// VerifyWithKeySet is like Verify, but the verification key is selected from
+// the supplied JWK Set, e.g., as fetched from the JWKS endpoint of a verifier.
+// If the JWT carries a `kid` header parameter, only the keys in the set with
+// that ID are tried, and a *KeyError wrapping ErrUnknownKey is returned if
+// there are none; otherwise, all the keys in the set are tried in turn.  The
+// algorithm is taken from the `alg` parameter of each key or, if missing, from
+// WithKeySetAlgorithm: the `alg` header of the JWT, which is under the control
+// of its issuer, is never trusted for selecting it.  The first key that
+// successfully verifies the EAR is used.
+func (o *AttestationResult) VerifyWithKeySet(
+	data []byte,
+	keyset jwk.Set,
+	opts ...VerifyOption,
+) error {
+	if keyset == nil || keyset.Len() == 0 {
+		return errors.New("empty key set")
+	}
+
+	hdrs, err := protectedHeaders(data)
+	if err != nil {
+		return err
+	}
+
+	candidates := candidateKeys(keyset, hdrs.KeyID())
+	if len(candidates) == 0 {
+		return &KeyError{Err: ErrUnknownKey, KeyID: hdrs.KeyID()}
+	}
+
+	cfg := newVerifyConfig(opts)
+
+	var problems []string
+
+	for _, key := range candidates {
+		alg, err := keyAlgorithm(key, cfg.keySetAlgorithm)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("key %q: %s", key.KeyID(), err))
+			continue
+		}
+
+		var ar AttestationResult
+
+		if err := ar.Verify(data, alg, key, opts...); err != nil {
+			problems = append(problems, fmt.Sprintf("key %q: %s", key.KeyID(), err))
+			continue
+		}
+
+		*o = ar
+
+		return nil
+	}
+
+	return fmt.Errorf("no key in set could verify the EAR: %s", strings.Join(problems, "; "))
+}
+
+// protectedHeaders returns the protected header of the (first signature of
+// the) supplied JWT, without verifying it
+func protectedHeaders(data []byte) (jws.Headers, error) {
+	msg, err := jws.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing JWT message: %w", err)
+	}
+
+	if len(msg.Signatures()) == 0 {
+		return nil, errors.New("failed parsing JWT message: no signatures")
+	}
+
+	return msg.Signatures()[0].ProtectedHeaders(), nil
+}
+
+// WithKeySetAlgorithm sets the signature algorithm that VerifyWithKeySet (and
+// VerifyWithKeyResolver) use with the keys that do not pin one with their
+// `alg` parameter.  Without it, such keys cannot be used.
+func WithKeySetAlgorithm(alg jwa.SignatureAlgorithm) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.keySetAlgorithm = alg
+	})
+}
+
+// candidateKeys returns the keys in the set whose ID matches kid or, if kid is
+// empty, all the keys in the set
+func candidateKeys(keyset jwk.Set, kid string) []jwk.Key {
+	var candidates []jwk.Key
+
+	for i := 0; i < keyset.Len(); i++ {
+		k, _ := keyset.Key(i)
+
+		if kid == "" || k.KeyID() == kid {
+			candidates = append(candidates, k)
+		}
+	}
+
+	return candidates
+}
+
+// keyAlgorithm returns the signature algorithm to use with the supplied key:
+// that pinned by the key itself, if any, or the configured one (see
+// WithKeySetAlgorithm)
+func keyAlgorithm(key jwk.Key, configured jwa.SignatureAlgorithm) (jwa.KeyAlgorithm, error) {
+	if a := key.Algorithm(); a != nil && a.String() != "" {
+		return a, nil
+	}
+
+	if configured == "" {
+		return nil, errors.New("the key has no algorithm, and none is configured")
+	}
+
+	if configured == jwa.NoSignature {
+		return nil, fmt.Errorf("unacceptable algorithm %q", configured)
+	}
+
+	return configured, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKeySet(t *testing.T) jwk.Set {
+	ecK := mustParseKey(t, testECDSAPublicKey)
+	require.NoError(t, ecK.Set(jwk.KeyIDKey, "ec"))
+
+	edK := mustParseKey(t, testEd25519PublicKey)
+	require.NoError(t, edK.Set(jwk.KeyIDKey, "ed"))
+	require.NoError(t, edK.Set(jwk.AlgorithmKey, jwa.EdDSA))
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(ecK))
+	require.NoError(t, set.AddKey(edK))
+
+	return set
+}
+
+func TestVerifyWithKeySet(t *testing.T) {
+	set := testKeySet(t)
+
+	ecSigK := mustParseKey(t, testECDSAPrivateKey)
+	edSigK := mustParseKey(t, testEd25519PrivateKey)
+
+	tvs := []struct {
+		alg jwa.KeyAlgorithm
+		key jwk.Key
+		kid string
+	}{
+		{jwa.ES256, ecSigK, "ec"},
+		{jwa.EdDSA, edSigK, "ed"},
+		// no kid: all keys are tried
+		{jwa.ES256, ecSigK, ""},
+		{jwa.EdDSA, edSigK, ""},
+	}
+
+	for i, tv := range tvs {
+		var opts []SignOption
+		if tv.kid != "" {
+			opts = append(opts, WithKeyID(tv.kid))
+		}
+
+		token, err := testAttestationResultsWithVeraisonExtns.Sign(tv.alg, tv.key, opts...)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		var actual AttestationResult
+		require.NoError(t, actual.VerifyWithKeySet(token, set, WithKeySetAlgorithm(jwa.ES256)),
+			"failed test vector at index %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual, "failed test vector at index %d", i)
+	}
+}
+
+func TestVerifyWithKeySet_fail(t *testing.T) {
+	set := testKeySet(t)
+
+	// kid matches the wrong key
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey), WithKeyID("ed"))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	err = actual.VerifyWithKeySet(token, set)
+	assert.ErrorContains(t, err, `no key in set could verify the EAR: key "ed": `)
+
+	// kid matches no key: the other keys are not tried
+	token, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey), WithKeyID("unknown"))
+	require.NoError(t, err)
+
+	err = actual.VerifyWithKeySet(token, set, WithKeySetAlgorithm(jwa.ES256))
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	assert.EqualError(t, err, `unknown key for kid "unknown"`)
+
+	// the key pins no algorithm, and none is configured: the one in the JWT
+	// header is not trusted
+	token, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey), WithKeyID("ec"))
+	require.NoError(t, err)
+
+	err = actual.VerifyWithKeySet(token, set)
+	assert.EqualError(t, err, `no key in set could verify the EAR: key "ec": the key has no algorithm, and none is configured`)
+
+	err = actual.VerifyWithKeySet(token, jwk.NewSet())
+	assert.EqualError(t, err, "empty key set")
+
+	err = actual.VerifyWithKeySet([]byte("not a JWT"), set)
+	assert.ErrorContains(t, err, "failed parsing JWT message")
+}
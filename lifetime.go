@@ -0,0 +1,53 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"time"
+)
+
+// SetExpiry sets the `exp` claim, i.e., the time after which the result must
+// no longer be accepted
+func (o *AttestationResult) SetExpiry(t time.Time) {
+	exp := t.Unix()
+	o.Expiry = &exp
+}
+
+// SetNotBefore sets the `nbf` claim, i.e., the time before which the result
+// must not be accepted
+func (o *AttestationResult) SetNotBefore(t time.Time) {
+	nbf := t.Unix()
+	o.NotBefore = &nbf
+}
+
+// WithNotBefore instructs Sign to set the `nbf` claim to `iat` plus offset,
+// overriding any existing value.  offset can be negative, to accommodate
+// relying parties whose clock is running behind.
+func WithNotBefore(offset time.Duration) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.notBefore = &offset
+	})
+}
+
+// WithClockSkew instructs Verify to tolerate a difference of up to d between
+// the Clock in use and that of the issuer when checking the `exp` and `nbf`
+// claims
+func WithClockSkew(d time.Duration) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.clockSkew = d
+	})
+}
+
+func (o AttestationResult) validateLifetime() error {
+	if o.Expiry != nil && o.IssuedAt != nil && *o.Expiry <= *o.IssuedAt {
+		return errors.New("exp (not after iat)")
+	}
+
+	if o.Expiry != nil && o.NotBefore != nil && *o.NotBefore >= *o.Expiry {
+		return errors.New("nbf (not before exp)")
+	}
+
+	return nil
+}
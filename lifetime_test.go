@@ -0,0 +1,119 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifetime_sign_verify_jwt(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	now := time.Unix(testIAT, 0)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK,
+		WithClock(FixedClock(now)), WithTTL(time.Hour),
+		WithNotBefore(time.Minute))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now.Add(time.Minute)))))
+	require.NotNil(t, actual.Expiry)
+	assert.Equal(t, testIAT+3600, *actual.Expiry)
+	require.NotNil(t, actual.NotBefore)
+	assert.Equal(t, testIAT+60, *actual.NotBefore)
+
+	err = actual.Verify(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now.Add(time.Hour))))
+	assert.EqualError(t, err, `failed verifying JWT message: "exp" not satisfied`)
+
+	assert.NoError(t, actual.Verify(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now.Add(time.Hour))),
+		WithClockSkew(time.Minute)))
+
+	err = actual.Verify(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now)))
+	assert.EqualError(t, err, `failed verifying JWT message: "nbf" not satisfied`)
+
+	assert.NoError(t, actual.Verify(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now)),
+		WithClockSkew(time.Minute)))
+}
+
+func TestLifetime_sign_verify_cwt(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	now := time.Unix(testIAT, 0)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.SetNotBefore(now)
+	ar.SetExpiry(now.Add(time.Hour))
+
+	token, err := ar.SignCWT(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifyCWT(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now))))
+	assert.Equal(t, ar, actual)
+
+	err = actual.VerifyCWT(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now.Add(-time.Second))))
+	assert.ErrorContains(t, err, "nbf")
+
+	assert.NoError(t, actual.VerifyCWT(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now.Add(-time.Second))),
+		WithClockSkew(time.Second)))
+
+	err = actual.VerifyCWT(token, jwa.ES256, vfyK,
+		WithClock(FixedClock(now.Add(time.Hour+time.Second))),
+		WithClockSkew(time.Second))
+	assert.ErrorContains(t, err, "exp")
+}
+
+func TestLifetime_validate(t *testing.T) {
+	now := time.Unix(testIAT, 0)
+
+	tvs := []struct {
+		nbf      time.Time
+		exp      time.Time
+		expected string
+	}{
+		{
+			nbf: now,
+			exp: now.Add(time.Hour),
+		},
+		{
+			nbf:      now,
+			exp:      now,
+			expected: "invalid value(s) for exp (not after iat)",
+		},
+		{
+			nbf:      now.Add(2 * time.Hour),
+			exp:      now.Add(time.Hour),
+			expected: "invalid value(s) for nbf (not before exp)",
+		},
+	}
+
+	for i, tv := range tvs {
+		ar := testAttestationResultsWithVeraisonExtns
+		ar.SetNotBefore(tv.nbf)
+		ar.SetExpiry(tv.exp)
+
+		err := ar.validate()
+		if tv.expected == "" {
+			assert.NoError(t, err, "failed test vector at index %d", i)
+		} else {
+			assert.ErrorContains(t, err, tv.expected, "failed test vector at index %d", i)
+		}
+	}
+}
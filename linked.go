@@ -0,0 +1,333 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// LinkedResult references another signed EAR from within an appraisal, e.g.,
+// the result of appraising a component of a composite attester, instead of
+// inlining it.  A list of LinkedResult is carried in the "ear.linked-results"
+// claim.
+type LinkedResult struct {
+	// Digest is the digest of the referenced signed EAR
+	Digest *Digest `json:"digest,omitempty"`
+	// JTI is the `jti` claim of the referenced signed EAR
+	JTI *string `json:"jti,omitempty"`
+	// Locator is an (optional) hint on where to fetch the referenced signed
+	// EAR from, e.g., a URL
+	Locator *string `json:"locator,omitempty"`
+}
+
+func (o LinkedResult) validate() error {
+	if o.Digest == nil && (o.JTI == nil || *o.JTI == "") {
+		return errors.New(`at least one of "digest" and "jti" must be present`)
+	}
+
+	if o.Digest != nil {
+		if err := o.Digest.Validate(); err != nil {
+			return fmt.Errorf(`invalid "digest": %w`, err)
+		}
+	}
+
+	return nil
+}
+
+// check checks that the supplied token is the one referenced by the link.  If
+// the link has a "jti", it must match that of the token.
+func (o LinkedResult) check(token []byte) error {
+	if o.Digest != nil {
+		if err := o.Digest.Verify(token); err != nil {
+			return err
+		}
+	}
+
+	if o.JTI != nil {
+		if jti := tokenID(token); jti != *o.JTI {
+			return fmt.Errorf("jti mismatch: want %q, got %q", *o.JTI, jti)
+		}
+	}
+
+	return nil
+}
+
+func (o LinkedResult) String() string {
+	var s []string
+
+	if o.JTI != nil {
+		s = append(s, "jti="+*o.JTI)
+	}
+
+	if o.Digest != nil && o.Digest.Alg != nil && o.Digest.Value != nil {
+		s = append(s, fmt.Sprintf("%s=%s", *o.Digest.Alg,
+			base64.RawURLEncoding.EncodeToString(*o.Digest.Value)))
+	}
+
+	if o.Locator != nil {
+		s = append(s, "locator="+*o.Locator)
+	}
+
+	return strings.Join(s, " ")
+}
+
+// NewLinkedResult returns a LinkedResult that references the supplied signed
+// EAR by its SHA-256 digest and, if present, its `jti`.  locator is optional
+// and can be empty.
+func NewLinkedResult(token []byte, locator string) (*LinkedResult, error) {
+	if len(token) == 0 {
+		return nil, errors.New("empty token")
+	}
+
+	d, err := NewDigest("sha-256", token)
+	if err != nil {
+		return nil, err
+	}
+
+	l := LinkedResult{Digest: d}
+
+	if jti := tokenID(token); jti != "" {
+		l.JTI = &jti
+	}
+
+	if locator != "" {
+		l.Locator = &locator
+	}
+
+	return &l, nil
+}
+
+// tokenID returns the `jti` of the supplied (compact or JSON serialized) JWS
+// token, or the empty string if there is none.  The signature is not checked.
+func tokenID(token []byte) string {
+	claims, err := unverifiedClaims(token)
+	if err != nil {
+		return ""
+	}
+
+	jti, _ := claims["jti"].(string)
+
+	return jti
+}
+
+// unverifiedClaims returns the claims-set of the supplied (compact or JSON
+// serialized) JWS token, without checking its signature
+func unverifiedClaims(token []byte) (map[string]interface{}, error) {
+	msg, err := parseJWSAsJSON(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.payloadClaims()
+}
+
+// AddLinkedResult appends a reference to the supplied signed EAR to the
+// "ear.linked-results" claim (see NewLinkedResult)
+func (o *Appraisal) AddLinkedResult(token []byte, locator string) error {
+	l, err := NewLinkedResult(token, locator)
+	if err != nil {
+		return err
+	}
+
+	if o.LinkedResults == nil {
+		o.LinkedResults = &[]LinkedResult{}
+	}
+
+	*o.LinkedResults = append(*o.LinkedResults, *l)
+
+	return nil
+}
+
+// GetLinkedResults returns the entries in the "ear.linked-results" claim
+func (o Appraisal) GetLinkedResults() ([]LinkedResult, error) {
+	if o.LinkedResults == nil {
+		return nil, errors.New(`"ear.linked-results" claim not found`)
+	}
+
+	return *o.LinkedResults, nil
+}
+
+func ToLinkedResults(v interface{}) (*[]LinkedResult, error) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON array")
+	}
+
+	ret := make([]LinkedResult, 0, len(l))
+
+	parsers := map[string]parser{
+		"digest": func(v interface{}) (interface{}, error) {
+			return ToDigest(v)
+		},
+	}
+
+	for i, e := range l {
+		var r LinkedResult
+
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry %d: not a JSON object", i)
+		}
+
+		if err := populateStructFromMap(&r, m, "json", parsers, stringPtrParser, false); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		ret = append(ret, r)
+	}
+
+	return &ret, nil
+}
+
+// LinkedResultResolver fetches the signed EAR referenced by a LinkedResult,
+// e.g., from the location in its "locator"
+type LinkedResultResolver interface {
+	ResolveLinkedResult(link LinkedResult) ([]byte, error)
+}
+
+// LinkedResultResolverFunc is an adapter that allows the use of an ordinary
+// function as a LinkedResultResolver.
+type LinkedResultResolverFunc func(link LinkedResult) ([]byte, error)
+
+// ResolveLinkedResult returns f(link)
+func (f LinkedResultResolverFunc) ResolveLinkedResult(link LinkedResult) ([]byte, error) {
+	return f(link)
+}
+
+// LinkedResultKeyFunc returns the algorithm and key that must be used to
+// verify the signed EAR referenced by the supplied link
+type LinkedResultKeyFunc func(link LinkedResult) (jwa.KeyAlgorithm, interface{}, error)
+
+// VerifyLinkedResults fetches each of the signed EARs referenced in the
+// "ear.linked-results" claim using resolver, checks that it matches the
+// reference, and verifies it using the key returned by keyFunc and the
+// supplied VerifyOption.  On success, the decoded attestation results are
+// returned in the same order as the references.  Verification of all linked
+// results is attempted, and any failure is reported in the returned error.
+// Linked results are not followed recursively, unless requested in opts using
+// WithLinkedResultResolver.
+func (o Appraisal) VerifyLinkedResults(
+	resolver LinkedResultResolver,
+	keyFunc LinkedResultKeyFunc,
+	opts ...VerifyOption,
+) ([]*AttestationResult, error) {
+	if resolver == nil {
+		return nil, errors.New("nil linked result resolver")
+	}
+
+	if keyFunc == nil {
+		return nil, errors.New("nil linked result key function")
+	}
+
+	links, err := o.GetLinkedResults()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*AttestationResult, 0, len(links))
+	var problems []string
+
+	for i, l := range links {
+		ar, err := verifyLinkedResult(l, resolver, keyFunc, opts)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("[%d] (%s): %s", i, l, err.Error()))
+			continue
+		}
+
+		results = append(results, ar)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("verifying linked results: %s", strings.Join(problems, "; "))
+	}
+
+	return results, nil
+}
+
+func verifyLinkedResult(
+	link LinkedResult,
+	resolver LinkedResultResolver,
+	keyFunc LinkedResultKeyFunc,
+	opts []VerifyOption,
+) (*AttestationResult, error) {
+	token, err := resolver.ResolveLinkedResult(link)
+	if err != nil {
+		return nil, fmt.Errorf("resolving: %w", err)
+	}
+
+	if err := link.check(token); err != nil {
+		return nil, err
+	}
+
+	alg, key, err := keyFunc(link)
+	if err != nil {
+		return nil, fmt.Errorf("resolving key: %w", err)
+	}
+
+	var ar AttestationResult
+	if err := ar.Verify(token, alg, key, opts...); err != nil {
+		return nil, err
+	}
+
+	return &ar, nil
+}
+
+// WithLinkedResultResolver instructs Verify to fetch, check and verify the
+// signed EARs referenced in the "ear.linked-results" claim of each appraisal
+// (see Appraisal.VerifyLinkedResults).  Linked results are verified with the
+// same options as the linking result, so references are followed
+// recursively.
+func WithLinkedResultResolver(
+	resolver LinkedResultResolver,
+	keyFunc LinkedResultKeyFunc,
+) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.linkedResultResolver = resolver
+		c.linkedResultKeyFunc = keyFunc
+	})
+}
+
+// maxLinkDepth bounds the recursion when following linked results
+const maxLinkDepth = 8
+
+// checkLinkedResults verifies the linked results of each appraisal, if
+// requested in cfg
+func (o AttestationResult) checkLinkedResults(cfg *verifyConfig) error {
+	if cfg.linkedResultResolver == nil {
+		return nil
+	}
+
+	if cfg.linkDepth >= maxLinkDepth {
+		return fmt.Errorf("linked results nested deeper than %d levels", maxLinkDepth)
+	}
+
+	depth := cfg.linkDepth + 1
+	opts := append(append([]VerifyOption{}, cfg.opts...), verifyOptionFunc(func(c *verifyConfig) {
+		c.linkDepth = depth
+	}))
+
+	for _, name := range o.submodNames() {
+		a := o.Submods[name]
+		if a == nil || a.LinkedResults == nil {
+			continue
+		}
+
+		if _, err := a.VerifyLinkedResults(
+			cfg.linkedResultResolver, cfg.linkedResultKeyFunc, opts...,
+		); err != nil {
+			return fmt.Errorf("submods[%s]: %w", name, err)
+		}
+	}
+
+	return nil
+}
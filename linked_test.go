@@ -0,0 +1,220 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLinkedResults returns a signed component EAR and a composite EAR that
+// links to it
+func testLinkedResults(t *testing.T) (component []byte, composite AttestationResult) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	component, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK, WithTokenID())
+	require.NoError(t, err)
+
+	composite = *NewAttestationResult("composite", testVidBuild, testVidDeveloper)
+	require.NoError(t, composite.Submods["composite"].AddLinkedResult(component, "https://example.com/ears/1"))
+
+	return component, composite
+}
+
+func testLinkedResultKeyFunc(t *testing.T) LinkedResultKeyFunc {
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	return func(LinkedResult) (jwa.KeyAlgorithm, interface{}, error) {
+		return jwa.ES256, vfyK, nil
+	}
+}
+
+func TestAppraisal_AddGetLinkedResults_ok(t *testing.T) {
+	component, composite := testLinkedResults(t)
+
+	links, err := composite.Submods["composite"].GetLinkedResults()
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+
+	assert.Equal(t, "sha-256", *links[0].Digest.Alg)
+	assert.NoError(t, links[0].Digest.Verify(component))
+	assert.Equal(t, tokenID(component), *links[0].JTI)
+	assert.NotEmpty(t, *links[0].JTI)
+	assert.Equal(t, "https://example.com/ears/1", *links[0].Locator)
+
+	_, err = (Appraisal{}).GetLinkedResults()
+	assert.EqualError(t, err, `"ear.linked-results" claim not found`)
+
+	err = (&Appraisal{}).AddLinkedResult(nil, "")
+	assert.EqualError(t, err, "empty token")
+}
+
+func TestLinkedResults_round_trip(t *testing.T) {
+	_, composite := testLinkedResults(t)
+
+	data, err := composite.MarshalJSON()
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Equal(t, composite.Submods, actual.Submods)
+
+	data, err = composite.MarshalCBOR()
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.UnmarshalCBOR(data))
+	assert.Equal(t, composite.Submods, actual.Submods)
+}
+
+func TestToLinkedResults_fail(t *testing.T) {
+	tvs := []struct {
+		v        string
+		expected string
+	}{
+		{`{}`, "not a JSON array"},
+		{`["x"]`, "entry 0: not a JSON object"},
+		{`[{"locator": "here"}]`, `entry 0: at least one of "digest" and "jti" must be present`},
+		{
+			`[{"digest": {"alg": "sha-256", "value": "AAAA"}}]`,
+			"entry 0: invalid value(s) for 'digest' (sha-256 digest has wrong length: want 32 bytes, got 3)",
+		},
+	}
+
+	for i, tv := range tvs {
+		var v interface{}
+		require.NoError(t, json.Unmarshal([]byte(tv.v), &v))
+
+		_, err := ToLinkedResults(v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestAppraisal_VerifyLinkedResults_ok(t *testing.T) {
+	component, composite := testLinkedResults(t)
+
+	var resolved []string
+	resolver := LinkedResultResolverFunc(func(l LinkedResult) ([]byte, error) {
+		resolved = append(resolved, *l.Locator)
+		return component, nil
+	})
+
+	results, err := composite.Submods["composite"].VerifyLinkedResults(
+		resolver, testLinkedResultKeyFunc(t))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, results[0].Submods)
+	assert.Equal(t, []string{"https://example.com/ears/1"}, resolved)
+}
+
+func TestAppraisal_VerifyLinkedResults_fail(t *testing.T) {
+	component, composite := testLinkedResults(t)
+	a := composite.Submods["composite"]
+	keyFunc := testLinkedResultKeyFunc(t)
+
+	other, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	tvs := []struct {
+		resolver LinkedResultResolver
+		keyFunc  LinkedResultKeyFunc
+		expected string
+	}{
+		{
+			LinkedResultResolverFunc(func(LinkedResult) ([]byte, error) {
+				return nil, errors.New("not found")
+			}),
+			keyFunc,
+			"resolving: not found",
+		},
+		{
+			LinkedResultResolverFunc(func(LinkedResult) ([]byte, error) {
+				return other, nil
+			}),
+			keyFunc,
+			"digest mismatch",
+		},
+		{
+			LinkedResultResolverFunc(func(LinkedResult) ([]byte, error) {
+				return component, nil
+			}),
+			func(LinkedResult) (jwa.KeyAlgorithm, interface{}, error) {
+				return nil, nil, errors.New("unknown verifier")
+			},
+			"resolving key: unknown verifier",
+		},
+		{
+			LinkedResultResolverFunc(func(LinkedResult) ([]byte, error) {
+				return component, nil
+			}),
+			func(LinkedResult) (jwa.KeyAlgorithm, interface{}, error) {
+				return jwa.EdDSA, mustParseKey(t, testEd25519PublicKey), nil
+			},
+			"failed verifying JWT message: ",
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := a.VerifyLinkedResults(tv.resolver, tv.keyFunc)
+		assert.ErrorContains(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+
+	_, err = a.VerifyLinkedResults(nil, keyFunc)
+	assert.EqualError(t, err, "nil linked result resolver")
+}
+
+func TestLinkedResult_check_jti_mismatch(t *testing.T) {
+	component, _ := testLinkedResults(t)
+
+	jti := "other"
+	l := LinkedResult{JTI: &jti}
+
+	assert.EqualError(t, l.check(component),
+		`jti mismatch: want "other", got "`+tokenID(component)+`"`)
+}
+
+func TestVerify_WithLinkedResultResolver(t *testing.T) {
+	component, composite := testLinkedResults(t)
+
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	token, err := composite.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	good := LinkedResultResolverFunc(func(LinkedResult) ([]byte, error) {
+		return component, nil
+	})
+
+	bad := LinkedResultResolverFunc(func(LinkedResult) ([]byte, error) {
+		return nil, errors.New("not found")
+	})
+
+	var ar AttestationResult
+
+	// linked results are not checked unless requested
+	assert.NoError(t, ar.Verify(token, jwa.ES256, vfyK))
+
+	assert.NoError(t, ar.Verify(token, jwa.ES256, vfyK,
+		WithLinkedResultResolver(good, testLinkedResultKeyFunc(t))))
+
+	err = ar.Verify(token, jwa.ES256, vfyK,
+		WithLinkedResultResolver(bad, testLinkedResultKeyFunc(t)))
+	assert.ErrorContains(t, err, "submods[composite]: verifying linked results: [0] (jti=")
+	assert.ErrorContains(t, err, "resolving: not found")
+}
+
+func mustParseKey(tb testing.TB, s string) jwk.Key {
+	k, err := jwk.ParseKey([]byte(s))
+	require.NoError(tb, err)
+	return k
+}
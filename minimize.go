@@ -0,0 +1,114 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+// MinimizableClaims lists the top-level claims that Minimize removes
+var MinimizableClaims = []string{
+	"ear.raw-evidence",
+	"ear.veraison.tee-info",
+	"ear.veraison.provenance",
+}
+
+// MinimizableAppraisalClaims lists the appraisal (i.e., submod) claims that
+// Minimize removes
+var MinimizableAppraisalClaims = []string{
+	"ear.veraison.annotated-evidence",
+	"ear.veraison.policy-claims",
+	"ear.veraison.key-attestation",
+	"ear.veraison.status-reasons",
+	"ear.veraison.policy-results",
+	"ear.veraison.reasons",
+	"ear.veraison.status-overrides",
+	"ear.veraison.unprocessed-evidence",
+}
+
+// ClaimSelector selects the claims that Minimize must keep.  submod is the
+// name of the submod the claim belongs to, or the empty string for top-level
+// claims.
+type ClaimSelector func(submod, claim string) bool
+
+// KeepClaim selects the named claim, wherever it appears
+func KeepClaim(claim string) ClaimSelector {
+	return func(_, c string) bool {
+		return c == claim
+	}
+}
+
+// KeepSubmodClaim selects the named claim of the named submod only
+func KeepSubmodClaim(submod, claim string) ClaimSelector {
+	return func(s, c string) bool {
+		return s == submod && c == claim
+	}
+}
+
+// Minimize removes the bulky, informational claims listed in
+// MinimizableClaims and MinimizableAppraisalClaims, except those selected by
+// any of the keep selectors, producing the smallest EAR that still carries
+// the appraisal outcome.  This is meant for constrained transports, such as
+// EST or DICE channels.  The claims that bind the result to the attester, the
+// evidence and the verifier (e.g., eat_nonce, cnf, ear.evidence-digest,
+// ear.verifier-id) and the appraisal outcome itself (ear.status,
+// ear.trustworthiness-vector, ear.appraisal-policy-id) are never removed, so
+// a valid result stays valid.  The result is modified in place, including
+// the appraisals it points to.
+func (o *AttestationResult) Minimize(keep ...ClaimSelector) {
+	kept := func(submod, claim string) bool {
+		for _, k := range keep {
+			if k(submod, claim) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !kept("", "ear.raw-evidence") {
+		o.RawEvidence = nil
+	}
+
+	if !kept("", "ear.veraison.tee-info") {
+		o.VeraisonTeeInfo = nil
+	}
+
+	if !kept("", "ear.veraison.provenance") {
+		o.VeraisonProvenance = nil
+	}
+
+	for name, a := range o.Submods {
+		if a == nil {
+			continue
+		}
+
+		if !kept(name, "ear.veraison.annotated-evidence") {
+			a.VeraisonAnnotatedEvidence = nil
+		}
+
+		if !kept(name, "ear.veraison.policy-claims") {
+			a.VeraisonPolicyClaims = nil
+		}
+
+		if !kept(name, "ear.veraison.key-attestation") {
+			a.VeraisonKeyAttestation = nil
+		}
+
+		if !kept(name, "ear.veraison.status-reasons") {
+			a.VeraisonStatusReasons = nil
+		}
+
+		if !kept(name, "ear.veraison.policy-results") {
+			a.VeraisonPolicyResults = nil
+		}
+
+		if !kept(name, "ear.veraison.reasons") {
+			a.VeraisonReasons = nil
+		}
+
+		if !kept(name, "ear.veraison.status-overrides") {
+			a.VeraisonStatusOverrides = nil
+		}
+
+		if !kept(name, "ear.veraison.unprocessed-evidence") {
+			a.VeraisonUnprocessedEvidence = nil
+		}
+	}
+}
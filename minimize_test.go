@@ -0,0 +1,67 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMinimizableResult(t *testing.T) AttestationResult {
+	data, err := testAttestationResultsWithVeraisonExtns.MarshalJSON()
+	require.NoError(t, err)
+
+	var ar AttestationResult
+	require.NoError(t, ar.UnmarshalJSON(data))
+
+	rawEvidence := B64Url("evidence")
+	ar.RawEvidence = &rawEvidence
+
+	return ar
+}
+
+func TestAttestationResult_Minimize(t *testing.T) {
+	ar := testMinimizableResult(t)
+
+	ar.Minimize()
+
+	data, err := ar.MarshalJSON()
+	require.NoError(t, err)
+
+	expected := `{
+		"eat_profile": "tag:github.com,2023:veraison/ear",
+		"iat": 1666091373,
+		"ear.verifier-id": {
+			"build": "rrtrap-v1.0.0",
+			"developer": "Acme Inc."
+		},
+		"submods": {
+			"test": {
+				"ear.status": "affirming",
+				"ear.appraisal-policy-id": "policy://test/01234"
+			}
+		}
+	}`
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestAttestationResult_Minimize_keep(t *testing.T) {
+	ar := testMinimizableResult(t)
+
+	ar.Minimize(
+		KeepClaim("ear.raw-evidence"),
+		KeepSubmodClaim("test", "ear.veraison.key-attestation"),
+		KeepSubmodClaim("other", "ear.veraison.policy-claims"),
+	)
+
+	require.NotNil(t, ar.RawEvidence)
+	assert.Equal(t, B64Url("evidence"), *ar.RawEvidence)
+
+	a := ar.Submods["test"]
+	assert.NotNil(t, a.VeraisonKeyAttestation)
+	assert.Nil(t, a.VeraisonPolicyClaims)
+	assert.Nil(t, a.VeraisonAnnotatedEvidence)
+}
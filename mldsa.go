@@ -0,0 +1,72 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build go1.26
+
+package ear
+
+import (
+	"crypto/mldsa"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	cose "github.com/veraison/go-cose"
+)
+
+// mldsaAlgorithm implements CustomAlgorithm for one of the ML-DSA parameter
+// sets.  Keys are *mldsa.PrivateKey (signing) and *mldsa.PublicKey
+// (verification).  COSE identifiers are from draft-ietf-cose-dilithium.
+type mldsaAlgorithm struct {
+	alg     jwa.SignatureAlgorithm
+	coseAlg cose.Algorithm
+	params  mldsa.Parameters
+}
+
+func (o mldsaAlgorithm) JWSAlgorithm() jwa.SignatureAlgorithm { return o.alg }
+
+func (o mldsaAlgorithm) COSEAlgorithm() cose.Algorithm { return o.coseAlg }
+
+func (o mldsaAlgorithm) Sign(key interface{}, data []byte) ([]byte, error) {
+	sk, ok := key.(*mldsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expecting *mldsa.PrivateKey, got %T", key)
+	}
+
+	if sk.PublicKey().Parameters() != o.params {
+		return nil, fmt.Errorf("%s key cannot be used with %s", sk.PublicKey().Parameters(), o.alg)
+	}
+
+	return sk.Sign(nil, data, nil)
+}
+
+func (o mldsaAlgorithm) Verify(key interface{}, data, sig []byte) error {
+	var pk *mldsa.PublicKey
+
+	switch t := key.(type) {
+	case *mldsa.PublicKey:
+		pk = t
+	case *mldsa.PrivateKey:
+		pk = t.PublicKey()
+	default:
+		return fmt.Errorf("expecting *mldsa.PublicKey, got %T", key)
+	}
+
+	if pk.Parameters() != o.params {
+		return errors.New("key parameters do not match algorithm")
+	}
+
+	return mldsa.Verify(pk, data, sig, nil)
+}
+
+func init() {
+	for _, a := range []mldsaAlgorithm{
+		{MLDSA44, -48, mldsa.MLDSA44()},
+		{MLDSA65, -49, mldsa.MLDSA65()},
+		{MLDSA87, -50, mldsa.MLDSA87()},
+	} {
+		if err := RegisterAlgorithm(a); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build go1.26
+
+package ear
+
+import (
+	"crypto/mldsa"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMLDSA_round_trip(t *testing.T) {
+	tvs := []struct {
+		alg    jwa.SignatureAlgorithm
+		params mldsa.Parameters
+	}{
+		{MLDSA44, mldsa.MLDSA44()},
+		{MLDSA65, mldsa.MLDSA65()},
+		{MLDSA87, mldsa.MLDSA87()},
+	}
+
+	for i, tv := range tvs {
+		sk, err := mldsa.GenerateKey(tv.params)
+		require.NoError(t, err)
+
+		var actual AttestationResult
+
+		token, err := testAttestationResultsWithVeraisonExtns.Sign(tv.alg, sk)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		err = actual.Verify(token, tv.alg, sk.PublicKey())
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+		token, err = testAttestationResultsWithVeraisonExtns.SignCWT(tv.alg, sk)
+		require.NoError(t, err, "failed test vector at index %d", i)
+
+		err = actual.VerifyCWT(token, tv.alg, sk.PublicKey())
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+	}
+}
+
+func TestMLDSA_wrong_parameters(t *testing.T) {
+	sk, err := mldsa.GenerateKey(mldsa.MLDSA44())
+	require.NoError(t, err)
+
+	_, err = testAttestationResultsWithVeraisonExtns.Sign(MLDSA65, sk)
+	assert.EqualError(t, err, "signing with ML-DSA-65: ML-DSA-44 key cannot be used with ML-DSA-65")
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(MLDSA44, sk)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	err = actual.Verify(token, MLDSA65, sk.PublicKey())
+	assert.ErrorContains(t, err, "failed verifying JWT message")
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// signConfig collects the settings that can be tweaked via SignOption
+type signConfig struct {
+	clock                Clock
+	stampIssuedAt        bool
+	issuerFromVerifierID bool
+	ttl                  time.Duration
+	expiry               *time.Time
+	notBefore            *time.Duration
+	generateTokenID      bool
+	topLevelAppraisal    string
+	skipValidation       bool
+	keyID                string
+	headers              map[string]interface{}
+	certChain            []*x509.Certificate
+	rawEvidenceRef       *rawEvidenceRefOption
+	auditSink            AuditSink
+	cborTagging          CBORTagging
+	securedTransport     bool
+}
+
+// verifyConfig collects the settings that can be tweaked via VerifyOption
+type verifyConfig struct {
+	clock                 Clock
+	clockSkew             time.Duration
+	maxAge                time.Duration
+	checkIssuerVerifierID bool
+	expectedIssuer        string
+	expectedSubject       string
+	requireConfirmation   bool
+	acceptedProfiles      []string
+	freshnessPolicy       FreshnessPolicy
+	expectedNonces        [][]byte
+	linkedResultResolver  LinkedResultResolver
+	linkedResultKeyFunc   LinkedResultKeyFunc
+	linkDepth             int
+	policyResolver        PolicyResolver
+	warningHandler        WarningHandler
+	topLevelAppraisal     string
+	decodingMode          DecodingMode
+	acceptancePolicy      *AcceptancePolicy
+	auditSink             AuditSink
+	securedTransport      bool
+	keySetAlgorithm       jwa.SignatureAlgorithm
+	// opts are the options the config has been built from
+	opts []VerifyOption
+}
+
+// SignOption configures the behaviour of Sign
+type SignOption interface {
+	applySignOption(*signConfig)
+}
+
+// VerifyOption configures the behaviour of Verify
+type VerifyOption interface {
+	applyVerifyOption(*verifyConfig)
+}
+
+// Option is an option that can be used with both Sign and Verify
+type Option interface {
+	SignOption
+	VerifyOption
+}
+
+type signOptionFunc func(*signConfig)
+
+func (f signOptionFunc) applySignOption(c *signConfig) { f(c) }
+
+type verifyOptionFunc func(*verifyConfig)
+
+func (f verifyOptionFunc) applyVerifyOption(c *verifyConfig) { f(c) }
+
+func newSignConfig(opts []SignOption) *signConfig {
+	cfg := &signConfig{clock: systemClock}
+
+	for _, opt := range opts {
+		opt.applySignOption(cfg)
+	}
+
+	return cfg
+}
+
+func newVerifyConfig(opts []VerifyOption) *verifyConfig {
+	cfg := &verifyConfig{clock: systemClock, opts: opts}
+
+	for _, opt := range opts {
+		opt.applyVerifyOption(cfg)
+	}
+
+	return cfg
+}
+
+type clockOption struct {
+	clock Clock
+}
+
+func (o clockOption) applySignOption(c *signConfig)     { c.clock = o.clock }
+func (o clockOption) applyVerifyOption(c *verifyConfig) { c.clock = o.clock }
+
+// WithClock makes Sign and Verify use the supplied Clock instead of the system
+// time.  A nil Clock selects the system time.
+func WithClock(clock Clock) Option {
+	if clock == nil {
+		clock = systemClock
+	}
+	return clockOption{clock}
+}
+
+// WithIssuedAtNow instructs Sign to set the `iat` claim to the current time
+// (as reported by the Clock in use), overriding any existing value.
+func WithIssuedAtNow() SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.stampIssuedAt = true
+	})
+}
+
+// WithMaxAge instructs Verify to reject results whose `iat` is older than d
+// with respect to the Clock in use.  A zero or negative d disables the check.
+func WithMaxAge(d time.Duration) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.maxAge = d
+	})
+}
+
+// WithIssuerFromVerifierID instructs Sign to set the `iss` claim to the
+// canonical form of the verifier identity (see VerifierIdentity.Issuer).
+func WithIssuerFromVerifierID() SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.issuerFromVerifierID = true
+	})
+}
+
+// WithIssuerVerifierIDCheck instructs Verify to require that the `iss` claim
+// is present and matches the canonical form of the verifier identity carried
+// in "ear.verifier-id" (see VerifierIdentity.Issuer).  This prevents a result
+// signed by one verifier from claiming the identity of another.
+func WithIssuerVerifierIDCheck() VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.checkIssuerVerifierID = true
+	})
+}
+
+// WithExpectedIssuer instructs Verify to reject results whose `iss` claim is
+// missing or different from iss
+func WithExpectedIssuer(iss string) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.expectedIssuer = iss
+	})
+}
+
+// WithExpectedSubject instructs Verify to reject results whose `sub` claim is
+// missing or different from sub
+func WithExpectedSubject(sub string) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.expectedSubject = sub
+	})
+}
+
+// WithTTL instructs Sign to set the `exp` claim to `iat` plus d, overriding
+// any existing value.  A zero or negative d means that the `exp` claim is
+// left as is.  WithExpiry takes precedence over WithTTL.
+func WithTTL(d time.Duration) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.ttl = d
+	})
+}
+
+// WithTokenID instructs Sign to set the `jti` claim to a freshly generated,
+// random (128-bit) identifier, overriding any existing value.  The probability
+// of two such identifiers colliding is negligible.
+func WithTokenID() SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.generateTokenID = true
+	})
+}
+
+// WithExpiry instructs Sign to set the `exp` claim to t, overriding any
+// existing value
+func WithExpiry(t time.Time) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.expiry = &t
+	})
+}
+
+// WithKeyID instructs Sign to set the `kid` protected header parameter to
+// kid, overriding the key ID (if any) of the signing jwk.Key
+func WithKeyID(kid string) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.keyID = kid
+	})
+}
+
+// WithHeader instructs Sign to add the supplied parameter to the JWS protected
+// header.  The `alg` parameter cannot be set this way.  Headers are ignored
+// by SignCWT, except for `kid` (see WithKeyID).
+func WithHeader(name string, value interface{}) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		if c.headers == nil {
+			c.headers = map[string]interface{}{}
+		}
+		c.headers[name] = value
+	})
+}
+
+// WithExpectedProfile instructs Verify to reject results whose `eat_profile`
+// is not p.  It is a shorthand for WithAcceptedProfiles(p).
+func WithExpectedProfile(p string) VerifyOption {
+	return WithAcceptedProfiles(p)
+}
+
+// WithValidation controls whether Sign checks that the result carries all the
+// mandatory claims and that their values are consistent.  Validation is
+// enabled by default; disabling it is meant for producing test vectors for
+// non-conformant peers, and should be avoided otherwise.  Mandatory claims that
+// are not set are left out of the claims-set.  Verify always requires the
+// mandatory claims.
+func WithValidation(enabled bool) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.skipValidation = !enabled
+	})
+}
+
+// protectedHeaders returns the extra JWS protected header parameters requested
+// via WithKeyID, WithHeader and WithCertChain
+func (o signConfig) protectedHeaders() map[string]interface{} {
+	hdrs := map[string]interface{}{}
+
+	for k, v := range o.headers {
+		hdrs[k] = v
+	}
+
+	if o.keyID != "" {
+		hdrs["kid"] = o.keyID
+	}
+
+	if len(o.certChain) > 0 {
+		hdrs["x5c"] = x5cChain(o.certChain)
+	}
+
+	return hdrs
+}
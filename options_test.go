@@ -0,0 +1,173 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/go-cose"
+)
+
+func TestSign_WithKeyID_WithHeader(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK,
+		WithKeyID("key#1"),
+		WithHeader("x-test", "value"),
+		WithHeader(jws.AlgorithmKey, "none"),
+	)
+	require.NoError(t, err)
+
+	msg, err := jws.Parse(token)
+	require.NoError(t, err)
+	require.Len(t, msg.Signatures(), 1)
+
+	hdrs := msg.Signatures()[0].ProtectedHeaders()
+	assert.Equal(t, "key#1", hdrs.KeyID())
+	assert.Equal(t, jwa.ES256, hdrs.Algorithm())
+
+	v, ok := hdrs.Get("x-test")
+	assert.True(t, ok)
+	assert.Equal(t, "value", v)
+
+	var actual AttestationResult
+	assert.NoError(t, actual.Verify(token, jwa.ES256, vfyK))
+}
+
+func TestSignCWT_WithKeyID(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK,
+		WithKeyID("key#1"))
+	require.NoError(t, err)
+
+	var msg cose.Sign1Message
+	require.NoError(t, msg.UnmarshalCBOR(token))
+	assert.Equal(t, []byte("key#1"), msg.Headers.Protected[cose.HeaderLabelKeyID])
+}
+
+func TestSign_WithExpiry(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	now := time.Unix(testIAT, 0)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK,
+		WithExpiry(now.Add(time.Minute)), WithTTL(time.Hour))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK, WithClock(FixedClock(now))))
+	require.NotNil(t, actual.Expiry)
+	assert.Equal(t, testIAT+60, *actual.Expiry)
+}
+
+func TestVerify_WithExpectedProfile(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	assert.NoError(t, actual.Verify(token, jwa.ES256, vfyK,
+		WithExpectedProfile(EatProfile)))
+
+	err = actual.Verify(token, jwa.ES256, vfyK,
+		WithExpectedProfile("tag:example.com,2026:other"))
+	var pnaErr *ProfileNotAcceptedError
+	assert.ErrorAs(t, err, &pnaErr)
+}
+
+func TestSign_Verify_iss_sub_jti(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	sub := "urn:example:attester:42"
+	ar.Subject = &sub
+
+	token, err := ar.Sign(jwa.ES256, sigK, WithIssuerFromVerifierID(), WithTokenID())
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK,
+		WithExpectedIssuer("Acme%20Inc./rrtrap-v1.0.0"),
+		WithExpectedSubject(sub)))
+	assert.Equal(t, "Acme%20Inc./rrtrap-v1.0.0", *actual.Issuer)
+	assert.Equal(t, sub, *actual.Subject)
+	require.NotNil(t, actual.TokenID)
+	assert.Len(t, *actual.TokenID, 22)
+
+	// re-signing preserves the claims
+	data, err := actual.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"sub":"urn:example:attester:42"`)
+	assert.Contains(t, string(data), `"jti":"`+*actual.TokenID+`"`)
+
+	actual = AttestationResult{}
+	err = actual.Verify(token, jwa.ES256, vfyK, WithExpectedIssuer("Evil%20Corp/rrtrap-v6.6.6"))
+	assert.EqualError(t, err, `iss check failed: want "Evil%20Corp/rrtrap-v6.6.6", got "Acme%20Inc./rrtrap-v1.0.0"`)
+
+	actual = AttestationResult{}
+	err = actual.Verify(token, jwa.ES256, vfyK, WithExpectedSubject("urn:example:attester:43"))
+	assert.EqualError(t, err, `sub check failed: want "urn:example:attester:43", got "urn:example:attester:42"`)
+
+	token, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	err = actual.Verify(token, jwa.ES256, vfyK, WithExpectedSubject(sub))
+	assert.EqualError(t, err, "sub check failed: missing 'sub'")
+}
+
+func TestAttestationResult_validate_empty_sub(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	sub := ""
+	ar.Subject = &sub
+
+	_, err := ar.MarshalJSON()
+	assert.EqualError(t, err, "invalid value(s) for sub (empty)")
+}
+
+func TestWithValidation(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	nonce := "short"
+
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Nonce = &nonce
+
+	_, err := ar.Sign(jwa.ES256, sigK)
+	assert.ErrorContains(t, err, "eat_nonce (5 bytes)")
+
+	token, err := ar.Sign(jwa.ES256, sigK, WithValidation(false))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK))
+	assert.Equal(t, ar, actual)
+
+	// missing mandatory claims are left out, rather than set to null
+	ar = testAttestationResultsWithVeraisonExtns
+	ar.Profile = nil
+
+	token, err = ar.Sign(jwa.ES256, sigK, WithValidation(false))
+	require.NoError(t, err)
+
+	msg, err := jws.Parse(token)
+	require.NoError(t, err)
+	assert.NotContains(t, string(msg.Payload()), "eat_profile")
+
+	actual = AttestationResult{}
+	err = actual.Verify(token, jwa.ES256, vfyK)
+	assert.ErrorContains(t, err, "missing mandatory 'eat_profile'")
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Policy is an appraisal policy document, as fetched by a PolicyResolver
+type Policy struct {
+	// ID is the policy identifier, as found in "ear.appraisal-policy-id"
+	ID string
+	// MediaType is the media type of Content, if known
+	MediaType string
+	// Content is the policy document
+	Content []byte
+	// Metadata is descriptive information about the policy (e.g., its name,
+	// version or author) that can be used to annotate reports
+	Metadata map[string]string
+}
+
+// PolicyResolver fetches the appraisal policy document identified by the
+// supplied "ear.appraisal-policy-id"
+type PolicyResolver interface {
+	ResolvePolicy(id string) (*Policy, error)
+}
+
+// PolicyResolverFunc is an adapter that allows the use of an ordinary function
+// as a PolicyResolver.
+type PolicyResolverFunc func(id string) (*Policy, error)
+
+// ResolvePolicy returns f(id)
+func (f PolicyResolverFunc) ResolvePolicy(id string) (*Policy, error) {
+	return f(id)
+}
+
+type policyCacheEntry struct {
+	policy  *Policy
+	expires time.Time
+}
+
+// CachingPolicyResolver wraps a PolicyResolver and caches the policies it
+// returns, so that the same policy document is not fetched again for each
+// result.  Failures are not cached.  It is safe for concurrent use.
+type CachingPolicyResolver struct {
+	// Resolver is the wrapped resolver
+	Resolver PolicyResolver
+	// TTL is how long a policy is cached for.  If zero, policies are cached
+	// until Purge is called.
+	TTL time.Duration
+	// Clock is used to expire cache entries.  If nil, the system time is
+	// used.
+	Clock Clock
+
+	mu      sync.Mutex
+	entries map[string]policyCacheEntry
+}
+
+// NewCachingPolicyResolver returns a CachingPolicyResolver that wraps resolver
+// and caches policies for ttl
+func NewCachingPolicyResolver(resolver PolicyResolver, ttl time.Duration) *CachingPolicyResolver {
+	return &CachingPolicyResolver{Resolver: resolver, TTL: ttl}
+}
+
+// ResolvePolicy returns the cached policy identified by id, if it has not
+// expired, or else fetches (and caches) it using the wrapped resolver
+func (o *CachingPolicyResolver) ResolvePolicy(id string) (*Policy, error) {
+	if o.Resolver == nil {
+		return nil, errors.New("nil policy resolver")
+	}
+
+	clock := o.Clock
+	if clock == nil {
+		clock = systemClock
+	}
+
+	now := clock.Now()
+
+	o.mu.Lock()
+	e, ok := o.entries[id]
+	o.mu.Unlock()
+
+	if ok && (e.expires.IsZero() || now.Before(e.expires)) {
+		return e.policy, nil
+	}
+
+	p, err := o.Resolver.ResolvePolicy(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, errors.New("no policy returned")
+	}
+
+	e = policyCacheEntry{policy: p}
+	if o.TTL > 0 {
+		e.expires = now.Add(o.TTL)
+	}
+
+	o.mu.Lock()
+	if o.entries == nil {
+		o.entries = map[string]policyCacheEntry{}
+	}
+	o.entries[id] = e
+	o.mu.Unlock()
+
+	return p, nil
+}
+
+// Purge removes all the cached policies
+func (o *CachingPolicyResolver) Purge() {
+	o.mu.Lock()
+	o.entries = nil
+	o.mu.Unlock()
+}
+
+// ResolvePolicies fetches, using resolver, the appraisal policy of each submod
+// that has an "ear.appraisal-policy-id".  The policies are returned keyed by
+// submod name.
+func (o AttestationResult) ResolvePolicies(resolver PolicyResolver) (map[string]*Policy, error) {
+	if resolver == nil {
+		return nil, errors.New("nil policy resolver")
+	}
+
+	policies := map[string]*Policy{}
+
+	for _, name := range o.submodNames() {
+		a := o.Submods[name]
+		if a == nil || a.AppraisalPolicyID == nil {
+			continue
+		}
+
+		id := *a.AppraisalPolicyID
+
+		p, err := resolver.ResolvePolicy(id)
+		if err == nil && p == nil {
+			err = errors.New("no policy returned")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resolving appraisal policy %q of submod %q: %w", id, name, err)
+		}
+
+		policies[name] = p
+	}
+
+	return policies, nil
+}
+
+// WithPolicyResolver instructs Verify to fetch the appraisal policy of each
+// submod using resolver (see AttestationResult.ResolvePolicies).  Verification
+// fails if a policy cannot be resolved.  The resolved policies are made
+// available, as annotations, via VerifiedResult.Policy.
+func WithPolicyResolver(resolver PolicyResolver) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.policyResolver = resolver
+	})
+}
+
+// checkPolicies resolves the appraisal policies of the submods, if requested
+// using WithPolicyResolver
+func (o AttestationResult) checkPolicies(cfg *verifyConfig) (map[string]*Policy, error) {
+	if cfg.policyResolver == nil {
+		return nil, nil
+	}
+
+	return o.ResolvePolicies(cfg.policyResolver)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPolicyResolver(calls *int) PolicyResolver {
+	return PolicyResolverFunc(func(id string) (*Policy, error) {
+		*calls++
+		if id != testPolicyID {
+			return nil, errors.New("policy not found")
+		}
+		return &Policy{
+			ID:        id,
+			MediaType: "text/x-rego",
+			Content:   []byte("package policy"),
+			Metadata:  map[string]string{"name": "test policy"},
+		}, nil
+	})
+}
+
+func TestCachingPolicyResolver(t *testing.T) {
+	var calls int
+
+	now := time.Unix(testIAT, 0)
+
+	r := NewCachingPolicyResolver(testPolicyResolver(&calls), time.Minute)
+	r.Clock = ClockFunc(func() time.Time { return now })
+
+	p, err := r.ResolvePolicy(testPolicyID)
+	require.NoError(t, err)
+	assert.Equal(t, "test policy", p.Metadata["name"])
+
+	_, err = r.ResolvePolicy(testPolicyID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Minute)
+
+	_, err = r.ResolvePolicy(testPolicyID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	r.Purge()
+
+	_, err = r.ResolvePolicy(testPolicyID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// failures are not cached
+	_, err = r.ResolvePolicy("policy://unknown")
+	assert.EqualError(t, err, "policy not found")
+	_, err = r.ResolvePolicy("policy://unknown")
+	assert.EqualError(t, err, "policy not found")
+	assert.Equal(t, 5, calls)
+
+	_, err = (&CachingPolicyResolver{}).ResolvePolicy(testPolicyID)
+	assert.EqualError(t, err, "nil policy resolver")
+}
+
+func TestVerify_WithPolicyResolver(t *testing.T) {
+	var calls int
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vr, err := Verify(token, jwa.ES256, mustParseKey(t, testECDSAPublicKey),
+		WithPolicyResolver(testPolicyResolver(&calls)))
+	require.NoError(t, err)
+
+	p, ok := vr.Policy("test")
+	require.True(t, ok)
+	assert.Equal(t, testPolicyID, p.ID)
+	assert.Equal(t, []byte("package policy"), p.Content)
+
+	// the returned policy is a copy
+	p.Metadata["name"] = "changed"
+	p, _ = vr.Policy("test")
+	assert.Equal(t, "test policy", p.Metadata["name"])
+
+	_, ok = vr.Policy("other")
+	assert.False(t, ok)
+
+	// without a resolver, no policy is resolved
+	vr, err = Verify(token, jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	require.NoError(t, err)
+	_, ok = vr.Policy("test")
+	assert.False(t, ok)
+}
+
+func TestVerify_WithPolicyResolver_fail(t *testing.T) {
+	failing := PolicyResolverFunc(func(id string) (*Policy, error) {
+		return nil, errors.New("unreachable")
+	})
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	var ar AttestationResult
+	err = ar.Verify(token, jwa.ES256, mustParseKey(t, testECDSAPublicKey), WithPolicyResolver(failing))
+	assert.EqualError(t, err, `resolving appraisal policy "policy://test/01234" of submod "test": unreachable`)
+
+	_, err = ar.ResolvePolicies(nil)
+	assert.EqualError(t, err, "nil policy resolver")
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PolicyOutcome is the result of evaluating a single appraisal policy rule
+type PolicyOutcome string
+
+const (
+	PolicyOutcomePass          PolicyOutcome = "pass"
+	PolicyOutcomeFail          PolicyOutcome = "fail"
+	PolicyOutcomeNotApplicable PolicyOutcome = "not-applicable"
+)
+
+// PolicyResult describes the outcome of an appraisal policy rule.  A list of
+// PolicyResult is carried in the "ear.veraison.policy-results" claim, and
+// provides a structured alternative to the free-form
+// "ear.veraison.policy-claims".
+type PolicyResult struct {
+	// RuleID identifies the policy rule that has been evaluated
+	RuleID *string `json:"rule-id"`
+	// Outcome is the result of evaluating the rule
+	Outcome *PolicyOutcome `json:"outcome"`
+	// Dimension is the (optional) name of the trustworthiness vector claim
+	// affected by the rule, e.g., "executables"
+	Dimension *string `json:"vector-dimension,omitempty"`
+}
+
+func (o PolicyResult) validate() error {
+	if o.RuleID == nil || *o.RuleID == "" {
+		return errors.New(`empty or missing "rule-id"`)
+	}
+
+	if o.Outcome == nil {
+		return errors.New(`missing "outcome"`)
+	}
+
+	switch *o.Outcome {
+	case PolicyOutcomePass, PolicyOutcomeFail, PolicyOutcomeNotApplicable:
+	default:
+		return fmt.Errorf(`unknown "outcome" %q`, *o.Outcome)
+	}
+
+	if o.Dimension != nil {
+		if _, ok := (TrustVector{}).AsMap()[*o.Dimension]; !ok {
+			return fmt.Errorf(`unknown "vector-dimension" %q`, *o.Dimension)
+		}
+	}
+
+	return nil
+}
+
+// AddPolicyResult appends a new entry to the "ear.veraison.policy-results"
+// claim.  dimension is optional and can be left empty.
+func (o *AppraisalExtensions) AddPolicyResult(
+	ruleID string,
+	outcome PolicyOutcome,
+	dimension string,
+) error {
+	r := PolicyResult{
+		RuleID:  &ruleID,
+		Outcome: &outcome,
+	}
+
+	if dimension != "" {
+		r.Dimension = &dimension
+	}
+
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	if o.VeraisonPolicyResults == nil {
+		o.VeraisonPolicyResults = &[]PolicyResult{}
+	}
+
+	*o.VeraisonPolicyResults = append(*o.VeraisonPolicyResults, r)
+
+	return nil
+}
+
+// GetPolicyResults returns the entries in the "ear.veraison.policy-results"
+// claim.
+func (o AppraisalExtensions) GetPolicyResults() ([]PolicyResult, error) {
+	if o.VeraisonPolicyResults == nil {
+		return nil, errors.New(`"ear.veraison.policy-results" claim not found`)
+	}
+
+	return *o.VeraisonPolicyResults, nil
+}
+
+// GetFailedPolicyResults returns the entries in the
+// "ear.veraison.policy-results" claim with a "fail" outcome.  The returned
+// slice is empty if the claim is not present.
+func (o AppraisalExtensions) GetFailedPolicyResults() []PolicyResult {
+	var ret []PolicyResult
+
+	if o.VeraisonPolicyResults == nil {
+		return ret
+	}
+
+	for _, r := range *o.VeraisonPolicyResults {
+		if r.Outcome != nil && *r.Outcome == PolicyOutcomeFail {
+			ret = append(ret, r)
+		}
+	}
+
+	return ret
+}
+
+func ToPolicyResults(v interface{}) (*[]PolicyResult, error) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON array")
+	}
+
+	ret := make([]PolicyResult, 0, len(l))
+
+	parsers := map[string]parser{
+		"outcome": func(v interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("not a string")
+			}
+			outcome := PolicyOutcome(s)
+			return &outcome, nil
+		},
+	}
+
+	for i, e := range l {
+		var r PolicyResult
+
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry %d: not a JSON object", i)
+		}
+
+		if err := populateStructFromMap(&r, m, "json", parsers, stringPtrParser, false); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		ret = append(ret, r)
+	}
+
+	return &ret, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppraisalExtensions_AddGetPolicyResults_ok(t *testing.T) {
+	var a AppraisalExtensions
+
+	_, err := a.GetPolicyResults()
+	assert.EqualError(t, err, `"ear.veraison.policy-results" claim not found`)
+	assert.Empty(t, a.GetFailedPolicyResults())
+
+	require.NoError(t, a.AddPolicyResult("psa-sw-components", PolicyOutcomePass, "executables"))
+	require.NoError(t, a.AddPolicyResult("psa-lifecycle", PolicyOutcomeFail, "configuration"))
+	require.NoError(t, a.AddPolicyResult("debug-disabled", PolicyOutcomeNotApplicable, ""))
+
+	results, err := a.GetPolicyResults()
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "psa-sw-components", *results[0].RuleID)
+	assert.Nil(t, results[2].Dimension)
+
+	failed := a.GetFailedPolicyResults()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "psa-lifecycle", *failed[0].RuleID)
+	assert.Equal(t, "configuration", *failed[0].Dimension)
+}
+
+func TestAppraisalExtensions_AddPolicyResult_fail(t *testing.T) {
+	var a AppraisalExtensions
+
+	err := a.AddPolicyResult("", PolicyOutcomePass, "")
+	assert.EqualError(t, err, `empty or missing "rule-id"`)
+
+	err = a.AddPolicyResult("r1", PolicyOutcome("maybe"), "")
+	assert.EqualError(t, err, `unknown "outcome" "maybe"`)
+
+	err = a.AddPolicyResult("r1", PolicyOutcomePass, "firmware")
+	assert.EqualError(t, err, `unknown "vector-dimension" "firmware"`)
+
+	assert.Nil(t, a.VeraisonPolicyResults)
+}
+
+func TestPolicyResults_round_trip(t *testing.T) {
+	status := TrustTierWarning
+	appraisal := Appraisal{Status: &status}
+
+	require.NoError(t, appraisal.AddPolicyResult("psa-lifecycle", PolicyOutcomeFail, "configuration"))
+
+	data, err := json.Marshal(appraisal.AsMap())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ear.status": "warning",
+		"ear.veraison.policy-results": [
+			{"rule-id": "psa-lifecycle", "outcome": "fail", "vector-dimension": "configuration"}
+		]
+	}`, string(data))
+
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	actual, err := ToAppraisal(m)
+	require.NoError(t, err)
+	assert.Equal(t, appraisal, *actual)
+}
+
+func TestToPolicyResults_fail(t *testing.T) {
+	tvs := []struct {
+		v        interface{}
+		expected string
+	}{
+		{
+			v:        map[string]interface{}{},
+			expected: "not a JSON array",
+		},
+		{
+			v:        []interface{}{1},
+			expected: "entry 0: not a JSON object",
+		},
+		{
+			v: []interface{}{
+				map[string]interface{}{"rule-id": "r1", "outcome": 1},
+			},
+			expected: "entry 0: invalid value(s) for 'outcome' (not a string)",
+		},
+		{
+			v: []interface{}{
+				map[string]interface{}{"rule-id": "r1", "outcome": "pass", "extra": 1},
+			},
+			expected: "entry 0: unexpected: extra",
+		},
+		{
+			v: []interface{}{
+				map[string]interface{}{"rule-id": "r1", "outcome": "pass", "vector-dimension": "nope"},
+			},
+			expected: `entry 0: unknown "vector-dimension" "nope"`,
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToPolicyResults(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
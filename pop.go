@@ -0,0 +1,182 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// VerifyPoP checks that signature is a valid signature over payload (e.g., a
+// relying party challenge, or a TLS handshake transcript hash) made with the
+// key bound to the attestation result.  The bound key is looked up first in
+// the `cnf` claim ("jwk" and "cose_key" methods), and, failing that, in the
+// "ear.veraison.key-attestation" claim of the submods.  If `cnf` only carries
+// a key thumbprint ("jkt" method), the attested keys are used, provided that
+// they match the thumbprint.  If more than one submod attests a key, a
+// signature made with any of them is accepted.
+//
+// The signature must be in JWS format (RFC 7518 §3), and its algorithm is
+// inferred from the type of the bound key: ES256, ES384 or ES512 for ECDSA
+// keys on the P-256, P-384 and P-521 curves respectively, EdDSA for Ed25519
+// keys, and PS256 for RSA keys.
+func VerifyPoP(ar *AttestationResult, signature, payload []byte) error {
+	if ar == nil {
+		return errors.New("nil attestation result")
+	}
+
+	keys, err := ar.boundKeys()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, key := range keys {
+		err := verifyPoPSignature(key, signature, payload)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 1 {
+		return fmt.Errorf("proof-of-possession verification failed: %w", errs[0])
+	}
+
+	return fmt.Errorf("proof-of-possession verification failed against all %d bound keys", len(errs))
+}
+
+func (o AttestationResult) boundKeys() ([]any, error) {
+	if o.Confirmation != nil && o.Confirmation.JKT == nil {
+		k, err := o.Confirmation.GetKey()
+		if err != nil {
+			return nil, fmt.Errorf("cnf: %w", err)
+		}
+
+		var raw any
+		if err := k.Raw(&raw); err != nil {
+			return nil, fmt.Errorf("cnf: extracting raw key: %w", err)
+		}
+
+		return []any{raw}, nil
+	}
+
+	keys, err := o.attestedKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Confirmation != nil {
+		return o.Confirmation.matchingKeys(keys)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New(`no bound key found in "cnf" or "ear.veraison.key-attestation"`)
+	}
+
+	return keys, nil
+}
+
+// attestedKeys returns the keys found in the "ear.veraison.key-attestation"
+// claim of the submods
+func (o AttestationResult) attestedKeys() ([]any, error) {
+	// iterate in a stable order so that errors are reproducible
+	names := make([]string, 0, len(o.Submods))
+	for name := range o.Submods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var keys []any
+
+	for _, name := range names {
+		a := o.Submods[name]
+		if a == nil || a.VeraisonKeyAttestation == nil {
+			continue
+		}
+
+		k, err := a.GetKeyAttestation()
+		if err != nil {
+			return nil, fmt.Errorf("submod %q: %w", name, err)
+		}
+
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+// matchingKeys returns the keys, among the supplied raw keys, that match the
+// confirmation claim
+func (o Confirmation) matchingKeys(keys []any) ([]any, error) {
+	var matching []any
+
+	for _, key := range keys {
+		k, err := jwk.FromRaw(key)
+		if err != nil {
+			return nil, fmt.Errorf("converting attested key to JWK: %w", err)
+		}
+
+		ok, err := o.MatchesKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("cnf: %w", err)
+		}
+
+		if ok {
+			matching = append(matching, key)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil, errors.New(`no key in "ear.veraison.key-attestation" matches the "jkt" in "cnf"`)
+	}
+
+	return matching, nil
+}
+
+func popAlgorithm(key any) (jwa.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwa.ES256, nil
+		case elliptic.P384():
+			return jwa.ES384, nil
+		case elliptic.P521():
+			return jwa.ES512, nil
+		default:
+			return "", fmt.Errorf("unsupported ECDSA curve: %s", k.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		return jwa.EdDSA, nil
+	case *rsa.PublicKey:
+		return jwa.PS256, nil
+	default:
+		return "", fmt.Errorf("unsupported type for bound key: %T", key)
+	}
+}
+
+func verifyPoPSignature(key any, signature, payload []byte) error {
+	alg, err := popAlgorithm(key)
+	if err != nil {
+		return err
+	}
+
+	v, err := jws.NewVerifier(alg)
+	if err != nil {
+		return fmt.Errorf("creating %s verifier: %w", alg, err)
+	}
+
+	return v.Verify(payload, signature, key)
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testChallenge = []byte("relying party challenge")
+
+func popSign(t *testing.T, alg jwa.SignatureAlgorithm, key any, payload []byte) []byte {
+	s, err := jws.NewSigner(alg)
+	require.NoError(t, err)
+
+	sig, err := s.Sign(payload, key)
+	require.NoError(t, err)
+
+	return sig
+}
+
+func TestVerifyPoP_cnf_ok(t *testing.T) {
+	holderK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	require.NoError(t, ar.SetConfirmationKey(holderK))
+
+	sig := popSign(t, jwa.ES256, holderK, testChallenge)
+
+	assert.NoError(t, VerifyPoP(&ar, sig, testChallenge))
+}
+
+func TestVerifyPoP_key_attestation_ok(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	status := TrustTierAffirming
+	appraisal := Appraisal{Status: &status}
+	require.NoError(t, appraisal.SetKeyAttestation(pub))
+
+	ar := AttestationResult{
+		Submods: map[string]*Appraisal{"test": &appraisal},
+	}
+
+	sig := popSign(t, jwa.EdDSA, priv, testChallenge)
+
+	assert.NoError(t, VerifyPoP(&ar, sig, testChallenge))
+}
+
+func TestVerifyPoP_fail_bad_signature(t *testing.T) {
+	holderK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	require.NoError(t, ar.SetConfirmationKey(holderK))
+
+	sig := popSign(t, jwa.ES256, holderK, []byte("some other challenge"))
+
+	err = VerifyPoP(&ar, sig, testChallenge)
+	assert.EqualError(t, err, "proof-of-possession verification failed: failed to verify signature using ecdsa")
+}
+
+func TestVerifyPoP_fail_no_bound_key(t *testing.T) {
+	status := TrustTierAffirming
+	ar := AttestationResult{
+		Submods: map[string]*Appraisal{"test": {Status: &status}},
+	}
+
+	err := VerifyPoP(&ar, []byte{0x00}, testChallenge)
+	assert.EqualError(t, err, `no bound key found in "cnf" or "ear.veraison.key-attestation"`)
+
+	err = VerifyPoP(nil, []byte{0x00}, testChallenge)
+	assert.EqualError(t, err, "nil attestation result")
+}
+
+func TestVerifyPoP_cnf_cose_key_ok(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	k, err := jwk.FromRaw(pub)
+	require.NoError(t, err)
+
+	coseKey, err := COSEKeyFromJWK(k)
+	require.NoError(t, err)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	require.NoError(t, ar.SetConfirmationCOSEKey(coseKey))
+
+	sig := popSign(t, jwa.EdDSA, priv, testChallenge)
+
+	assert.NoError(t, VerifyPoP(&ar, sig, testChallenge))
+}
+
+func TestVerifyPoP_cnf_jkt_ok(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	k, err := jwk.FromRaw(pub)
+	require.NoError(t, err)
+
+	status := TrustTierAffirming
+	a, b := Appraisal{Status: &status}, Appraisal{Status: &status}
+	require.NoError(t, a.SetKeyAttestation(otherPub))
+	require.NoError(t, b.SetKeyAttestation(pub))
+
+	ar := AttestationResult{
+		Submods: map[string]*Appraisal{"a": &a, "b": &b},
+	}
+	require.NoError(t, ar.SetConfirmationThumbprint(k))
+
+	keys, err := ar.boundKeys()
+	require.NoError(t, err)
+	assert.Equal(t, []any{pub}, keys)
+
+	sig := popSign(t, jwa.EdDSA, priv, testChallenge)
+
+	assert.NoError(t, VerifyPoP(&ar, sig, testChallenge))
+}
+
+func TestVerifyPoP_fail_thumbprint_mismatch(t *testing.T) {
+	holderK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	var ar AttestationResult
+	require.NoError(t, ar.SetConfirmationThumbprint(holderK))
+
+	err = VerifyPoP(&ar, []byte{0x00}, testChallenge)
+	assert.EqualError(t, err, `no key in "ear.veraison.key-attestation" matches the "jkt" in "cnf"`)
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	status := TrustTierAffirming
+	appraisal := Appraisal{Status: &status}
+	require.NoError(t, appraisal.SetKeyAttestation(pub))
+	ar.Submods = map[string]*Appraisal{"test": &appraisal}
+
+	err = VerifyPoP(&ar, []byte{0x00}, testChallenge)
+	assert.EqualError(t, err, `no key in "ear.veraison.key-attestation" matches the "jkt" in "cnf"`)
+}
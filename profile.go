@@ -0,0 +1,60 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProfileNotAcceptedError is returned by Verify (and friends) when the
+// `eat_profile` of a verified EAR is not among those supplied using
+// WithAcceptedProfiles
+type ProfileNotAcceptedError struct {
+	// Profile is the `eat_profile` of the EAR, or the empty string if absent
+	Profile string
+	// Accepted lists the profiles accepted by the relying party
+	Accepted []string
+}
+
+func (e *ProfileNotAcceptedError) Error() string {
+	if e.Profile == "" {
+		return fmt.Sprintf("missing eat_profile (accepted: %s)", strings.Join(e.Accepted, ", "))
+	}
+
+	return fmt.Sprintf("eat_profile %q not accepted (accepted: %s)",
+		e.Profile, strings.Join(e.Accepted, ", "))
+}
+
+// WithAcceptedProfiles instructs Verify to only accept results whose
+// `eat_profile` is one of the supplied profiles, so that relying parties can
+// pin exactly the profiles they understand.  Otherwise, a
+// *ProfileNotAcceptedError is returned.  Supplying no profiles disables the
+// check.
+func WithAcceptedProfiles(profiles ...string) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.acceptedProfiles = append([]string(nil), profiles...)
+	})
+}
+
+// checkProfile checks the `eat_profile` of the target AttestationResult
+// against the accepted profiles, if any
+func (o AttestationResult) checkProfile(accepted []string) error {
+	if len(accepted) == 0 {
+		return nil
+	}
+
+	var profile string
+	if o.Profile != nil {
+		profile = *o.Profile
+	}
+
+	for _, p := range accepted {
+		if p == profile {
+			return nil
+		}
+	}
+
+	return &ProfileNotAcceptedError{Profile: profile, Accepted: accepted}
+}
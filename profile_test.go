@@ -0,0 +1,60 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify_WithAcceptedProfiles(t *testing.T) {
+	vfyK, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	tvs := []struct {
+		accepted []string
+		expected string
+	}{
+		{
+			accepted: nil,
+		},
+		{
+			accepted: []string{EatProfile},
+		},
+		{
+			accepted: []string{testUnsupportedProfile, EatProfile},
+		},
+		{
+			accepted: []string{testUnsupportedProfile},
+			expected: `eat_profile "tag:github.com,2023:veraison/ear" not accepted (accepted: 1.2.3.4.5)`,
+		},
+	}
+
+	for i, tv := range tvs {
+		var ar AttestationResult
+		err := ar.Verify([]byte(testEdDSAToken), jwa.EdDSA, vfyK, WithAcceptedProfiles(tv.accepted...))
+
+		if tv.expected == "" {
+			assert.NoError(t, err, "failed test vector at index %d", i)
+			continue
+		}
+
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+
+		var pErr *ProfileNotAcceptedError
+		require.True(t, errors.As(err, &pErr), "failed test vector at index %d", i)
+		assert.Equal(t, EatProfile, pErr.Profile)
+		assert.Equal(t, tv.accepted, pErr.Accepted)
+	}
+}
+
+func TestAttestationResult_checkProfile_missing(t *testing.T) {
+	err := AttestationResult{}.checkProfile([]string{EatProfile})
+	assert.EqualError(t, err, "missing eat_profile (accepted: tag:github.com,2023:veraison/ear)")
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// RawEvidenceRef references the raw evidence by its digest and, optionally,
+// by the URI it can be fetched from.  It is carried in the
+// "ear.raw-evidence-ref" claim, as an alternative to embedding the evidence in
+// "ear.raw-evidence", which can bloat the result.
+type RawEvidenceRef struct {
+	// HashAlg identifies the hash algorithm used to compute Digest, using the
+	// "Hash Name String" values in the IANA Named Information Hash Algorithm
+	// Registry (e.g., "sha-256")
+	HashAlg *string `json:"hash-alg"`
+	// Digest is the digest of the raw evidence
+	Digest *B64Url `json:"digest"`
+	// URI is the (optional) location of the raw evidence
+	URI *string `json:"uri,omitempty"`
+}
+
+// NewRawEvidenceRef computes the digest of evidence using the hash algorithm
+// identified by alg (e.g., "sha-256") and returns the corresponding
+// RawEvidenceRef.  uri is optional and can be left empty.
+func NewRawEvidenceRef(alg string, evidence []byte, uri string) (*RawEvidenceRef, error) {
+	d, err := NewDigest(alg, evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := RawEvidenceRef{
+		HashAlg: d.Alg,
+		Digest:  d.Value,
+	}
+
+	if uri != "" {
+		ref.URI = &uri
+	}
+
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ref, nil
+}
+
+// Validate checks that the RawEvidenceRef uses a supported hash algorithm,
+// that its digest has the expected length and that its URI, if present, is an
+// absolute URI
+func (o RawEvidenceRef) Validate() error {
+	if err := o.digest().Validate(); err != nil {
+		return err
+	}
+
+	if o.URI != nil {
+		u, err := url.Parse(*o.URI)
+		if err != nil {
+			return fmt.Errorf(`invalid "uri": %w`, err)
+		}
+
+		if !u.IsAbs() {
+			return fmt.Errorf(`invalid "uri" %q: not an absolute URI`, *o.URI)
+		}
+	}
+
+	return nil
+}
+
+// Verify checks that evidence matches the referenced digest
+func (o RawEvidenceRef) Verify(evidence []byte) error {
+	return o.digest().Verify(evidence)
+}
+
+// digest returns the (hash-alg, digest) pair as a Digest
+func (o RawEvidenceRef) digest() Digest {
+	return Digest{Alg: o.HashAlg, Value: o.Digest}
+}
+
+// ToRawEvidenceRef parses the value of the "ear.raw-evidence-ref" claim
+func ToRawEvidenceRef(v interface{}) (*RawEvidenceRef, error) {
+	var ref RawEvidenceRef
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON object")
+	}
+
+	parsers := map[string]parser{
+		"digest": b64urlBytesPtrParser,
+	}
+
+	if err := populateStructFromMap(&ref, m, "json", parsers, stringPtrParser, false); err != nil {
+		return nil, err
+	}
+
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &ref, nil
+}
+
+// SetRawEvidenceRef computes the digest of evidence using the hash algorithm
+// identified by alg (e.g., "sha-256"), and sets it, together with the
+// (optional) uri, in the "ear.raw-evidence-ref" claim
+func (o *AttestationResult) SetRawEvidenceRef(alg string, evidence []byte, uri string) error {
+	ref, err := NewRawEvidenceRef(alg, evidence, uri)
+	if err != nil {
+		return err
+	}
+
+	o.RawEvidenceRef = ref
+
+	return nil
+}
+
+// GetRawEvidenceRef returns the "ear.raw-evidence-ref" claim
+func (o AttestationResult) GetRawEvidenceRef() (*RawEvidenceRef, error) {
+	if o.RawEvidenceRef == nil {
+		return nil, errors.New(`"ear.raw-evidence-ref" claim not found`)
+	}
+
+	return o.RawEvidenceRef, nil
+}
+
+type rawEvidenceRefOption struct {
+	alg      string
+	evidence []byte
+	uri      string
+}
+
+// WithRawEvidenceRef instructs Sign to set the "ear.raw-evidence-ref" claim
+// to the digest of evidence, computed using the hash algorithm identified by
+// alg, and to the (optional) uri, overriding any existing value.  The
+// evidence itself is not embedded.
+func WithRawEvidenceRef(alg string, evidence []byte, uri string) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.rawEvidenceRef = &rawEvidenceRefOption{alg, evidence, uri}
+	})
+}
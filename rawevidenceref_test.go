@@ -0,0 +1,93 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationResult_SetGetRawEvidenceRef(t *testing.T) {
+	var ar AttestationResult
+
+	_, err := ar.GetRawEvidenceRef()
+	assert.EqualError(t, err, `"ear.raw-evidence-ref" claim not found`)
+
+	require.NoError(t, ar.SetRawEvidenceRef("sha-256", testEvidence, "https://veraison.example/evidence/1"))
+
+	ref, err := ar.GetRawEvidenceRef()
+	require.NoError(t, err)
+	assert.Equal(t, "sha-256", *ref.HashAlg)
+	assert.Len(t, *ref.Digest, 32)
+	assert.Equal(t, "https://veraison.example/evidence/1", *ref.URI)
+	assert.NoError(t, ref.Verify(testEvidence))
+	assert.EqualError(t, ref.Verify([]byte("other evidence")), "digest mismatch")
+
+	err = ar.SetRawEvidenceRef("md5", testEvidence, "")
+	assert.EqualError(t, err, `unsupported hash algorithm "md5"`)
+
+	err = ar.SetRawEvidenceRef("sha-256", testEvidence, "evidence/1")
+	assert.EqualError(t, err, `invalid "uri" "evidence/1": not an absolute URI`)
+}
+
+func TestRawEvidenceRef_round_trip(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	require.NoError(t, ar.SetRawEvidenceRef("sha-384", testEvidence, ""))
+
+	data, err := ar.MarshalJSON()
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(data))
+	assert.Equal(t, ar.RawEvidenceRef, actual.RawEvidenceRef)
+	assert.Nil(t, actual.RawEvidenceRef.URI)
+
+	c, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.UnmarshalCBOR(c))
+	assert.Equal(t, ar.RawEvidenceRef, actual.RawEvidenceRef)
+}
+
+func TestRawEvidenceRef_validate_mismatch(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	require.NoError(t, ar.SetRawEvidenceRef("sha-256", testEvidence, ""))
+	ar.SetRawEvidence([]byte("other evidence"))
+
+	_, err := ar.MarshalJSON()
+	assert.EqualError(t, err, "invalid value(s) for ear.raw-evidence-ref (ear.raw-evidence digest mismatch)")
+}
+
+func TestToRawEvidenceRef_fail(t *testing.T) {
+	_, err := ToRawEvidenceRef("not an object")
+	assert.EqualError(t, err, "not a JSON object")
+
+	_, err = ToRawEvidenceRef(map[string]interface{}{
+		"hash-alg": "sha-256",
+		"digest":   "AAAA",
+	})
+	assert.EqualError(t, err, "sha-256 digest has wrong length: want 32 bytes, got 3")
+}
+
+func TestSign_WithRawEvidenceRef(t *testing.T) {
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey),
+		WithRawEvidenceRef("sha-256", testEvidence, "https://veraison.example/evidence/1"))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, mustParseKey(t, testECDSAPublicKey)))
+	require.NotNil(t, actual.RawEvidenceRef)
+	assert.NoError(t, actual.RawEvidenceRef.Verify(testEvidence))
+	assert.Nil(t, actual.RawEvidence)
+
+	_, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey),
+		WithRawEvidenceRef("sha-1", testEvidence, ""))
+	assert.EqualError(t, err, `deriving raw evidence reference: unsupported hash algorithm "sha-1"`)
+}
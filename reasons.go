@@ -0,0 +1,188 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReasonSeverity is the severity of a Reason
+type ReasonSeverity string
+
+const (
+	ReasonSeverityInfo    ReasonSeverity = "info"
+	ReasonSeverityWarning ReasonSeverity = "warning"
+	ReasonSeverityError   ReasonSeverity = "error"
+)
+
+// Reason is a machine-readable explanation, added by the verifier, of why an
+// appraisal claim (typically a trustworthiness vector claim) has been set the
+// way it has.  A list of Reason is carried in the "ear.veraison.reasons"
+// claim.
+type Reason struct {
+	// Code is a machine-readable identifier of the reason, e.g.,
+	// "unknown-kernel-hash"
+	Code *string `json:"code"`
+	// Severity is the severity of the finding
+	Severity *ReasonSeverity `json:"severity"`
+	// ClaimPath is the (optional) slash-separated path, relative to the
+	// appraisal, of the claim being explained, e.g.,
+	// "ear.trustworthiness-vector/executables"
+	ClaimPath *string `json:"claim-path,omitempty"`
+	// Message is an (optional) human readable explanation
+	Message *string `json:"message,omitempty"`
+}
+
+func (o Reason) validate() error {
+	if o.Code == nil || *o.Code == "" {
+		return errors.New(`empty or missing "code"`)
+	}
+
+	if o.Severity == nil {
+		return errors.New(`missing "severity"`)
+	}
+
+	switch *o.Severity {
+	case ReasonSeverityInfo, ReasonSeverityWarning, ReasonSeverityError:
+	default:
+		return fmt.Errorf(`unknown "severity" %q`, *o.Severity)
+	}
+
+	if o.ClaimPath != nil && *o.ClaimPath == "" {
+		return errors.New(`empty "claim-path"`)
+	}
+
+	return nil
+}
+
+// summary returns a one-line description of the reason, e.g.:
+//
+//	ear.trustworthiness-vector/executables: unknown kernel (error: unknown-kernel-hash)
+func (o Reason) summary() string {
+	var s string
+
+	if o.ClaimPath != nil {
+		s = *o.ClaimPath + ": "
+	}
+
+	if o.Message != nil {
+		s += *o.Message + " "
+	}
+
+	var severity ReasonSeverity
+	if o.Severity != nil {
+		severity = *o.Severity
+	}
+
+	var code string
+	if o.Code != nil {
+		code = *o.Code
+	}
+
+	return fmt.Sprintf("%s(%s: %s)", s, severity, code)
+}
+
+// AddReason appends a new entry to the "ear.veraison.reasons" claim.
+// claimPath and message are optional and can be left empty.
+func (o *AppraisalExtensions) AddReason(
+	code string,
+	severity ReasonSeverity,
+	claimPath string,
+	message string,
+) error {
+	r := Reason{
+		Code:     &code,
+		Severity: &severity,
+	}
+
+	if claimPath != "" {
+		r.ClaimPath = &claimPath
+	}
+
+	if message != "" {
+		r.Message = &message
+	}
+
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	if o.VeraisonReasons == nil {
+		o.VeraisonReasons = &[]Reason{}
+	}
+
+	*o.VeraisonReasons = append(*o.VeraisonReasons, r)
+
+	return nil
+}
+
+// GetReasons returns the entries in the "ear.veraison.reasons" claim.
+func (o AppraisalExtensions) GetReasons() ([]Reason, error) {
+	if o.VeraisonReasons == nil {
+		return nil, errors.New(`"ear.veraison.reasons" claim not found`)
+	}
+
+	return *o.VeraisonReasons, nil
+}
+
+// GetReasonsForClaim returns the entries in the "ear.veraison.reasons" claim
+// that explain the claim at the supplied path.  The returned slice is empty
+// if the claim is not present.
+func (o AppraisalExtensions) GetReasonsForClaim(claimPath string) []Reason {
+	var ret []Reason
+
+	if o.VeraisonReasons == nil {
+		return ret
+	}
+
+	for _, r := range *o.VeraisonReasons {
+		if r.ClaimPath != nil && *r.ClaimPath == claimPath {
+			ret = append(ret, r)
+		}
+	}
+
+	return ret
+}
+
+func ToReasons(v interface{}) (*[]Reason, error) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON array")
+	}
+
+	ret := make([]Reason, 0, len(l))
+
+	parsers := map[string]parser{
+		"severity": func(v interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("not a string")
+			}
+			severity := ReasonSeverity(s)
+			return &severity, nil
+		},
+	}
+
+	for i, e := range l {
+		var r Reason
+
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry %d: not a JSON object", i)
+		}
+
+		if err := populateStructFromMap(&r, m, "json", parsers, stringPtrParser, false); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		ret = append(ret, r)
+	}
+
+	return &ret, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppraisalExtensions_AddGetReasons_ok(t *testing.T) {
+	var a AppraisalExtensions
+
+	_, err := a.GetReasons()
+	assert.EqualError(t, err, `"ear.veraison.reasons" claim not found`)
+	assert.Empty(t, a.GetReasonsForClaim("ear.trustworthiness-vector/executables"))
+
+	require.NoError(t, a.AddReason("unknown-kernel-hash", ReasonSeverityError,
+		"ear.trustworthiness-vector/executables", "kernel not in reference values"))
+	require.NoError(t, a.AddReason("debug-enabled", ReasonSeverityWarning,
+		"ear.trustworthiness-vector/configuration", ""))
+	require.NoError(t, a.AddReason("fresh-endorsements", ReasonSeverityInfo, "", ""))
+
+	reasons, err := a.GetReasons()
+	require.NoError(t, err)
+	require.Len(t, reasons, 3)
+	assert.Equal(t, "unknown-kernel-hash", *reasons[0].Code)
+	assert.Nil(t, reasons[1].Message)
+	assert.Nil(t, reasons[2].ClaimPath)
+
+	exe := a.GetReasonsForClaim("ear.trustworthiness-vector/executables")
+	require.Len(t, exe, 1)
+	assert.Equal(t, ReasonSeverityError, *exe[0].Severity)
+	assert.Equal(t, "kernel not in reference values", *exe[0].Message)
+}
+
+func TestAppraisalExtensions_AddReason_fail(t *testing.T) {
+	var a AppraisalExtensions
+
+	err := a.AddReason("", ReasonSeverityInfo, "", "")
+	assert.EqualError(t, err, `empty or missing "code"`)
+
+	err = a.AddReason("r1", ReasonSeverity("fatal"), "", "")
+	assert.EqualError(t, err, `unknown "severity" "fatal"`)
+
+	assert.Nil(t, a.VeraisonReasons)
+}
+
+func TestReasons_round_trip(t *testing.T) {
+	status := TrustTierContraindicated
+	appraisal := Appraisal{Status: &status}
+
+	require.NoError(t, appraisal.AddReason("unknown-kernel-hash", ReasonSeverityError,
+		"ear.trustworthiness-vector/executables", "kernel not in reference values"))
+
+	data, err := json.Marshal(appraisal.AsMap())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ear.status": "contraindicated",
+		"ear.veraison.reasons": [
+			{
+				"code": "unknown-kernel-hash",
+				"severity": "error",
+				"claim-path": "ear.trustworthiness-vector/executables",
+				"message": "kernel not in reference values"
+			}
+		]
+	}`, string(data))
+
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	actual, err := ToAppraisal(m)
+	require.NoError(t, err)
+	assert.Equal(t, appraisal, *actual)
+}
+
+func TestToReasons_fail(t *testing.T) {
+	tvs := []struct {
+		v        interface{}
+		expected string
+	}{
+		{
+			v:        map[string]interface{}{},
+			expected: "not a JSON array",
+		},
+		{
+			v:        []interface{}{1},
+			expected: "entry 0: not a JSON object",
+		},
+		{
+			v: []interface{}{
+				map[string]interface{}{"code": "c1", "severity": 1},
+			},
+			expected: "entry 0: invalid value(s) for 'severity' (not a string)",
+		},
+		{
+			v: []interface{}{
+				map[string]interface{}{"code": "c1", "severity": "info", "extra": 1},
+			},
+			expected: "entry 0: unexpected: extra",
+		},
+		{
+			v: []interface{}{
+				map[string]interface{}{"code": "c1", "severity": "info", "claim-path": ""},
+			},
+			expected: `entry 0: empty "claim-path"`,
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToReasons(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
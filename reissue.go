@@ -0,0 +1,121 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// DefaultReissueTTL is the lifetime given to re-issued results, unless a
+// different one is requested using WithTTL
+const DefaultReissueTTL = 24 * time.Hour
+
+// Provenance records the origin of a re-issued attestation result (see
+// Reissue)
+type Provenance struct {
+	// TokenDigest is the digest of the original signed token
+	TokenDigest *Digest `json:"token-digest"`
+	// IssuedAt is the `iat` of the original result
+	IssuedAt *int64 `json:"iat,omitempty"`
+}
+
+func (o Provenance) validate() error {
+	if o.TokenDigest == nil {
+		return errors.New("missing mandatory 'token-digest'")
+	}
+
+	return o.TokenDigest.Validate()
+}
+
+// Verify checks that token is the original token this result was re-issued
+// from
+func (o Provenance) Verify(token []byte) error {
+	if o.TokenDigest == nil {
+		return errors.New("missing mandatory 'token-digest'")
+	}
+
+	return o.TokenDigest.Verify(token)
+}
+
+func ToProvenance(v interface{}) (*Provenance, error) {
+	var p Provenance
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON object")
+	}
+
+	parsers := map[string]parser{
+		"token-digest": func(v interface{}) (interface{}, error) {
+			return ToDigest(v)
+		},
+		"iat": int64PtrParser,
+	}
+
+	if err := populateStructFromMap(&p, m, "json", parsers, stringPtrParser, false); err != nil {
+		return nil, err
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// GetProvenance returns the "ear.veraison.provenance" claim
+func (o AttestationResult) GetProvenance() (*Provenance, error) {
+	if o.VeraisonProvenance == nil {
+		return nil, errors.New(`"ear.veraison.provenance" claim not found`)
+	}
+
+	return o.VeraisonProvenance, nil
+}
+
+// Reissue re-publishes the claims of ar, which must have been obtained by
+// verifying original, under a fresh signature made with the supplied
+// (possibly different) algorithm and key.  This is meant for gateways that
+// re-publish results under their own identity.
+//
+// The re-issued result gets new `iat`, `exp` (DefaultReissueTTL after `iat`)
+// and `jti` claims, and records the SHA-256 digest of original, together with
+// its `iat`, in the "ear.veraison.provenance" claim.  The supplied SignOption
+// are applied after the defaults, so that, e.g., WithTTL can be used to
+// select a different lifetime.
+func Reissue(
+	ar *AttestationResult,
+	original []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...SignOption,
+) ([]byte, error) {
+	if ar == nil {
+		return nil, errors.New("nil attestation result")
+	}
+
+	if len(original) == 0 {
+		return nil, errors.New("empty original token")
+	}
+
+	digest, err := NewDigest("sha-256", original)
+	if err != nil {
+		return nil, fmt.Errorf("computing original token digest: %w", err)
+	}
+
+	reissued := *ar
+	reissued.VeraisonProvenance = &Provenance{TokenDigest: digest}
+
+	if ar.IssuedAt != nil {
+		iat := *ar.IssuedAt
+		reissued.VeraisonProvenance.IssuedAt = &iat
+	}
+
+	defaults := []SignOption{WithIssuedAtNow(), WithTTL(DefaultReissueTTL), WithTokenID()}
+
+	return reissued.Sign(alg, key, append(defaults, opts...)...)
+}
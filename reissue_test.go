@@ -0,0 +1,94 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReissue_ok(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	gwSigK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	gwVfyK, err := jwk.PublicKeyOf(gwSigK)
+	require.NoError(t, err)
+
+	clock := FixedClock(time.Unix(testIAT, 0).Add(time.Hour))
+
+	original, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var ar AttestationResult
+	require.NoError(t, ar.Verify(original, jwa.ES256, vfyK, WithClock(clock)))
+
+	reissued, err := Reissue(&ar, original, jwa.ES256, gwSigK,
+		WithClock(clock), WithTTL(time.Hour))
+	require.NoError(t, err)
+
+	token, err := jwt.Parse(reissued, jwt.WithKey(jwa.ES256, gwVfyK), jwt.WithClock(clock))
+	require.NoError(t, err)
+	assert.Equal(t, clock.Now().Unix(), token.IssuedAt().Unix())
+	assert.Equal(t, clock.Now().Add(time.Hour).Unix(), token.Expiration().Unix())
+	assert.NotEmpty(t, token.JwtID())
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(reissued, jwa.ES256, gwVfyK, WithClock(clock)))
+	assert.Equal(t, ar.Submods, actual.Submods)
+
+	provenance, err := actual.GetProvenance()
+	require.NoError(t, err)
+	assert.Equal(t, testIAT, *provenance.IssuedAt)
+	assert.NoError(t, provenance.Verify(original))
+	assert.EqualError(t, provenance.Verify(reissued), "digest mismatch")
+
+	// the re-issued result expires
+	late := WithClock(FixedClock(clock.Now().Add(2 * time.Hour)))
+	assert.ErrorContains(t, actual.Verify(reissued, jwa.ES256, gwVfyK, late), `"exp" not satisfied`)
+}
+
+func TestReissue_fail(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	_, err = Reissue(nil, []byte("token"), jwa.ES256, sigK)
+	assert.EqualError(t, err, "nil attestation result")
+
+	ar := testAttestationResultsWithVeraisonExtns
+	_, err = Reissue(&ar, nil, jwa.ES256, sigK)
+	assert.EqualError(t, err, "empty original token")
+}
+
+func TestToProvenance_fail(t *testing.T) {
+	tvs := []struct {
+		v        interface{}
+		expected string
+	}{
+		{
+			v:        "provenance",
+			expected: "not a JSON object",
+		},
+		{
+			v:        map[string]interface{}{"iat": 1},
+			expected: "missing mandatory 'token-digest'",
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToProvenance(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
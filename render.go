@@ -0,0 +1,190 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// tierColors maps trust tiers onto the colors used when rendering them
+var tierColors = map[TrustTier]string{
+	TrustTierNone:            "#9e9e9e",
+	TrustTierAffirming:       "#4caf50",
+	TrustTierWarning:         "#ffc107",
+	TrustTierContraindicated: "#f44336",
+}
+
+func tierColor(t *TrustTier) string {
+	if t == nil {
+		return tierColors[TrustTierNone]
+	}
+
+	if c, ok := tierColors[*t]; ok {
+		return c
+	}
+
+	return tierColors[TrustTierNone]
+}
+
+func (o AttestationResult) submodNames() []string {
+	names := make([]string, 0, len(o.Submods))
+	for name := range o.Submods {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// DOT renders the per-submod statuses and trust vectors of the
+// AttestationResult as a Graphviz graph in the DOT language.  Each node is
+// filled with the color of its trust tier.
+func (o AttestationResult) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph EAR {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	root := "EAR"
+	if o.VerifierID != nil && o.VerifierID.Developer != nil {
+		root += `\n` + dotEscape(*o.VerifierID.Developer)
+	}
+	fmt.Fprintf(&b, "\t\"ear\" [label=\"%s\", fillcolor=\"#ffffff\"];\n", root)
+
+	for _, name := range o.submodNames() {
+		a := o.Submods[name]
+		if a == nil {
+			continue
+		}
+
+		id := "submod/" + name
+
+		status := "none"
+		if a.Status != nil {
+			status = a.Status.String()
+		}
+
+		fmt.Fprintf(&b, "\t\"%s\" [label=\"%s\\n%s\", fillcolor=\"%s\"];\n",
+			dotEscape(id), dotEscape(name), status, tierColor(a.Status))
+		fmt.Fprintf(&b, "\t\"ear\" -> \"%s\";\n", dotEscape(id))
+
+		if a.TrustVector == nil {
+			continue
+		}
+
+		for _, c := range trustVectorCategories {
+			claim := c.claim(*a.TrustVector)
+			tier := claim.GetTier()
+			claimID := id + "/" + c.name
+
+			fmt.Fprintf(&b, "\t\"%s\" [label=\"%s\\n%s\", fillcolor=\"%s\"];\n",
+				dotEscape(claimID), c.name,
+				dotEscape(claim.detailsPrinter(c.details, true, false)),
+				tierColor(&tier))
+			fmt.Fprintf(&b, "\t\"%s\" -> \"%s\";\n", dotEscape(id), dotEscape(claimID))
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// geometry of the SVG badge
+const (
+	svgCharWidth  = 7
+	svgPadding    = 8
+	svgRowHeight  = 20
+	svgCellWidth  = 12
+	svgCellMargin = 2
+)
+
+// SVG renders the AttestationResult as an SVG badge, with one row per submod
+// (in name order) showing its status, followed by one colored cell per trust
+// vector claim (in the order used by TrustVector.Report).  Each cell carries
+// a tooltip with the claim description.
+func (o AttestationResult) SVG() string {
+	names := o.submodNames()
+
+	nameWidth, statusWidth := 0, 0
+	for _, name := range names {
+		if w := len(name)*svgCharWidth + 2*svgPadding; w > nameWidth {
+			nameWidth = w
+		}
+
+		if a := o.Submods[name]; a != nil && a.Status != nil {
+			if w := len(a.Status.String())*svgCharWidth + 2*svgPadding; w > statusWidth {
+				statusWidth = w
+			}
+		}
+	}
+
+	if w := len("none")*svgCharWidth + 2*svgPadding; w > statusWidth {
+		statusWidth = w
+	}
+
+	vectorWidth := len(trustVectorCategories)*(svgCellWidth+svgCellMargin) + svgCellMargin
+	width := nameWidth + statusWidth + vectorWidth
+	height := len(names) * svgRowHeight
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" role="img">`+"\n",
+		width, height)
+	b.WriteString(`<g font-family="Verdana,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+
+	for i, name := range names {
+		a := o.Submods[name]
+		y := i * svgRowHeight
+
+		status := "none"
+		var tier *TrustTier
+		if a != nil && a.Status != nil {
+			status = a.Status.String()
+			tier = a.Status
+		}
+
+		fmt.Fprintf(&b, `<rect x="0" y="%d" width="%d" height="%d" fill="#555"/>`+"\n",
+			y, nameWidth, svgRowHeight)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#fff">%s</text>`+"\n",
+			svgPadding, y+14, html.EscapeString(name))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+			nameWidth, y, statusWidth, svgRowHeight, tierColor(tier))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#fff">%s</text>`+"\n",
+			nameWidth+svgPadding, y+14, status)
+
+		x := nameWidth + statusWidth + svgCellMargin
+
+		for _, c := range trustVectorCategories {
+			claimTier := TrustTierNone
+			desc := "not present"
+
+			if a != nil && a.TrustVector != nil {
+				claim := c.claim(*a.TrustVector)
+				claimTier = claim.GetTier()
+				desc = claim.detailsPrinter(c.details, true, false)
+			}
+
+			fmt.Fprintf(&b,
+				`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s: %s</title></rect>`+"\n",
+				x, y+(svgRowHeight-svgCellWidth)/2, svgCellWidth, svgCellWidth,
+				tierColor(&claimTier), c.name, html.EscapeString(desc))
+
+			x += svgCellWidth + svgCellMargin
+		}
+	}
+
+	b.WriteString("</g>\n</svg>\n")
+
+	return b.String()
+}
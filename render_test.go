@@ -0,0 +1,71 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRenderResult() AttestationResult {
+	warning := TrustTierWarning
+
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Submods = map[string]*Appraisal{
+		"cpu": {
+			Status:      &warning,
+			TrustVector: &TrustVector{Configuration: UnsafeConfigClaim},
+		},
+		`"gpu"`: {Status: &testStatus},
+	}
+
+	return ar
+}
+
+func TestAttestationResult_DOT(t *testing.T) {
+	dot := testRenderResult().DOT()
+
+	assert.True(t, strings.HasPrefix(dot, "digraph EAR {\n"))
+	assert.True(t, strings.HasSuffix(dot, "}\n"))
+
+	for _, expected := range []string{
+		`"ear" [label="EAR\nAcme Inc.", fillcolor="#ffffff"];`,
+		`"submod/cpu" [label="cpu\nwarning", fillcolor="#ffc107"];`,
+		`"submod/cpu/configuration" [label="configuration\nknown vulnerabilities", fillcolor="#ffc107"];`,
+		`"submod/cpu" -> "submod/cpu/configuration";`,
+		`"submod/\"gpu\"" [label="\"gpu\"\naffirming", fillcolor="#4caf50"];`,
+		`"ear" -> "submod/\"gpu\"";`,
+	} {
+		assert.Contains(t, dot, expected)
+	}
+
+	// no trust vector, no claim nodes
+	assert.NotContains(t, dot, `submod/\"gpu\"/`)
+}
+
+func TestAttestationResult_SVG(t *testing.T) {
+	svg := testRenderResult().SVG()
+
+	// well-formed XML
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		_, err := dec.Token()
+		if err != nil {
+			require.EqualError(t, err, "EOF")
+			break
+		}
+	}
+
+	for _, expected := range []string{
+		`<text x="8" y="14" fill="#fff">&#34;gpu&#34;</text>`,
+		`fill="#ffc107"><title>configuration: known vulnerabilities</title></rect>`,
+		`<title>hardware: not present</title>`,
+	} {
+		assert.Contains(t, svg, expected)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// Names of the checks recorded in a VerificationReport
+const (
+	CheckSignature = "signature"
+	CheckLifetime  = "lifetime"
+	CheckClaims    = "claims"
+	CheckProfile   = "profile"
+	CheckFreshness = "freshness"
+)
+
+// VerificationCheck is the outcome of one of the checks made by
+// VerifyDetailed
+type VerificationCheck struct {
+	// Name identifies the check (e.g., CheckSignature)
+	Name string
+	// Err is the reason why the check failed, or nil if it passed
+	Err error
+}
+
+// Passed reports whether the check passed
+func (o VerificationCheck) Passed() bool {
+	return o.Err == nil
+}
+
+// VerificationReport describes how an EAR fared in verification, for the
+// benefit of callers debugging rejected results.  Unlike Verify, which stops
+// at the first failure, VerifyDetailed makes as many checks as it can, so
+// that all the problems with a result are reported at once.
+type VerificationReport struct {
+	// Algorithm is the `alg` protected header parameter
+	Algorithm string
+	// KeyID is the `kid` protected header parameter, if any
+	KeyID string
+	// Headers are the protected header parameters
+	Headers map[string]interface{}
+	// Checks are the checks made, in the order in which they were made
+	Checks []VerificationCheck
+	// SubmodIssues maps the name of each submod that failed validation onto
+	// the reason why
+	SubmodIssues map[string]error
+	// Result is the verified result, if verification succeeded
+	Result *VerifiedResult
+}
+
+// Passed reports whether all the checks passed
+func (o VerificationReport) Passed() bool {
+	return len(o.Failed()) == 0
+}
+
+// Failed returns the checks that failed
+func (o VerificationReport) Failed() []VerificationCheck {
+	var ret []VerificationCheck
+
+	for _, c := range o.Checks {
+		if !c.Passed() {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret
+}
+
+// Check returns the outcome of the named check, if it was made
+func (o VerificationReport) Check(name string) (VerificationCheck, bool) {
+	for _, c := range o.Checks {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return VerificationCheck{}, false
+}
+
+func (o *VerificationReport) record(name string, err error) {
+	o.Checks = append(o.Checks, VerificationCheck{Name: name, Err: err})
+}
+
+// VerifyDetailed verifies the JWT data using the supplied key and algorithm,
+// exactly as Verify does, and also returns a VerificationReport detailing the
+// protected header, and the outcome of the signature, lifetime, claims,
+// profile and freshness checks, including the validation issues of each
+// submod.  The returned error, if any, is the one Verify would return.  The
+// report is returned even if verification fails; it is only nil if data is
+// not a JWS at all.
+func VerifyDetailed(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) (*VerificationReport, error) {
+	msg, err := parseJWSAsJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing JWT message: %w", err)
+	}
+
+	report := &VerificationReport{}
+
+	report.Headers, err = decodeProtectedHeader(msg.Signatures[0].Protected)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing JWT message: %w", err)
+	}
+
+	report.Algorithm, _ = report.Headers[jws.AlgorithmKey].(string)
+	report.KeyID, _ = report.Headers[jws.KeyIDKey].(string)
+
+	report.record(CheckSignature, checkSignature(msg, alg, key))
+
+	cfg := newVerifyConfig(opts)
+
+	claims, err := msg.payloadClaims()
+	if err != nil {
+		report.record(CheckClaims, fmt.Errorf("failed to parse token: %w", err))
+	} else {
+		report.record(CheckLifetime, checkValidityPeriod(claims, cfg.clock.Now(), cfg.clockSkew))
+		report.checkClaims(claims, cfg)
+	}
+
+	vr, err := Verify(data, alg, key, opts...)
+	if err != nil {
+		return report, err
+	}
+
+	report.Result = vr
+
+	return report, nil
+}
+
+// checkClaims records the outcome of the checks made on the (unverified)
+// claims-set
+func (o *VerificationReport) checkClaims(claims map[string]interface{}, cfg *verifyConfig) {
+	if cfg.topLevelAppraisal != "" {
+		if err := foldTopLevelAppraisal(claims, cfg.topLevelAppraisal); err != nil {
+			o.record(CheckClaims, err)
+			return
+		}
+	}
+
+	var ar AttestationResult
+
+	if err := ar.populateFromAnyMap(claims); err != nil {
+		o.record(CheckClaims, err)
+		return
+	}
+
+	var names []string
+	for name := range ar.Submods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		a := ar.Submods[name]
+		if a == nil {
+			continue
+		}
+
+		if err := a.validate(); err != nil {
+			if o.SubmodIssues == nil {
+				o.SubmodIssues = map[string]error{}
+			}
+			o.SubmodIssues[name] = err
+		}
+	}
+
+	o.record(CheckClaims, ar.validate())
+	o.record(CheckProfile, ar.checkProfile(cfg.acceptedProfiles))
+	o.record(CheckFreshness, ar.checkFreshness(cfg))
+}
+
+// checkSignature checks that any of the signatures of msg can be verified
+// using the supplied algorithm and key
+func checkSignature(msg *jwsJSON, alg jwa.KeyAlgorithm, key interface{}) error {
+	verify, err := newJWSVerifyFunc(alg, key)
+	if err != nil {
+		return err
+	}
+
+	if !msg.verifiedBy(msg.Signatures, alg, verify) {
+		return errors.New("could not verify message using any of the signatures or keys")
+	}
+
+	return nil
+}
+
+// decodeProtectedHeader decodes a base64url-encoded JWS protected header
+func decodeProtectedHeader(protected string) (map[string]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(protected)
+	if err != nil {
+		return nil, fmt.Errorf("decoding protected header: %w", err)
+	}
+
+	var hdr map[string]interface{}
+	if err := json.Unmarshal(data, &hdr); err != nil {
+		return nil, fmt.Errorf("decoding protected header: %w", err)
+	}
+
+	return hdr, nil
+}
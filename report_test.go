@@ -0,0 +1,80 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyDetailed_ok(t *testing.T) {
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey), WithKeyID("key-1"))
+	require.NoError(t, err)
+
+	report, err := VerifyDetailed(token, jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	require.NoError(t, err)
+
+	assert.True(t, report.Passed())
+	assert.Equal(t, "ES256", report.Algorithm)
+	assert.Equal(t, "key-1", report.KeyID)
+	assert.Equal(t, "JWT", report.Headers["typ"])
+	assert.Empty(t, report.SubmodIssues)
+	require.NotNil(t, report.Result)
+	assert.Equal(t, EatProfile, report.Result.Profile())
+
+	var names []string
+	for _, c := range report.Checks {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{CheckSignature, CheckLifetime, CheckClaims, CheckProfile, CheckFreshness}, names)
+}
+
+func TestVerifyDetailed_reports_all_problems(t *testing.T) {
+	ar := testAttestationResultsWithVeraisonExtns
+	ar.Submods = map[string]*Appraisal{
+		"good": {Status: ar.Submods["test"].Status},
+		"bad": {
+			Status: ar.Submods["test"].Status,
+			AppraisalExtensions: AppraisalExtensions{
+				VeraisonKeyAttestation: &map[string]interface{}{"akpub": "YWtwdWIK", "kid": ""},
+			},
+		},
+	}
+
+	token, err := ar.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey),
+		WithValidation(false), WithTTL(time.Hour))
+	require.NoError(t, err)
+
+	// wrong key, expired result, unexpected profile and invalid submod
+	report, err := VerifyDetailed(token, jwa.ES256, mustParseKey(t, testEd25519PublicKey),
+		WithClock(FixedClock(time.Unix(testIAT, 0).Add(2*time.Hour))),
+		WithExpectedProfile("tag:example.com,2026:other"))
+	assert.ErrorContains(t, err, "failed verifying JWT message")
+	require.NotNil(t, report)
+	assert.Nil(t, report.Result)
+	assert.False(t, report.Passed())
+
+	for _, name := range []string{CheckSignature, CheckLifetime, CheckClaims, CheckProfile} {
+		c, ok := report.Check(name)
+		require.True(t, ok, name)
+		assert.False(t, c.Passed(), name)
+	}
+
+	c, _ := report.Check(CheckLifetime)
+	assert.EqualError(t, c.Err, `"exp" not satisfied`)
+
+	require.Len(t, report.SubmodIssues, 1)
+	assert.Contains(t, report.SubmodIssues, "bad")
+}
+
+func TestVerifyDetailed_not_a_jws(t *testing.T) {
+	report, err := VerifyDetailed([]byte("not.a.jws.at.all"), jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	assert.EqualError(t, err, "failed parsing JWT message: malformed compact JWS: expecting 3 segments")
+	assert.Nil(t, report)
+}
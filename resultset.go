@@ -0,0 +1,308 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// ResultSetEntry is a signed attestation result carried in a ResultSet,
+// together with its metadata
+type ResultSetEntry struct {
+	// Name uniquely identifies the entry within the set (e.g., "platform",
+	// "workload")
+	Name *string `json:"name"`
+	// Token is the signed EAR
+	Token *string `json:"token"`
+	// Verifier is an optional hint that identifies the verifier that issued
+	// the EAR, e.g., to select the verification key
+	Verifier *string `json:"verifier,omitempty"`
+	// Metadata is optional, application-specific information about the entry
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (o ResultSetEntry) validate() error {
+	if o.Name == nil || *o.Name == "" {
+		return errors.New("missing mandatory 'name'")
+	}
+
+	if o.Token == nil || *o.Token == "" {
+		return errors.New("missing mandatory 'token'")
+	}
+
+	return nil
+}
+
+func ToResultSetEntry(v interface{}) (*ResultSetEntry, error) {
+	var e ResultSetEntry
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON object")
+	}
+
+	parsers := map[string]parser{
+		"metadata": stringMapPtrParser,
+	}
+
+	if err := populateStructFromMap(&e, m, "json", parsers, stringPtrParser, false); err != nil {
+		return nil, err
+	}
+
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// ResultSet is an envelope that carries multiple signed attestation results,
+// possibly issued by different verifiers (e.g., a platform and a workload
+// result), so that they can be conveyed and evaluated together.
+type ResultSet struct {
+	Entries []ResultSetEntry `json:"results"`
+}
+
+// Add appends a signed EAR to the set under the supplied (unique) name.
+// verifier and metadata are optional and can be empty.
+func (o *ResultSet) Add(
+	name string,
+	token []byte,
+	verifier string,
+	metadata map[string]interface{},
+) error {
+	if _, ok := o.Get(name); ok {
+		return fmt.Errorf("duplicate entry %q", name)
+	}
+
+	tok := string(token)
+	e := ResultSetEntry{Name: &name, Token: &tok}
+
+	if verifier != "" {
+		e.Verifier = &verifier
+	}
+
+	if metadata != nil {
+		e.Metadata = &metadata
+	}
+
+	if err := e.validate(); err != nil {
+		return err
+	}
+
+	o.Entries = append(o.Entries, e)
+
+	return nil
+}
+
+// Get returns the entry with the supplied name
+func (o ResultSet) Get(name string) (*ResultSetEntry, bool) {
+	for i := range o.Entries {
+		if e := o.Entries[i]; e.Name != nil && *e.Name == name {
+			return &o.Entries[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func (o ResultSet) validate() error {
+	if len(o.Entries) == 0 {
+		return errors.New("missing mandatory 'results' (at least one entry must be present)")
+	}
+
+	var problems []string
+	seen := map[string]bool{}
+
+	for i, e := range o.Entries {
+		if err := e.validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("results[%d]: %s", i, err.Error()))
+			continue
+		}
+
+		if seen[*e.Name] {
+			problems = append(problems, fmt.Sprintf("results[%d]: duplicate entry %q", i, *e.Name))
+		}
+		seen[*e.Name] = true
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// MarshalJSON validates and serializes to JSON a ResultSet object
+func (o ResultSet) MarshalJSON() ([]byte, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	m, err := structAsMap(o, "json")
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON de-serializes a ResultSet object from its JSON representation
+// and validates it.
+func (o *ResultSet) UnmarshalJSON(data []byte) error {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	parsers := map[string]parser{
+		"results": func(v interface{}) (interface{}, error) {
+			a, ok := v.([]interface{})
+			if !ok {
+				return nil, errors.New("not a JSON array")
+			}
+
+			entries := make([]ResultSetEntry, 0, len(a))
+
+			for i, ev := range a {
+				e, err := ToResultSetEntry(ev)
+				if err != nil {
+					return nil, fmt.Errorf("[%d]: %w", i, err)
+				}
+				entries = append(entries, *e)
+			}
+
+			return entries, nil
+		},
+	}
+
+	if err := populateStructFromMap(o, m, "json", parsers, stringPtrParser, false); err != nil {
+		return err
+	}
+
+	return o.validate()
+}
+
+// MarshalCBOR validates and serializes to CBOR a ResultSet object.  The CBOR
+// serialization mirrors the JSON one, member names included.
+func (o ResultSet) MarshalCBOR() ([]byte, error) {
+	data, err := o.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonToCBOR(data, cborClaim{})
+}
+
+// UnmarshalCBOR de-serializes a ResultSet object from its CBOR representation
+// and validates it.
+func (o *ResultSet) UnmarshalCBOR(data []byte) error {
+	j, err := cborToJSON(data, cborClaim{})
+	if err != nil {
+		return err
+	}
+
+	return o.UnmarshalJSON(j)
+}
+
+// ResultSetKeyFunc returns the algorithm and key that must be used to verify
+// the supplied entry
+type ResultSetKeyFunc func(entry ResultSetEntry) (jwa.KeyAlgorithm, interface{}, error)
+
+// Verify verifies each entry in the set using the key returned by keyFunc,
+// and the supplied VerifyOption.  On success, the decoded attestation results
+// are returned indexed by entry name.  Verification of all entries is
+// attempted, and any failure is reported in the returned error.
+func (o ResultSet) Verify(
+	keyFunc ResultSetKeyFunc,
+	opts ...VerifyOption,
+) (map[string]*AttestationResult, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*AttestationResult, len(o.Entries))
+	var problems []string
+
+	for _, e := range o.Entries {
+		alg, key, err := keyFunc(e)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: resolving key: %s", *e.Name, err.Error()))
+			continue
+		}
+
+		var ar AttestationResult
+		if err := ar.Verify([]byte(*e.Token), alg, key, opts...); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", *e.Name, err.Error()))
+			continue
+		}
+
+		results[*e.Name] = &ar
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("verifying result set: %s", strings.Join(problems, "; "))
+	}
+
+	return results, nil
+}
+
+// ResultSetPolicy maps the name of a ResultSet entry onto the minimum status
+// that each of the appraisals in the corresponding attestation result must
+// have.  TrustTierNone as a minimum status accepts any status, while an
+// appraisal with status TrustTierNone only satisfies that.
+type ResultSetPolicy map[string]TrustTier
+
+// Evaluate checks the verified results (as returned by ResultSet.Verify)
+// against the policy.  Every entry named in the policy must be present;
+// entries not named in the policy are ignored.
+func (o ResultSetPolicy) Evaluate(results map[string]*AttestationResult) error {
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+
+	for _, name := range names {
+		min := o[name]
+
+		ar, ok := results[name]
+		if !ok || ar == nil {
+			problems = append(problems, fmt.Sprintf("%s: result not found", name))
+			continue
+		}
+
+		submods := make([]string, 0, len(ar.Submods))
+		for submod := range ar.Submods {
+			submods = append(submods, submod)
+		}
+		sort.Strings(submods)
+
+		for _, submod := range submods {
+			status := TrustTierNone
+			if a := ar.Submods[submod]; a != nil && a.Status != nil {
+				status = *a.Status
+			}
+
+			if !meetsTier(status, min) {
+				problems = append(problems,
+					fmt.Sprintf("%s: submods[%s]: status %s does not meet %s", name, submod, status, min))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("result set policy not satisfied: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
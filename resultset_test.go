@@ -0,0 +1,155 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestResultSet(t *testing.T) (*ResultSet, jwk.Key) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	platform := testAttestationResultsWithVeraisonExtns
+
+	workloadStatus := TrustTierWarning
+	workload := testAttestationResultsWithVeraisonExtns
+	workload.Submods = map[string]*Appraisal{
+		"workload": {Status: &workloadStatus},
+	}
+
+	platformToken, err := platform.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	workloadToken, err := workload.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var rs ResultSet
+	require.NoError(t, rs.Add("platform", platformToken, "platform-verifier", nil))
+	require.NoError(t, rs.Add("workload", workloadToken, "", map[string]interface{}{"tenant": "acme"}))
+
+	return &rs, vfyK
+}
+
+func TestResultSet_round_trip(t *testing.T) {
+	rs, _ := makeTestResultSet(t)
+
+	data, err := json.Marshal(rs)
+	require.NoError(t, err)
+
+	var actual ResultSet
+	require.NoError(t, json.Unmarshal(data, &actual))
+	assert.Equal(t, *rs, actual)
+
+	e, ok := actual.Get("workload")
+	require.True(t, ok)
+	assert.Equal(t, "acme", (*e.Metadata)["tenant"])
+	assert.Nil(t, e.Verifier)
+}
+
+func TestResultSet_CBOR_round_trip(t *testing.T) {
+	rs, vfyK := makeTestResultSet(t)
+
+	data, err := rs.MarshalCBOR()
+	require.NoError(t, err)
+
+	var m map[string]interface{}
+	require.NoError(t, cbor.Unmarshal(data, &m))
+	assert.Contains(t, m, "results")
+
+	var actual ResultSet
+	require.NoError(t, actual.UnmarshalCBOR(data))
+	assert.Equal(t, *rs, actual)
+
+	_, err = actual.Verify(func(ResultSetEntry) (jwa.KeyAlgorithm, interface{}, error) {
+		return jwa.ES256, vfyK, nil
+	})
+	assert.NoError(t, err)
+
+	_, err = ResultSet{}.MarshalCBOR()
+	assert.EqualError(t, err, "missing mandatory 'results' (at least one entry must be present)")
+
+	assert.ErrorContains(t, actual.UnmarshalCBOR([]byte{0xff}), "cbor: ")
+}
+
+func TestResultSet_Verify_and_Evaluate(t *testing.T) {
+	rs, vfyK := makeTestResultSet(t)
+
+	keyFunc := func(ResultSetEntry) (jwa.KeyAlgorithm, interface{}, error) {
+		return jwa.ES256, vfyK, nil
+	}
+
+	results, err := rs.Verify(keyFunc)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	policy := ResultSetPolicy{
+		"platform": TrustTierAffirming,
+		"workload": TrustTierWarning,
+	}
+	assert.NoError(t, policy.Evaluate(results))
+
+	policy = ResultSetPolicy{
+		"workload": TrustTierAffirming,
+		"other":    TrustTierNone,
+	}
+	assert.EqualError(t, policy.Evaluate(results),
+		"result set policy not satisfied: other: result not found; "+
+			"workload: submods[workload]: status warning does not meet affirming")
+}
+
+func TestResultSet_Verify_fail(t *testing.T) {
+	rs, _ := makeTestResultSet(t)
+
+	keyFunc := func(e ResultSetEntry) (jwa.KeyAlgorithm, interface{}, error) {
+		return nil, nil, errors.New("unknown verifier")
+	}
+
+	_, err := rs.Verify(keyFunc)
+	assert.EqualError(t, err, "verifying result set: "+
+		"platform: resolving key: unknown verifier; workload: resolving key: unknown verifier")
+}
+
+func TestResultSet_fail(t *testing.T) {
+	var rs ResultSet
+
+	assert.EqualError(t, rs.Add("", []byte("token"), "", nil), "missing mandatory 'name'")
+	require.NoError(t, rs.Add("a", []byte("token"), "", nil))
+	assert.EqualError(t, rs.Add("a", []byte("token"), "", nil), `duplicate entry "a"`)
+
+	tvs := []struct {
+		data     string
+		expected string
+	}{
+		{
+			data:     `{"results": []}`,
+			expected: "missing mandatory 'results' (at least one entry must be present)",
+		},
+		{
+			data:     `{"results": [{"name": "a"}]}`,
+			expected: "invalid value(s) for 'results' ([0]: missing mandatory 'token')",
+		},
+		{
+			data:     `{"results": [{"name": "a", "token": "x"}, {"name": "a", "token": "y"}]}`,
+			expected: `results[1]: duplicate entry "a"`,
+		},
+	}
+
+	for i, tv := range tvs {
+		var actual ResultSet
+		err := json.Unmarshal([]byte(tv.data), &actual)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
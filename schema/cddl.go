@@ -0,0 +1,134 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/huandu/xstrings"
+	"github.com/veraison/ear"
+)
+
+// cddlPrelude defines the generic JC<J, C> (from the EAT specification),
+// which selects J in the JSON serialization and C in the CBOR one, and the
+// types shared by the rules
+const cddlPrelude = `JC<J, C> = J .feature "json" / C .feature "cbor"
+
+binary-data = JC<base64-url-text, bstr>
+base64-url-text = tstr .regexp "[A-Za-z0-9_-]*"
+COSE_Key = { + (int / tstr) => any }
+`
+
+// CDDL returns the CDDL of the EAR claims-set, covering both its JSON and CBOR
+// serializations.  The rule for the claims-set is "ear"; each struct of the
+// ear package gets a rule named after its Go type, in kebab-case (e.g.,
+// "verifier-identity").
+func CDDL() string {
+	m := newModel()
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "; EAR (EAT Attestation Result) claims-set, as implemented by github.com/veraison/ear\n\n")
+	fmt.Fprintf(&b, "start = ear\n\n")
+
+	for _, n := range m.defs {
+		fmt.Fprintf(&b, "%s = %s\n\n", cddlRuleName(n), cddlDef(n, n == m.root))
+	}
+
+	b.WriteString(cddlPrelude)
+
+	return b.String()
+}
+
+func cddlRuleName(n *node) string {
+	if n.name == "AttestationResult" {
+		return "ear"
+	}
+	return xstrings.ToKebabCase(n.name)
+}
+
+// cddlDef returns the definition of a named type.  Unknown claims are allowed
+// in the claims-set and in the appraisals.
+func cddlDef(n *node, isRoot bool) string {
+	switch n.kind {
+	case kindStruct:
+		var b strings.Builder
+
+		b.WriteString("{\n")
+
+		for _, f := range n.fields {
+			opt := ""
+			if !f.mandatory {
+				opt = "? "
+			}
+
+			label := fmt.Sprintf("%q", f.name)
+			if f.key != nil {
+				label = fmt.Sprintf("JC<%q, %d>", f.name, *f.key)
+			}
+
+			fmt.Fprintf(&b, "  %s%s => %s,\n", opt, label, cddlType(f.node))
+		}
+
+		if isRoot || n.name == "Appraisal" {
+			b.WriteString("  * (tstr / int) => any,\n")
+		}
+
+		b.WriteString("}")
+
+		return b.String()
+	case kindEnum:
+		quoted := make([]string, len(n.enum))
+		for i, v := range n.enum {
+			quoted[i] = fmt.Sprintf("%q", v)
+		}
+		return strings.Join(quoted, " / ")
+	case kindTier:
+		var tiers []ear.TrustTier
+		for t := range ear.TrustTierToString {
+			tiers = append(tiers, t)
+		}
+		sort.Slice(tiers, func(i, j int) bool { return tiers[i] < tiers[j] })
+
+		alts := make([]string, len(tiers))
+		for i, t := range tiers {
+			alts[i] = fmt.Sprintf("JC<%q, %d>", t.String(), t)
+		}
+		return strings.Join(alts, " / ")
+	case kindTrustClaim:
+		return "-128..127"
+	case kindHardwareVersion:
+		return "[ version: tstr, ? scheme: int ]"
+	default:
+		return cddlType(n)
+	}
+}
+
+// cddlType returns the CDDL type of a claim of type n
+func cddlType(n *node) string {
+	if n.name != "" {
+		return cddlRuleName(n)
+	}
+
+	switch n.kind {
+	case kindText:
+		return "tstr"
+	case kindInt:
+		return "int"
+	case kindBytes:
+		return "binary-data"
+	case kindCOSEKey:
+		return "JC<base64-url-text, COSE_Key>"
+	case kindObject:
+		return "{ * tstr => any }"
+	case kindMap:
+		return fmt.Sprintf("{ + tstr => %s }", cddlType(n.elem))
+	case kindArray:
+		return fmt.Sprintf("[ * %s ]", cddlType(n.elem))
+	default:
+		return "any"
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/veraison/ear"
+)
+
+// JSONSchemaDialect is the JSON Schema version used by JSONSchema
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// base64URLPattern matches base64url (§5 of RFC4648) without padding
+const base64URLPattern = "^[A-Za-z0-9_-]*$"
+
+// JSONSchema returns the JSON Schema of the JSON serialization of the EAR
+// claims-set.  Each struct of the ear package is described in "$defs", under
+// the name of its Go type.
+func JSONSchema() ([]byte, error) {
+	m := newModel()
+
+	defs := map[string]interface{}{}
+	for _, n := range m.defs {
+		defs[n.name] = jsonSchemaDef(n)
+	}
+
+	s := map[string]interface{}{
+		"$schema": JSONSchemaDialect,
+		"title":   "EAR (EAT Attestation Result) claims-set",
+		"$ref":    jsonSchemaRef(m.root),
+		"$defs":   defs,
+	}
+
+	return json.MarshalIndent(s, "", "  ")
+}
+
+func jsonSchemaRef(n *node) string {
+	return "#/$defs/" + n.name
+}
+
+// jsonSchemaDef returns the definition of a named type
+func jsonSchemaDef(n *node) map[string]interface{} {
+	switch n.kind {
+	case kindStruct:
+		props := map[string]interface{}{}
+		var required []string
+
+		for _, f := range n.fields {
+			props[f.name] = jsonSchemaType(f.node)
+			if f.mandatory {
+				required = append(required, f.name)
+			}
+		}
+
+		def := map[string]interface{}{
+			"type":       "object",
+			"properties": props,
+		}
+
+		if len(required) > 0 {
+			def["required"] = required
+		}
+
+		return def
+	case kindEnum:
+		return map[string]interface{}{"enum": n.enum}
+	case kindTier:
+		var names []string
+		for _, name := range ear.TrustTierToString {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return map[string]interface{}{"enum": names}
+	case kindTrustClaim:
+		return map[string]interface{}{"type": "integer", "minimum": -128, "maximum": 127}
+	case kindHardwareVersion:
+		return map[string]interface{}{
+			"type": "array",
+			"prefixItems": []interface{}{
+				map[string]interface{}{"type": "string"},
+				map[string]interface{}{"type": "integer"},
+			},
+			"minItems": 1,
+			"maxItems": 2,
+		}
+	default:
+		return jsonSchemaType(n)
+	}
+}
+
+// jsonSchemaType returns the schema of a claim of type n
+func jsonSchemaType(n *node) map[string]interface{} {
+	if n.name != "" {
+		return map[string]interface{}{"$ref": jsonSchemaRef(n)}
+	}
+
+	switch n.kind {
+	case kindText:
+		return map[string]interface{}{"type": "string"}
+	case kindInt:
+		return map[string]interface{}{"type": "integer"}
+	case kindBytes, kindCOSEKey:
+		return map[string]interface{}{"type": "string", "pattern": base64URLPattern}
+	case kindObject:
+		return map[string]interface{}{"type": "object"}
+	case kindMap:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": jsonSchemaType(n.elem),
+			"minProperties":        1,
+		}
+	case kindArray:
+		return map[string]interface{}{"type": "array", "items": jsonSchemaType(n.elem)}
+	default:
+		return map[string]interface{}{}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema emits the JSON Schema and the CDDL of the EAR claims-set
+// implemented by the ear package.  Both are derived from the Go types of the
+// ear package (their struct tags, and the integer keys used in CBOR), so that
+// validators written in other languages stay in sync with this
+// implementation.
+//
+// The schemas describe the canonical form of the claims-set, i.e., the one
+// produced when signing.  When decoding, the ear package is more lenient
+// (e.g., it accepts trust tiers encoded as integers in JSON).  Claims that are
+// not defined by EAR are allowed, since they are ignored when decoding.
+package schema
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/veraison/ear"
+)
+
+type kind int
+
+const (
+	kindText kind = iota
+	kindInt
+	kindBytes
+	kindAny
+	// kindObject is a free-form JSON object
+	kindObject
+	// kindMap is an object with arbitrary keys and values of type elem
+	kindMap
+	kindArray
+	kindStruct
+	kindEnum
+	kindTier
+	kindTrustClaim
+	kindHardwareVersion
+	// kindCOSEKey is a COSE_Key, which is base64url-encoded in JSON and
+	// embedded as-is in CBOR
+	kindCOSEKey
+)
+
+// node describes the type of a claim
+type node struct {
+	kind kind
+	// name is the name of the Go type, for the types that get their own
+	// definition (structs, enums and the special types)
+	name   string
+	elem   *node
+	fields []field
+	enum   []string
+}
+
+type field struct {
+	name      string
+	key       *int64
+	mandatory bool
+	node      *node
+}
+
+// model is the description of the EAR claims-set
+type model struct {
+	root *node
+	// defs are the named types, in the order in which they are first met
+	defs  []*node
+	named map[reflect.Type]*node
+}
+
+var (
+	trustTierType       = reflect.TypeOf(ear.TrustTier(0))
+	trustClaimType      = reflect.TypeOf(ear.TrustClaim(0))
+	b64UrlType          = reflect.TypeOf(ear.B64Url{})
+	hardwareVersionType = reflect.TypeOf(ear.HardwareVersion{})
+	policyOutcomeType   = reflect.TypeOf(ear.PolicyOutcome(""))
+	reasonSeverityType  = reflect.TypeOf(ear.ReasonSeverity(""))
+)
+
+// enumValues lists the values of the string types that are enumerations
+var enumValues = map[reflect.Type][]string{
+	policyOutcomeType: {
+		string(ear.PolicyOutcomePass),
+		string(ear.PolicyOutcomeFail),
+		string(ear.PolicyOutcomeNotApplicable),
+	},
+	reasonSeverityType: {
+		string(ear.ReasonSeverityInfo),
+		string(ear.ReasonSeverityWarning),
+		string(ear.ReasonSeverityError),
+	},
+}
+
+// embeddedCOSEKeys are the (slash-separated) paths of the claims carrying a
+// COSE_Key
+var embeddedCOSEKeys = map[string]bool{
+	"cnf/cose_key": true,
+}
+
+func newModel() *model {
+	m := &model{named: map[reflect.Type]*node{}}
+	m.root = m.describe(reflect.TypeOf(ear.AttestationResult{}), nil)
+	return m
+}
+
+// describe returns the description of t, found at path
+func (o *model) describe(t reflect.Type, path []string) *node {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if n, ok := o.named[t]; ok {
+		return n
+	}
+
+	if embeddedCOSEKeys[strings.Join(path, "/")] {
+		return &node{kind: kindCOSEKey}
+	}
+
+	switch t {
+	case trustTierType:
+		return o.define(t, &node{kind: kindTier})
+	case trustClaimType:
+		return o.define(t, &node{kind: kindTrustClaim})
+	case b64UrlType:
+		return &node{kind: kindBytes}
+	case hardwareVersionType:
+		return o.define(t, &node{kind: kindHardwareVersion})
+	}
+
+	if values, ok := enumValues[t]; ok {
+		return o.define(t, &node{kind: kindEnum, enum: values})
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &node{kind: kindText}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &node{kind: kindInt}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &node{kind: kindObject}
+		}
+		return &node{kind: kindMap, elem: o.describe(t.Elem(), appendPath(path, "*"))}
+	case reflect.Slice:
+		return &node{kind: kindArray, elem: o.describe(t.Elem(), path)}
+	case reflect.Struct:
+		n := o.define(t, &node{kind: kindStruct})
+		n.fields = o.fields(t, path)
+		return n
+	default:
+		return &node{kind: kindAny}
+	}
+}
+
+func (o *model) define(t reflect.Type, n *node) *node {
+	n.name = t.Name()
+	o.named[t] = n
+	o.defs = append(o.defs, n)
+	return n
+}
+
+// fields returns the JSON-serialized fields of the struct type t (including
+// those of its embedded structs), found at path
+func (o *model) fields(t reflect.Type, path []string) []field {
+	var fs []field
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag, ok := f.Tag.Lookup("json")
+		if !ok || tag == "-" {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				fs = append(fs, o.fields(f.Type, path)...)
+			}
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		fieldPath := appendPath(path, parts[0])
+
+		fld := field{
+			name:      parts[0],
+			mandatory: true,
+			node:      o.describe(f.Type, fieldPath),
+		}
+
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				fld.mandatory = false
+			}
+		}
+
+		if k, ok := ear.CBORKey(fieldPath...); ok {
+			fld.key = &k
+		}
+
+		fs = append(fs, fld)
+	}
+
+	return fs
+}
+
+// appendPath returns a copy of path with name appended
+func appendPath(path []string, name string) []string {
+	return append(append([]string{}, path...), name)
+}
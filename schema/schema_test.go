@@ -0,0 +1,71 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	require.NoError(t, err)
+
+	var s struct {
+		Schema string `json:"$schema"`
+		Ref    string `json:"$ref"`
+		Defs   map[string]struct {
+			Type       string                            `json:"type"`
+			Properties map[string]map[string]interface{} `json:"properties"`
+			Required   []string                          `json:"required"`
+			Enum       []string                          `json:"enum"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &s))
+
+	assert.Equal(t, JSONSchemaDialect, s.Schema)
+	assert.Equal(t, "#/$defs/AttestationResult", s.Ref)
+
+	ar := s.Defs["AttestationResult"]
+	assert.Equal(t, "object", ar.Type)
+	assert.Equal(t, []string{"eat_profile", "ear.verifier-id", "iat", "submods"}, ar.Required)
+	assert.Equal(t, "#/$defs/Appraisal", ar.Properties["submods"]["additionalProperties"].(map[string]interface{})["$ref"])
+	assert.Equal(t, "#/$defs/VeraisonTeeInfo", ar.Properties["ear.veraison.tee-info"]["$ref"])
+
+	appraisal := s.Defs["Appraisal"]
+	assert.Equal(t, []string{"ear.status"}, appraisal.Required)
+	assert.Equal(t, "#/$defs/TrustTier", appraisal.Properties["ear.status"]["$ref"])
+	assert.Equal(t, base64URLPattern, appraisal.Properties["ueid"]["pattern"])
+
+	assert.Equal(t, []string{"affirming", "contraindicated", "none", "warning"}, s.Defs["TrustTier"].Enum)
+	assert.Nil(t, s.Defs["TrustVector"].Required)
+	assert.Len(t, s.Defs["TrustVector"].Properties, 8)
+}
+
+func TestCDDL(t *testing.T) {
+	cddl := CDDL()
+
+	for _, expected := range []string{
+		"start = ear\n",
+		"ear = {\n  JC<\"eat_profile\", 265> => tstr,\n",
+		"  JC<\"submods\", 266> => { + tstr => appraisal },\n",
+		"  ? \"epoch-id\" => tstr,\n",
+		"  JC<\"ear.status\", 1000> => trust-tier,\n",
+		"  ? JC<\"ear.veraison.reasons\", -70007> => [ * reason ],\n",
+		"  ? JC<\"cose_key\", 1> => JC<base64-url-text, COSE_Key>,\n",
+		"verifier-identity = {\n  JC<\"build\", 0> => tstr,\n",
+		"trust-tier = JC<\"none\", 0> / JC<\"affirming\", 2> / JC<\"warning\", 32> / JC<\"contraindicated\", 96>\n",
+		"policy-outcome = \"pass\" / \"fail\" / \"not-applicable\"\n",
+		"JC<J, C> = J .feature \"json\" / C .feature \"cbor\"\n",
+	} {
+		assert.Contains(t, cddl, expected)
+	}
+
+	// every rule is defined once
+	assert.Equal(t, 1, strings.Count(cddl, "\ndigest = {"))
+}
@@ -0,0 +1,436 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// SD-JWT (draft-ietf-oauth-selective-disclosure-jwt) support.  An SD-JWT is
+// serialized as the issuer-signed JWT followed by zero or more disclosures,
+// each terminated by a tilde:
+//
+//	<JWT>~<Disclosure 1>~...~<Disclosure N>~
+//
+// Key binding JWTs are not supported: proof of possession can instead be
+// obtained using the `cnf` claim (see VerifyPoP).
+
+const (
+	// SDAlgSHA256 is the (only) hash algorithm used for SD-JWT disclosure
+	// digests
+	SDAlgSHA256 = "sha-256"
+
+	sdSeparator = "~"
+	sdClaim     = "_sd"
+	sdAlgClaim  = "_sd_alg"
+	sdSaltLen   = 16
+)
+
+// SelectivelyDisclosableClaims lists the top-level claims that SignSD issues
+// as disclosures
+var SelectivelyDisclosableClaims = []string{
+	"ear.raw-evidence",
+}
+
+// SelectivelyDisclosableAppraisalClaims lists the appraisal (i.e., submod)
+// claims that SignSD issues as disclosures
+var SelectivelyDisclosableAppraisalClaims = []string{
+	"ear.veraison.annotated-evidence",
+	"ear.veraison.policy-claims",
+}
+
+// Disclosure is a selectively disclosable claim
+type Disclosure struct {
+	// Submod is the name of the submod the claim belongs to, or the empty
+	// string for top-level claims.  It is only informative, and it is not
+	// part of the disclosure itself.
+	Submod string
+	// Name is the claim name
+	Name string
+	// Value is the claim value
+	Value interface{}
+
+	salt    string
+	encoded string
+}
+
+func newDisclosure(submod, name string, value interface{}) (*Disclosure, error) {
+	salt := make([]byte, sdSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generating salt: %w", err)
+	}
+
+	d := Disclosure{
+		Submod: submod,
+		Name:   name,
+		Value:  value,
+		salt:   base64.RawURLEncoding.EncodeToString(salt),
+	}
+
+	data, err := json.Marshal([]interface{}{d.salt, d.Name, d.Value})
+	if err != nil {
+		return nil, fmt.Errorf("serializing disclosure for %q: %w", name, err)
+	}
+
+	d.encoded = base64.RawURLEncoding.EncodeToString(data)
+
+	return &d, nil
+}
+
+func decodeDisclosure(encoded string) (*Disclosure, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding disclosure: %w", err)
+	}
+
+	var a []interface{}
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("parsing disclosure: %w", err)
+	}
+
+	if len(a) != 3 {
+		return nil, fmt.Errorf("disclosure must be a JSON array of 3 elements, found %d", len(a))
+	}
+
+	salt, ok := a[0].(string)
+	if !ok {
+		return nil, errors.New("disclosure salt is not a string")
+	}
+
+	name, ok := a[1].(string)
+	if !ok {
+		return nil, errors.New("disclosure claim name is not a string")
+	}
+
+	if name == sdClaim || name == sdAlgClaim {
+		return nil, fmt.Errorf("disclosure uses reserved claim name %q", name)
+	}
+
+	return &Disclosure{
+		Name:    name,
+		Value:   a[2],
+		salt:    salt,
+		encoded: encoded,
+	}, nil
+}
+
+// Digest returns the base64url-encoded SHA-256 digest of the disclosure, as
+// found in the `_sd` array of the issuer-signed JWT
+func (o Disclosure) Digest() string {
+	h := sha256.Sum256([]byte(o.encoded))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// SDJWT is a parsed SD-JWT, which can be used by the holder to select the
+// disclosures that are presented to a relying party
+type SDJWT struct {
+	JWT         []byte
+	Disclosures []Disclosure
+}
+
+// ParseSDJWT splits the supplied SD-JWT into its issuer-signed JWT and
+// disclosures, and locates each disclosure in the JWT payload to set its
+// Submod.  The JWT signature is NOT verified: use
+// AttestationResult.VerifySD for that.
+func ParseSDJWT(data []byte) (*SDJWT, error) {
+	parts := strings.Split(string(data), sdSeparator)
+	if len(parts) < 2 {
+		return nil, errors.New("not an SD-JWT: missing disclosures separator")
+	}
+
+	if kb := parts[len(parts)-1]; kb != "" {
+		return nil, errors.New("key binding JWTs are not supported")
+	}
+
+	sd := SDJWT{JWT: []byte(parts[0])}
+
+	msg, err := jws.Parse(sd.JWT)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JWT: %w", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(msg.Payload(), &payload); err != nil {
+		return nil, fmt.Errorf("parsing JWT payload: %w", err)
+	}
+
+	refs := sdDigestRefs(payload)
+
+	for i, encoded := range parts[1 : len(parts)-1] {
+		d, err := decodeDisclosure(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("disclosure at index %d: %w", i, err)
+		}
+
+		ref, ok := refs[d.Digest()]
+		if !ok {
+			return nil, fmt.Errorf("disclosure at index %d (%q) not referenced by the JWT", i, d.Name)
+		}
+		d.Submod = ref.submod
+
+		sd.Disclosures = append(sd.Disclosures, *d)
+	}
+
+	return &sd, nil
+}
+
+// Bytes returns the serialized SD-JWT
+func (o SDJWT) Bytes() []byte {
+	var b bytes.Buffer
+
+	b.Write(o.JWT)
+	b.WriteString(sdSeparator)
+
+	for _, d := range o.Disclosures {
+		b.WriteString(d.encoded)
+		b.WriteString(sdSeparator)
+	}
+
+	return b.Bytes()
+}
+
+// Present returns the serialized SD-JWT with only the disclosures for which
+// keep returns true.  The withheld claims cannot be recovered by the relying
+// party, though the issuer signature is still verifiable.
+func (o SDJWT) Present(keep func(Disclosure) bool) []byte {
+	p := SDJWT{JWT: o.JWT}
+
+	for _, d := range o.Disclosures {
+		if keep(d) {
+			p.Disclosures = append(p.Disclosures, d)
+		}
+	}
+
+	return p.Bytes()
+}
+
+// SignSD is like Sign, but issues the sensitive claims listed in
+// SelectivelyDisclosableClaims and SelectivelyDisclosableAppraisalClaims as
+// SD-JWT disclosures, so that the holder can withhold them on a per relying
+// party basis.  On success, the serialized SD-JWT carrying all the
+// disclosures is returned.
+func (o AttestationResult) SignSD(
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...SignOption,
+) ([]byte, error) {
+	cfg := newSignConfig(opts)
+
+	claims, err := o.claimsSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var disclosures []Disclosure
+
+	top, err := makeDisclosures(claims, "", SelectivelyDisclosableClaims)
+	if err != nil {
+		return nil, err
+	}
+	disclosures = append(disclosures, top...)
+
+	if submods, ok := claims["submods"].(map[string]interface{}); ok {
+		for name, v := range submods {
+			appraisal, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			ds, err := makeDisclosures(appraisal, name, SelectivelyDisclosableAppraisalClaims)
+			if err != nil {
+				return nil, err
+			}
+			disclosures = append(disclosures, ds...)
+		}
+	}
+
+	claims[sdAlgClaim] = SDAlgSHA256
+
+	jwt, err := signClaimsSet(claims, alg, key, cfg.protectedHeaders())
+	if err != nil {
+		return nil, err
+	}
+
+	token := SDJWT{JWT: jwt, Disclosures: disclosures}.Bytes()
+
+	o.auditSigned(AuditFormatJWT, token, cfg)
+
+	return token, nil
+}
+
+// makeDisclosures replaces the claims in m that are listed in names with
+// their digests in the `_sd` array, and returns the corresponding
+// disclosures
+func makeDisclosures(m map[string]interface{}, submod string, names []string) ([]Disclosure, error) {
+	var (
+		disclosures []Disclosure
+		digests     []string
+	)
+
+	for _, name := range names {
+		v, ok := m[name]
+		if !ok {
+			continue
+		}
+
+		d, err := newDisclosure(submod, name, v)
+		if err != nil {
+			return nil, err
+		}
+
+		disclosures = append(disclosures, *d)
+		digests = append(digests, d.Digest())
+		delete(m, name)
+	}
+
+	if len(digests) == 0 {
+		return nil, nil
+	}
+
+	// sorting the digests hides the original order of the claims
+	sort.Strings(digests)
+	m[sdClaim] = digests
+
+	return disclosures, nil
+}
+
+// VerifySD is like Verify, but for SD-JWTs produced by SignSD.  After the
+// issuer-signed JWT is verified, the presented disclosures are checked against
+// the digests in the JWT and the disclosed claims are reconstructed.  Claims
+// whose disclosure has been withheld are simply absent from the populated
+// AttestationResult.
+func (o *AttestationResult) VerifySD(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) error {
+	cfg := newVerifyConfig(opts)
+
+	parts := strings.Split(string(data), sdSeparator)
+	if len(parts) < 2 {
+		return errors.New("not an SD-JWT: missing disclosures separator")
+	}
+
+	if kb := parts[len(parts)-1]; kb != "" {
+		return errors.New("key binding JWTs are not supported")
+	}
+
+	token, err := parseToken([]byte(parts[0]), alg, key, cfg)
+	if err != nil {
+		return err
+	}
+
+	claims := token.PrivateClaims()
+
+	if err := reconstructSD(claims, parts[1:len(parts)-1]); err != nil {
+		return err
+	}
+
+	if _, err := o.populateFromToken(token, claims, cfg); err != nil {
+		return err
+	}
+
+	o.auditVerified(AuditFormatJWT, data, cfg)
+
+	return nil
+}
+
+type sdDigestRef struct {
+	submod string
+	target map[string]interface{}
+}
+
+// sdDigestRefs indexes the digests found in the `_sd` arrays of the top-level
+// claims-set and of each submod
+func sdDigestRefs(claims map[string]interface{}) map[string]sdDigestRef {
+	refs := map[string]sdDigestRef{}
+
+	add := func(m map[string]interface{}, submod string) {
+		a, ok := m[sdClaim].([]interface{})
+		if !ok {
+			return
+		}
+
+		for _, v := range a {
+			if digest, ok := v.(string); ok {
+				refs[digest] = sdDigestRef{submod: submod, target: m}
+			}
+		}
+	}
+
+	add(claims, "")
+
+	if submods, ok := claims["submods"].(map[string]interface{}); ok {
+		for name, v := range submods {
+			if m, ok := v.(map[string]interface{}); ok {
+				add(m, name)
+			}
+		}
+	}
+
+	return refs
+}
+
+// reconstructSD replaces the digests in claims with the supplied (encoded)
+// disclosures, and strips the SD-JWT specific claims
+func reconstructSD(claims map[string]interface{}, encoded []string) error {
+	if len(encoded) > 0 {
+		alg, ok := claims[sdAlgClaim].(string)
+		if !ok {
+			return fmt.Errorf("missing mandatory %q", sdAlgClaim)
+		}
+
+		if alg != SDAlgSHA256 {
+			return fmt.Errorf("unsupported %q: %s", sdAlgClaim, alg)
+		}
+	}
+
+	refs := sdDigestRefs(claims)
+	seen := map[string]bool{}
+
+	for i, e := range encoded {
+		d, err := decodeDisclosure(e)
+		if err != nil {
+			return fmt.Errorf("disclosure at index %d: %w", i, err)
+		}
+
+		digest := d.Digest()
+
+		if seen[digest] {
+			return fmt.Errorf("disclosure at index %d (%q) presented more than once", i, d.Name)
+		}
+		seen[digest] = true
+
+		ref, ok := refs[digest]
+		if !ok {
+			return fmt.Errorf("disclosure at index %d (%q) not referenced by the JWT", i, d.Name)
+		}
+
+		if _, ok := ref.target[d.Name]; ok {
+			return fmt.Errorf("disclosure at index %d (%q) overwrites an existing claim", i, d.Name)
+		}
+
+		ref.target[d.Name] = d.Value
+	}
+
+	delete(claims, sdAlgClaim)
+
+	for _, ref := range refs {
+		delete(ref.target, sdClaim)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signTestSDJWT(t *testing.T) ([]byte, jwk.Key) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	rawEvidence := B64Url(testEvidence)
+	ar.RawEvidence = &rawEvidence
+
+	sd, err := ar.SignSD(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	return sd, vfyK
+}
+
+func TestAttestationResult_SignSD_VerifySD_all_disclosed(t *testing.T) {
+	sd, vfyK := signTestSDJWT(t)
+
+	parsed, err := ParseSDJWT(sd)
+	require.NoError(t, err)
+	assert.Len(t, parsed.Disclosures, 3)
+
+	// the sensitive claims are not in the clear in the issuer-signed JWT
+	var plain AttestationResult
+	require.NoError(t, plain.Verify(parsed.JWT, jwa.ES256, vfyK))
+	assert.Nil(t, plain.RawEvidence)
+	assert.Nil(t, plain.Submods["test"].VeraisonAnnotatedEvidence)
+	assert.Nil(t, plain.Submods["test"].VeraisonPolicyClaims)
+	assert.NotNil(t, plain.Submods["test"].VeraisonKeyAttestation)
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifySD(sd, jwa.ES256, vfyK))
+	assert.Equal(t, testEvidence, []byte(*actual.RawEvidence))
+	assert.Equal(t,
+		*testAttestationResultsWithVeraisonExtns.Submods["test"].VeraisonAnnotatedEvidence,
+		*actual.Submods["test"].VeraisonAnnotatedEvidence)
+	assert.Equal(t,
+		*testAttestationResultsWithVeraisonExtns.Submods["test"].VeraisonPolicyClaims,
+		*actual.Submods["test"].VeraisonPolicyClaims)
+	assert.NoError(t, actual.validate())
+}
+
+func TestAttestationResult_SignSD_WithKeyID_WithHeader(t *testing.T) {
+	sd, err := testAttestationResultsWithVeraisonExtns.SignSD(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey),
+		WithKeyID("key#1"),
+		WithHeader("x-test", "value"),
+	)
+	require.NoError(t, err)
+
+	parsed, err := ParseSDJWT(sd)
+	require.NoError(t, err)
+
+	msg, err := jws.Parse(parsed.JWT)
+	require.NoError(t, err)
+	require.Len(t, msg.Signatures(), 1)
+
+	hdrs := msg.Signatures()[0].ProtectedHeaders()
+	assert.Equal(t, "key#1", hdrs.KeyID())
+
+	v, ok := hdrs.Get("x-test")
+	assert.True(t, ok)
+	assert.Equal(t, "value", v)
+
+	var actual AttestationResult
+	assert.NoError(t, actual.VerifySD(sd, jwa.ES256, mustParseKey(t, testECDSAPublicKey)))
+}
+
+func TestAttestationResult_VerifySD_withheld(t *testing.T) {
+	sd, vfyK := signTestSDJWT(t)
+
+	parsed, err := ParseSDJWT(sd)
+	require.NoError(t, err)
+
+	presented := parsed.Present(func(d Disclosure) bool {
+		return d.Submod == "test" && d.Name == "ear.veraison.policy-claims"
+	})
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifySD(presented, jwa.ES256, vfyK))
+	assert.Nil(t, actual.RawEvidence)
+	assert.Nil(t, actual.Submods["test"].VeraisonAnnotatedEvidence)
+	assert.Equal(t,
+		*testAttestationResultsWithVeraisonExtns.Submods["test"].VeraisonPolicyClaims,
+		*actual.Submods["test"].VeraisonPolicyClaims)
+}
+
+func TestAttestationResult_VerifySD_fail(t *testing.T) {
+	sd, vfyK := signTestSDJWT(t)
+
+	parts := strings.Split(string(sd), sdSeparator)
+	jwt, disclosure := parts[0], parts[1]
+
+	foreign, err := newDisclosure("", "ear.raw-evidence", "AAAA")
+	require.NoError(t, err)
+
+	tvs := []struct {
+		sd       string
+		expected string
+	}{
+		{
+			sd:       jwt,
+			expected: "not an SD-JWT: missing disclosures separator",
+		},
+		{
+			sd:       jwt + "~" + disclosure + "~" + "kb",
+			expected: "key binding JWTs are not supported",
+		},
+		{
+			sd:       jwt + "~" + disclosure + "~" + disclosure + "~",
+			expected: `disclosure at index 1 ("` + mustDecodeDisclosure(t, disclosure).Name + `") presented more than once`,
+		},
+		{
+			sd:       jwt + "~" + foreign.encoded + "~",
+			expected: `disclosure at index 0 ("ear.raw-evidence") not referenced by the JWT`,
+		},
+		{
+			sd:       jwt + "~" + "WyJzYWx0IiwgIl9zZCIsIDFd" + "~",
+			expected: `disclosure at index 0: disclosure uses reserved claim name "_sd"`,
+		},
+		{
+			sd:       jwt + "~" + "WyJzYWx0Il0" + "~",
+			expected: "disclosure at index 0: disclosure must be a JSON array of 3 elements, found 1",
+		},
+	}
+
+	for i, tv := range tvs {
+		var actual AttestationResult
+		err := actual.VerifySD([]byte(tv.sd), jwa.ES256, vfyK)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func mustDecodeDisclosure(t *testing.T, encoded string) *Disclosure {
+	d, err := decodeDisclosure(encoded)
+	require.NoError(t, err)
+	return d
+}
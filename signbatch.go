@@ -0,0 +1,87 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// SignBatch is like Sign, but signs many results with the same algorithm,
+// key and options.  The raw key and the protected header are prepared once
+// and shared, and the results are signed concurrently, using up to GOMAXPROCS
+// workers.  On success, the returned tokens are in the same order as results.
+// If any result cannot be signed, no token is returned, and the error
+// identifies the (first) offending result by its index.
+func SignBatch(
+	results []*AttestationResult,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...SignOption,
+) ([][]byte, error) {
+	cfg := newSignConfig(opts)
+
+	headers := cfg.protectedHeaders()
+
+	// the key ID would otherwise be lost with the jwk.Key wrapper
+	if k, ok := key.(jwk.Key); ok && k.KeyID() != "" {
+		if _, ok := headers[jws.KeyIDKey]; !ok {
+			headers[jws.KeyIDKey] = k.KeyID()
+		}
+	}
+
+	raw, err := rawKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	custom, isCustom := lookupCustomAlgorithm(alg)
+
+	tokens := make([][]byte, len(results))
+	errs := make([]error, len(results))
+
+	runBatch(len(results), runtime.GOMAXPROCS(0), func(i int) {
+		ar := results[i]
+		if ar == nil {
+			errs[i] = errors.New("nil result")
+			return
+		}
+
+		claims, err := ar.claimsSet(cfg)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+
+		var token []byte
+
+		if isCustom {
+			token, err = signCustomJWT(claims, custom, raw, headers)
+		} else {
+			token, err = signClaimsSet(claims, alg, raw, headers)
+		}
+
+		if err != nil {
+			errs[i] = err
+			return
+		}
+
+		ar.auditSigned(AuditFormatJWT, token, cfg)
+
+		tokens[i] = token
+	})
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("result %d: %w", i, err)
+		}
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignBatch(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	require.NoError(t, sigK.Set(jwk.KeyIDKey, "batch-key"))
+
+	var results []*AttestationResult
+
+	for i := 0; i < 20; i++ {
+		ar := testAttestationResultsWithVeraisonExtns
+		iat := testIAT + int64(i)
+		ar.IssuedAt = &iat
+
+		results = append(results, &ar)
+	}
+
+	tokens, err := SignBatch(results, jwa.ES256, sigK, WithHeader("x-batch", true))
+	require.NoError(t, err)
+	require.Len(t, tokens, len(results))
+
+	for i, token := range tokens {
+		msg, err := jws.Parse(token)
+		require.NoError(t, err)
+
+		hdrs := msg.Signatures()[0].ProtectedHeaders()
+		assert.Equal(t, "batch-key", hdrs.KeyID())
+		v, ok := hdrs.Get("x-batch")
+		assert.True(t, ok)
+		assert.Equal(t, true, v)
+
+		var actual AttestationResult
+		require.NoError(t, actual.Verify(token, jwa.ES256, vfyK), "token %d", i)
+		assert.Equal(t, testIAT+int64(i), *actual.IssuedAt)
+	}
+}
+
+func TestSignBatch_fail(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	invalid := AttestationResult{}
+
+	_, err := SignBatch([]*AttestationResult{&ar, &invalid, &ar}, jwa.ES256, sigK)
+	assert.ErrorContains(t, err, "result 1: missing mandatory")
+
+	_, err = SignBatch([]*AttestationResult{&ar, nil}, jwa.ES256, sigK)
+	assert.EqualError(t, err, "result 1: nil result")
+
+	tokens, err := SignBatch(nil, jwa.ES256, sigK)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func BenchmarkSignBatch(b *testing.B) {
+	sigK := mustParseKey(b, testECDSAPrivateKey)
+
+	results := make([]*AttestationResult, 100)
+	for i := range results {
+		ar := testAttestationResultsWithVeraisonExtns
+		results[i] = &ar
+	}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if _, err := SignBatch(results, jwa.ES256, sigK); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
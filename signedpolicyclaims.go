@@ -0,0 +1,230 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/veraison/go-cose"
+)
+
+// Keys in "ear.veraison.policy-claims" that carry the policy claims signed by
+// the policy engine, so that they can be verified independently of the EAR
+const (
+	// SignedPolicyClaimsJWS is a compact JWS whose payload is the JSON
+	// policy claims
+	SignedPolicyClaimsJWS = "signed-jws"
+	// SignedPolicyClaimsCOSE is a base64url-encoded, tagged COSE_Sign1
+	// whose payload is the JSON policy claims
+	SignedPolicyClaimsCOSE = "signed-cose"
+)
+
+// policyClaimsContentType is the content type of the COSE_Sign1 payload
+const policyClaimsContentType = "application/json"
+
+// SetSignedPolicyClaims signs the supplied policy claims as a compact JWS
+// using the policy authority's key, and stores the JWS in the
+// "ear.veraison.policy-claims" claim (see SignedPolicyClaimsJWS).  Any signed
+// policy claims already present are replaced; other policy claims are
+// retained.  The same algorithms as Sign are supported.
+func (o *AppraisalExtensions) SetSignedPolicyClaims(
+	claims map[string]interface{},
+	alg jwa.KeyAlgorithm,
+	key interface{},
+) error {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return fmt.Errorf("serializing policy claims: %w", err)
+	}
+
+	signFn, err := newJWSSignFunc(alg, key)
+	if err != nil {
+		return err
+	}
+
+	j := jwsJSON{Payload: base64.RawURLEncoding.EncodeToString(payload)}
+
+	sig, err := j.sign(alg, key, signFn)
+	if err != nil {
+		return fmt.Errorf("signing policy claims: %w", err)
+	}
+
+	o.setSignedPolicyClaims(SignedPolicyClaimsJWS, sig.Protected+"."+j.Payload+"."+sig.Signature)
+
+	return nil
+}
+
+// SetCOSESignedPolicyClaims is like SetSignedPolicyClaims, but the policy
+// claims are signed as a COSE_Sign1 message (see SignedPolicyClaimsCOSE).
+// The same algorithms as SignCWT are supported.
+func (o *AppraisalExtensions) SetCOSESignedPolicyClaims(
+	claims map[string]interface{},
+	alg jwa.KeyAlgorithm,
+	key interface{},
+) error {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return fmt.Errorf("serializing policy claims: %w", err)
+	}
+
+	signer, err := newCOSESigner(alg, key)
+	if err != nil {
+		return err
+	}
+
+	headers := cose.Headers{
+		Protected: cose.ProtectedHeader{
+			cose.HeaderLabelAlgorithm:   signer.Algorithm(),
+			cose.HeaderLabelContentType: policyClaimsContentType,
+		},
+	}
+
+	msg, err := cose.Sign1(rand.Reader, signer, headers, payload, nil)
+	if err != nil {
+		return fmt.Errorf("signing policy claims: %w", err)
+	}
+
+	o.setSignedPolicyClaims(SignedPolicyClaimsCOSE, base64.RawURLEncoding.EncodeToString(msg))
+
+	return nil
+}
+
+// setSignedPolicyClaims stores the supplied signed object under key, removing
+// any other signed policy claims
+func (o *AppraisalExtensions) setSignedPolicyClaims(key, signed string) {
+	if o.VeraisonPolicyClaims == nil {
+		o.VeraisonPolicyClaims = &map[string]interface{}{}
+	}
+
+	pc := *o.VeraisonPolicyClaims
+
+	delete(pc, SignedPolicyClaimsJWS)
+	delete(pc, SignedPolicyClaimsCOSE)
+
+	pc[key] = signed
+}
+
+// HasSignedPolicyClaims reports whether the "ear.veraison.policy-claims"
+// claim carries signed policy claims
+func (o AppraisalExtensions) HasSignedPolicyClaims() bool {
+	if o.VeraisonPolicyClaims == nil {
+		return false
+	}
+
+	pc := *o.VeraisonPolicyClaims
+
+	_, hasJWS := pc[SignedPolicyClaimsJWS]
+	_, hasCOSE := pc[SignedPolicyClaimsCOSE]
+
+	return hasJWS || hasCOSE
+}
+
+// VerifySignedPolicyClaims verifies the signed policy claims carried in the
+// "ear.veraison.policy-claims" claim (either as a JWS or as a COSE_Sign1)
+// against the policy authority's key, and returns the decoded policy claims.
+// Unsigned policy claims alongside the signed ones are ignored.
+func (o AppraisalExtensions) VerifySignedPolicyClaims(
+	alg jwa.KeyAlgorithm,
+	key interface{},
+) (map[string]interface{}, error) {
+	if o.VeraisonPolicyClaims == nil {
+		return nil, errors.New(`"ear.veraison.policy-claims" claim not found`)
+	}
+
+	pc := *o.VeraisonPolicyClaims
+
+	if v, ok := pc[SignedPolicyClaimsJWS]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%q: expecting string, got %T", SignedPolicyClaimsJWS, v)
+		}
+
+		claims, err := verifyPolicyClaimsJWS(s, alg, key)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", SignedPolicyClaimsJWS, err)
+		}
+
+		return claims, nil
+	}
+
+	if v, ok := pc[SignedPolicyClaimsCOSE]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%q: expecting string, got %T", SignedPolicyClaimsCOSE, v)
+		}
+
+		claims, err := verifyPolicyClaimsCOSE(s, alg, key)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", SignedPolicyClaimsCOSE, err)
+		}
+
+		return claims, nil
+	}
+
+	return nil, errors.New("no signed policy claims found")
+}
+
+func verifyPolicyClaimsJWS(
+	s string,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+) (map[string]interface{}, error) {
+	j, err := parseJWSAsJSON([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+
+	verifyFn, err := newJWSVerifyFunc(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !j.verifiedBy(j.Signatures, alg, verifyFn) {
+		return nil, errors.New("signature verification failed")
+	}
+
+	claims, err := j.payloadClaims()
+	if err != nil {
+		return nil, fmt.Errorf("decoding payload: %w", err)
+	}
+
+	return claims, nil
+}
+
+func verifyPolicyClaimsCOSE(
+	s string,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+) (map[string]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decoding base64url: %w", err)
+	}
+
+	verifier, err := newCOSEVerifier(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg cose.Sign1Message
+	if err := msg.UnmarshalCBOR(data); err != nil {
+		return nil, fmt.Errorf("parsing COSE_Sign1: %w", err)
+	}
+
+	if err := msg.Verify(nil, verifier); err != nil {
+		return nil, fmt.Errorf("signature verification failed: %w", err)
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(msg.Payload, &claims); err != nil {
+		return nil, fmt.Errorf("decoding payload: %w", err)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicyClaims = map[string]interface{}{
+	"rule": "secure-boot-enabled",
+	"pass": true,
+}
+
+func TestSignedPolicyClaims_round_trip(t *testing.T) {
+	policyK := mustParseKey(t, testHolderPrivateKey)
+	policyPub, err := jwk.PublicKeyOf(policyK)
+	require.NoError(t, err)
+
+	tvs := []struct {
+		set func(*AppraisalExtensions) error
+		key string
+	}{
+		{
+			func(e *AppraisalExtensions) error {
+				return e.SetSignedPolicyClaims(testPolicyClaims, jwa.ES256, policyK)
+			},
+			SignedPolicyClaimsJWS,
+		},
+		{
+			func(e *AppraisalExtensions) error {
+				return e.SetCOSESignedPolicyClaims(testPolicyClaims, jwa.ES256, policyK)
+			},
+			SignedPolicyClaimsCOSE,
+		},
+	}
+
+	for i, tv := range tvs {
+		ar := testStreamResult("a", TrustTierAffirming)
+		a := ar.Submods["platform"]
+
+		a.VeraisonPolicyClaims = &map[string]interface{}{"unsigned": "claim"}
+		require.NoError(t, tv.set(&a.AppraisalExtensions), "failed test vector at index %d", i)
+		assert.True(t, a.HasSignedPolicyClaims(), "failed test vector at index %d", i)
+		assert.Contains(t, *a.VeraisonPolicyClaims, tv.key, "failed test vector at index %d", i)
+		assert.Contains(t, *a.VeraisonPolicyClaims, "unsigned", "failed test vector at index %d", i)
+
+		// the signed policy claims survive the EAR round-trip
+		token, err := ar.Sign(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+		require.NoError(t, err)
+
+		var actual AttestationResult
+		require.NoError(t, actual.Verify(token, jwa.ES256, mustParseKey(t, testECDSAPublicKey)))
+
+		claims, err := actual.Submods["platform"].VerifySignedPolicyClaims(jwa.ES256, policyPub)
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, testPolicyClaims, claims, "failed test vector at index %d", i)
+
+		// ...and cannot be verified with a different key
+		_, err = actual.Submods["platform"].VerifySignedPolicyClaims(jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+		assert.ErrorContains(t, err, "signature verification failed", "failed test vector at index %d", i)
+	}
+}
+
+func TestSignedPolicyClaims_replace(t *testing.T) {
+	policyK := mustParseKey(t, testHolderPrivateKey)
+
+	var e AppraisalExtensions
+
+	require.NoError(t, e.SetSignedPolicyClaims(testPolicyClaims, jwa.ES256, policyK))
+	require.NoError(t, e.SetCOSESignedPolicyClaims(testPolicyClaims, jwa.ES256, policyK))
+
+	assert.NotContains(t, *e.VeraisonPolicyClaims, SignedPolicyClaimsJWS)
+	assert.Contains(t, *e.VeraisonPolicyClaims, SignedPolicyClaimsCOSE)
+}
+
+func TestVerifySignedPolicyClaims_fail(t *testing.T) {
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	tvs := []struct {
+		claims   *map[string]interface{}
+		expected string
+	}{
+		{nil, `"ear.veraison.policy-claims" claim not found`},
+		{&map[string]interface{}{"rule": "x"}, "no signed policy claims found"},
+		{&map[string]interface{}{SignedPolicyClaimsJWS: 1}, `"signed-jws": expecting string, got int`},
+		{&map[string]interface{}{SignedPolicyClaimsJWS: "a.b"}, `"signed-jws": malformed compact JWS: expecting 3 segments`},
+		{&map[string]interface{}{SignedPolicyClaimsCOSE: "!"}, `"signed-cose": decoding base64url: illegal base64 data at input byte 0`},
+		{&map[string]interface{}{SignedPolicyClaimsCOSE: "oA"}, `"signed-cose": parsing COSE_Sign1: `},
+	}
+
+	for i, tv := range tvs {
+		e := AppraisalExtensions{VeraisonPolicyClaims: tv.claims}
+		_, err := e.VerifySignedPolicyClaims(jwa.ES256, vfyK)
+		assert.ErrorContains(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+
+	assert.False(t, AppraisalExtensions{}.HasSignedPolicyClaims())
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	cose "github.com/veraison/go-cose"
+)
+
+// EARSigner is a pluggable signing backend (e.g., a cloud KMS or a vault),
+// used by SignWith.  Sign wraps the JSON-encoded claims-set claims in a
+// signed token, whose format is up to the signer.  Algorithm returns the
+// identifier of the signing algorithm (e.g., "ES256").  NewJWTSigner and
+// NewCWTSigner adapt the built-in JWT and CWT signing to this interface.
+type EARSigner interface {
+	Sign(claims []byte) (token []byte, err error)
+	Algorithm() string
+}
+
+// SignWith is like Sign, but the claims-set is handed, JSON-encoded, to the
+// supplied EARSigner for wrapping in a signed token.  Options affecting the
+// protected header (WithKeyID, WithHeader and WithCertChain) have no effect,
+// since the header is up to the signer: they can be supplied to NewJWTSigner
+// and NewCWTSigner instead.
+func (o AttestationResult) SignWith(signer EARSigner, opts ...SignOption) ([]byte, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+
+	cfg := newSignConfig(opts)
+
+	claims, err := o.claimsSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(numericDates(claims))
+	if err != nil {
+		return nil, fmt.Errorf("serializing claims-set: %w", err)
+	}
+
+	token, err := signer.Sign(data)
+	if err != nil {
+		return nil, fmt.Errorf("signing with %s signer: %w", signer.Algorithm(), err)
+	}
+
+	o.auditSigned(tokenFormat(token), token, cfg)
+
+	return token, nil
+}
+
+// tokenFormat guesses the format of a token produced by an EARSigner: CWTs
+// start with a CBOR tag, whereas JWTs are text
+func tokenFormat(token []byte) string {
+	// CBOR major type 6 (tag)
+	if len(token) > 0 && token[0]>>5 == 6 {
+		return AuditFormatCWT
+	}
+
+	return AuditFormatJWT
+}
+
+type jwtSigner struct {
+	alg jwa.KeyAlgorithm
+	key interface{}
+	cfg *signConfig
+}
+
+// NewJWTSigner returns an EARSigner that wraps the claims-set in a JWT signed
+// using the supplied algorithm and key, exactly as Sign does.  Only the
+// options affecting the protected header (WithKeyID, WithHeader and
+// WithCertChain) are honoured; the others must be supplied to SignWith.
+func NewJWTSigner(alg jwa.KeyAlgorithm, key interface{}, opts ...SignOption) (EARSigner, error) {
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+
+	if _, ok := lookupCustomAlgorithm(alg); !ok {
+		var sa jwa.SignatureAlgorithm
+		if err := sa.Accept(alg.String()); err != nil || sa == jwa.NoSignature {
+			return nil, fmt.Errorf("unsupported signing algorithm %q", alg)
+		}
+	}
+
+	return jwtSigner{alg: alg, key: key, cfg: newSignConfig(opts)}, nil
+}
+
+func (o jwtSigner) Algorithm() string {
+	return o.alg.String()
+}
+
+func (o jwtSigner) Sign(claims []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(claims))
+	dec.UseNumber()
+
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("decoding claims-set: %w", err)
+	}
+
+	if a, ok := lookupCustomAlgorithm(o.alg); ok {
+		return signCustomJWT(m, a, o.key, o.cfg.protectedHeaders())
+	}
+
+	return signClaimsSet(m, o.alg, o.key, o.cfg.protectedHeaders())
+}
+
+type cwtSigner struct {
+	alg    jwa.KeyAlgorithm
+	signer cose.Signer
+	key    interface{}
+	cfg    *signConfig
+}
+
+// NewCWTSigner returns an EARSigner that wraps the claims-set in a CWT signed
+// using the supplied algorithm and key, exactly as SignCWT does.  Only the
+// options affecting the protected header (WithKeyID and WithCertChain) are
+// honoured; the others must be supplied to SignWith.
+func NewCWTSigner(alg jwa.KeyAlgorithm, key interface{}, opts ...SignOption) (EARSigner, error) {
+	signer, err := newCOSESigner(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cwtSigner{alg: alg, signer: signer, key: key, cfg: newSignConfig(opts)}, nil
+}
+
+func (o cwtSigner) Algorithm() string {
+	return o.alg.String()
+}
+
+func (o cwtSigner) Sign(claims []byte) ([]byte, error) {
+	payload, err := jsonClaimsToCBOR(claims)
+	if err != nil {
+		return nil, fmt.Errorf("encoding claims-set: %w", err)
+	}
+
+	return signCWTPayload(payload, o.signer, o.key, o.cfg)
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKMSSigner stands for a remote signing backend, which records the
+// claims-sets it is asked to sign
+type testKMSSigner struct {
+	EARSigner
+	claims [][]byte
+	err    error
+}
+
+func (o *testKMSSigner) Sign(claims []byte) ([]byte, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	o.claims = append(o.claims, claims)
+
+	return o.EARSigner.Sign(claims)
+}
+
+func TestSignWith_JWT(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	signer, err := NewJWTSigner(jwa.ES256, sigK, WithKeyID("kms-key-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "ES256", signer.Algorithm())
+
+	now := time.Unix(1700000000, 0)
+
+	ar := testAttestationResultsWithVeraisonExtns
+	token, err := ar.SignWith(signer, WithClock(FixedClock(now)), WithIssuedAtNow())
+	require.NoError(t, err)
+
+	msg, err := jws.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, "kms-key-1", msg.Signatures()[0].ProtectedHeaders().KeyID())
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK, WithClock(FixedClock(now))))
+	assert.Equal(t, now.Unix(), *actual.IssuedAt)
+	assert.Equal(t, ar.Submods, actual.Submods)
+}
+
+func TestSignWith_CWT(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	signer, err := NewCWTSigner(jwa.ES256, sigK)
+	require.NoError(t, err)
+	assert.Equal(t, "ES256", signer.Algorithm())
+
+	sink := &testAuditSink{}
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignWith(signer, WithAuditSink(sink))
+	require.NoError(t, err)
+	require.Len(t, sink.signed, 1)
+	assert.Equal(t, AuditFormatCWT, sink.signed[0].Format)
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifyCWT(token, jwa.ES256, vfyK))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, actual.Submods)
+}
+
+func TestSignWith_custom_signer(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	jwtSigner, err := NewJWTSigner(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	signer := &testKMSSigner{EARSigner: jwtSigner}
+
+	token, err := testAttestationResultsWithVeraisonExtns.SignWith(signer)
+	require.NoError(t, err)
+	require.Len(t, signer.claims, 1)
+
+	var signed AttestationResult
+	require.NoError(t, signed.UnmarshalJSON(signer.claims[0]))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, signed.Submods)
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK))
+
+	signer.err = errors.New("KMS unavailable")
+
+	_, err = testAttestationResultsWithVeraisonExtns.SignWith(signer)
+	assert.EqualError(t, err, "signing with ES256 signer: KMS unavailable")
+}
+
+func TestSignWith_fail(t *testing.T) {
+	_, err := testAttestationResultsWithVeraisonExtns.SignWith(nil)
+	assert.EqualError(t, err, "nil signer")
+
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	signer, err := NewJWTSigner(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	_, err = AttestationResult{}.SignWith(signer)
+	assert.ErrorContains(t, err, "missing mandatory")
+
+	_, err = NewJWTSigner(jwa.ES256, nil)
+	assert.EqualError(t, err, "nil key")
+
+	_, err = NewJWTSigner(jwa.RSA_OAEP, sigK)
+	assert.EqualError(t, err, `unsupported signing algorithm "RSA-OAEP"`)
+
+	_, err = NewJWTSigner(jwa.NoSignature, sigK)
+	assert.EqualError(t, err, `unsupported signing algorithm "none"`)
+
+	_, err = NewCWTSigner(jwa.HS256, sigK)
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StatusOverride records that a policy has overridden the appraisal status
+// that would otherwise be derived from the trustworthiness vector, together
+// with when and why.  Entries are accumulated in the
+// "ear.veraison.status-overrides" claim by OverrideStatus.  Unlike the
+// downgrades recorded in "ear.veraison.status-reasons", an override can also
+// raise the status.
+type StatusOverride struct {
+	// From is the status before the override
+	From *TrustTier `json:"from"`
+	// To is the status set by the override
+	To *TrustTier `json:"to"`
+	// Reason is a machine-readable explanation of the override
+	Reason *string `json:"reason"`
+	// Time is when the override was made (seconds since the epoch)
+	Time *int64 `json:"time"`
+}
+
+func (o StatusOverride) validate() error {
+	if o.From == nil || o.To == nil || o.Reason == nil || o.Time == nil {
+		return errors.New("missing mandatory 'from', 'to', 'reason' or 'time'")
+	}
+
+	if *o.Reason == "" {
+		return errors.New("empty 'reason'")
+	}
+
+	return nil
+}
+
+// ToStatusOverrides parses the value of the "ear.veraison.status-overrides"
+// claim
+func ToStatusOverrides(v interface{}) (*[]StatusOverride, error) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON array")
+	}
+
+	ret := make([]StatusOverride, 0, len(l))
+
+	parsers := map[string]parser{
+		"from": func(v interface{}) (interface{}, error) {
+			return ToTrustTier(v)
+		},
+		"to": func(v interface{}) (interface{}, error) {
+			return ToTrustTier(v)
+		},
+		"time": int64PtrParser,
+	}
+
+	for i, e := range l {
+		var override StatusOverride
+
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry %d: not a JSON object", i)
+		}
+
+		if err := populateStructFromMap(&override, m, "json", parsers, stringPtrParser, false); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		if err := override.validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		ret = append(ret, override)
+	}
+
+	return &ret, nil
+}
+
+// OverrideStatus sets the appraisal status to the specified tier on behalf of
+// a policy, and records the override, stamped with the current time, in the
+// "ear.veraison.status-overrides" claim.  From then on,
+// UpdateStatusFromTrustVector leaves the overridden status alone.  An error is
+// returned if the tier is not valid or the reason is empty.
+func (o *Appraisal) OverrideStatus(to TrustTier, reason string) error {
+	if _, ok := TrustTierToString[to]; !ok {
+		return fmt.Errorf("not a valid TrustTier value: %d", to)
+	}
+
+	if reason == "" {
+		return errors.New("empty reason")
+	}
+
+	from := TrustTierNone
+	if o.Status != nil {
+		from = *o.Status
+	}
+
+	status := to
+	o.Status = &status
+
+	now := systemClock.Now().Unix()
+
+	if o.VeraisonStatusOverrides == nil {
+		o.VeraisonStatusOverrides = &[]StatusOverride{}
+	}
+
+	*o.VeraisonStatusOverrides = append(*o.VeraisonStatusOverrides, StatusOverride{
+		From:   &from,
+		To:     &to,
+		Reason: &reason,
+		Time:   &now,
+	})
+
+	return nil
+}
+
+// GetStatusOverrides returns the entries in the "ear.veraison.status-overrides"
+// claim
+func (o AppraisalExtensions) GetStatusOverrides() ([]StatusOverride, error) {
+	if o.VeraisonStatusOverrides == nil {
+		return nil, errors.New(`"ear.veraison.status-overrides" claim not found`)
+	}
+
+	return *o.VeraisonStatusOverrides, nil
+}
+
+// IsStatusOverridden reports whether the appraisal status has been set by a
+// policy override (see OverrideStatus), rather than concluded by the verifier
+func (o AppraisalExtensions) IsStatusOverridden() bool {
+	return o.VeraisonStatusOverrides != nil && len(*o.VeraisonStatusOverrides) != 0
+}
+
+// overriddenStatus returns the status set by the last recorded override, if
+// any
+func (o AppraisalExtensions) overriddenStatus() (TrustTier, bool) {
+	if !o.IsStatusOverridden() {
+		return TrustTierNone, false
+	}
+
+	last := (*o.VeraisonStatusOverrides)[len(*o.VeraisonStatusOverrides)-1]
+	if last.To == nil {
+		return TrustTierNone, false
+	}
+
+	return *last.To, true
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppraisal_OverrideStatus_ok(t *testing.T) {
+	status := TrustTierContraindicated
+	appraisal := Appraisal{
+		Status:      &status,
+		TrustVector: &TrustVector{},
+	}
+
+	assert.False(t, appraisal.IsStatusOverridden())
+	_, err := appraisal.GetStatusOverrides()
+	assert.EqualError(t, err, `"ear.veraison.status-overrides" claim not found`)
+
+	// an override can raise the status
+	err = appraisal.OverrideStatus(TrustTierWarning, "known-issue-waiver")
+	require.NoError(t, err)
+	assert.Equal(t, TrustTierWarning, *appraisal.Status)
+	assert.True(t, appraisal.IsStatusOverridden())
+
+	overrides, err := appraisal.GetStatusOverrides()
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, TrustTierContraindicated, *overrides[0].From)
+	assert.Equal(t, TrustTierWarning, *overrides[0].To)
+	assert.Equal(t, "known-issue-waiver", *overrides[0].Reason)
+	assert.NotNil(t, overrides[0].Time)
+
+	// UpdateStatusFromTrustVector leaves the overridden status alone
+	appraisal.TrustVector.Executables = UnrecognizedRuntimeClaim
+	appraisal.UpdateStatusFromTrustVector()
+	assert.Equal(t, TrustTierWarning, *appraisal.Status)
+}
+
+func TestAppraisal_OverrideStatus_fail(t *testing.T) {
+	var appraisal Appraisal
+
+	err := appraisal.OverrideStatus(TrustTier(42), "reason")
+	assert.EqualError(t, err, "not a valid TrustTier value: 42")
+
+	err = appraisal.OverrideStatus(TrustTierWarning, "")
+	assert.EqualError(t, err, "empty reason")
+
+	assert.Nil(t, appraisal.Status)
+	assert.Nil(t, appraisal.VeraisonStatusOverrides)
+}
+
+func TestStatusOverrides_round_trip(t *testing.T) {
+	status := TrustTierAffirming
+	appraisal := Appraisal{Status: &status}
+
+	require.NoError(t, appraisal.OverrideStatus(TrustTierContraindicated, "tenant-deny-list"))
+	(*appraisal.VeraisonStatusOverrides)[0].Time = new(int64)
+
+	data, err := json.Marshal(appraisal.AsMap())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ear.status": "contraindicated",
+		"ear.veraison.status-overrides": [
+			{
+				"from": "affirming",
+				"to": "contraindicated",
+				"reason": "tenant-deny-list",
+				"time": 0
+			}
+		]
+	}`, string(data))
+
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	actual, err := ToAppraisal(m)
+	require.NoError(t, err)
+	assert.Equal(t, appraisal, *actual)
+}
+
+func TestToStatusOverrides_fail(t *testing.T) {
+	_, err := ToStatusOverrides("not a list")
+	assert.EqualError(t, err, "not a JSON array")
+
+	_, err = ToStatusOverrides([]interface{}{"not an object"})
+	assert.EqualError(t, err, "entry 0: not a JSON object")
+
+	_, err = ToStatusOverrides([]interface{}{
+		map[string]interface{}{"from": "affirming", "to": "warning"},
+	})
+	assert.EqualError(t, err, "entry 0: missing mandatory 'reason', 'time'")
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// StreamFormat is the framing of a stream of signed EARs
+type StreamFormat int
+
+const (
+	// StreamFormatJSONLines is a sequence of JWTs, one per line
+	StreamFormatJSONLines StreamFormat = iota
+	// StreamFormatCBORSequence is a CBOR sequence (RFC 8742) of CWTs
+	StreamFormatCBORSequence
+)
+
+func (o StreamFormat) String() string {
+	switch o {
+	case StreamFormatJSONLines:
+		return "JSON Lines"
+	case StreamFormatCBORSequence:
+		return "CBOR sequence"
+	default:
+		return fmt.Sprintf("StreamFormat(%d)", o)
+	}
+}
+
+// maxStreamLine is the maximum size of a line in a JSON Lines stream
+const maxStreamLine = 1 << 20
+
+// TierTransition reports a change in the status of one of the submods of an
+// attester, as observed in a stream of attestation results
+type TierTransition struct {
+	// Attester is the identity of the attester (see ResultStream.AttesterID)
+	Attester string
+	// Submod is the name of the submod whose status has changed
+	Submod string
+	// From is the previous status, or nil if the submod has not been seen
+	// before
+	From *TrustTier
+	// To is the new status
+	To TrustTier
+	// Result is the attestation result carrying the new status
+	Result *AttestationResult
+}
+
+// ResultStream consumes a stream of signed EARs, such as the feed of results
+// produced by periodic re-appraisals of a fleet of attesters.  Each EAR is
+// verified, the latest result of each attester is retained, and any change in
+// the status of its submods is surfaced via OnTransition.
+type ResultStream struct {
+	// Format is the framing of the stream
+	Format StreamFormat
+	// Alg and Key are used to verify each EAR
+	Alg jwa.KeyAlgorithm
+	Key interface{}
+	// Options are applied when verifying each EAR
+	Options []VerifyOption
+	// AttesterID returns the identity of the attester a result is about.  If
+	// nil, DefaultAttesterID is used.
+	AttesterID func(ar *AttestationResult) string
+	// OnResult, if set, is called for each verified result
+	OnResult func(attester string, ar *AttestationResult)
+	// OnTransition, if set, is called for each change in the status of a
+	// submod, in submod name order
+	OnTransition func(t TierTransition)
+	// OnError, if set, is called when an EAR in the stream cannot be decoded
+	// or verified.  index is the position of the EAR in the stream.  If
+	// OnError returns nil, the EAR is skipped and processing continues;
+	// otherwise, Run stops and returns the error.  If OnError is not set, Run
+	// stops at the first error.
+	OnError func(index int, err error) error
+
+	mu     sync.RWMutex
+	latest map[string]*AttestationResult
+}
+
+// DefaultAttesterID identifies the attester by the "ueid" of its submods, if
+// any, or else by the names of its submods
+func DefaultAttesterID(ar *AttestationResult) string {
+	names := ar.submodNames()
+
+	for _, name := range names {
+		if a := ar.Submods[name]; a != nil && a.UEID != nil {
+			return "ueid:" + base64.RawURLEncoding.EncodeToString(*a.UEID)
+		}
+	}
+
+	return "submods:" + strings.Join(names, ",")
+}
+
+// Run reads and processes the signed EARs in r until EOF, which is not
+// reported as an error
+func (o *ResultStream) Run(r io.Reader) error {
+	next, err := o.reader(r)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; ; i++ {
+		token, err := next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err == nil {
+			err = o.process(token)
+		}
+
+		if err != nil {
+			if o.OnError == nil {
+				return fmt.Errorf("stream[%d]: %w", i, err)
+			}
+
+			if err := o.OnError(i, err); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// reader returns a function that returns the next signed EAR in r
+func (o *ResultStream) reader(r io.Reader) (func() ([]byte, error), error) {
+	switch o.Format {
+	case StreamFormatJSONLines:
+		scanner := newJSONLScanner(r)
+
+		return func() ([]byte, error) {
+			for scanner.Scan() {
+				line := bytes.TrimSpace(scanner.Bytes())
+				if len(line) == 0 {
+					continue
+				}
+				return append([]byte(nil), line...), nil
+			}
+
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+
+			return nil, io.EOF
+		}, nil
+	case StreamFormatCBORSequence:
+		return NewCBORSeqDecoder(r).Next, nil
+	default:
+		return nil, fmt.Errorf("unsupported stream format %s", o.Format)
+	}
+}
+
+func (o *ResultStream) process(token []byte) error {
+	var ar AttestationResult
+
+	switch o.Format {
+	case StreamFormatCBORSequence:
+		if err := ar.VerifyCWT(token, o.Alg, o.Key, o.Options...); err != nil {
+			return err
+		}
+	default:
+		if err := ar.Verify(token, o.Alg, o.Key, o.Options...); err != nil {
+			return err
+		}
+	}
+
+	attesterID := o.AttesterID
+	if attesterID == nil {
+		attesterID = DefaultAttesterID
+	}
+
+	attester := attesterID(&ar)
+	if attester == "" {
+		return errors.New("empty attester identity")
+	}
+
+	o.mu.Lock()
+	if o.latest == nil {
+		o.latest = map[string]*AttestationResult{}
+	}
+	previous := o.latest[attester]
+	o.latest[attester] = &ar
+	o.mu.Unlock()
+
+	if o.OnResult != nil {
+		o.OnResult(attester, &ar)
+	}
+
+	if o.OnTransition != nil {
+		for _, t := range tierTransitions(attester, previous, &ar) {
+			o.OnTransition(t)
+		}
+	}
+
+	return nil
+}
+
+func tierTransitions(attester string, previous, current *AttestationResult) []TierTransition {
+	var ret []TierTransition
+
+	for _, name := range current.submodNames() {
+		a := current.Submods[name]
+		if a == nil || a.Status == nil {
+			continue
+		}
+
+		t := TierTransition{
+			Attester: attester,
+			Submod:   name,
+			To:       *a.Status,
+			Result:   current,
+		}
+
+		if previous != nil {
+			if p := previous.Submods[name]; p != nil && p.Status != nil {
+				if *p.Status == *a.Status {
+					continue
+				}
+				from := *p.Status
+				t.From = &from
+			}
+		}
+
+		ret = append(ret, t)
+	}
+
+	return ret
+}
+
+// Latest returns the latest verified result of the supplied attester
+func (o *ResultStream) Latest(attester string) (*AttestationResult, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	ar, ok := o.latest[attester]
+
+	return ar, ok
+}
+
+// Attesters returns the (sorted) identities of the attesters seen so far
+func (o *ResultStream) Attesters() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	ret := make([]string, 0, len(o.latest))
+	for a := range o.latest {
+		ret = append(ret, a)
+	}
+
+	sort.Strings(ret)
+
+	return ret
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStreamResult returns an attestation result for the attester with an
+// EUI-48 UEID made of the repeated supplied character
+func testStreamResult(c string, status TrustTier) AttestationResult {
+	ar := *NewAttestationResult("platform", testVidBuild, testVidDeveloper)
+	id := B64Url(append([]byte{UEIDTypeEUI}, strings.Repeat(c, 6)...))
+	ar.Submods["platform"].UEID = &id
+	ar.Submods["platform"].Status = &status
+	return ar
+}
+
+func TestResultStream_JSONLines(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	var stream bytes.Buffer
+
+	for _, ar := range []AttestationResult{
+		testStreamResult("a", TrustTierAffirming),
+		testStreamResult("b", TrustTierWarning),
+		testStreamResult("a", TrustTierAffirming),
+		testStreamResult("a", TrustTierContraindicated),
+	} {
+		token, err := ar.Sign(jwa.ES256, sigK)
+		require.NoError(t, err)
+		stream.Write(token)
+		stream.WriteString("\n\n")
+	}
+
+	var (
+		transitions []TierTransition
+		results     int
+	)
+
+	rs := ResultStream{
+		Alg: jwa.ES256,
+		Key: mustParseKey(t, testECDSAPublicKey),
+		OnResult: func(string, *AttestationResult) {
+			results++
+		},
+		OnTransition: func(tt TierTransition) {
+			transitions = append(transitions, tt)
+		},
+	}
+
+	require.NoError(t, rs.Run(&stream))
+	assert.Equal(t, 4, results)
+	assert.Equal(t, []string{"ueid:AmFhYWFhYQ", "ueid:AmJiYmJiYg"}, rs.Attesters())
+
+	require.Len(t, transitions, 3)
+	assert.Equal(t, "ueid:AmFhYWFhYQ", transitions[0].Attester)
+	assert.Nil(t, transitions[0].From)
+	assert.Equal(t, TrustTierAffirming, transitions[0].To)
+	assert.Equal(t, "ueid:AmJiYmJiYg", transitions[1].Attester)
+	assert.Equal(t, "ueid:AmFhYWFhYQ", transitions[2].Attester)
+	assert.Equal(t, "platform", transitions[2].Submod)
+	assert.Equal(t, TrustTierAffirming, *transitions[2].From)
+	assert.Equal(t, TrustTierContraindicated, transitions[2].To)
+
+	latest, ok := rs.Latest("ueid:AmFhYWFhYQ")
+	require.True(t, ok)
+	assert.Equal(t, TrustTierContraindicated, *latest.Submods["platform"].Status)
+
+	_, ok = rs.Latest("ueid:AmNjY2NjYw")
+	assert.False(t, ok)
+}
+
+func TestResultStream_CBORSequence(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	var stream bytes.Buffer
+
+	for _, status := range []TrustTier{TrustTierAffirming, TrustTierWarning} {
+		ar := testStreamResult("a", status)
+		token, err := ar.SignCWT(jwa.ES256, sigK)
+		require.NoError(t, err)
+		stream.Write(token)
+	}
+
+	var transitions []TierTransition
+
+	rs := ResultStream{
+		Format: StreamFormatCBORSequence,
+		Alg:    jwa.ES256,
+		Key:    mustParseKey(t, testECDSAPublicKey),
+		OnTransition: func(tt TierTransition) {
+			transitions = append(transitions, tt)
+		},
+	}
+
+	require.NoError(t, rs.Run(&stream))
+	require.Len(t, transitions, 2)
+	assert.Equal(t, TrustTierWarning, transitions[1].To)
+}
+
+func TestResultStream_errors(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+
+	ar := testStreamResult("a", TrustTierAffirming)
+	token, err := ar.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	input := "garbage\n" + string(token) + "\n"
+
+	// stop at the first error
+	rs := ResultStream{Alg: jwa.ES256, Key: mustParseKey(t, testECDSAPublicKey)}
+	err = rs.Run(strings.NewReader(input))
+	assert.ErrorContains(t, err, "stream[0]: failed verifying JWT message: ")
+	assert.Empty(t, rs.Attesters())
+
+	// skip bad EARs
+	var skipped []int
+	rs = ResultStream{
+		Alg: jwa.ES256,
+		Key: mustParseKey(t, testECDSAPublicKey),
+		OnError: func(i int, err error) error {
+			skipped = append(skipped, i)
+			return nil
+		},
+	}
+	require.NoError(t, rs.Run(strings.NewReader(input)))
+	assert.Equal(t, []int{0}, skipped)
+	assert.Equal(t, []string{"ueid:AmFhYWFhYQ"}, rs.Attesters())
+
+	// abort from the callback
+	rs.OnError = func(int, error) error { return errors.New("abort") }
+	assert.EqualError(t, rs.Run(strings.NewReader(input)), "abort")
+
+	rs = ResultStream{Format: StreamFormat(7)}
+	assert.EqualError(t, rs.Run(strings.NewReader(input)), "unsupported stream format StreamFormat(7)")
+}
+
+func TestDefaultAttesterID(t *testing.T) {
+	ar := testStreamResult("a", TrustTierAffirming)
+	assert.Equal(t, "ueid:AmFhYWFhYQ", DefaultAttesterID(&ar))
+
+	assert.Equal(t, "submods:test", DefaultAttesterID(&testAttestationResultsWithVeraisonExtns))
+}
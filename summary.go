@@ -0,0 +1,111 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// trustVectorCategories lists the trust vector categories, in report order,
+// together with their human-readable names and claim descriptions
+var trustVectorCategories = []struct {
+	name    string
+	claim   func(TrustVector) TrustClaim
+	details detailsMap
+}{
+	{"instance identity", func(tv TrustVector) TrustClaim { return tv.InstanceIdentity }, instanceIdentityDetails},
+	{"configuration", func(tv TrustVector) TrustClaim { return tv.Configuration }, configurationDetails},
+	{"executables", func(tv TrustVector) TrustClaim { return tv.Executables }, executablesDetails},
+	{"file system", func(tv TrustVector) TrustClaim { return tv.FileSystem }, fileSystemDetails},
+	{"hardware", func(tv TrustVector) TrustClaim { return tv.Hardware }, hardwareDetails},
+	{"runtime opaque", func(tv TrustVector) TrustClaim { return tv.RuntimeOpaque }, runtimeOpaqueDetails},
+	{"storage opaque", func(tv TrustVector) TrustClaim { return tv.StorageOpaque }, storageOpaqueDetails},
+	{"sourced data", func(tv TrustVector) TrustClaim { return tv.SourcedData }, sourcedDataDetails},
+}
+
+// Summary returns a concise, one-line natural-language summary of the
+// AttestationResult, suitable for alerts and ticketing integrations, e.g.:
+//
+//	Submod 'cpu' is warning; configuration: known vulnerabilities (warning); issued by Acme Inc. verifier build rrtrap-v1.0.0 at 2022-10-18T11:09:33Z
+//
+// For each submod (in name order), the status is followed by the trust
+// vector claims that are not affirming, described using the same catalog as
+// TrustVector.Report, and by the reasons in "ear.veraison.reasons", if any.
+// The result is not validated: missing claims are simply left out of the
+// summary.
+func (o AttestationResult) Summary() string {
+	var parts []string
+
+	for _, name := range o.submodNames() {
+		parts = append(parts, o.Submods[name].summary(name)...)
+	}
+
+	if issuer := o.issuerSummary(); issuer != "" {
+		parts = append(parts, issuer)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+func (o *Appraisal) summary(name string) []string {
+	if o == nil {
+		return []string{fmt.Sprintf("Submod '%s' has no appraisal", name)}
+	}
+
+	status := "not appraised"
+	if o.Status != nil {
+		status = o.Status.String()
+	}
+
+	parts := []string{fmt.Sprintf("Submod '%s' is %s", name, status)}
+
+	if o.TrustVector != nil {
+		for _, c := range trustVectorCategories {
+			claim := c.claim(*o.TrustVector)
+
+			if claim == NoClaim || claim.IsAffirming() {
+				continue
+			}
+
+			parts = append(parts, fmt.Sprintf("%s: %s (%s)",
+				c.name, claim.detailsPrinter(c.details, true, false), claim.GetTier()))
+		}
+	}
+
+	if o.VeraisonReasons != nil {
+		for _, r := range *o.VeraisonReasons {
+			parts = append(parts, r.summary())
+		}
+	}
+
+	return parts
+}
+
+func (o AttestationResult) issuerSummary() string {
+	var words []string
+
+	if o.VerifierID != nil {
+		words = append(words, "issued by")
+
+		if o.VerifierID.Developer != nil {
+			words = append(words, *o.VerifierID.Developer)
+		}
+
+		words = append(words, "verifier")
+
+		if o.VerifierID.Build != nil {
+			words = append(words, "build", *o.VerifierID.Build)
+		}
+	} else if o.IssuedAt != nil {
+		words = append(words, "issued")
+	}
+
+	if o.IssuedAt != nil {
+		words = append(words, "at", time.Unix(*o.IssuedAt, 0).UTC().Format(time.RFC3339))
+	}
+
+	return strings.Join(words, " ")
+}
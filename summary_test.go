@@ -0,0 +1,92 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttestationResult_Summary(t *testing.T) {
+	warning := TrustTierWarning
+	affirming := TrustTierAffirming
+
+	var (
+		testReasonCode      = "debug-enabled"
+		testReasonSeverity  = ReasonSeverityWarning
+		testReasonClaimPath = "ear.trustworthiness-vector/configuration"
+		testReasonMessage   = "debug enabled"
+	)
+
+	tvs := []struct {
+		ar       AttestationResult
+		expected string
+	}{
+		{
+			ar:       testAttestationResultsWithVeraisonExtns,
+			expected: "Submod 'test' is affirming; issued by Acme Inc. verifier build rrtrap-v1.0.0 at 2022-10-18T11:09:33Z",
+		},
+		{
+			ar: AttestationResult{
+				IssuedAt:   &testIAT,
+				VerifierID: &testVerifierID,
+				Submods: map[string]*Appraisal{
+					"gpu": {Status: &affirming},
+					"cpu": {
+						Status: &warning,
+						TrustVector: &TrustVector{
+							InstanceIdentity: TrustworthyInstanceClaim,
+							Configuration:    UnsafeConfigClaim,
+							Hardware:         UnexpectedEvidenceClaim,
+						},
+					},
+				},
+			},
+			expected: "Submod 'cpu' is warning; " +
+				"configuration: known vulnerabilities (warning); " +
+				"hardware: unexpected evidence (none); " +
+				"Submod 'gpu' is affirming; " +
+				"issued by Acme Inc. verifier build rrtrap-v1.0.0 at 2022-10-18T11:09:33Z",
+		},
+		{
+			ar: AttestationResult{
+				Submods: map[string]*Appraisal{
+					"cpu": {
+						Status: &warning,
+						AppraisalExtensions: AppraisalExtensions{
+							VeraisonReasons: &[]Reason{
+								{
+									Code:      &testReasonCode,
+									Severity:  &testReasonSeverity,
+									ClaimPath: &testReasonClaimPath,
+									Message:   &testReasonMessage,
+								},
+								{Code: &testReasonCode, Severity: &testReasonSeverity},
+							},
+						},
+					},
+				},
+			},
+			expected: "Submod 'cpu' is warning; " +
+				"ear.trustworthiness-vector/configuration: debug enabled (warning: debug-enabled); " +
+				"(warning: debug-enabled)",
+		},
+		{
+			ar: AttestationResult{
+				IssuedAt: &testIAT,
+				Submods:  map[string]*Appraisal{"x": {}},
+			},
+			expected: "Submod 'x' is not appraised; issued at 2022-10-18T11:09:33Z",
+		},
+		{
+			ar:       AttestationResult{},
+			expected: "",
+		},
+	}
+
+	for i, tv := range tvs {
+		assert.Equal(t, tv.expected, tv.ar.Summary(), "failed test vector at index %d", i)
+	}
+}
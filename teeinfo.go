@@ -4,15 +4,26 @@
 package ear
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"mime"
+	"strings"
 )
 
 type VeraisonTeeInfo struct {
 	TeeName    *string `json:"tee-name"`
 	EvidenceID *string `json:"evidence-id"`
-	Evidence   *[]byte `json:"evidence,omitempty"`
+	// Evidence is the (optional) embedded evidence.  Like "ear.raw-evidence",
+	// it is serialized as base64url without padding.
+	Evidence *B64Url `json:"evidence,omitempty"`
+	// MediaType is the (optional) media type of Evidence
+	MediaType *string `json:"media-type,omitempty"`
+	// EvidenceDigest is the (optional) digest of the referenced evidence,
+	// which makes the reference tamper-evident when the evidence is conveyed
+	// out of band
+	EvidenceDigest *Digest `json:"evidence-digest,omitempty"`
 }
 
 func str(v interface{}) string {
@@ -39,11 +50,19 @@ func ToVeraisonTeeInfo(v interface{}) (*VeraisonTeeInfo, error) {
 		case "evidence-id":
 			teeInfo.EvidenceID = &s
 		case "evidence":
-			buf, err := base64.StdEncoding.DecodeString(str(val))
+			buf, err := decodeTeeInfoEvidence(s)
 			if err != nil {
 				return nil, fmt.Errorf(`decoding "evidence": %w`, err)
 			}
 			teeInfo.Evidence = &buf
+		case "media-type":
+			teeInfo.MediaType = &s
+		case "evidence-digest":
+			d, err := ToDigest(val)
+			if err != nil {
+				return nil, fmt.Errorf(`decoding "evidence-digest": %w`, err)
+			}
+			teeInfo.EvidenceDigest = d
 		default:
 			return nil, fmt.Errorf(`found unknown key %q in "tee-info" object`, key)
 		}
@@ -56,8 +75,22 @@ func ToVeraisonTeeInfo(v interface{}) (*VeraisonTeeInfo, error) {
 	return &teeInfo, nil
 }
 
+// decodeTeeInfoEvidence decodes the "evidence" member, which is base64url
+// without padding.  For compatibility with older releases, which used the
+// standard (padded) base64 alphabet, strings that contain any of the
+// characters that are specific to standard base64 are decoded as such.  (An
+// unpadded string with neither '+' nor '/' decodes to the same bytes using
+// either alphabet.)
+func decodeTeeInfoEvidence(s string) (B64Url, error) {
+	if strings.ContainsAny(s, "+/=") {
+		return base64.StdEncoding.DecodeString(s)
+	}
+
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
 func (o VeraisonTeeInfo) Validate() error {
-	// NOTE: checking that (optional) evidence is base64-encoded is already
+	// NOTE: checking that (optional) evidence is base64url-encoded is already
 	// taken care of in ToVeraisonTeeInfo()
 
 	if o.TeeName == nil || *o.TeeName == "" {
@@ -68,5 +101,104 @@ func (o VeraisonTeeInfo) Validate() error {
 		return errors.New(`empty or missing "evidence-id"`)
 	}
 
+	if o.MediaType != nil {
+		if o.Evidence == nil {
+			return errors.New(`"media-type" without "evidence"`)
+		}
+
+		if _, _, err := mime.ParseMediaType(*o.MediaType); err != nil {
+			return fmt.Errorf(`invalid "media-type" %q: %w`, *o.MediaType, err)
+		}
+	}
+
+	if o.EvidenceDigest != nil {
+		if err := o.EvidenceDigest.Validate(); err != nil {
+			return fmt.Errorf(`invalid "evidence-digest": %w`, err)
+		}
+
+		if o.Evidence != nil {
+			if err := o.EvidenceDigest.Verify(*o.Evidence); err != nil {
+				return fmt.Errorf(`"evidence" does not match "evidence-digest": %w`, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetEvidence embeds the supplied evidence in "evidence".  mediaType is
+// optional and can be left empty.
+func (o *VeraisonTeeInfo) SetEvidence(evidence []byte, mediaType string) error {
+	if mediaType != "" {
+		if _, _, err := mime.ParseMediaType(mediaType); err != nil {
+			return fmt.Errorf("invalid media type %q: %w", mediaType, err)
+		}
+		o.MediaType = &mediaType
+	} else {
+		o.MediaType = nil
+	}
+
+	e := B64Url(append([]byte(nil), evidence...))
+	o.Evidence = &e
+
+	return nil
+}
+
+// GetEvidence returns the embedded "evidence" and its "media-type", if any
+func (o VeraisonTeeInfo) GetEvidence() ([]byte, string, error) {
+	if o.Evidence == nil {
+		return nil, "", errors.New(`"evidence" not found`)
+	}
+
+	var mediaType string
+	if o.MediaType != nil {
+		mediaType = *o.MediaType
+	}
+
+	return []byte(*o.Evidence), mediaType, nil
+}
+
+// SetEvidenceDigest computes the digest of evidence using the hash algorithm
+// identified by alg (e.g., "sha-256") and sets it in "evidence-digest"
+func (o *VeraisonTeeInfo) SetEvidenceDigest(alg string, evidence []byte) error {
+	d, err := NewDigest(alg, evidence)
+	if err != nil {
+		return err
+	}
+
+	o.EvidenceDigest = d
+
+	return nil
+}
+
+// VerifyEvidence checks that the supplied evidence, received out of band
+// with the supplied evidence identifier, is the one referenced by the
+// tee-info: evidenceID must match "evidence-id", and evidence must match
+// "evidence-digest" and the embedded "evidence" (if present).  If neither
+// "evidence-digest" nor "evidence" are present, the evidence cannot be bound
+// to the reference and an error is returned.
+func (o VeraisonTeeInfo) VerifyEvidence(evidence []byte, evidenceID string) error {
+	if o.EvidenceID == nil {
+		return errors.New(`missing "evidence-id"`)
+	}
+
+	if *o.EvidenceID != evidenceID {
+		return fmt.Errorf(`"evidence-id" mismatch: want %q, got %q`, *o.EvidenceID, evidenceID)
+	}
+
+	if o.EvidenceDigest == nil && o.Evidence == nil {
+		return errors.New(`neither "evidence-digest" nor "evidence" found`)
+	}
+
+	if o.EvidenceDigest != nil {
+		if err := o.EvidenceDigest.Verify(evidence); err != nil {
+			return fmt.Errorf(`"evidence-digest": %w`, err)
+		}
+	}
+
+	if o.Evidence != nil && !bytes.Equal(*o.Evidence, evidence) {
+		return errors.New(`"evidence" mismatch`)
+	}
+
 	return nil
 }
@@ -0,0 +1,187 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTeeInfoWithDigest(t *testing.T) *VeraisonTeeInfo {
+	name, id := testTeeName, testEvidenceID
+
+	ti := VeraisonTeeInfo{TeeName: &name, EvidenceID: &id}
+	require.NoError(t, ti.SetEvidenceDigest("sha-256", testEvidence))
+
+	return &ti
+}
+
+func TestVeraisonTeeInfo_evidence_digest_round_trip(t *testing.T) {
+	ar := testStreamResult("a", TrustTierAffirming)
+	ar.VeraisonTeeInfo = testTeeInfoWithDigest(t)
+
+	j, err := ar.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(j), `"evidence-digest":{"alg":"sha-256","value":"7oJQ-3bglLNLRx8Tpz275R0a4ULp31nXwNMewg8KCo4"}`)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalJSON(j))
+	assert.Equal(t, ar.VeraisonTeeInfo, actual.VeraisonTeeInfo)
+
+	c, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.UnmarshalCBOR(c))
+	assert.Equal(t, ar.VeraisonTeeInfo, actual.VeraisonTeeInfo)
+
+	token, err := ar.SignCWT(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.VerifyCWT(token, jwa.ES256, mustParseKey(t, testECDSAPublicKey)))
+	assert.NoError(t, actual.VeraisonTeeInfo.VerifyEvidence(testEvidence, testEvidenceID))
+}
+
+func TestVeraisonTeeInfo_VerifyEvidence(t *testing.T) {
+	withDigest := testTeeInfoWithDigest(t)
+
+	withEvidence := *withDigest
+	withEvidence.EvidenceDigest = nil
+	withEvidence.Evidence = (*B64Url)(&testEvidence)
+
+	neither := withEvidence
+	neither.Evidence = nil
+
+	tvs := []struct {
+		ti         *VeraisonTeeInfo
+		evidence   []byte
+		evidenceID string
+		expected   string
+	}{
+		{withDigest, testEvidence, testEvidenceID, ""},
+		{&withEvidence, testEvidence, testEvidenceID, ""},
+		{withDigest, testEvidence, "other", `"evidence-id" mismatch: want "405e0c3127e455ebc22361210b43ca9499ca80d3f6b1dc79b89fa35290cee3d9", got "other"`},
+		{withDigest, []byte("tampered"), testEvidenceID, `"evidence-digest": digest mismatch`},
+		{&withEvidence, []byte("tampered"), testEvidenceID, `"evidence" mismatch`},
+		{&neither, testEvidence, testEvidenceID, `neither "evidence-digest" nor "evidence" found`},
+		{&VeraisonTeeInfo{}, testEvidence, testEvidenceID, `missing "evidence-id"`},
+	}
+
+	for i, tv := range tvs {
+		err := tv.ti.VerifyEvidence(tv.evidence, tv.evidenceID)
+		if tv.expected == "" {
+			assert.NoError(t, err, "failed test vector at index %d", i)
+		} else {
+			assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+		}
+	}
+}
+
+func TestToVeraisonTeeInfo_evidence_digest_fail(t *testing.T) {
+	tvs := []struct {
+		v        map[string]interface{}
+		expected string
+	}{
+		{
+			map[string]interface{}{
+				"tee-name":        testTeeName,
+				"evidence-id":     testEvidenceID,
+				"evidence-digest": "sha-256",
+			},
+			`decoding "evidence-digest": not a JSON object`,
+		},
+		{
+			map[string]interface{}{
+				"tee-name":        testTeeName,
+				"evidence-id":     testEvidenceID,
+				"evidence":        "dGFtcGVyZWQ=",
+				"evidence-digest": map[string]interface{}{"alg": "sha-256", "value": "7oJQ-3bglLNLRx8Tpz275R0a4ULp31nXwNMewg8KCo4"},
+			},
+			`"tee-info" validation failed: "evidence" does not match "evidence-digest": digest mismatch`,
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToVeraisonTeeInfo(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestToVeraisonTeeInfo_evidence_encodings(t *testing.T) {
+	tvs := []struct {
+		evidence string
+		expected []byte
+	}{
+		// base64url, no padding
+		{"ZXZpZGVuY2U", testEvidence},
+		{"-_8", []byte{0xfb, 0xff}},
+		// legacy standard base64
+		{"ZXZpZGVuY2U=", testEvidence},
+		{"+/8=", []byte{0xfb, 0xff}},
+	}
+
+	for i, tv := range tvs {
+		ti, err := ToVeraisonTeeInfo(map[string]interface{}{
+			"tee-name":    testTeeName,
+			"evidence-id": testEvidenceID,
+			"evidence":    tv.evidence,
+		})
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, B64Url(tv.expected), *ti.Evidence, "failed test vector at index %d", i)
+	}
+
+	_, err := ToVeraisonTeeInfo(map[string]interface{}{
+		"tee-name":    testTeeName,
+		"evidence-id": testEvidenceID,
+		"evidence":    "-/8",
+	})
+	assert.EqualError(t, err, `decoding "evidence": illegal base64 data at input byte 0`)
+}
+
+func TestVeraisonTeeInfo_media_type(t *testing.T) {
+	name, id := testTeeName, testEvidenceID
+	ti := VeraisonTeeInfo{TeeName: &name, EvidenceID: &id}
+
+	_, _, err := ti.GetEvidence()
+	assert.EqualError(t, err, `"evidence" not found`)
+
+	assert.EqualError(t, ti.SetEvidence(testEvidence, "not a media type"),
+		`invalid media type "not a media type": mime: expected slash after first token`)
+
+	require.NoError(t, ti.SetEvidence(testEvidence, "application/vnd.aws.nitro-attestation"))
+
+	ar := testStreamResult("a", TrustTierAffirming)
+	ar.VeraisonTeeInfo = &ti
+
+	j, err := ar.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(j), `"evidence":"ZXZpZGVuY2U","media-type":"application/vnd.aws.nitro-attestation"`)
+
+	c, err := ar.MarshalCBOR()
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.UnmarshalCBOR(c))
+
+	evidence, mediaType, err := actual.VeraisonTeeInfo.GetEvidence()
+	require.NoError(t, err)
+	assert.Equal(t, testEvidence, evidence)
+	assert.Equal(t, "application/vnd.aws.nitro-attestation", mediaType)
+
+	// in CBOR, the tee-info members use integer keys, and evidence is a bstr
+	var m map[interface{}]interface{}
+	require.NoError(t, cbor.Unmarshal(c, &m))
+	teeInfo, ok := m[int64(CBORKeyVeraisonTeeInfo)].(map[interface{}]interface{})
+	require.True(t, ok)
+	assert.Equal(t, testEvidence, teeInfo[uint64(2)])
+	assert.Equal(t, "application/vnd.aws.nitro-attestation", teeInfo[uint64(3)])
+
+	ti.Evidence = nil
+	assert.EqualError(t, ti.Validate(), `"media-type" without "evidence"`)
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// TestVector is a malformed-but-plausible signed EAR, meant to check that
+// other EAR implementations reject it
+type TestVector struct {
+	// Name is a short, file-name friendly identifier of the test vector,
+	// e.g., "missing-eat_profile"
+	Name string `json:"name"`
+	// Description explains what is wrong with the EAR
+	Description string `json:"description"`
+	// Token is the (compact) signed EAR
+	Token []byte `json:"-"`
+}
+
+// claimsMutation modifies a claims-set, whose submods are all guaranteed to
+// be JSON objects
+type claimsMutation func(claims map[string]interface{})
+
+var negativeClaimsVectors = []struct {
+	name        string
+	description string
+	mutate      claimsMutation
+}{
+	{
+		"missing-eat_profile", "the mandatory eat_profile claim is missing",
+		func(c map[string]interface{}) { delete(c, "eat_profile") },
+	},
+	{
+		"wrong-eat_profile", "eat_profile is not the EAR profile",
+		func(c map[string]interface{}) { c["eat_profile"] = "tag:example.com,2023:not-ear" },
+	},
+	{
+		"missing-iat", "the mandatory iat claim is missing",
+		func(c map[string]interface{}) { delete(c, "iat") },
+	},
+	{
+		"missing-verifier-id", "the mandatory ear.verifier-id claim is missing",
+		func(c map[string]interface{}) { delete(c, "ear.verifier-id") },
+	},
+	{
+		"missing-verifier-id-build", "ear.verifier-id lacks the mandatory build",
+		func(c map[string]interface{}) {
+			c["ear.verifier-id"] = map[string]interface{}{"developer": "Acme Inc."}
+		},
+	},
+	{
+		"missing-submods", "the mandatory submods claim is missing",
+		func(c map[string]interface{}) { delete(c, "submods") },
+	},
+	{
+		"empty-submods", "submods does not contain any appraisal",
+		func(c map[string]interface{}) { c["submods"] = map[string]interface{}{} },
+	},
+	{
+		"missing-status", "an appraisal lacks the mandatory ear.status",
+		func(c map[string]interface{}) {
+			forEachSubmod(c, func(a map[string]interface{}) { delete(a, "ear.status") })
+		},
+	},
+	{
+		"unknown-status", "an appraisal has an ear.status that is not a trust tier",
+		func(c map[string]interface{}) {
+			forEachSubmod(c, func(a map[string]interface{}) { a["ear.status"] = "excellent" })
+		},
+	},
+	{
+		"out-of-range-trust-claim", "a trustworthiness vector claim does not fit in a signed byte",
+		func(c map[string]interface{}) {
+			forEachSubmod(c, func(a map[string]interface{}) {
+				a["ear.trustworthiness-vector"] = map[string]interface{}{"executables": 200}
+			})
+		},
+	},
+	{
+		"bad-raw-evidence-b64url", "ear.raw-evidence is not base64url encoded",
+		func(c map[string]interface{}) { c["ear.raw-evidence"] = "bm90*YmFzZTY0dXJs!" },
+	},
+	{
+		"padded-raw-evidence", "ear.raw-evidence is base64url encoded with padding",
+		func(c map[string]interface{}) { c["ear.raw-evidence"] = "ZXZpZGVuY2U=" },
+	},
+	{
+		"short-eat_nonce", "eat_nonce is shorter than 8 bytes",
+		func(c map[string]interface{}) { c["eat_nonce"] = "1337" },
+	},
+}
+
+func forEachSubmod(claims map[string]interface{}, f func(map[string]interface{})) {
+	submods, _ := claims["submods"].(map[string]interface{})
+	for _, v := range submods {
+		if a, ok := v.(map[string]interface{}); ok {
+			f(a)
+		}
+	}
+}
+
+// NegativeTestVectors returns a corpus of malformed-but-plausible EARs,
+// derived from base (which must be valid), that a conforming verifier must
+// reject: EARs with missing mandatory or out-of-range claims, bad base64url
+// encodings or the wrong profile, all correctly signed using the supplied
+// algorithm and key, as well as EARs with a tampered signature or payload, or
+// no signature at all.
+func NegativeTestVectors(
+	base AttestationResult,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+) ([]TestVector, error) {
+	data, err := base.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("serializing base result: %w", err)
+	}
+
+	sign, err := newJWSSignFunc(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	tvs := make([]TestVector, 0, len(negativeClaimsVectors)+3)
+
+	for _, v := range negativeClaimsVectors {
+		var claims map[string]interface{}
+		if err := json.Unmarshal(data, &claims); err != nil {
+			return nil, err
+		}
+
+		v.mutate(claims)
+
+		token, err := signTestVector(claims, alg, key, sign)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", v.name, err)
+		}
+
+		tvs = append(tvs, TestVector{Name: v.name, Description: v.description, Token: token})
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, err
+	}
+
+	valid, err := signTestVector(claims, alg, key, sign)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(string(valid), ".")
+
+	// flip one bit in the middle of the signature
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	sig[len(sig)/2] ^= 0x01
+
+	tvs = append(tvs, TestVector{
+		Name:        "tampered-signature",
+		Description: "the signature does not match the (well-formed) claims-set",
+		Token:       []byte(parts[0] + "." + parts[1] + "." + base64.RawURLEncoding.EncodeToString(sig)),
+	})
+
+	claims["ear.verifier-id"] = map[string]interface{}{"build": "evil-v6.6.6", "developer": "Mallory"}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	tvs = append(tvs, TestVector{
+		Name:        "tampered-payload",
+		Description: "the claims-set has been modified after signing",
+		Token:       []byte(parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]),
+	})
+
+	tvs = append(tvs, TestVector{
+		Name:        "alg-none",
+		Description: `the EAR is not signed ("alg": "none")`,
+		Token: []byte(base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) +
+			"." + parts[1] + "."),
+	})
+
+	return tvs, nil
+}
+
+// signTestVector signs the claims-set as is, bypassing any validation
+func signTestVector(
+	claims map[string]interface{},
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	sign func([]byte) ([]byte, error),
+) ([]byte, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := jwsJSON{Payload: base64.RawURLEncoding.EncodeToString(payload)}
+
+	sig, err := msg.sign(alg, key, sign)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(sig.Protected + "." + msg.Payload + "." + sig.Signature), nil
+}
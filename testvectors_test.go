@@ -0,0 +1,64 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegativeTestVectors(t *testing.T) {
+	base := testAttestationResultsWithVeraisonExtns
+	nonce := testNonce
+	base.Nonce = &nonce
+
+	tvs, err := NegativeTestVectors(base, jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+	require.Len(t, tvs, len(negativeClaimsVectors)+3)
+
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+	seen := map[string]bool{}
+
+	for i, tv := range tvs {
+		assert.NotEmpty(t, tv.Description, "failed test vector at index %d", i)
+		assert.False(t, seen[tv.Name], "failed test vector at index %d", i)
+		seen[tv.Name] = true
+
+		assert.Error(t, verifyTestVector(tv.Token, vfyK),
+			"test vector %q verified", tv.Name)
+	}
+
+	for _, name := range []string{"tampered-signature", "tampered-payload", "alg-none"} {
+		assert.True(t, seen[name], name)
+	}
+}
+
+// verifyTestVector checks token as a conforming verifier would: Verify checks
+// the signature, and the claims-set is then validated in full
+func verifyTestVector(token []byte, key jwk.Key) error {
+	var ar AttestationResult
+	if err := ar.Verify(token, jwa.ES256, key); err != nil {
+		return err
+	}
+
+	msg, err := jws.Parse(token)
+	if err != nil {
+		return err
+	}
+
+	return ar.UnmarshalJSON(msg.Payload())
+}
+
+func TestNegativeTestVectors_fail(t *testing.T) {
+	_, err := NegativeTestVectors(AttestationResult{}, jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	assert.ErrorContains(t, err, "serializing base result: missing mandatory")
+
+	_, err = NegativeTestVectors(testAttestationResultsWithVeraisonExtns, jwa.KeyEncryptionAlgorithm("RSA-OAEP"), nil)
+	assert.EqualError(t, err, `expecting a signature algorithm, got "RSA-OAEP"`)
+}
@@ -0,0 +1,274 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/digitorus/pkcs7"
+	"github.com/digitorus/timestamp"
+)
+
+// TimestampHeader is the JWS unprotected header parameter that carries the
+// (base64url-encoded, DER) RFC 3161 timestamp token attached by
+// AttachTimestamp
+const TimestampHeader = "ear.timestamp"
+
+const (
+	timestampQueryMediaType = "application/timestamp-query"
+	timestampReplyMediaType = "application/timestamp-reply"
+	// maxTimestampResponseSize caps the size of a TSA response
+	maxTimestampResponseSize = 1 << 20
+)
+
+// timestampedData returns the data that is timestamped for the supplied
+// signed EAR, i.e., the value of its original (first) signature.  This makes
+// the timestamp independent of the JWS serialization in use.
+func timestampedData(token []byte) ([]byte, error) {
+	msg, err := parseJWSAsJSON(token)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(msg.Signatures[0].Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+
+	return sig, nil
+}
+
+// RequestTimestamp obtains an RFC 3161 timestamp token over the signature of
+// the supplied signed EAR from the TSA at tsaURL.  The TSA is asked to
+// include its certificate in the response, which is checked against the
+// request (imprint and nonce).  On success, the DER-encoded timestamp token is
+// returned: it can be stored as a sidecar, or attached to the EAR using
+// AttachTimestamp.  If client is nil, http.DefaultClient is used.
+func RequestTimestamp(
+	ctx context.Context,
+	tsaURL string,
+	token []byte,
+	client *http.Client,
+) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	data, err := timestampedData(token)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	h := crypto.SHA256.New()
+	h.Write(data)
+
+	req := timestamp.Request{
+		HashAlgorithm: crypto.SHA256,
+		HashedMessage: h.Sum(nil),
+		Certificates:  true,
+		Nonce:         nonce,
+	}
+
+	reqData, err := req.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("creating timestamp request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, tsaURL, bytes.NewReader(reqData))
+	if err != nil {
+		return nil, fmt.Errorf("creating HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", timestampQueryMediaType)
+	httpReq.Header.Set("Accept", timestampReplyMediaType)
+
+	httpRes, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("contacting TSA: %w", err)
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("TSA returned status %d", httpRes.StatusCode)
+	}
+
+	resData, err := io.ReadAll(io.LimitReader(httpRes.Body, maxTimestampResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading TSA response: %w", err)
+	}
+
+	if len(resData) > maxTimestampResponseSize {
+		return nil, fmt.Errorf("TSA response exceeds %d bytes", maxTimestampResponseSize)
+	}
+
+	ts, err := timestamp.ParseResponse(resData)
+	if err != nil {
+		return nil, fmt.Errorf("parsing TSA response: %w", err)
+	}
+
+	if ts.Nonce == nil || ts.Nonce.Cmp(nonce) != 0 {
+		return nil, errors.New("TSA response nonce does not match request")
+	}
+
+	if !bytes.Equal(ts.HashedMessage, req.HashedMessage) {
+		return nil, errors.New("TSA response imprint does not match request")
+	}
+
+	return ts.RawToken, nil
+}
+
+// AttachTimestamp attaches the supplied DER-encoded timestamp token (see
+// RequestTimestamp) to the unprotected header of the original signature of
+// the supplied signed EAR.  The token is returned in the general JWS JSON
+// serialization; since unprotected headers are not signed, attaching a
+// timestamp does not invalidate the EAR signature.
+func AttachTimestamp(token, tst []byte) ([]byte, error) {
+	if len(tst) == 0 {
+		return nil, errors.New("empty timestamp token")
+	}
+
+	msg, err := parseJWSAsJSON(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Signatures[0].Header == nil {
+		msg.Signatures[0].Header = map[string]interface{}{}
+	}
+
+	msg.Signatures[0].Header[TimestampHeader] = base64.RawURLEncoding.EncodeToString(tst)
+
+	return json.Marshal(msg)
+}
+
+// GetTimestamp returns the DER-encoded timestamp token attached to the
+// supplied signed EAR by AttachTimestamp
+func GetTimestamp(token []byte) ([]byte, error) {
+	msg, err := parseJWSAsJSON(token)
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := msg.Signatures[0].Header[TimestampHeader]
+	if !ok {
+		return nil, fmt.Errorf("%q header not found", TimestampHeader)
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("%q header is not a string", TimestampHeader)
+	}
+
+	tst, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %q header: %w", TimestampHeader, err)
+	}
+
+	return tst, nil
+}
+
+// VerifyTimestamp verifies the timestamp token attached to the supplied
+// signed EAR (see VerifyDetachedTimestamp).
+func VerifyTimestamp(token []byte, roots *x509.CertPool) (time.Time, error) {
+	tst, err := GetTimestamp(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return VerifyDetachedTimestamp(token, tst, roots)
+}
+
+// VerifyDetachedTimestamp verifies that tst is a valid RFC 3161 timestamp
+// token over the signature of the supplied signed EAR, signed by a TSA whose
+// certificate is embedded in the token, and chains up to one of roots (and is
+// valid for timestamping at the time of the timestamp).  On success, the time asserted by the TSA is
+// returned: this allows archived EARs to be assessed as of that time, e.g.,
+// after the verifier key has been rotated or has expired.
+func VerifyDetachedTimestamp(token, tst []byte, roots *x509.CertPool) (time.Time, error) {
+	data, err := timestampedData(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	ts, err := timestamp.Parse(tst)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing timestamp token: %w", err)
+	}
+
+	if !ts.HashAlgorithm.Available() {
+		return time.Time{}, fmt.Errorf("unsupported timestamp imprint hash: %s", ts.HashAlgorithm)
+	}
+
+	h := ts.HashAlgorithm.New()
+	h.Write(data)
+
+	if !bytes.Equal(ts.HashedMessage, h.Sum(nil)) {
+		return time.Time{}, errors.New("timestamp token imprint does not match EAR signature")
+	}
+
+	p7, err := pkcs7.Parse(tst)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing timestamp token: %w", err)
+	}
+
+	// the TSA certificate is the one identified by the (only) SignerInfo, not
+	// just any of the embedded certificates
+	tsaCert := p7.GetOnlySigner()
+	if tsaCert == nil {
+		return time.Time{}, errors.New(
+			"timestamp token must have exactly one signer, whose certificate is embedded")
+	}
+
+	if !hasExtKeyUsage(tsaCert, x509.ExtKeyUsageTimeStamping) {
+		return time.Time{}, errors.New("TSA certificate is not valid for timestamping")
+	}
+
+	if err := p7.Verify(); err != nil {
+		return time.Time{}, fmt.Errorf("verifying timestamp token signature: %w", err)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range p7.Certificates {
+		if !c.Equal(tsaCert) {
+			intermediates.AddCert(c)
+		}
+	}
+
+	_, err = tsaCert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   ts.Time,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("verifying TSA certificate: %w", err)
+	}
+
+	return ts.Time, nil
+}
+
+func hasExtKeyUsage(c *x509.Certificate, usage x509.ExtKeyUsage) bool {
+	for _, u := range c.ExtKeyUsage {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/digitorus/pkcs7"
+	"github.com/digitorus/timestamp"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCert(
+	t *testing.T,
+	tmpl *x509.Certificate,
+	parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+// newTestTSA returns a test TSA server, together with the pool containing its
+// root certificate
+func newTestTSA(t *testing.T, tsTime time.Time) (*httptest.Server, *x509.CertPool) {
+	// the signing time of the token is the current time
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.Add(2 * time.Hour)
+
+	caCert, caKey := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	tsaCert, tsaKey := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test TSA"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}, caCert, caKey)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req, err := timestamp.ParseRequest(data)
+		require.NoError(t, err)
+
+		ts := timestamp.Timestamp{
+			HashAlgorithm:     req.HashAlgorithm,
+			HashedMessage:     req.HashedMessage,
+			Time:              tsTime,
+			Nonce:             req.Nonce,
+			Policy:            asn1.ObjectIdentifier{2, 4, 5, 6},
+			AddTSACertificate: true,
+		}
+
+		res, err := ts.CreateResponseWithOpts(tsaCert, tsaKey, crypto.SHA256)
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", timestampReplyMediaType)
+		_, _ = w.Write(res)
+	}))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	return srv, roots
+}
+
+func TestTimestamp_round_trip(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	tsTime := time.Now().Truncate(time.Second).UTC()
+
+	srv, roots := newTestTSA(t, tsTime)
+	defer srv.Close()
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	tst, err := RequestTimestamp(context.Background(), srv.URL, token, srv.Client())
+	require.NoError(t, err)
+
+	// sidecar
+	ts, err := VerifyDetachedTimestamp(token, tst, roots)
+	require.NoError(t, err)
+	assert.True(t, tsTime.Equal(ts))
+
+	// attached
+	stamped, err := AttachTimestamp(token, tst)
+	require.NoError(t, err)
+
+	ts, err = VerifyTimestamp(stamped, roots)
+	require.NoError(t, err)
+	assert.True(t, tsTime.Equal(ts))
+
+	// attaching the timestamp does not invalidate the EAR signature
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(stamped, jwa.ES256, vfyK))
+
+	// untrusted TSA
+	_, err = VerifyTimestamp(stamped, x509.NewCertPool())
+	assert.ErrorContains(t, err, "verifying TSA certificate: ")
+
+	// timestamp over another EAR
+	other, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	_, err = VerifyDetachedTimestamp(other, tst, roots)
+	assert.EqualError(t, err, "timestamp token imprint does not match EAR signature")
+}
+
+func TestTimestamp_fail(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	_, err = GetTimestamp(token)
+	assert.EqualError(t, err, `"ear.timestamp" header not found`)
+
+	_, err = AttachTimestamp(token, nil)
+	assert.EqualError(t, err, "empty timestamp token")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, err = RequestTimestamp(context.Background(), srv.URL, token, srv.Client())
+	assert.EqualError(t, err, "TSA returned status 503")
+
+	huge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(make([]byte, maxTimestampResponseSize+1))
+	}))
+	defer huge.Close()
+
+	_, err = RequestTimestamp(context.Background(), huge.URL, token, huge.Client())
+	assert.EqualError(t, err, "TSA response exceeds 1048576 bytes")
+}
+
+func TestVerifyDetachedTimestamp_forged_signer(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	data, err := timestampedData(token)
+	require.NoError(t, err)
+
+	h := crypto.SHA256.New()
+	h.Write(data)
+
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.Add(2 * time.Hour)
+
+	caCert, caKey := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	tsaCert, _ := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test TSA"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}, caCert, caKey)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	tvs := []struct {
+		usage    []x509.ExtKeyUsage
+		expected string
+	}{
+		{nil, "TSA certificate is not valid for timestamping"},
+		{[]x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping}, "verifying TSA certificate: "},
+	}
+
+	for i, tv := range tvs {
+		// the token is signed with the attacker's key, but carries the
+		// genuine TSA certificate too
+		attackerCert, attackerKey := newTestCert(t, &x509.Certificate{
+			SerialNumber: big.NewInt(3),
+			Subject:      pkix.Name{CommonName: "Attacker"},
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  tv.usage,
+		}, nil, nil)
+
+		ts := timestamp.Timestamp{
+			HashAlgorithm: crypto.SHA256,
+			HashedMessage: h.Sum(nil),
+			Time:          time.Now().Truncate(time.Second).UTC(),
+			Policy:        asn1.ObjectIdentifier{2, 4, 5, 6},
+		}
+
+		res, err := ts.CreateResponseWithOpts(attackerCert, attackerKey, crypto.SHA256)
+		require.NoError(t, err)
+
+		tst, err := timestamp.ParseResponse(res)
+		require.NoError(t, err)
+
+		p7, err := pkcs7.Parse(tst.RawToken)
+		require.NoError(t, err)
+
+		sd, err := pkcs7.NewSignedData(p7.Content)
+		require.NoError(t, err)
+		sd.SetContentType(asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4})
+		sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+		sd.AddCertificate(tsaCert)
+		require.NoError(t, sd.AddSigner(attackerCert, attackerKey, pkcs7.SignerInfoConfig{}))
+
+		forged, err := sd.Finish()
+		require.NoError(t, err)
+
+		_, err = VerifyDetachedTimestamp(token, forged, roots)
+		assert.ErrorContains(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// TopLevelAppraisalClaims lists the appraisal claims that are mirrored at the
+// top level of the claims-set when WithTopLevelAppraisal is used
+var TopLevelAppraisalClaims = []string{
+	"ear.status",
+	"ear.trustworthiness-vector",
+	"ear.appraisal-policy-id",
+}
+
+type topLevelAppraisalOption struct {
+	submod string
+}
+
+func (o topLevelAppraisalOption) applySignOption(c *signConfig) {
+	c.topLevelAppraisal = o.submod
+}
+
+func (o topLevelAppraisalOption) applyVerifyOption(c *verifyConfig) {
+	c.topLevelAppraisal = o.submod
+}
+
+// WithTopLevelAppraisal eases the migration of relying parties that expect
+// the appraisal claims (see TopLevelAppraisalClaims) at the top level of the
+// claims-set, as in the layout that predates submods.  When signing, the
+// claims of the named submod are mirrored at the top level, alongside
+// "submods".  When verifying, top-level appraisal claims are folded back into
+// the named submod, which is created if missing (e.g., in results issued
+// before submods were introduced); if the submod exists, any claim it shares
+// with the top level must have the same value.
+func WithTopLevelAppraisal(submod string) Option {
+	return topLevelAppraisalOption{submod}
+}
+
+// mirrorTopLevelAppraisal copies the claims of the named submod to the top
+// level of claims
+func (o AttestationResult) mirrorTopLevelAppraisal(claims map[string]interface{}, submod string) error {
+	a, ok := o.Submods[submod]
+	if !ok || a == nil {
+		return fmt.Errorf("top-level appraisal: submod %q not found", submod)
+	}
+
+	am := a.AsMap()
+
+	for _, name := range TopLevelAppraisalClaims {
+		if v, ok := am[name]; ok {
+			claims[name] = v
+		}
+	}
+
+	return nil
+}
+
+// foldTopLevelAppraisal moves the top-level appraisal claims, if any, into the
+// named submod
+func foldTopLevelAppraisal(claims map[string]interface{}, submod string) error {
+	top := map[string]interface{}{}
+
+	for _, name := range TopLevelAppraisalClaims {
+		if v, ok := claims[name]; ok {
+			top[name] = v
+		}
+	}
+
+	if len(top) == 0 {
+		return nil
+	}
+
+	submods, ok := claims["submods"].(map[string]interface{})
+	if !ok {
+		if _, found := claims["submods"]; found {
+			return errors.New(`top-level appraisal: "submods" is not a map object`)
+		}
+
+		submods = map[string]interface{}{}
+		claims["submods"] = submods
+	}
+
+	a, ok := submods[submod].(map[string]interface{})
+	if !ok {
+		if _, found := submods[submod]; found {
+			return fmt.Errorf("top-level appraisal: submod %q is not a map object", submod)
+		}
+
+		a = map[string]interface{}{}
+		submods[submod] = a
+	}
+
+	for name, v := range top {
+		if sv, ok := a[name]; ok && !reflect.DeepEqual(sv, v) {
+			return fmt.Errorf("top-level appraisal: %q does not match that of submod %q", name, submod)
+		}
+
+		a[name] = v
+		delete(claims, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTopLevelAppraisal_round_trip(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK, WithTopLevelAppraisal("test"))
+	require.NoError(t, err)
+
+	msg, err := jws.Parse(token)
+	require.NoError(t, err)
+
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal(msg.Payload(), &claims))
+	assert.Equal(t, "affirming", claims["ear.status"])
+	assert.Equal(t, testPolicyID, claims["ear.appraisal-policy-id"])
+	assert.NotContains(t, claims, "ear.trustworthiness-vector")
+
+	var actual AttestationResult
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK, WithTopLevelAppraisal("test")))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+	// relying parties that are not aware of the mirrored claims just ignore
+	// them
+	actual = AttestationResult{}
+	require.NoError(t, actual.Verify(token, jwa.ES256, vfyK))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+	token, err = testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, sigK, WithTopLevelAppraisal("test"))
+	require.NoError(t, err)
+
+	actual = AttestationResult{}
+	require.NoError(t, actual.VerifyCWT(token, jwa.ES256, vfyK, WithTopLevelAppraisal("test")))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+}
+
+func TestWithTopLevelAppraisal_sign_fail(t *testing.T) {
+	_, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey), WithTopLevelAppraisal("missing"))
+	assert.EqualError(t, err, `top-level appraisal: submod "missing" not found`)
+}
+
+func TestWithTopLevelAppraisal_fold(t *testing.T) {
+	sigK := mustParseKey(t, testECDSAPrivateKey)
+	vfyK := mustParseKey(t, testECDSAPublicKey)
+
+	tvs := []struct {
+		claims   string
+		expected string
+	}{
+		// pre-submods layout
+		{
+			claims: `{
+				"eat_profile": "tag:github.com,2023:veraison/ear",
+				"iat": 1666091373,
+				"ear.verifier-id": {"build": "rrtrap-v1.0.0", "developer": "Acme Inc."},
+				"ear.status": "warning",
+				"ear.appraisal-policy-id": "policy://test/01234"
+			}`,
+		},
+		// mirrored claims that agree with the submod
+		{
+			claims: `{
+				"eat_profile": "tag:github.com,2023:veraison/ear",
+				"iat": 1666091373,
+				"ear.verifier-id": {"build": "rrtrap-v1.0.0", "developer": "Acme Inc."},
+				"ear.status": "warning",
+				"submods": {"legacy": {"ear.status": "warning", "ear.appraisal-policy-id": "policy://test/01234"}}
+			}`,
+		},
+		{
+			claims: `{
+				"eat_profile": "tag:github.com,2023:veraison/ear",
+				"iat": 1666091373,
+				"ear.verifier-id": {"build": "rrtrap-v1.0.0", "developer": "Acme Inc."},
+				"ear.status": "affirming",
+				"submods": {"legacy": {"ear.status": "warning"}}
+			}`,
+			expected: `top-level appraisal: "ear.status" does not match that of submod "legacy"`,
+		},
+		{
+			claims: `{
+				"ear.status": "affirming",
+				"submods": []
+			}`,
+			expected: `top-level appraisal: "submods" is not a map object`,
+		},
+		{
+			claims: `{
+				"ear.status": "affirming",
+				"submods": {"legacy": "affirming"}
+			}`,
+			expected: `top-level appraisal: submod "legacy" is not a map object`,
+		},
+	}
+
+	for i, tv := range tvs {
+		token, err := jws.Sign([]byte(tv.claims), jws.WithKey(jwa.ES256, sigK))
+		require.NoError(t, err)
+
+		var ar AttestationResult
+
+		err = ar.Verify(token, jwa.ES256, vfyK, WithTopLevelAppraisal("legacy"))
+		if tv.expected != "" {
+			assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+			continue
+		}
+
+		require.NoError(t, err, "failed test vector at index %d", i)
+		require.Contains(t, ar.Submods, "legacy", "failed test vector at index %d", i)
+		assert.Equal(t, TrustTierWarning, *ar.Submods["legacy"].Status, "failed test vector at index %d", i)
+		assert.Equal(t, testPolicyID, *ar.Submods["legacy"].AppraisalPolicyID, "failed test vector at index %d", i)
+	}
+}
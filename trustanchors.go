@@ -0,0 +1,616 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// TrustAnchorMetadataSuffix is the suffix of the files that carry the
+// metadata for the anchors loaded from the file with the same base name
+// (e.g., "verifier.meta.json" for "verifier.pem")
+const TrustAnchorMetadataSuffix = ".meta.json"
+
+// TrustAnchor is a verifier public key, together with the constraints on its
+// use
+type TrustAnchor struct {
+	// ID identifies the anchor.  It is matched against the `kid` header of
+	// the EAR, if present.
+	ID string
+	// Key is the verifier public key
+	Key jwk.Key
+	// Certificate is the certificate the key was taken from, if any
+	Certificate *x509.Certificate
+	// Algorithm, if set, is the only signature algorithm accepted for the key
+	Algorithm jwa.SignatureAlgorithm
+	// Profiles, if not empty, lists the EAR profiles the anchor can vouch for
+	Profiles []string
+	// NotBefore and NotAfter, if not zero, bound the anchor validity window
+	NotBefore time.Time
+	NotAfter  time.Time
+	// Verifier, if set, names the verifier the key belongs to.  A verifier
+	// can have several keys, possibly with overlapping validity windows, to
+	// allow key rollovers.
+	Verifier string
+}
+
+func (o TrustAnchor) validate() error {
+	if o.ID == "" {
+		return errors.New("missing anchor ID")
+	}
+
+	if o.Key == nil {
+		return fmt.Errorf("anchor %q: missing key", o.ID)
+	}
+
+	if o.Key.KeyType() == jwa.OctetSeq {
+		return fmt.Errorf("anchor %q: symmetric keys cannot be used as trust anchors", o.ID)
+	}
+
+	if !o.NotBefore.IsZero() && !o.NotAfter.IsZero() && o.NotAfter.Before(o.NotBefore) {
+		return fmt.Errorf("anchor %q: not-after precedes not-before", o.ID)
+	}
+
+	return nil
+}
+
+// isValidAt reports whether t falls within the anchor validity window
+func (o TrustAnchor) isValidAt(t time.Time) bool {
+	if !o.NotBefore.IsZero() && t.Before(o.NotBefore) {
+		return false
+	}
+
+	if !o.NotAfter.IsZero() && t.After(o.NotAfter) {
+		return false
+	}
+
+	return true
+}
+
+var ecdsaCurveAlgorithms = map[jwa.EllipticCurveAlgorithm]jwa.SignatureAlgorithm{
+	jwa.P256: jwa.ES256,
+	jwa.P384: jwa.ES384,
+	jwa.P521: jwa.ES512,
+}
+
+// allowsAlgorithm reports whether alg can be used with the anchor key
+func (o TrustAnchor) allowsAlgorithm(alg jwa.SignatureAlgorithm) bool {
+	if o.Algorithm != "" {
+		return o.Algorithm == alg
+	}
+
+	// ECDSA algorithms are bound to a specific curve
+	if k, ok := o.Key.(jwk.ECDSAPublicKey); ok {
+		return ecdsaCurveAlgorithms[k.Crv()] == alg
+	}
+
+	algs, err := jws.AlgorithmsForKey(o.Key)
+	if err != nil {
+		return false
+	}
+
+	for _, a := range algs {
+		if a == alg {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowsProfile reports whether the anchor can vouch for results with the
+// supplied profile
+func (o TrustAnchor) allowsProfile(profile *string) bool {
+	if len(o.Profiles) == 0 {
+		return true
+	}
+
+	if profile == nil {
+		return false
+	}
+
+	for _, p := range o.Profiles {
+		if p == *profile {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trustAnchorMetadata is the content of the TrustAnchorMetadataSuffix files
+type trustAnchorMetadata struct {
+	Verifier  string    `json:"verifier,omitempty"`
+	Algorithm string    `json:"alg,omitempty"`
+	Profiles  []string  `json:"profiles,omitempty"`
+	NotBefore time.Time `json:"not-before,omitempty"`
+	NotAfter  time.Time `json:"not-after,omitempty"`
+}
+
+func (o trustAnchorMetadata) apply(a *TrustAnchor) {
+	if o.Verifier != "" {
+		a.Verifier = o.Verifier
+	}
+
+	if o.Algorithm != "" {
+		a.Algorithm = jwa.SignatureAlgorithm(o.Algorithm)
+	}
+
+	if len(o.Profiles) > 0 {
+		a.Profiles = o.Profiles
+	}
+
+	if !o.NotBefore.IsZero() {
+		a.NotBefore = o.NotBefore
+	}
+
+	if !o.NotAfter.IsZero() {
+		a.NotAfter = o.NotAfter
+	}
+}
+
+// TrustAnchors is a store of verifier keys that is used by VerifyWithAnchors
+// to automatically select the key for verifying an EAR
+type TrustAnchors struct {
+	anchors []TrustAnchor
+}
+
+// NewTrustAnchors returns an empty TrustAnchors store
+func NewTrustAnchors() *TrustAnchors {
+	return &TrustAnchors{}
+}
+
+// Add adds an anchor to the store.  Anchor IDs must be unique.
+func (o *TrustAnchors) Add(a TrustAnchor) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+
+	for _, existing := range o.anchors {
+		if existing.ID == a.ID {
+			return fmt.Errorf("duplicate anchor %q", a.ID)
+		}
+	}
+
+	o.anchors = append(o.anchors, a)
+
+	return nil
+}
+
+// Anchors returns the anchors in the store
+func (o TrustAnchors) Anchors() []TrustAnchor {
+	return o.anchors
+}
+
+// Len returns the number of anchors in the store
+func (o TrustAnchors) Len() int {
+	return len(o.anchors)
+}
+
+// LoadTrustAnchorsDir loads the trust anchors found in directory dir (see
+// LoadTrustAnchors)
+func LoadTrustAnchorsDir(dir string) (*TrustAnchors, error) {
+	return LoadTrustAnchors(os.DirFS(dir))
+}
+
+// LoadTrustAnchors loads the trust anchors found in the top-level directory of
+// fsys.  The following file types are recognised by their extension:
+//
+//   - .jwk, .json: a JWK
+//   - .jwks: a JWK set, with each key becoming an anchor
+//   - .pem, .crt, .cer: PEM-encoded certificates and/or public keys
+//
+// Anchors are identified by the JWK `kid`, if present, or else by the base
+// name of the file (with a "#<n>" suffix if the file contains multiple keys).
+// Certificate anchors have their validity window set from the certificate.
+// A file named <base name>.meta.json, if present, can add constraints to the
+// anchors loaded from the file with the same base name:
+//
+//	{
+//	  "verifier": "acme-verifier",
+//	  "alg": "ES256",
+//	  "profiles": [ "tag:github.com,2023:veraison/ear" ],
+//	  "not-before": "2026-01-01T00:00:00Z",
+//	  "not-after": "2027-01-01T00:00:00Z"
+//	}
+//
+// Other files are ignored.
+func LoadTrustAnchors(fsys fs.FS) (*TrustAnchors, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("reading trust anchors: %w", err)
+	}
+
+	store := NewTrustAnchors()
+
+	for _, e := range entries {
+		name := e.Name()
+
+		if e.IsDir() || strings.HasSuffix(name, TrustAnchorMetadataSuffix) {
+			continue
+		}
+
+		ext := path.Ext(name)
+		base := strings.TrimSuffix(name, ext)
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+
+		var anchors []TrustAnchor
+
+		switch ext {
+		case ".jwk", ".json":
+			anchors, err = anchorsFromJWKSet(base, data, false)
+		case ".jwks":
+			anchors, err = anchorsFromJWKSet(base, data, true)
+		case ".pem", ".crt", ".cer":
+			anchors, err = anchorsFromPEM(base, data)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", name, err)
+		}
+
+		md, err := readTrustAnchorMetadata(fsys, base+TrustAnchorMetadataSuffix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range anchors {
+			if md != nil {
+				md.apply(&a)
+			}
+
+			if err := store.Add(a); err != nil {
+				return nil, fmt.Errorf("loading %s: %w", name, err)
+			}
+		}
+	}
+
+	return store, nil
+}
+
+func readTrustAnchorMetadata(fsys fs.FS, name string) (*trustAnchorMetadata, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	var md trustAnchorMetadata
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", name, err)
+	}
+
+	return &md, nil
+}
+
+func anchorID(base string, i, n int) string {
+	if n == 1 {
+		return base
+	}
+	return fmt.Sprintf("%s#%d", base, i)
+}
+
+func anchorsFromJWKSet(base string, data []byte, isSet bool) ([]TrustAnchor, error) {
+	var keys []jwk.Key
+
+	if isSet {
+		set, err := jwk.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < set.Len(); i++ {
+			k, _ := set.Key(i)
+			keys = append(keys, k)
+		}
+	} else {
+		k, err := jwk.ParseKey(data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	anchors := make([]TrustAnchor, 0, len(keys))
+
+	for i, k := range keys {
+		pub, err := jwk.PublicKeyOf(k)
+		if err != nil {
+			return nil, err
+		}
+
+		a := TrustAnchor{ID: pub.KeyID(), Key: pub}
+		if a.ID == "" {
+			a.ID = anchorID(base, i, len(keys))
+		}
+
+		if alg, ok := pub.Algorithm().(jwa.SignatureAlgorithm); ok && alg != "" {
+			a.Algorithm = alg
+		}
+
+		anchors = append(anchors, a)
+	}
+
+	return anchors, nil
+}
+
+func anchorsFromPEM(base string, data []byte) ([]TrustAnchor, error) {
+	var anchors []TrustAnchor
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var a TrustAnchor
+		var raw interface{}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			c, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			raw = c.PublicKey
+			a.Certificate = c
+			a.NotBefore = c.NotBefore
+			a.NotAfter = c.NotAfter
+		case "PUBLIC KEY":
+			k, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			raw = k
+		default:
+			continue
+		}
+
+		k, err := jwk.FromRaw(raw)
+		if err != nil {
+			return nil, err
+		}
+		a.Key = k
+
+		anchors = append(anchors, a)
+	}
+
+	if len(anchors) == 0 {
+		return nil, errors.New("no certificates or public keys found")
+	}
+
+	for i := range anchors {
+		anchors[i].ID = anchorID(base, i, len(anchors))
+	}
+
+	return anchors, nil
+}
+
+// candidates returns the anchors that could be used to verify a token with
+// the supplied header parameters, sorted by ID
+func (o TrustAnchors) candidates(kid string, alg jwa.SignatureAlgorithm) []TrustAnchor {
+	var ret []TrustAnchor
+
+	for _, a := range o.anchors {
+		if kid != "" && a.Key.KeyID() != "" && a.Key.KeyID() != kid {
+			continue
+		}
+
+		if !a.allowsAlgorithm(alg) {
+			continue
+		}
+
+		ret = append(ret, a)
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID < ret[j].ID })
+
+	return ret
+}
+
+// VerifierKeys returns the anchors of the supplied verifier that are valid at
+// time t.  During a key rollover, more than one key can be valid at the same
+// time.
+func (o TrustAnchors) VerifierKeys(verifier string, t time.Time) []TrustAnchor {
+	var ret []TrustAnchor
+
+	for _, a := range o.anchors {
+		if a.Verifier == verifier && a.isValidAt(t) {
+			ret = append(ret, a)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID < ret[j].ID })
+
+	return ret
+}
+
+// VerifyWithAnchors is like Verify, but the verification key and algorithm
+// are automatically selected from the supplied store.  Candidate anchors must
+// match the `kid` header of the EAR (if both are present), and accept its
+// `alg` header.  Since a verifier can have several valid keys at the same time
+// (e.g., while rolling its keys over), all candidates are tried.  The anchor
+// whose key made the signature must have been valid when the EAR was issued,
+// i.e., at its `iat`, so that the EARs signed before a key rollover keep
+// verifying after it.  Whether the EAR is still fresh is a separate check,
+// made against the current time (according to the Clock in use), as in
+// Verify: the EAR must not have been issued in the future, nor be expired, nor
+// be older than allowed by WithMaxAge, if supplied.  The anchor used must also
+// allow the profile of the EAR.  On success, the anchor that was used is
+// returned.
+//
+// If the EAR signature cannot be verified with any valid anchor, a *KeyError
+// is returned, which wraps ErrKeyExpired or ErrKeyNotYetValid if the EAR was
+// signed with a known key outside of its validity window, and ErrUnknownKey
+// otherwise.
+func (o *AttestationResult) VerifyWithAnchors(
+	data []byte,
+	anchors *TrustAnchors,
+	opts ...VerifyOption,
+) (*TrustAnchor, error) {
+	if anchors == nil || anchors.Len() == 0 {
+		return nil, errors.New("no trust anchors supplied")
+	}
+
+	msg, err := jws.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing JWT message: %w", err)
+	}
+
+	if len(msg.Signatures()) == 0 {
+		return nil, errors.New("failed parsing JWT message: no signatures")
+	}
+
+	hdr := msg.Signatures()[0].ProtectedHeaders()
+	kid, alg := hdr.KeyID(), hdr.Algorithm()
+
+	cfg := newVerifyConfig(opts)
+
+	var kerr *KeyError
+
+	for _, a := range anchors.candidates(kid, alg) {
+		a := a
+
+		// only the anchor whose key made the signature is relevant
+		payload, err := jws.Verify(data, jws.WithKey(alg, a.Key))
+		if err != nil {
+			continue
+		}
+
+		iat := issuedAt(payload, cfg.clock.Now())
+
+		if !a.isValidAt(iat) {
+			// a known key used outside of its validity window, which is
+			// reported unless another anchor verifies the EAR
+			if kerr == nil {
+				kerr = &KeyError{Err: ErrKeyExpired, KeyID: kid, AnchorID: a.ID, Verifier: a.Verifier}
+				if !a.NotBefore.IsZero() && iat.Before(a.NotBefore) {
+					kerr.Err = ErrKeyNotYetValid
+				}
+			}
+			continue
+		}
+
+		var ar AttestationResult
+		if err := ar.Verify(data, alg, a.Key, opts...); err != nil {
+			return nil, fmt.Errorf("verifying with anchor %q: %w", a.ID, err)
+		}
+
+		if !a.allowsProfile(ar.Profile) {
+			return nil, fmt.Errorf("verifying with anchor %q: profile %q not allowed",
+				a.ID, stringOrEmpty(ar.Profile))
+		}
+
+		*o = ar
+
+		return &a, nil
+	}
+
+	if kerr != nil {
+		return nil, kerr
+	}
+
+	return nil, &KeyError{Err: ErrUnknownKey, KeyID: kid}
+}
+
+// issuedAt returns the `iat` claim of the supplied (verified) JWT payload, or
+// def if it cannot be found.  (EARs without a valid `iat` are rejected by
+// Verify anyway.)
+func issuedAt(payload []byte, def time.Time) time.Time {
+	var claims struct {
+		IssuedAt *json.Number `json:"iat"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.IssuedAt == nil {
+		return def
+	}
+
+	i, err := claims.IssuedAt.Int64()
+	if err != nil {
+		return def
+	}
+
+	return time.Unix(i, 0)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+var (
+	// ErrUnknownKey means that the EAR was not signed by any known key
+	ErrUnknownKey = errors.New("unknown key")
+	// ErrKeyExpired means that the EAR was signed by a known key whose
+	// validity window has ended
+	ErrKeyExpired = errors.New("key expired")
+	// ErrKeyNotYetValid means that the EAR was signed by a known key whose
+	// validity window has not started yet
+	ErrKeyNotYetValid = errors.New("key not yet valid")
+)
+
+// KeyError is returned by VerifyWithAnchors when no valid anchor can verify
+// the EAR signature, and by VerifyWithKeySet when no key in the set matches
+// the `kid` header of the EAR.  Use errors.Is with ErrUnknownKey, ErrKeyExpired and
+// ErrKeyNotYetValid to find out why.
+type KeyError struct {
+	// Err is one of ErrUnknownKey, ErrKeyExpired and ErrKeyNotYetValid
+	Err error
+	// KeyID is the `kid` header of the EAR, if any
+	KeyID string
+	// AnchorID is the ID of the anchor that matched the signature, if any
+	AnchorID string
+	// Verifier is the verifier of the anchor that matched the signature, if
+	// any
+	Verifier string
+}
+
+func (e *KeyError) Error() string {
+	var b strings.Builder
+
+	b.WriteString(e.Err.Error())
+
+	if e.AnchorID != "" {
+		fmt.Fprintf(&b, " (anchor %q", e.AnchorID)
+		if e.Verifier != "" {
+			fmt.Fprintf(&b, ", verifier %q", e.Verifier)
+		}
+		b.WriteString(")")
+	}
+
+	if e.KeyID != "" {
+		fmt.Fprintf(&b, " for kid %q", e.KeyID)
+	}
+
+	return b.String()
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,235 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func holderPublicKeyPEM(t *testing.T) []byte {
+	k, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	raw, err := jwk.PublicRawKeyOf(k)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(raw)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func mustGenerateP384Key(t *testing.T) *ecdsa.PrivateKey {
+	k, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	return k
+}
+
+// testSignedAt signs a copy of testAttestationResultsWithVeraisonExtns issued
+// at the supplied time
+func testSignedAt(t *testing.T, iat time.Time, key jwk.Key) ([]byte, error) {
+	ar := testAttestationResultsWithVeraisonExtns
+	i := iat.Unix()
+	ar.IssuedAt = &i
+
+	return ar.Sign(jwa.ES256, key)
+}
+
+func makeTestTrustAnchorsFS(t *testing.T) fstest.MapFS {
+	return fstest.MapFS{
+		"verifier.jwk": {Data: []byte(testECDSAPublicKey)},
+		"verifier.meta.json": {Data: []byte(`{
+			"profiles": [ "tag:github.com,2023:veraison/ear" ]
+		}`)},
+		"holder.pem": {Data: holderPublicKeyPEM(t)},
+		"holder.meta.json": {Data: []byte(`{
+			"alg": "ES256",
+			"not-before": "2022-01-01T00:00:00Z",
+			"not-after": "2023-01-01T00:00:00Z"
+		}`)},
+		"README.md": {Data: []byte("ignored")},
+	}
+}
+
+func TestLoadTrustAnchors_ok(t *testing.T) {
+	anchors, err := LoadTrustAnchors(makeTestTrustAnchorsFS(t))
+	require.NoError(t, err)
+	require.Equal(t, 2, anchors.Len())
+
+	holder := anchors.Anchors()[0]
+	assert.Equal(t, "holder", holder.ID)
+	assert.Equal(t, jwa.ES256, holder.Algorithm)
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), holder.NotAfter)
+
+	verifier := anchors.Anchors()[1]
+	assert.Equal(t, "verifier", verifier.ID)
+	assert.Equal(t, []string{EatProfile}, verifier.Profiles)
+}
+
+func TestAttestationResult_VerifyWithAnchors(t *testing.T) {
+	anchors, err := LoadTrustAnchors(makeTestTrustAnchorsFS(t))
+	require.NoError(t, err)
+
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	holderK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var ar AttestationResult
+	anchor, err := ar.VerifyWithAnchors(token, anchors)
+	require.NoError(t, err)
+	assert.Equal(t, "verifier", anchor.ID)
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, ar.Submods)
+
+	// the holder anchor is only valid in 2022: EARs issued then keep
+	// verifying afterwards
+	token, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, holderK)
+	require.NoError(t, err)
+
+	anchor, err = ar.VerifyWithAnchors(token, anchors)
+	require.NoError(t, err)
+	assert.Equal(t, "holder", anchor.ID)
+
+	// ... but not those issued after
+	token, err = testSignedAt(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), holderK)
+	require.NoError(t, err)
+
+	_, err = ar.VerifyWithAnchors(token, anchors)
+	assert.ErrorIs(t, err, ErrKeyExpired)
+	assert.EqualError(t, err, `key expired (anchor "holder")`)
+
+	// ... or before
+	token, err = testSignedAt(t, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), holderK)
+	require.NoError(t, err)
+
+	_, err = ar.VerifyWithAnchors(token, anchors)
+	assert.ErrorIs(t, err, ErrKeyNotYetValid)
+
+	// the freshness of the EAR is still checked against the current time
+	token, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, holderK)
+	require.NoError(t, err)
+
+	_, err = ar.VerifyWithAnchors(token, anchors,
+		WithClock(FixedClock(time.Unix(testIAT, 0).Add(-time.Hour))))
+	assert.ErrorContains(t, err, `verifying with anchor "holder": failed verifying JWT message`)
+
+	_, err = ar.VerifyWithAnchors(token, anchors,
+		WithClock(FixedClock(time.Unix(testIAT, 0).Add(48*time.Hour))), WithMaxAge(time.Hour))
+	assert.ErrorContains(t, err, `verifying with anchor "holder": `)
+
+	// algorithm not accepted by any anchor
+	token, err = testAttestationResultsWithVeraisonExtns.Sign(jwa.ES384, mustGenerateP384Key(t))
+	require.NoError(t, err)
+
+	_, err = ar.VerifyWithAnchors(token, anchors)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	assert.EqualError(t, err, "unknown key")
+}
+
+func TestAttestationResult_VerifyWithAnchors_rotation(t *testing.T) {
+	oldK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	newK, err := jwk.ParseKey([]byte(testHolderPrivateKey))
+	require.NoError(t, err)
+
+	anchors, err := LoadTrustAnchors(fstest.MapFS{
+		"old.jwk": {Data: []byte(testECDSAPublicKey)},
+		"old.meta.json": {Data: []byte(`{
+			"verifier": "acme",
+			"not-after": "2022-12-31T00:00:00Z"
+		}`)},
+		"new.pem": {Data: holderPublicKeyPEM(t)},
+		"new.meta.json": {Data: []byte(`{
+			"verifier": "acme",
+			"not-before": "2022-10-01T00:00:00Z"
+		}`)},
+	})
+	require.NoError(t, err)
+
+	overlap := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Len(t, anchors.VerifierKeys("acme", overlap), 2)
+	assert.Len(t, anchors.VerifierKeys("acme", after), 1)
+	assert.Len(t, anchors.VerifierKeys("other", overlap), 0)
+
+	oldToken, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, oldK)
+	require.NoError(t, err)
+
+	newToken, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, newK)
+	require.NoError(t, err)
+
+	// both keys are accepted during the overlap window
+	var ar AttestationResult
+	for _, tv := range []struct {
+		token  []byte
+		anchor string
+	}{
+		{oldToken, "old"},
+		{newToken, "new"},
+	} {
+		anchor, err := ar.VerifyWithAnchors(tv.token, anchors, WithClock(FixedClock(overlap)))
+		require.NoError(t, err)
+		assert.Equal(t, tv.anchor, anchor.ID)
+		assert.Equal(t, "acme", anchor.Verifier)
+	}
+
+	// after the rollover, the EARs issued before it keep verifying...
+	anchor, err := ar.VerifyWithAnchors(oldToken, anchors, WithClock(FixedClock(after)))
+	require.NoError(t, err)
+	assert.Equal(t, "old", anchor.ID)
+
+	// ... but the old key is reported as expired for those issued after it
+	oldToken, err = testSignedAt(t, after, oldK)
+	require.NoError(t, err)
+
+	_, err = ar.VerifyWithAnchors(oldToken, anchors, WithClock(FixedClock(after)))
+	var kerr *KeyError
+	require.True(t, errors.As(err, &kerr))
+	assert.Equal(t, ErrKeyExpired, kerr.Err)
+	assert.Equal(t, "old", kerr.AnchorID)
+	assert.Equal(t, "acme", kerr.Verifier)
+
+	_, err = ar.VerifyWithAnchors(newToken, anchors, WithClock(FixedClock(after)))
+	require.NoError(t, err)
+}
+
+func TestTrustAnchors_Add_fail(t *testing.T) {
+	k, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	sym, err := jwk.FromRaw([]byte("secret"))
+	require.NoError(t, err)
+
+	anchors := NewTrustAnchors()
+
+	assert.EqualError(t, anchors.Add(TrustAnchor{Key: k}), "missing anchor ID")
+	assert.EqualError(t, anchors.Add(TrustAnchor{ID: "a"}), `anchor "a": missing key`)
+	assert.EqualError(t, anchors.Add(TrustAnchor{ID: "a", Key: sym}),
+		`anchor "a": symmetric keys cannot be used as trust anchors`)
+	require.NoError(t, anchors.Add(TrustAnchor{ID: "a", Key: k}))
+	assert.EqualError(t, anchors.Add(TrustAnchor{ID: "a", Key: k}), `duplicate anchor "a"`)
+
+	var ar AttestationResult
+	_, err = ar.VerifyWithAnchors([]byte("token"), nil)
+	assert.EqualError(t, err, "no trust anchors supplied")
+}
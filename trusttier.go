@@ -56,6 +56,31 @@ var (
 	}
 )
 
+// trustTierRank orders trust tiers from worst to best.  The absence of a
+// claim (TrustTierNone) ranks below warning, but above contraindicated.
+var trustTierRank = map[TrustTier]int{
+	TrustTierContraindicated: 0,
+	TrustTierNone:            1,
+	TrustTierWarning:         2,
+	TrustTierAffirming:       3,
+}
+
+// CompareTiers returns 0 if a and b are the same tier, -1 if a is worse than
+// b, and +1 if a is better than b.  From best to worst, tiers are ordered as
+// follows: affirming, warning, none, contraindicated.
+func CompareTiers(a, b TrustTier) int {
+	ra, rb := trustTierRank[a], trustTierRank[b]
+
+	switch {
+	case ra < rb:
+		return -1
+	case ra > rb:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // NewTrustTier returns a pointer to a newly-created TrustTier that has the
 // specified value. If the provided value is invalid for a TrustTier,
 // TrustTierNone will be used instead.
@@ -173,3 +173,25 @@ func TestTrustTier_ToTrustTier(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, TrustTierAffirming, *tt)
 }
+
+func TestCompareTiers(t *testing.T) {
+	ordered := []TrustTier{
+		TrustTierContraindicated,
+		TrustTierNone,
+		TrustTierWarning,
+		TrustTierAffirming,
+	}
+
+	for i, a := range ordered {
+		for j, b := range ordered {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+
+			assert.Equal(t, expected, CompareTiers(a, b), "%s vs %s", a, b)
+		}
+	}
+}
@@ -16,6 +16,19 @@ type TrustVector struct {
 	SourcedData      TrustClaim `json:"sourced-data,omitempty"`
 }
 
+// trustVectorClaimNames are the names of the trustworthiness vector claims,
+// in the order in which they are defined
+var trustVectorClaimNames = []string{
+	"instance-identity",
+	"configuration",
+	"executables",
+	"file-system",
+	"hardware",
+	"runtime-opaque",
+	"storage-opaque",
+	"sourced-data",
+}
+
 // AsMap() returns a map[string]TrustClaim with claims names mapped onto
 // corresponding TrustClaim values.
 func (o TrustVector) AsMap() map[string]TrustClaim {
@@ -38,17 +51,47 @@ func ToTrustVector(v interface{}) (*TrustVector, error) {
 
 	var tv TrustVector
 
+	if m, ok := v.(map[string]interface{}); ok {
+		return &tv, tv.populateFromMap(m)
+	}
+
 	err := populateStructFromInterface(
 		&tv, v, "json",
-		map[string]parser{}, // use defaultParser below for everything
-		func(iface interface{}) (interface{}, error) {
-			claim, err := ToTrustClaim(iface)
-			return *claim, err
-		}, false)
+		map[string]parser{}, // use trustClaimParser for everything
+		trustClaimParser, false)
 
 	return &tv, err
 }
 
+func trustClaimParser(v interface{}) (interface{}, error) {
+	claim, err := ToTrustClaim(v)
+	return *claim, err
+}
+
+func (o *TrustVector) populateFromMap(m map[string]interface{}) error {
+	r := claimsReader{m: m}
+
+	for _, c := range []struct {
+		dst  *TrustClaim
+		name string
+	}{
+		{&o.InstanceIdentity, "instance-identity"},
+		{&o.Configuration, "configuration"},
+		{&o.Executables, "executables"},
+		{&o.FileSystem, "file-system"},
+		{&o.Hardware, "hardware"},
+		{&o.RuntimeOpaque, "runtime-opaque"},
+		{&o.StorageOpaque, "storage-opaque"},
+		{&o.SourcedData, "sourced-data"},
+	} {
+		if v, ok := r.read(c.name, false, trustClaimParser); ok {
+			*c.dst = v.(TrustClaim)
+		}
+	}
+
+	return r.err(trustVectorClaimNames)
+}
+
 // SetAll sets all vector elements to the specified claim. This is primarily
 // useful with globally-applicable claims such as -1 (verifier malfunction), 0
 // (no claim, in order to "reset" the vector), or 99 (cryptographic validation
@@ -118,3 +161,144 @@ func (o TrustVector) Report(short, color bool) string {
 
 	return s
 }
+
+// ClaimComparison is the comparison of one claim of two trustworthiness
+// vectors (see TrustVector.Compare)
+type ClaimComparison struct {
+	// Claim is the name of the claim, e.g., "executables"
+	Claim string
+	// Tier and OtherTier are the tiers of the claim in the two vectors
+	Tier      TrustTier
+	OtherTier TrustTier
+	// Result is 0 if the tiers are the same, -1 if Tier is worse than
+	// OtherTier, and +1 if it is better (see CompareTiers)
+	Result int
+}
+
+// Compare compares the target vector with other, tier-by-tier, and returns
+// the outcome for each claim, in the order in which claims are defined
+func (o TrustVector) Compare(other TrustVector) []ClaimComparison {
+	mine, theirs := o.AsMap(), other.AsMap()
+
+	res := make([]ClaimComparison, 0, len(trustVectorClaimNames))
+
+	for _, name := range trustVectorClaimNames {
+		a, b := mine[name].GetTier(), theirs[name].GetTier()
+
+		res = append(res, ClaimComparison{
+			Claim:     name,
+			Tier:      a,
+			OtherTier: b,
+			Result:    CompareTiers(a, b),
+		})
+	}
+
+	return res
+}
+
+// MeetsOrExceeds checks the target vector against a reference vector stating
+// the minimum tier required for each claim.  Claims that are not set in the
+// reference vector (i.e., NoClaim) are not checked.  A claim that is not set
+// in the target vector only meets a NoClaim requirement.  The names of the
+// claims that fall short, if any, are returned in the order in which claims
+// are defined.
+func (o TrustVector) MeetsOrExceeds(required TrustVector) (bool, []string) {
+	var short []string
+
+	for _, c := range o.Compare(required) {
+		if !meetsTier(c.Tier, c.OtherTier) {
+			short = append(short, c.Claim)
+		}
+	}
+
+	return len(short) == 0, short
+}
+
+// meetsTier reports whether the actual tier is at least as good as the
+// required one (see CompareTiers).  A TrustTierNone requirement is always
+// met, whereas TrustTierNone only meets a TrustTierNone requirement.
+func meetsTier(actual, required TrustTier) bool {
+	if required == TrustTierNone {
+		return true
+	}
+
+	return actual != TrustTierNone && CompareTiers(actual, required) >= 0
+}
+
+// MergeStrategy picks the claim that results from merging claim a, made by
+// the target vector, with claim b, made by the other vector (see
+// TrustVector.Merge)
+type MergeStrategy func(a, b TrustClaim) TrustClaim
+
+// MergeWorstOf returns a MergeStrategy that picks the worse of the two claims
+// (see CompareTiers).  A claim in the "none" tier is only picked if the other
+// one is in the "none" tier too, so that a vector making no statement about
+// an aspect of the attester does not mask the statements of the other.  Ties
+// are resolved in favour of the target vector.
+func MergeWorstOf() MergeStrategy {
+	return func(a, b TrustClaim) TrustClaim {
+		switch {
+		case a.IsNone():
+			return b
+		case b.IsNone():
+			return a
+		case CompareTiers(b.GetTier(), a.GetTier()) < 0:
+			return b
+		default:
+			return a
+		}
+	}
+}
+
+// MergeBestOf returns a MergeStrategy that picks the better of the two claims
+// (see CompareTiers).  As with MergeWorstOf, a claim in the "none" tier is
+// only picked if the other one is in the "none" tier too.  Ties are resolved
+// in favour of the target vector.
+func MergeBestOf() MergeStrategy {
+	return func(a, b TrustClaim) TrustClaim {
+		switch {
+		case a.IsNone():
+			return b
+		case b.IsNone():
+			return a
+		case CompareTiers(b.GetTier(), a.GetTier()) > 0:
+			return b
+		default:
+			return a
+		}
+	}
+}
+
+// MergeLatestWins returns a MergeStrategy that picks the claim made by the
+// other vector, which is assumed to be the most recent, unless it is in the
+// "none" tier
+func MergeLatestWins() MergeStrategy {
+	return func(a, b TrustClaim) TrustClaim {
+		if b.IsNone() {
+			return a
+		}
+
+		return b
+	}
+}
+
+// Merge combines the target vector with other, claim-by-claim, using the
+// supplied strategy, e.g., so that the vectors produced by the components of
+// a composite attester can be folded into a single appraisal.  A nil
+// strategy selects MergeWorstOf.  Neither vector is modified.
+func (o TrustVector) Merge(other TrustVector, strategy MergeStrategy) TrustVector {
+	if strategy == nil {
+		strategy = MergeWorstOf()
+	}
+
+	return TrustVector{
+		InstanceIdentity: strategy(o.InstanceIdentity, other.InstanceIdentity),
+		Configuration:    strategy(o.Configuration, other.Configuration),
+		Executables:      strategy(o.Executables, other.Executables),
+		FileSystem:       strategy(o.FileSystem, other.FileSystem),
+		Hardware:         strategy(o.Hardware, other.Hardware),
+		RuntimeOpaque:    strategy(o.RuntimeOpaque, other.RuntimeOpaque),
+		StorageOpaque:    strategy(o.StorageOpaque, other.StorageOpaque),
+		SourcedData:      strategy(o.SourcedData, other.SourcedData),
+	}
+}
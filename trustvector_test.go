@@ -135,3 +135,128 @@ func TestTrustVector_SetAll(t *testing.T) {
 	tv.SetAll(VerifierMalfunctionClaim)
 	assert.Equal(t, VerifierMalfunctionClaim, tv.Configuration)
 }
+
+func TestTrustVector_Compare(t *testing.T) {
+	a := TrustVector{
+		InstanceIdentity: TrustworthyInstanceClaim,
+		Executables:      ApprovedRuntimeClaim,
+		Hardware:         UnsafeHardwareClaim,
+	}
+
+	b := TrustVector{
+		InstanceIdentity: TrustworthyInstanceClaim,
+		Executables:      UnrecognizedRuntimeClaim,
+		Hardware:         GenuineHardwareClaim,
+	}
+
+	expected := []ClaimComparison{
+		{"instance-identity", TrustTierAffirming, TrustTierAffirming, 0},
+		{"configuration", TrustTierNone, TrustTierNone, 0},
+		{"executables", TrustTierAffirming, TrustTierWarning, 1},
+		{"file-system", TrustTierNone, TrustTierNone, 0},
+		{"hardware", TrustTierWarning, TrustTierAffirming, -1},
+		{"runtime-opaque", TrustTierNone, TrustTierNone, 0},
+		{"storage-opaque", TrustTierNone, TrustTierNone, 0},
+		{"sourced-data", TrustTierNone, TrustTierNone, 0},
+	}
+
+	assert.Equal(t, expected, a.Compare(b))
+}
+
+func TestTrustVector_MeetsOrExceeds(t *testing.T) {
+	tv := TrustVector{
+		InstanceIdentity: TrustworthyInstanceClaim,
+		Executables:      UnrecognizedRuntimeClaim,
+		Hardware:         GenuineHardwareClaim,
+		FileSystem:       ContraindicatedFilesClaim,
+	}
+
+	tvs := []struct {
+		required TrustVector
+		short    []string
+	}{
+		{
+			required: TrustVector{},
+		},
+		{
+			required: TrustVector{
+				InstanceIdentity: TrustworthyInstanceClaim,
+				Executables:      UnrecognizedRuntimeClaim,
+				Hardware:         UnsafeHardwareClaim,
+			},
+		},
+		{
+			required: TrustVector{
+				Executables:   ApprovedRuntimeClaim,
+				FileSystem:    UnrecognizedFilesClaim,
+				Configuration: UnsafeConfigClaim,
+			},
+			short: []string{"configuration", "executables", "file-system"},
+		},
+	}
+
+	for i, v := range tvs {
+		ok, short := tv.MeetsOrExceeds(v.required)
+		assert.Equal(t, len(v.short) == 0, ok, "failed test vector at index %d", i)
+		assert.Equal(t, v.short, short, "failed test vector at index %d", i)
+	}
+}
+
+func TestTrustVector_Merge(t *testing.T) {
+	a := TrustVector{
+		InstanceIdentity: TrustworthyInstanceClaim,
+		Executables:      ApprovedRuntimeClaim,
+		Hardware:         UnsafeHardwareClaim,
+	}
+
+	b := TrustVector{
+		InstanceIdentity: TrustworthyInstanceClaim,
+		Configuration:    UnsafeConfigClaim,
+		Executables:      ContraindicatedRuntimeClaim,
+		Hardware:         GenuineHardwareClaim,
+	}
+
+	tvs := []struct {
+		strategy MergeStrategy
+		expected TrustVector
+	}{
+		{
+			strategy: MergeWorstOf(),
+			expected: TrustVector{
+				InstanceIdentity: TrustworthyInstanceClaim,
+				Configuration:    UnsafeConfigClaim,
+				Executables:      ContraindicatedRuntimeClaim,
+				Hardware:         UnsafeHardwareClaim,
+			},
+		},
+		{
+			strategy: MergeBestOf(),
+			expected: TrustVector{
+				InstanceIdentity: TrustworthyInstanceClaim,
+				Configuration:    UnsafeConfigClaim,
+				Executables:      ApprovedRuntimeClaim,
+				Hardware:         GenuineHardwareClaim,
+			},
+		},
+		{
+			strategy: MergeLatestWins(),
+			expected: b,
+		},
+		{
+			// nil selects MergeWorstOf
+			expected: TrustVector{
+				InstanceIdentity: TrustworthyInstanceClaim,
+				Configuration:    UnsafeConfigClaim,
+				Executables:      ContraindicatedRuntimeClaim,
+				Hardware:         UnsafeHardwareClaim,
+			},
+		},
+	}
+
+	for i, v := range tvs {
+		assert.Equal(t, v.expected, a.Merge(b, v.strategy), "failed test vector at index %d", i)
+	}
+
+	// claims the latest vector makes no statement about are retained
+	assert.Equal(t, a, a.Merge(TrustVector{}, MergeLatestWins()))
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// errInsecureUCCS is returned when an unprotected claims-set is produced or
+// consumed without acknowledging that the transport is secured
+var errInsecureUCCS = errors.New(
+	"unprotected claims-set refused: use WithSecuredTransport to acknowledge that the transport protects it",
+)
+
+type securedTransportOption struct{}
+
+func (o securedTransportOption) applySignOption(c *signConfig)     { c.securedTransport = true }
+func (o securedTransportOption) applyVerifyOption(c *verifyConfig) { c.securedTransport = true }
+
+// WithSecuredTransport acknowledges that the EAR is conveyed over a channel
+// that already guarantees its integrity and authenticity (e.g., a secure
+// channel inside a TEE), and can therefore be left unsigned.  It must be
+// supplied to ToUnprotectedCBOR and FromUnprotectedCBOR, so that an unsigned
+// result cannot be mistaken for a verified one by accident.
+func WithSecuredTransport() Option {
+	return securedTransportOption{}
+}
+
+// ToUnprotectedCBOR is like SignCWT, but the claims-set is not signed: it is
+// serialized to CBOR (see MarshalCBOR) and wrapped in the UCCS tag (601), as
+// an Unprotected CWT Claims Set (RFC 9781).  It fails unless
+// WithSecuredTransport is supplied.  Options affecting the protected header
+// have no effect.
+func (o AttestationResult) ToUnprotectedCBOR(opts ...SignOption) ([]byte, error) {
+	cfg := newSignConfig(opts)
+
+	if !cfg.securedTransport {
+		return nil, errInsecureUCCS
+	}
+
+	claims, err := o.claimsSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := claimsToCBOR(claims)
+	if err != nil {
+		return nil, fmt.Errorf("encoding claims-set: %w", err)
+	}
+
+	return append(append([]byte{}, cborTagUCCSPrefix...), payload...), nil
+}
+
+// FromUnprotectedCBOR is like VerifyCWT, but for an Unprotected CWT Claims
+// Set, as produced by ToUnprotectedCBOR: there is no signature to check, but
+// the claims-set is otherwise decoded, validated and checked exactly as
+// VerifyCWT does.  It fails unless WithSecuredTransport is supplied, and it
+// only accepts data wrapped in the UCCS tag (601), so that neither a bare
+// claims-set nor a CWT can be taken for a UCCS.
+func (o *AttestationResult) FromUnprotectedCBOR(data []byte, opts ...VerifyOption) error {
+	cfg := newVerifyConfig(opts)
+
+	if !cfg.securedTransport {
+		return errInsecureUCCS
+	}
+
+	if !bytes.HasPrefix(data, cborTagUCCSPrefix) {
+		return fmt.Errorf("not an unprotected claims-set: missing tag %d", CBORTagUCCS)
+	}
+
+	claims, err := claimsFromCBOR(data[len(cborTagUCCSPrefix):])
+	if err != nil {
+		return fmt.Errorf("decoding claims-set: %w", err)
+	}
+
+	if err := checkValidityPeriod(claims, cfg.clock.Now(), cfg.clockSkew); err != nil {
+		return fmt.Errorf("failed checking unprotected claims-set: %w", err)
+	}
+
+	iss, _ := claims["iss"].(string)
+
+	_, err = o.populateFromClaims(claims, iss, cfg)
+	return err
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnprotectedCBOR_round_trip(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := WithClock(FixedClock(now))
+
+	data, err := testAttestationResultsWithVeraisonExtns.ToUnprotectedCBOR(
+		WithSecuredTransport(), clock, WithIssuedAtNow(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{CBORTagUCCS}, cborTags(data))
+
+	var actual AttestationResult
+	require.NoError(t, actual.FromUnprotectedCBOR(data, WithSecuredTransport(), clock))
+	assert.Equal(t, now.Unix(), *actual.IssuedAt)
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns.Submods, actual.Submods)
+
+	// Decode treats it as a bare claims-set
+	ar, f, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, FormatCBOR, f)
+	assert.Equal(t, now.Unix(), *ar.IssuedAt)
+}
+
+func TestUnprotectedCBOR_requires_secured_transport(t *testing.T) {
+	_, err := testAttestationResultsWithVeraisonExtns.ToUnprotectedCBOR()
+	assert.ErrorIs(t, err, errInsecureUCCS)
+
+	data, err := testAttestationResultsWithVeraisonExtns.ToUnprotectedCBOR(WithSecuredTransport())
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	err = actual.FromUnprotectedCBOR(data)
+	assert.ErrorIs(t, err, errInsecureUCCS)
+
+	// an unprotected claims-set is no substitute for a signed EAR
+	err = actual.VerifyCWT(data, jwa.ES256, mustParseKey(t, testECDSAPublicKey))
+	assert.ErrorContains(t, err, "failed parsing CWT message")
+
+	_, _, err = Decode(data, WithVerificationKey(jwa.ES256, mustParseKey(t, testECDSAPublicKey)))
+	assert.ErrorContains(t, err, "bare CBOR claims-set found, expecting a signed EAR")
+}
+
+func TestFromUnprotectedCBOR_fail(t *testing.T) {
+	var actual AttestationResult
+
+	bare, err := testAttestationResultsWithVeraisonExtns.MarshalCBOR()
+	require.NoError(t, err)
+
+	err = actual.FromUnprotectedCBOR(bare, WithSecuredTransport())
+	assert.EqualError(t, err, "not an unprotected claims-set: missing tag 601")
+
+	cwt, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	err = actual.FromUnprotectedCBOR(cwt, WithSecuredTransport())
+	assert.EqualError(t, err, "not an unprotected claims-set: missing tag 601")
+
+	expiring, err := testAttestationResultsWithVeraisonExtns.ToUnprotectedCBOR(
+		WithSecuredTransport(), WithTTL(time.Minute),
+	)
+	require.NoError(t, err)
+
+	err = actual.FromUnprotectedCBOR(expiring, WithSecuredTransport(),
+		WithClock(FixedClock(time.Unix(testIAT, 0).Add(time.Hour))))
+	assert.EqualError(t, err, `failed checking unprotected claims-set: "exp" not satisfied`)
+
+	err = actual.FromUnprotectedCBOR(expiring, WithSecuredTransport(),
+		WithClock(FixedClock(time.Unix(testIAT, 0))),
+		WithExpectedProfile("tag:example.com,2026:other"))
+	assert.ErrorContains(t, err, "eat_profile")
+}
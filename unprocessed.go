@@ -0,0 +1,117 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+)
+
+// UnprocessedEvidence describes a piece of evidence that the verifier
+// received but could not appraise, e.g., because no verification plugin
+// supports its media type.  A list of UnprocessedEvidence is carried in the
+// "ear.veraison.unprocessed-evidence" claim, so that relying parties can tell
+// evidence that has been appraised from evidence that has been ignored.
+type UnprocessedEvidence struct {
+	// MediaType is the media type of the evidence
+	MediaType *string `json:"media-type"`
+	// EvidenceID is an (optional) identifier of the evidence, e.g., the
+	// "evidence-id" of the corresponding "ear.veraison.tee-info"
+	EvidenceID *string `json:"evidence-id,omitempty"`
+	// Reason is an (optional) machine-readable explanation of why the
+	// evidence was not processed, e.g., "unsupported-media-type"
+	Reason *string `json:"reason,omitempty"`
+}
+
+func (o UnprocessedEvidence) validate() error {
+	if o.MediaType == nil {
+		return errors.New(`missing "media-type"`)
+	}
+
+	if _, _, err := mime.ParseMediaType(*o.MediaType); err != nil {
+		return fmt.Errorf(`invalid "media-type" %q: %w`, *o.MediaType, err)
+	}
+
+	if o.EvidenceID != nil && *o.EvidenceID == "" {
+		return errors.New(`empty "evidence-id"`)
+	}
+
+	if o.Reason != nil && *o.Reason == "" {
+		return errors.New(`empty "reason"`)
+	}
+
+	return nil
+}
+
+// AddUnprocessedEvidence appends a new entry to the
+// "ear.veraison.unprocessed-evidence" claim.  evidenceID and reason are
+// optional and can be left empty.
+func (o *AppraisalExtensions) AddUnprocessedEvidence(mediaType, evidenceID, reason string) error {
+	u := UnprocessedEvidence{
+		MediaType: &mediaType,
+	}
+
+	if evidenceID != "" {
+		u.EvidenceID = &evidenceID
+	}
+
+	if reason != "" {
+		u.Reason = &reason
+	}
+
+	if err := u.validate(); err != nil {
+		return err
+	}
+
+	if o.VeraisonUnprocessedEvidence == nil {
+		o.VeraisonUnprocessedEvidence = &[]UnprocessedEvidence{}
+	}
+
+	*o.VeraisonUnprocessedEvidence = append(*o.VeraisonUnprocessedEvidence, u)
+
+	return nil
+}
+
+// GetUnprocessedEvidence returns the entries in the
+// "ear.veraison.unprocessed-evidence" claim.
+func (o AppraisalExtensions) GetUnprocessedEvidence() ([]UnprocessedEvidence, error) {
+	if o.VeraisonUnprocessedEvidence == nil {
+		return nil, errors.New(`"ear.veraison.unprocessed-evidence" claim not found`)
+	}
+
+	return *o.VeraisonUnprocessedEvidence, nil
+}
+
+// ToUnprocessedEvidence parses the value of the
+// "ear.veraison.unprocessed-evidence" claim
+func ToUnprocessedEvidence(v interface{}) (*[]UnprocessedEvidence, error) {
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("not a JSON array")
+	}
+
+	ret := make([]UnprocessedEvidence, 0, len(l))
+
+	for i, e := range l {
+		var u UnprocessedEvidence
+
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry %d: not a JSON object", i)
+		}
+
+		if err := populateStructFromMap(&u, m, "json", nil, stringPtrParser, false); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		if err := u.validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		ret = append(ret, u)
+	}
+
+	return &ret, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppraisalExtensions_AddGetUnprocessedEvidence_ok(t *testing.T) {
+	var a AppraisalExtensions
+
+	_, err := a.GetUnprocessedEvidence()
+	assert.EqualError(t, err, `"ear.veraison.unprocessed-evidence" claim not found`)
+
+	require.NoError(t, a.AddUnprocessedEvidence("application/eat+cwt", "evidence-01", "unsupported-media-type"))
+	require.NoError(t, a.AddUnprocessedEvidence(`application/eat+cwt; eat_profile="tag:psacertified.org,2023:psa#tfm"`, "", ""))
+
+	l, err := a.GetUnprocessedEvidence()
+	require.NoError(t, err)
+	require.Len(t, l, 2)
+	assert.Equal(t, "application/eat+cwt", *l[0].MediaType)
+	assert.Equal(t, "evidence-01", *l[0].EvidenceID)
+	assert.Equal(t, "unsupported-media-type", *l[0].Reason)
+	assert.Nil(t, l[1].EvidenceID)
+	assert.Nil(t, l[1].Reason)
+}
+
+func TestAppraisalExtensions_AddUnprocessedEvidence_fail(t *testing.T) {
+	var a AppraisalExtensions
+
+	err := a.AddUnprocessedEvidence("", "", "")
+	assert.EqualError(t, err, `invalid "media-type" "": mime: no media type`)
+
+	err = a.AddUnprocessedEvidence("application/", "", "")
+	assert.ErrorContains(t, err, `invalid "media-type" "application/"`)
+
+	assert.Nil(t, a.VeraisonUnprocessedEvidence)
+}
+
+func TestUnprocessedEvidence_round_trip(t *testing.T) {
+	status := TrustTierNone
+	appraisal := Appraisal{Status: &status}
+
+	require.NoError(t, appraisal.AddUnprocessedEvidence("application/vnd.example.evidence", "ev-1", "no-plugin"))
+
+	data, err := json.Marshal(appraisal.AsMap())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ear.status": "none",
+		"ear.veraison.unprocessed-evidence": [
+			{
+				"media-type": "application/vnd.example.evidence",
+				"evidence-id": "ev-1",
+				"reason": "no-plugin"
+			}
+		]
+	}`, string(data))
+
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	actual, err := ToAppraisal(m)
+	require.NoError(t, err)
+	assert.Equal(t, appraisal, *actual)
+}
+
+func TestToUnprocessedEvidence_fail(t *testing.T) {
+	_, err := ToUnprocessedEvidence("not a list")
+	assert.EqualError(t, err, "not a JSON array")
+
+	_, err = ToUnprocessedEvidence([]interface{}{"not an object"})
+	assert.EqualError(t, err, "entry 0: not a JSON object")
+
+	_, err = ToUnprocessedEvidence([]interface{}{
+		map[string]interface{}{"media-type": "text/plain", "reason": ""},
+	})
+	assert.EqualError(t, err, `entry 0: empty "reason"`)
+}
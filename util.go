@@ -308,7 +308,6 @@ func populateStructFromMap(
 	ignoreUnexpected bool,
 ) error {
 	var missing, invalid []string
-	var problems []string
 
 	destType := reflect.TypeOf(dest)
 	destVal := reflect.ValueOf(dest)
@@ -321,7 +320,18 @@ func populateStructFromMap(
 		m, tagKey, parsers, defaultParser,
 		&missing, &invalid)
 
-	extra := getExtraKeys(m, found)
+	var extra []string
+	if !ignoreUnexpected {
+		extra = getExtraKeys(m, found)
+	}
+
+	return mapProblems(missing, invalid, extra)
+}
+
+// mapProblems returns an error summarizing the problems found populating a
+// struct from a map, if any
+func mapProblems(missing, invalid, extra []string) error {
+	var problems []string
 
 	if len(missing) > 0 {
 		msg := fmt.Sprintf("missing mandatory %s", strings.Join(missing, ", "))
@@ -333,7 +343,7 @@ func populateStructFromMap(
 		problems = append(problems, msg)
 	}
 
-	if len(extra) > 0 && !ignoreUnexpected {
+	if len(extra) > 0 {
 		msg := fmt.Sprintf("unexpected: %s", strings.Join(extra, ", "))
 		problems = append(problems, msg)
 	}
@@ -343,7 +353,6 @@ func populateStructFromMap(
 	}
 
 	return nil
-
 }
 
 func doPopulateStructFromMap(
@@ -0,0 +1,217 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+// VerifiedResult is a read-only view of a verified AttestationResult.  It has
+// no exported fields, and its accessors only ever return copies of the
+// underlying claims, so one VerifiedResult can be shared by concurrent
+// relying-party handlers without any locking.  Use AttestationResult to get a
+// (mutable) deep copy of the whole result.
+type VerifiedResult struct {
+	ar       AttestationResult
+	data     []byte
+	policies map[string]*Policy
+	warnings []Warning
+}
+
+// Verify cryptographically verifies the JWT data using the supplied key and
+// algorithm, exactly as AttestationResult.Verify does.  On success, the result
+// is returned as a VerifiedResult, annotated with the appraisal policies
+// resolved during verification, if any (see WithPolicyResolver), and with the
+// warnings found in the claims-set (see Warnings).
+func Verify(
+	data []byte,
+	alg jwa.KeyAlgorithm,
+	key interface{},
+	opts ...VerifyOption,
+) (*VerifiedResult, error) {
+	cfg := newVerifyConfig(opts)
+
+	var ar AttestationResult
+
+	v, err := ar.verify(data, alg, key, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	vr, err := newVerifiedResult(ar)
+	if err != nil {
+		return nil, err
+	}
+
+	vr.policies = v.policies
+	vr.warnings = v.warnings
+
+	return vr, nil
+}
+
+func newVerifiedResult(ar AttestationResult) (*VerifiedResult, error) {
+	data, err := ar.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	// decouple the view from the (caller-owned) pointers in ar
+	var o VerifiedResult
+	if err := o.ar.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+
+	o.data = data
+
+	return &o, nil
+}
+
+// AttestationResult returns a deep copy of the verified result, which the
+// caller is free to modify
+func (o VerifiedResult) AttestationResult() AttestationResult {
+	var ar AttestationResult
+	if err := ar.UnmarshalJSON(o.data); err != nil {
+		// the data has been validated on construction
+		panic(fmt.Sprintf("decoding verified result: %v", err))
+	}
+
+	return ar
+}
+
+// MarshalJSON returns the JSON serialization of the verified result
+func (o VerifiedResult) MarshalJSON() ([]byte, error) {
+	return append([]byte(nil), o.data...), nil
+}
+
+// Profile returns the value of the "eat_profile" claim
+func (o VerifiedResult) Profile() string {
+	return *o.ar.Profile
+}
+
+// IssuedAt returns the value of the "iat" claim
+func (o VerifiedResult) IssuedAt() time.Time {
+	return time.Unix(*o.ar.IssuedAt, 0)
+}
+
+// Nonce returns the value of the "eat_nonce" claim, if present and not in the
+// array form (see Nonces)
+func (o VerifiedResult) Nonce() (string, bool) {
+	if o.ar.Nonce == nil {
+		return "", false
+	}
+
+	return *o.ar.Nonce, true
+}
+
+// Nonces returns the nonces in the "eat_nonce" claim, if present, whether it
+// is a single nonce or an array of nonces
+func (o VerifiedResult) Nonces() ([]string, bool) {
+	nonces := o.ar.rawNonces()
+	if len(nonces) == 0 {
+		return nil, false
+	}
+
+	return append([]string(nil), nonces...), true
+}
+
+// VerifierID returns a copy of the "ear.verifier-id" claim
+func (o VerifiedResult) VerifierID() VerifierIdentity {
+	return *o.AttestationResult().VerifierID
+}
+
+// RawEvidence returns a copy of the "ear.raw-evidence" claim, if present
+func (o VerifiedResult) RawEvidence() ([]byte, bool) {
+	if o.ar.RawEvidence == nil {
+		return nil, false
+	}
+
+	return append([]byte(nil), *o.ar.RawEvidence...), true
+}
+
+// Submods returns the (sorted) names of the submodules
+func (o VerifiedResult) Submods() []string {
+	names := make([]string, 0, len(o.ar.Submods))
+	for name := range o.ar.Submods {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Appraisal returns a copy of the appraisal of the named submodule, if present
+func (o VerifiedResult) Appraisal(submod string) (Appraisal, bool) {
+	if _, ok := o.ar.Submods[submod]; !ok {
+		return Appraisal{}, false
+	}
+
+	return *o.AttestationResult().Submods[submod], true
+}
+
+// Status returns the "ear.status" of the named submodule, if present
+func (o VerifiedResult) Status(submod string) (TrustTier, bool) {
+	a, ok := o.ar.Submods[submod]
+	if !ok {
+		return TrustTierNone, false
+	}
+
+	return *a.Status, true
+}
+
+// TrustVector returns the "ear.trustworthiness-vector" of the named
+// submodule, if present
+func (o VerifiedResult) TrustVector(submod string) (TrustVector, bool) {
+	a, ok := o.ar.Submods[submod]
+	if !ok || a.TrustVector == nil {
+		return TrustVector{}, false
+	}
+
+	return *a.TrustVector, true
+}
+
+// AppraisalPolicyID returns the "ear.appraisal-policy-id" of the named
+// submodule, if present
+func (o VerifiedResult) AppraisalPolicyID(submod string) (string, bool) {
+	a, ok := o.ar.Submods[submod]
+	if !ok || a.AppraisalPolicyID == nil {
+		return "", false
+	}
+
+	return *a.AppraisalPolicyID, true
+}
+
+// Policy returns a copy of the appraisal policy of the named submod, if it has
+// been resolved during verification (see WithPolicyResolver)
+func (o VerifiedResult) Policy(submod string) (Policy, bool) {
+	p, ok := o.policies[submod]
+	if !ok {
+		return Policy{}, false
+	}
+
+	cp := Policy{
+		ID:        p.ID,
+		MediaType: p.MediaType,
+		Content:   append([]byte(nil), p.Content...),
+	}
+
+	if p.Metadata != nil {
+		cp.Metadata = make(map[string]string, len(p.Metadata))
+		for k, v := range p.Metadata {
+			cp.Metadata[k] = v
+		}
+	}
+
+	return cp, true
+}
+
+// Warnings returns the non-fatal findings about the verified claims-set, e.g.,
+// unknown claims, sorted by claim path
+func (o VerifiedResult) Warnings() []Warning {
+	return append([]Warning(nil), o.warnings...)
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify_VerifiedResult(t *testing.T) {
+	vfyK, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	vr, err := Verify([]byte(testEdDSAToken), jwa.EdDSA, vfyK)
+	require.NoError(t, err)
+
+	assert.Equal(t, EatProfile, vr.Profile())
+	assert.Equal(t, time.Unix(testIAT, 0), vr.IssuedAt())
+	assert.Equal(t, testVerifierID, vr.VerifierID())
+	assert.Equal(t, []string{"test"}, vr.Submods())
+
+	_, ok := vr.Nonce()
+	assert.False(t, ok)
+
+	_, ok = vr.RawEvidence()
+	assert.False(t, ok)
+
+	status, ok := vr.Status("test")
+	assert.True(t, ok)
+	assert.Equal(t, TrustTierAffirming, status)
+
+	policyID, ok := vr.AppraisalPolicyID("test")
+	assert.True(t, ok)
+	assert.Equal(t, testPolicyID, policyID)
+
+	_, ok = vr.TrustVector("test")
+	assert.False(t, ok)
+
+	_, ok = vr.Status("missing")
+	assert.False(t, ok)
+
+	_, ok = vr.Appraisal("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, vr.AttestationResult())
+
+	data, err := vr.MarshalJSON()
+	require.NoError(t, err)
+
+	expected, err := testAttestationResultsWithVeraisonExtns.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(data))
+}
+
+func TestVerifiedResult_copies(t *testing.T) {
+	vfyK, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	vr, err := Verify([]byte(testEdDSAToken), jwa.EdDSA, vfyK)
+	require.NoError(t, err)
+
+	// modifying returned values does not affect the verified result
+	ar := vr.AttestationResult()
+	*ar.Submods["test"].Status = TrustTierContraindicated
+	(*ar.Submods["test"].VeraisonPolicyClaims)["foo"] = "tampered"
+
+	appraisal, ok := vr.Appraisal("test")
+	require.True(t, ok)
+	*appraisal.AppraisalPolicyID = "tampered"
+
+	vid := vr.VerifierID()
+	*vid.Build = "tampered"
+
+	data, err := vr.MarshalJSON()
+	require.NoError(t, err)
+	data[0] = 'X'
+
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, vr.AttestationResult())
+
+	status, _ := vr.Status("test")
+	assert.Equal(t, TrustTierAffirming, status)
+}
+
+func TestVerifiedResult_concurrent(t *testing.T) {
+	vfyK, err := jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	vr, err := Verify([]byte(testEdDSAToken), jwa.EdDSA, vfyK)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ar := vr.AttestationResult()
+			ar.Submods["test"].Status = NewTrustTier(TrustTierWarning)
+
+			status, _ := vr.Status("test")
+			assert.Equal(t, TrustTierAffirming, status)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestVerify_VerifiedResult_fail(t *testing.T) {
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	_, err = Verify([]byte(testEdDSAToken), jwa.ES256, vfyK)
+	assert.ErrorContains(t, err, "failed verifying JWT message: ")
+}
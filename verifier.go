@@ -5,17 +5,31 @@ package ear
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 )
 
 // VerifierIdentity is the verifier software identification as defined by AR4SI:
 //
 //	https://datatracker.ietf.org/doc/html/draft-ietf-rats-ar4si-03#section-2.2.2
+//
+// On top of the AR4SI fields, a few optional extension fields are provided to
+// surface operational details about the verifier.
 type VerifierIdentity struct {
 	// Build uniquely identifies the software build running the verifier.
 	Build *string `json:"build"`
 	// Developer uniquely identifies the organizational unit responsible
 	// for this build.
 	Developer *string `json:"developer"`
+	// Instance (optional) identifies the verifier instance (e.g., a
+	// hostname or a deployment identifier) that produced the result.
+	Instance *string `json:"instance,omitempty"`
+	// Version (optional) is the software version of the verifier,
+	// independent of the build identifier.
+	Version *string `json:"version,omitempty"`
+	// Endpoint (optional) is the absolute URL at which the verifier can be
+	// reached.
+	Endpoint *string `json:"endpoint,omitempty"`
 }
 
 func ToVerifierIdentity(v interface{}) (*VerifierIdentity, error) {
@@ -26,8 +40,62 @@ func ToVerifierIdentity(v interface{}) (*VerifierIdentity, error) {
 		return nil, errors.New("not a JSON object")
 	}
 
-	err := populateStructFromMap(&verifierID, m, "json",
-		map[string]parser{}, stringPtrParser, false)
+	if err := verifierID.populateFromMap(m); err != nil {
+		return &verifierID, err
+	}
+
+	return &verifierID, verifierID.validate()
+}
+
+// verifierIdentityClaimNames are the names of the claims of a
+// VerifierIdentity
+var verifierIdentityClaimNames = []string{"build", "developer", "instance", "version", "endpoint"}
+
+func (o *VerifierIdentity) populateFromMap(m map[string]interface{}) error {
+	r := claimsReader{m: m}
+
+	r.readString(&o.Build, "build", true)
+	r.readString(&o.Developer, "developer", true)
+	r.readString(&o.Instance, "instance", false)
+	r.readString(&o.Version, "version", false)
+	r.readString(&o.Endpoint, "endpoint", false)
+
+	return r.err(verifierIdentityClaimNames)
+}
+
+// Issuer returns the canonical issuer string associated with the verifier
+// identity.  This is the value used for the `iss` claim when it is linked to
+// the verifier identity (see WithIssuerFromVerifierID and
+// WithIssuerVerifierIDCheck).  The canonical form is "<developer>/<build>",
+// with each component percent-encoded as an URI path segment.
+func (o VerifierIdentity) Issuer() (string, error) {
+	if o.Developer == nil || o.Build == nil {
+		return "", errors.New("missing 'developer' or 'build'")
+	}
+
+	return url.PathEscape(*o.Developer) + "/" + url.PathEscape(*o.Build), nil
+}
+
+func (o VerifierIdentity) validate() error {
+	for name, v := range map[string]*string{
+		"instance": o.Instance,
+		"version":  o.Version,
+	} {
+		if v != nil && *v == "" {
+			return fmt.Errorf("empty '%s'", name)
+		}
+	}
+
+	if o.Endpoint != nil {
+		u, err := url.Parse(*o.Endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid 'endpoint': %w", err)
+		}
+
+		if !u.IsAbs() || u.Host == "" {
+			return fmt.Errorf("invalid 'endpoint': %q is not an absolute URL", *o.Endpoint)
+		}
+	}
 
-	return &verifierID, err
+	return nil
 }
@@ -0,0 +1,134 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToVerifierIdentity_extended_ok(t *testing.T) {
+	v := map[string]interface{}{
+		"build":     "rrtrap-v1.0.0",
+		"developer": "Acme Inc.",
+		"instance":  "verifier-eu-west-1a",
+		"version":   "1.0.0",
+		"endpoint":  "https://verifier.example/challenge-response/v1",
+	}
+
+	vid, err := ToVerifierIdentity(v)
+	require.NoError(t, err)
+	assert.Equal(t, "verifier-eu-west-1a", *vid.Instance)
+	assert.Equal(t, "1.0.0", *vid.Version)
+	assert.Equal(t, "https://verifier.example/challenge-response/v1", *vid.Endpoint)
+}
+
+func TestToVerifierIdentity_fail(t *testing.T) {
+	tvs := []struct {
+		v        interface{}
+		expected string
+	}{
+		{
+			v:        "acme",
+			expected: "not a JSON object",
+		},
+		{
+			v: map[string]interface{}{
+				"build": "b", "developer": "d", "instance": "",
+			},
+			expected: "empty 'instance'",
+		},
+		{
+			v: map[string]interface{}{
+				"build": "b", "developer": "d", "endpoint": "/relative/path",
+			},
+			expected: `invalid 'endpoint': "/relative/path" is not an absolute URL`,
+		},
+		{
+			v: map[string]interface{}{
+				"build": "b", "developer": "d", "endpoint": "http://[::1",
+			},
+			expected: `invalid 'endpoint': parse "http://[::1": missing ']' in host`,
+		},
+		{
+			v: map[string]interface{}{
+				"build": "b", "developer": "d", "location": "eu",
+			},
+			expected: "unexpected: location",
+		},
+	}
+
+	for i, tv := range tvs {
+		_, err := ToVerifierIdentity(tv.v)
+		assert.EqualError(t, err, tv.expected, "failed test vector at index %d", i)
+	}
+}
+
+func TestVerifierIdentity_validation_on_marshal(t *testing.T) {
+	ar := NewAttestationResult("test", testVidBuild, testVidDeveloper)
+
+	version := ""
+	ar.VerifierID.Version = &version
+
+	_, err := ar.MarshalJSON()
+	assert.EqualError(t, err, "invalid value(s) for verifier-id (empty 'version')")
+}
+
+func TestVerifierIdentity_Issuer(t *testing.T) {
+	build := "rrtrap v1.0.0"
+	developer := "Acme Inc./R&D"
+
+	vid := VerifierIdentity{Build: &build, Developer: &developer}
+
+	iss, err := vid.Issuer()
+	require.NoError(t, err)
+	assert.Equal(t, "Acme%20Inc.%2FR&D/rrtrap%20v1.0.0", iss)
+
+	_, err = VerifierIdentity{Build: &build}.Issuer()
+	assert.EqualError(t, err, "missing 'developer' or 'build'")
+}
+
+func TestVerifierIdentity_issuer_link(t *testing.T) {
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	ar := testAttestationResultsWithVeraisonExtns
+
+	// no iss
+	token, err := ar.Sign(jwa.ES256, sigK)
+	require.NoError(t, err)
+
+	var actual AttestationResult
+
+	err = actual.Verify(token, jwa.ES256, vfyK, WithIssuerVerifierIDCheck())
+	assert.EqualError(t, err, "issuer check failed: missing 'iss'")
+
+	// iss derived from verifier-id
+	token, err = ar.Sign(jwa.ES256, sigK, WithIssuerFromVerifierID())
+	require.NoError(t, err)
+
+	err = actual.Verify(token, jwa.ES256, vfyK, WithIssuerVerifierIDCheck())
+	assert.NoError(t, err)
+
+	// iss belonging to a different verifier
+	jwtToken := jwt.New()
+	for k, v := range ar.AsMap() {
+		require.NoError(t, jwtToken.Set(k, v))
+	}
+	require.NoError(t, jwtToken.Set(jwt.IssuerKey, "Evil%20Corp/rrtrap-v6.6.6"))
+
+	token, err = jwt.Sign(jwtToken, jwt.WithKey(jwa.ES256, sigK))
+	require.NoError(t, err)
+
+	err = actual.Verify(token, jwa.ES256, vfyK, WithIssuerVerifierIDCheck())
+	assert.EqualError(t, err, `issuer check failed: 'iss' ("Evil%20Corp/rrtrap-v6.6.6") does not match 'ear.verifier-id' ("Acme%20Inc./rrtrap-v1.0.0")`)
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// VerifyBatch is like VerifyWithKeySet, but verifies many JWTs against the
+// same JWK Set, e.g., for relying parties ingesting streams of EARs from a
+// fleet of attesters.  The tokens are verified concurrently, using up to
+// GOMAXPROCS workers.  The returned slices have the same length as tokens:
+// for each token, either the decoded attestation result or the verification
+// error is set.  An empty key set is reported for every token.
+func VerifyBatch(
+	tokens [][]byte,
+	keyset jwk.Set,
+	opts ...VerifyOption,
+) ([]*AttestationResult, []error) {
+	results := make([]*AttestationResult, len(tokens))
+	errs := make([]error, len(tokens))
+
+	if keyset == nil || keyset.Len() == 0 {
+		err := errors.New("empty key set")
+		for i := range errs {
+			errs[i] = err
+		}
+		return results, errs
+	}
+
+	runBatch(len(tokens), runtime.GOMAXPROCS(0), func(i int) {
+		var ar AttestationResult
+		if err := ar.VerifyWithKeySet(tokens[i], keyset, opts...); err != nil {
+			errs[i] = err
+			return
+		}
+
+		results[i] = &ar
+	})
+
+	return results, errs
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	set := testKeySet(t)
+
+	ecSigK := mustParseKey(t, testECDSAPrivateKey)
+	edSigK := mustParseKey(t, testEd25519PrivateKey)
+
+	var tokens [][]byte
+
+	for i := 0; i < 20; i++ {
+		ar := testAttestationResultsWithVeraisonExtns
+		iat := testIAT + int64(i)
+		ar.IssuedAt = &iat
+
+		var (
+			token []byte
+			err   error
+		)
+
+		// alternate between the keys in the set
+		if i%2 == 0 {
+			token, err = ar.Sign(jwa.ES256, ecSigK, WithKeyID("ec"))
+		} else {
+			token, err = ar.Sign(jwa.EdDSA, edSigK, WithKeyID("ed"))
+		}
+		require.NoError(t, err)
+
+		tokens = append(tokens, token)
+	}
+
+	// tamper with one token, and garble another one
+	tokens[3] = append([]byte(nil), tokens[3]...)
+	tokens[3][len(tokens[3])-1] ^= 0x01
+	tokens[8] = []byte("not a JWT")
+
+	results, errs := VerifyBatch(tokens, set, WithKeySetAlgorithm(jwa.ES256))
+	require.Len(t, results, len(tokens))
+	require.Len(t, errs, len(tokens))
+
+	for i := range tokens {
+		switch i {
+		case 3:
+			assert.ErrorContains(t, errs[i], "no key in set could verify the EAR")
+			assert.Nil(t, results[i])
+		case 8:
+			assert.ErrorContains(t, errs[i], "failed parsing JWT message")
+			assert.Nil(t, results[i])
+		default:
+			require.NoError(t, errs[i], "token %d", i)
+			assert.Equal(t, testIAT+int64(i), *results[i].IssuedAt)
+		}
+	}
+}
+
+func TestVerifyBatch_empty_key_set(t *testing.T) {
+	tokens := [][]byte{{0x00}, {0x01}}
+
+	results, errs := VerifyBatch(tokens, jwk.NewSet())
+	assert.Equal(t, []*AttestationResult{nil, nil}, results)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "empty key set")
+	assert.Equal(t, errs[0], errs[1])
+
+	results, errs = VerifyBatch(nil, testKeySet(t))
+	assert.Empty(t, results)
+	assert.Empty(t, errs)
+}
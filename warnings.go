@@ -0,0 +1,222 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// WarningCode classifies a Warning
+type WarningCode string
+
+const (
+	// WarningUnknownClaim is reported for claims that are neither defined by
+	// EAR (including its Veraison extensions) nor registered JWT claims.
+	// Unknown claims are retained in the Extra of the AttestationResult or
+	// the Appraisal.
+	WarningUnknownClaim WarningCode = "unknown-claim"
+	// WarningNonCanonicalTier is reported for an "ear.status" that is
+	// encoded as an integer (or a string holding an integer) rather than as
+	// the name of the trust tier
+	WarningNonCanonicalTier WarningCode = "non-canonical-tier"
+	// WarningDeprecatedClaim is reported for the claims of a legacy AR4SI
+	// result, which is converted into the current shape (see FromAR4SI)
+	WarningDeprecatedClaim WarningCode = "deprecated-claim"
+)
+
+// Warning is a non-fatal finding about a decoded claims-set: something that
+// does not make it invalid, but that the issuer should probably fix
+type Warning struct {
+	Code WarningCode
+	// Path is the slash-separated path to the offending claim, e.g.,
+	// "submods/PARSEC_TPM/ear.status"
+	Path string
+	// Detail is a human readable explanation
+	Detail string
+}
+
+func (o Warning) String() string {
+	return fmt.Sprintf("%s: %s (%s)", o.Path, o.Detail, o.Code)
+}
+
+// WarningHandler is called by Verify for each Warning found in a verified
+// result
+type WarningHandler func(Warning)
+
+// WithWarningHandler makes Verify report the warnings found in the verified
+// claims-set to h.  Warnings are reported only if verification succeeds.
+// (The package-level Verify also makes them available via
+// VerifiedResult.Warnings.)
+func WithWarningHandler(h WarningHandler) VerifyOption {
+	return verifyOptionFunc(func(c *verifyConfig) {
+		c.warningHandler = h
+	})
+}
+
+// UnmarshalJSONWithWarnings is like UnmarshalJSON, but it also returns the
+// warnings found in the claims-set
+func (o *AttestationResult) UnmarshalJSONWithWarnings(data []byte) ([]Warning, error) {
+	if err := o.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return claimsWarnings(m), nil
+}
+
+// jwtClaimNames are the registered JWT claims, which are not part of the
+// AttestationResult struct, but can be legitimately found in an EAR
+var jwtClaimNames = []string{"aud"}
+
+// legacyClaimNames maps the claims of a legacy AR4SI result onto their
+// current equivalents
+var legacyClaimNames = map[string]string{
+	"status":                         "submods/" + LegacySubmodName + "/ear.status",
+	"profile":                        "eat_profile",
+	"timestamp":                      "iat",
+	"trust-vector":                   "submods/" + LegacySubmodName + "/ear.trustworthiness-vector",
+	"evidence":                       "ear.raw-evidence",
+	"appraisal-policy-id":            "submods/" + LegacySubmodName + "/ear.appraisal-policy-id",
+	"veraison.processed-evidence":    "submods/" + LegacySubmodName + "/ear.veraison.annotated-evidence",
+	"veraison.verifier-added-claims": "submods/" + LegacySubmodName + "/ear.veraison.policy-claims",
+}
+
+// claimsWarnings returns the warnings found in a (successfully decoded)
+// claims-set, sorted by path
+func claimsWarnings(m map[string]interface{}) []Warning {
+	var ws []Warning
+
+	if isLegacyAR4SIMap(m) {
+		for k := range m {
+			if current, ok := legacyClaimNames[k]; ok {
+				ws = append(ws, Warning{
+					Code:   WarningDeprecatedClaim,
+					Path:   k,
+					Detail: fmt.Sprintf("legacy AR4SI claim, superseded by %q", current),
+				})
+			}
+		}
+
+		return sortWarnings(ws)
+	}
+
+	profile, _ := m["eat_profile"].(string)
+	known, knownAppraisal := knownClaimNames(profile)
+
+	ws = append(ws, unknownClaimsWarnings("", m, known)...)
+
+	submods, _ := m["submods"].(map[string]interface{})
+
+	for name, v := range submods {
+		a, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		path := "submods/" + name
+
+		ws = append(ws, unknownClaimsWarnings(path, a, knownAppraisal)...)
+
+		if w, ok := tierWarning(path+"/ear.status", a["ear.status"]); ok {
+			ws = append(ws, w)
+		}
+	}
+
+	return sortWarnings(ws)
+}
+
+func unknownClaimsWarnings(path string, m map[string]interface{}, known map[string]bool) []Warning {
+	var ws []Warning
+
+	for k := range m {
+		if known[k] {
+			continue
+		}
+
+		p := k
+		if path != "" {
+			p = path + "/" + k
+		}
+
+		ws = append(ws, Warning{
+			Code:   WarningUnknownClaim,
+			Path:   p,
+			Detail: "unknown claim retained in Extra",
+		})
+	}
+
+	return ws
+}
+
+func tierWarning(path string, v interface{}) (Warning, bool) {
+	var canonical TrustTier
+
+	switch t := v.(type) {
+	case string:
+		i, err := strconv.Atoi(t)
+		if err != nil {
+			return Warning{}, false
+		}
+		canonical = IntToTrustTier[i]
+	case float64:
+		canonical = IntToTrustTier[int(t)]
+	case int64:
+		canonical = IntToTrustTier[int(t)]
+	default:
+		return Warning{}, false
+	}
+
+	return Warning{
+		Code:   WarningNonCanonicalTier,
+		Path:   path,
+		Detail: fmt.Sprintf("trust tier encoded as %v, should be %q", v, canonical.String()),
+	}, true
+}
+
+// jsonClaimNames returns the JSON names of the fields of the supplied struct
+// type (including those of its embedded structs), plus the extra names
+func jsonClaimNames(t reflect.Type, extra ...string) map[string]bool {
+	names := map[string]bool{}
+
+	for _, n := range extra {
+		names[n] = true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		spec, ok := parseTag(f.Tag, "json")
+		if !ok {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				for n := range jsonClaimNames(f.Type) {
+					names[n] = true
+				}
+			}
+			continue
+		}
+
+		names[spec.Name] = true
+	}
+
+	return names
+}
+
+func sortWarnings(ws []Warning) []Warning {
+	sort.Slice(ws, func(i, j int) bool {
+		if ws[i].Path != ws[j].Path {
+			return ws[i].Path < ws[j].Path
+		}
+		return ws[i].Code < ws[j].Code
+	})
+
+	return ws
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationResult_UnmarshalJSONWithWarnings(t *testing.T) {
+	tvs := []struct {
+		claims   string
+		expected []Warning
+	}{
+		{
+			claims: `{
+				"eat_profile": "tag:github.com,2023:veraison/ear",
+				"iat": 1666091373,
+				"exp": 1666091999,
+				"ear.verifier-id": {"build": "rrtrap-v1.0.0", "developer": "Acme Inc."},
+				"submods": {"test": {"ear.status": "affirming"}}
+			}`,
+		},
+		{
+			claims: `{
+				"eat_profile": "tag:github.com,2023:veraison/ear",
+				"iat": 1666091373,
+				"ear.verifier-id": {"build": "rrtrap-v1.0.0", "developer": "Acme Inc."},
+				"ear.acme.extra": true,
+				"submods": {
+					"a": {"ear.status": 2, "ear.acme.extra": "x"},
+					"b": {"ear.status": "96"}
+				}
+			}`,
+			expected: []Warning{
+				{WarningUnknownClaim, "ear.acme.extra", "unknown claim retained in Extra"},
+				{WarningUnknownClaim, "submods/a/ear.acme.extra", "unknown claim retained in Extra"},
+				{WarningNonCanonicalTier, "submods/a/ear.status", `trust tier encoded as 2, should be "affirming"`},
+				{WarningNonCanonicalTier, "submods/b/ear.status", `trust tier encoded as 96, should be "contraindicated"`},
+			},
+		},
+		{
+			claims: string(testLegacyTokenClaimsSet),
+			expected: []Warning{
+				{WarningDeprecatedClaim, "appraisal-policy-id", `legacy AR4SI claim, superseded by "submods/ar4si/ear.appraisal-policy-id"`},
+				{WarningDeprecatedClaim, "status", `legacy AR4SI claim, superseded by "submods/ar4si/ear.status"`},
+				{WarningDeprecatedClaim, "timestamp", `legacy AR4SI claim, superseded by "iat"`},
+				{WarningDeprecatedClaim, "veraison.processed-evidence", `legacy AR4SI claim, superseded by "submods/ar4si/ear.veraison.annotated-evidence"`},
+				{WarningDeprecatedClaim, "veraison.verifier-added-claims", `legacy AR4SI claim, superseded by "submods/ar4si/ear.veraison.policy-claims"`},
+			},
+		},
+	}
+
+	for i, tv := range tvs {
+		var ar AttestationResult
+
+		ws, err := ar.UnmarshalJSONWithWarnings([]byte(tv.claims))
+		require.NoError(t, err, "failed test vector at index %d", i)
+		assert.Equal(t, tv.expected, ws, "failed test vector at index %d", i)
+	}
+
+	var ar AttestationResult
+	_, err := ar.UnmarshalJSONWithWarnings([]byte(`{"submods": {}}`))
+	assert.Error(t, err)
+}
+
+func TestVerify_warnings(t *testing.T) {
+	claims := `{
+		"eat_profile": "tag:github.com,2023:veraison/ear",
+		"iat": 1666091373,
+		"ear.verifier-id": {"build": "rrtrap-v1.0.0", "developer": "Acme Inc."},
+		"submods": {"test": {"ear.status": 32, "ear.acme.extra": "x"}}
+	}`
+
+	sigK, err := jwk.ParseKey([]byte(testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	vfyK, err := jwk.ParseKey([]byte(testECDSAPublicKey))
+	require.NoError(t, err)
+
+	token, err := jws.Sign([]byte(claims), jws.WithKey(jwa.ES256, sigK))
+	require.NoError(t, err)
+
+	var reported []Warning
+
+	vr, err := Verify(token, jwa.ES256, vfyK,
+		WithWarningHandler(func(w Warning) { reported = append(reported, w) }))
+	require.NoError(t, err)
+
+	expected := []Warning{
+		{WarningUnknownClaim, "submods/test/ear.acme.extra", "unknown claim retained in Extra"},
+		{WarningNonCanonicalTier, "submods/test/ear.status", `trust tier encoded as 32, should be "warning"`},
+	}
+
+	assert.Equal(t, expected, reported)
+	assert.Equal(t, expected, vr.Warnings())
+	assert.Equal(t,
+		`submods/test/ear.status: trust tier encoded as 32, should be "warning" (non-canonical-tier)`,
+		vr.Warnings()[1].String())
+
+	// nothing is reported if verification fails
+	reported = nil
+
+	_, err = Verify(token, jwa.ES256, vfyK, WithMaxAge(1),
+		WithWarningHandler(func(w Warning) { reported = append(reported, w) }))
+	assert.Error(t, err)
+	assert.Empty(t, reported)
+
+	// a clean result has no warnings
+	vfyK, err = jwk.ParseKey([]byte(testEd25519PublicKey))
+	require.NoError(t, err)
+
+	vr, err = Verify([]byte(testEdDSAToken), jwa.EdDSA, vfyK)
+	require.NoError(t, err)
+	assert.Empty(t, vr.Warnings())
+}
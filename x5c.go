@@ -0,0 +1,192 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/cert"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/veraison/go-cose"
+)
+
+// WithCertChain instructs Sign and SignCWT to embed the supplied X.509
+// certificate chain (leaf first) in the protected header, as the `x5c` (JWT)
+// or `x5chain` (CWT) parameter.  The leaf certificate must certify the public
+// part of the signing key.
+func WithCertChain(chain ...*x509.Certificate) SignOption {
+	return signOptionFunc(func(c *signConfig) {
+		c.certChain = append([]*x509.Certificate(nil), chain...)
+	})
+}
+
+// VerifyWithCertChain is like Verify, but the verification key is the public
+// key of the leaf certificate found in the `x5c` header parameter, and the
+// algorithm is that found in the `alg` header parameter.  The certificate
+// chain is validated against roots at the time reported by the Clock in use.
+func (o *AttestationResult) VerifyWithCertChain(
+	data []byte,
+	roots *x509.CertPool,
+	opts ...VerifyOption,
+) error {
+	hdrs, err := protectedHeaders(data)
+	if err != nil {
+		return err
+	}
+
+	x5c := hdrs.X509CertChain()
+	if x5c == nil || x5c.Len() == 0 {
+		return errors.New(`no "x5c" header parameter found`)
+	}
+
+	chain, err := parseX5C(x5c)
+	if err != nil {
+		return err
+	}
+
+	alg := hdrs.Algorithm()
+	if alg == "" || alg == jwa.NoSignature {
+		return fmt.Errorf("unacceptable algorithm %q", alg)
+	}
+
+	cfg := newVerifyConfig(opts)
+
+	if err := verifyCertChain(chain, roots, cfg.clock.Now()); err != nil {
+		return err
+	}
+
+	_, err = o.verify(data, alg, chain[0].PublicKey, cfg)
+	return err
+}
+
+// VerifyCWTWithCertChain is like VerifyWithCertChain, but for EARs signed
+// using SignCWT, which carry the certificate chain in the `x5chain` header
+// parameter
+func (o *AttestationResult) VerifyCWTWithCertChain(
+	data []byte,
+	roots *x509.CertPool,
+	opts ...VerifyOption,
+) error {
+	var msg cose.Sign1Message
+	if err := msg.UnmarshalCBOR(data); err != nil {
+		return fmt.Errorf("failed parsing CWT message: %w", err)
+	}
+
+	v, ok := msg.Headers.Protected[cose.HeaderLabelX5Chain]
+	if !ok {
+		return errors.New(`no "x5chain" header parameter found`)
+	}
+
+	chain, err := parseCOSEX5Chain(v)
+	if err != nil {
+		return err
+	}
+
+	coseAlg, err := msg.Headers.Protected.Algorithm()
+	if err != nil {
+		return fmt.Errorf("failed parsing CWT message: %w", err)
+	}
+
+	alg, err := jwsAlgorithm(int64(coseAlg))
+	if err != nil {
+		return err
+	}
+
+	cfg := newVerifyConfig(opts)
+
+	if err := verifyCertChain(chain, roots, cfg.clock.Now()); err != nil {
+		return err
+	}
+
+	return o.VerifyCWT(data, alg, chain[0].PublicKey, opts...)
+}
+
+// x5cChain encodes the supplied certificates as the value of an `x5c` header
+// parameter (RFC 7515, Section 4.1.6)
+func x5cChain(certs []*x509.Certificate) *cert.Chain {
+	var chain cert.Chain
+
+	for _, c := range certs {
+		_ = chain.AddString(base64.StdEncoding.EncodeToString(c.Raw))
+	}
+
+	return &chain
+}
+
+func parseX5C(x5c *cert.Chain) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+
+	for i := 0; i < x5c.Len(); i++ {
+		b64, _ := x5c.Get(i)
+
+		der, err := base64.StdEncoding.DecodeString(string(b64))
+		if err != nil {
+			return nil, fmt.Errorf("x5c[%d]: %w", i, err)
+		}
+
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("x5c[%d]: %w", i, err)
+		}
+
+		chain = append(chain, c)
+	}
+
+	return chain, nil
+}
+
+// coseX5Chain encodes the supplied certificates as the value of an `x5chain`
+// header parameter (RFC 9360, Section 2): a single certificate is encoded as
+// a byte string, a chain as an array of byte strings
+func coseX5Chain(certs []*x509.Certificate) interface{} {
+	if len(certs) == 1 {
+		return certs[0].Raw
+	}
+
+	ders := make([][]byte, len(certs))
+	for i, c := range certs {
+		ders[i] = c.Raw
+	}
+
+	return ders
+}
+
+func parseCOSEX5Chain(v interface{}) ([]*x509.Certificate, error) {
+	var ders [][]byte
+
+	switch t := v.(type) {
+	case []byte:
+		ders = [][]byte{t}
+	case []interface{}:
+		for i, e := range t {
+			der, ok := e.([]byte)
+			if !ok {
+				return nil, fmt.Errorf("x5chain[%d]: expecting a byte string, got %T", i, e)
+			}
+			ders = append(ders, der)
+		}
+	default:
+		return nil, fmt.Errorf("x5chain: expecting a byte string or an array, got %T", v)
+	}
+
+	if len(ders) == 0 {
+		return nil, errors.New("x5chain: empty")
+	}
+
+	var chain []*x509.Certificate
+
+	for i, der := range ders {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("x5chain[%d]: %w", i, err)
+		}
+
+		chain = append(chain, c)
+	}
+
+	return chain, nil
+}
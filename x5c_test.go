@@ -0,0 +1,136 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package ear
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertChain returns a leaf certificate (and its key) issued by an
+// intermediate CA, in turn issued by a root CA, all valid around testIAT
+func newTestCertChain(t *testing.T) ([]*x509.Certificate, *ecdsa.PrivateKey, *x509.CertPool) {
+	notBefore := time.Unix(testIAT, 0).Add(-time.Hour)
+	notAfter := notBefore.Add(2 * time.Hour)
+
+	rootCert, rootKey := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	intCert, intKey := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, rootCert, rootKey)
+
+	leafCert, leafKey := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "Test Verifier"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, intCert, intKey)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+
+	return []*x509.Certificate{leafCert, intCert}, leafKey, roots
+}
+
+func TestCertChain_round_trip(t *testing.T) {
+	chain, key, roots := newTestCertChain(t)
+
+	clock := WithClock(FixedClock(time.Unix(testIAT, 0)))
+
+	// JWT
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256, key,
+		WithCertChain(chain...))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	require.NoError(t, actual.VerifyWithCertChain(token, roots, clock))
+	assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+
+	err = actual.VerifyWithCertChain(token, roots)
+	assert.ErrorContains(t, err, "certificate chain: x509: certificate has expired")
+
+	err = actual.VerifyWithCertChain(token, x509.NewCertPool(), clock)
+	assert.ErrorContains(t, err, "certificate chain: x509: certificate signed by unknown authority")
+
+	// CWT, with chain and single certificate
+	for _, c := range [][]*x509.Certificate{chain, chain[:1]} {
+		token, err = testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256, key,
+			WithCertChain(c...))
+		require.NoError(t, err)
+
+		actual = AttestationResult{}
+		err = actual.VerifyCWTWithCertChain(token, roots, clock)
+		if len(c) == 1 {
+			// the intermediate CA is missing
+			assert.ErrorContains(t, err, "certificate chain: x509: certificate signed by unknown authority")
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, testAttestationResultsWithVeraisonExtns, actual)
+	}
+}
+
+func TestCertChain_wrong_key(t *testing.T) {
+	chain, _, roots := newTestCertChain(t)
+
+	clock := WithClock(FixedClock(time.Unix(testIAT, 0)))
+
+	// signed with a key other than that certified by the leaf
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey), WithCertChain(chain...))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	err = actual.VerifyWithCertChain(token, roots, clock)
+	assert.ErrorContains(t, err, "failed verifying JWT message")
+
+	cwt, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey), WithCertChain(chain...))
+	require.NoError(t, err)
+
+	err = actual.VerifyCWTWithCertChain(cwt, roots, clock)
+	assert.Error(t, err)
+}
+
+func TestCertChain_missing(t *testing.T) {
+	_, _, roots := newTestCertChain(t)
+
+	token, err := testAttestationResultsWithVeraisonExtns.Sign(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	var actual AttestationResult
+	err = actual.VerifyWithCertChain(token, roots)
+	assert.EqualError(t, err, `no "x5c" header parameter found`)
+
+	cwt, err := testAttestationResultsWithVeraisonExtns.SignCWT(jwa.ES256,
+		mustParseKey(t, testECDSAPrivateKey))
+	require.NoError(t, err)
+
+	err = actual.VerifyCWTWithCertChain(cwt, roots)
+	assert.EqualError(t, err, `no "x5chain" header parameter found`)
+}